@@ -576,16 +576,9 @@ func (h *healSequence) healMinioSysMeta(metaPrefix string) func() error {
 		marker := ""
 		isTruncated := true
 		for isTruncated {
-			if globalHTTPServer != nil {
-				// Wait at max 1 minute for an inprogress request
-				// before proceeding to heal
-				waitCount := 60
-				// Any requests in progress, delay the heal.
-				for globalHTTPServer.GetRequestCount() > 2 && waitCount > 0 {
-					waitCount--
-					time.Sleep(1 * time.Second)
-				}
-			}
+			// Wait at max 1 minute for an inprogress request
+			// before proceeding to heal
+			waitForLowHTTPReq()
 
 			// Lists all objects under `config` prefix.
 			objectInfos, err := objectAPI.ListObjectsHeal(h.ctx, minioMetaBucket, metaPrefix,
@@ -725,16 +718,9 @@ func (h *healSequence) healBucket(bucket string) error {
 	marker := ""
 	isTruncated := true
 	for isTruncated {
-		if globalHTTPServer != nil {
-			// Wait at max 1 minute for an inprogress request
-			// before proceeding to heal
-			waitCount := 60
-			// Any requests in progress, delay the heal.
-			for globalHTTPServer.GetRequestCount() > 2 && waitCount > 0 {
-				waitCount--
-				time.Sleep(1 * time.Second)
-			}
-		}
+		// Wait at max 1 minute for an inprogress request
+		// before proceeding to heal
+		waitForLowHTTPReq()
 
 		// Heal numCPU * nodes objects at a time.
 		objectInfos, err := objectAPI.ListObjectsHeal(h.ctx, bucket,
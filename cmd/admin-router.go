@@ -52,6 +52,8 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 
 	// Info operations
 	adminV1Router.Methods(http.MethodGet).Path("/info").HandlerFunc(httpTraceAll(adminAPI.ServerInfoHandler))
+	// Data usage info
+	adminV1Router.Methods(http.MethodGet).Path("/datausageinfo").HandlerFunc(httpTraceAll(adminAPI.DataUsageInfoHandler))
 
 	if globalIsDistXL || globalIsXL {
 		/// Heal operations
@@ -61,6 +63,17 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 		adminV1Router.Methods(http.MethodPost).Path("/heal/{bucket}").HandlerFunc(httpTraceAll(adminAPI.HealHandler))
 		adminV1Router.Methods(http.MethodPost).Path("/heal/{bucket}/{prefix:.*}").HandlerFunc(httpTraceAll(adminAPI.HealHandler))
 
+		// Progress of the background heal of replaced drives.
+		adminV1Router.Methods(http.MethodGet).Path("/background-heal/status").HandlerFunc(httpTraceAll(adminAPI.BackgroundHealStatusHandler))
+		// Progress of the background bitrot scrubber.
+		adminV1Router.Methods(http.MethodGet).Path("/bitrot-scrub/status").HandlerFunc(httpTraceAll(adminAPI.BitrotScrubStatusHandler))
+
+		/// Decommission operations
+
+		// Drain a server pool or an erasure set and get the progress of the decommissions.
+		adminV1Router.Methods(http.MethodPost).Path("/decommission").HandlerFunc(httpTraceAll(adminAPI.DecommissionPoolHandler)).Queries("pool", "{pool:.*}")
+		adminV1Router.Methods(http.MethodGet).Path("/decommission-status").HandlerFunc(httpTraceAll(adminAPI.DecommissionStatusHandler))
+
 		/// Health operations
 
 	}
@@ -112,8 +125,33 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 
 		// List policies
 		adminV1Router.Methods(http.MethodGet).Path("/list-canned-policies").HandlerFunc(httpTraceHdrs(adminAPI.ListCannedPolicies))
+
+		// Set policies of a user or a group
+		adminV1Router.Methods(http.MethodPut).Path("/set-user-or-group-policy").HandlerFunc(httpTraceHdrs(adminAPI.SetPolicyForUserOrGroup)).
+			Queries("policyName", "{policyName:.*}", "userOrGroup", "{userOrGroup:.*}", "isGroup", "{isGroup:true|false}")
+
+		// Add/Remove members from group
+		adminV1Router.Methods(http.MethodPut).Path("/update-group-members").HandlerFunc(httpTraceHdrs(adminAPI.UpdateGroupMembers))
+
+		// Get group info
+		adminV1Router.Methods(http.MethodGet).Path("/group").HandlerFunc(httpTraceHdrs(adminAPI.GetGroup)).Queries("group", "{group:.*}")
+
+		// List groups
+		adminV1Router.Methods(http.MethodGet).Path("/groups").HandlerFunc(httpTraceHdrs(adminAPI.ListGroups))
+
+		// Add, list and delete service accounts
+		adminV1Router.Methods(http.MethodPut).Path("/add-service-account").HandlerFunc(httpTraceHdrs(adminAPI.AddServiceAccount))
+		adminV1Router.Methods(http.MethodGet).Path("/list-service-accounts").HandlerFunc(httpTraceHdrs(adminAPI.ListServiceAccounts)).Queries("user", "{user:.*}")
+		adminV1Router.Methods(http.MethodDelete).Path("/delete-service-account").HandlerFunc(httpTraceHdrs(adminAPI.DeleteServiceAccount)).Queries("accessKey", "{accessKey:.*}")
 	}
 
+	// -- Bucket APIs --
+
+	// Set, get and remove bucket quota
+	adminV1Router.Methods(http.MethodPut).Path("/set-bucket-quota").HandlerFunc(httpTraceHdrs(adminAPI.SetBucketQuotaHandler)).Queries("bucket", "{bucket:.*}")
+	adminV1Router.Methods(http.MethodGet).Path("/get-bucket-quota").HandlerFunc(httpTraceHdrs(adminAPI.GetBucketQuotaHandler)).Queries("bucket", "{bucket:.*}")
+	adminV1Router.Methods(http.MethodDelete).Path("/remove-bucket-quota").HandlerFunc(httpTraceHdrs(adminAPI.RemoveBucketQuotaHandler)).Queries("bucket", "{bucket:.*}")
+
 	// -- Top APIs --
 	// Top locks
 	adminV1Router.Methods(http.MethodGet).Path("/top/locks").HandlerFunc(httpTraceHdrs(adminAPI.TopLocksHandler))
@@ -27,9 +27,14 @@ const (
 	responseDeploymentIDKey = "x-minio-deployment-id"
 )
 
-// ObjectIdentifier carries key name for the object to delete.
+// ObjectIdentifier carries key name and optional version ID for the object to delete.
 type ObjectIdentifier struct {
 	ObjectName string `xml:"Key"`
+	VersionID  string `xml:"VersionId,omitempty"`
+
+	// Delete marker details, only set in the response.
+	DeleteMarker          bool   `xml:"DeleteMarker,omitempty"`
+	DeleteMarkerVersionID string `xml:"DeleteMarkerVersionId,omitempty"`
 }
 
 // createBucketConfiguration container for bucket configuration request from client.
@@ -87,6 +87,9 @@ func setObjectHeaders(w http.ResponseWriter, objInfo ObjectInfo, rs *HTTPRangeSp
 		w.Header().Set("Content-Encoding", objInfo.ContentEncoding)
 	}
 
+	// Set version ID and delete marker if available.
+	setVersionHeaders(w, objInfo)
+
 	// Set all other user defined metadata.
 	for k, v := range objInfo.UserDefined {
 		if hasPrefix(k, ReservedMetadataPrefix) {
@@ -94,6 +97,14 @@ func setObjectHeaders(w http.ResponseWriter, objInfo ObjectInfo, rs *HTTPRangeSp
 			// values to client.
 			continue
 		}
+		if k == amzObjectTagging {
+			// Tags are returned by GetObjectTagging, only
+			// their count is sent to client.
+			if t, err := getObjectTags(objInfo); err == nil && !t.IsEmpty() {
+				w.Header().Set(amzObjectTaggingCount, strconv.Itoa(len(t.TagSet.Tags)))
+			}
+			continue
+		}
 		w.Header().Set(k, v)
 	}
 
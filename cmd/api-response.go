@@ -178,6 +178,53 @@ type Upload struct {
 	Initiated    string
 }
 
+// ListVersionsResponse - format for list object versions response.
+type ListVersionsResponse struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListVersionsResult" json:"-"`
+
+	Name            string
+	Prefix          string
+	KeyMarker       string
+	VersionIDMarker string `xml:"VersionIdMarker"`
+
+	// When response is truncated (the IsTruncated element value in the response
+	// is true), you can use the key name and version ID in these fields as
+	// markers in the subsequent request to get the next set of versions.
+	NextKeyMarker       string `xml:"NextKeyMarker,omitempty"`
+	NextVersionIDMarker string `xml:"NextVersionIdMarker,omitempty"`
+
+	MaxKeys   int
+	Delimiter string
+	// A flag that indicates whether or not ListObjectVersions returned all of the
+	// results that satisfied the search criteria.
+	IsTruncated bool
+
+	// Versions and delete markers in the order they are listed.
+	Versions       []ObjectVersion
+	CommonPrefixes []CommonPrefix
+
+	// Encoding type used to encode object keys in the response.
+	EncodingType string `xml:"EncodingType,omitempty"`
+}
+
+// ObjectVersion container for a version or a delete marker of an object,
+// XMLName is either "Version" or "DeleteMarker".
+type ObjectVersion struct {
+	XMLName      xml.Name
+	Key          string
+	VersionID    string `xml:"VersionId"`
+	IsLatest     bool
+	LastModified string // time string of format "2006-01-02T15:04:05.000Z"
+	ETag         string `xml:"ETag,omitempty"`
+	Size         int64
+
+	// Owner of the object.
+	Owner Owner
+
+	// The class of storage used to store the object.
+	StorageClass string `xml:"StorageClass,omitempty"`
+}
+
 // CommonPrefix container for prefix response in ListObjectsResponse
 type CommonPrefix struct {
 	Prefix string
@@ -247,9 +294,10 @@ type CompleteMultipartUploadResponse struct {
 
 // DeleteError structure.
 type DeleteError struct {
-	Code    string
-	Message string
-	Key     string
+	Code      string
+	Message   string
+	Key       string
+	VersionID string `xml:"VersionId,omitempty"`
 }
 
 // DeleteObjectsResponse container for multiple object deletes.
@@ -341,6 +389,59 @@ func generateListBucketsResponse(buckets []BucketInfo) ListBucketsResponse {
 	return data
 }
 
+// generates an ListObjectVersions response for the said bucket with other enumerated options.
+func generateListVersionsResponse(bucket, prefix, keyMarker, versionIDMarker, delimiter, encodingType string, maxKeys int, resp ListObjectVersionsInfo) ListVersionsResponse {
+	var versions []ObjectVersion
+	var prefixes []CommonPrefix
+	var owner = Owner{}
+	var data = ListVersionsResponse{}
+
+	owner.ID = globalMinioDefaultOwnerID
+	for _, object := range resp.Objects {
+		var content = ObjectVersion{}
+		if object.Name == "" {
+			continue
+		}
+		content.XMLName.Local = "Version"
+		if object.DeleteMarker {
+			content.XMLName.Local = "DeleteMarker"
+		}
+		content.Key = s3EncodeName(object.Name, encodingType)
+		content.VersionID = getVersionID(object.VersionID)
+		content.IsLatest = object.IsLatest
+		content.LastModified = object.ModTime.UTC().Format(timeFormatAMZLong)
+		if !object.DeleteMarker {
+			if object.ETag != "" {
+				content.ETag = "\"" + object.ETag + "\""
+			}
+			content.Size = object.Size
+			content.StorageClass = object.StorageClass
+		}
+		content.Owner = owner
+		versions = append(versions, content)
+	}
+	data.Name = bucket
+	data.Versions = versions
+
+	data.EncodingType = encodingType
+	data.Prefix = s3EncodeName(prefix, encodingType)
+	data.KeyMarker = s3EncodeName(keyMarker, encodingType)
+	data.VersionIDMarker = versionIDMarker
+	data.Delimiter = s3EncodeName(delimiter, encodingType)
+	data.MaxKeys = maxKeys
+
+	data.NextKeyMarker = s3EncodeName(resp.NextKeyMarker, encodingType)
+	data.NextVersionIDMarker = resp.NextVersionIDMarker
+	data.IsTruncated = resp.IsTruncated
+	for _, prefix := range resp.Prefixes {
+		var prefixItem = CommonPrefix{}
+		prefixItem.Prefix = s3EncodeName(prefix, encodingType)
+		prefixes = append(prefixes, prefixItem)
+	}
+	data.CommonPrefixes = prefixes
+	return data
+}
+
 // generates an ListObjectsV1 response for the said bucket with other enumerated options.
 func generateListObjectsV1Response(bucket, prefix, marker, delimiter, encodingType string, maxKeys int, resp ListObjectsInfo) ListObjectsResponse {
 	var contents []Object
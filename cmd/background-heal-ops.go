@@ -0,0 +1,323 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Interval at which every node checks if replaced drives need healing.
+	backgroundHealTick = time.Minute
+
+	// Lock held by the node healing the replaced drives.
+	backgroundHealLeaderLock = "leader-background-heal.lock"
+
+	// Holds the progress of the background heal.
+	backgroundHealFile = "background-heal.json"
+
+	// Number of objects healed between two progress updates.
+	backgroundHealBatch = 100
+)
+
+// Set when an unformatted disk is found while connecting the disks of an
+// erasure set, reset once `format.json` of the new disks has been healed.
+var globalUnformattedDiskFound int32
+
+// signalUnformattedDisk - signals the background healing that a fresh
+// disk, usually replacing a failed one, needs to be formatted and healed.
+func signalUnformattedDisk() {
+	atomic.StoreInt32(&globalUnformattedDiskFound, 1)
+}
+
+// waitForLowHTTPReq - waits at most a minute for the in-progress requests
+// to finish, healing is delayed while the server serves requests.
+func waitForLowHTTPReq() {
+	if globalHTTPServer == nil {
+		return
+	}
+	waitCount := 60
+	for globalHTTPServer.GetRequestCount() > 2 && waitCount > 0 {
+		waitCount--
+		time.Sleep(1 * time.Second)
+	}
+}
+
+// startBackgroundHealing - formats replaced drives and heals all buckets
+// and objects onto them in background until the server stops, should be
+// run in a go-routine.
+func startBackgroundHealing(objAPI ObjectLayer) {
+	// Only erasure coded backends have drives to heal.
+	if !globalIsXL && !globalIsDistXL {
+		return
+	}
+
+	ctx := context.Background()
+
+	ticker := time.NewTicker(backgroundHealTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-GlobalServiceDoneCh:
+			return
+		case <-ticker.C:
+			if err := backgroundHealRound(ctx, objAPI); err != nil {
+				// Another node holds the leader lock and heals the drives.
+				if _, ok := err.(OperationTimedOut); ok {
+					continue
+				}
+				logger.LogIf(ctx, err)
+			}
+		}
+	}
+}
+
+// backgroundHealRound - formats the fresh drives and resumes the heal of
+// all buckets and objects where the last round stopped, only one node of
+// the cluster heals at a time.
+func backgroundHealRound(ctx context.Context, objAPI ObjectLayer) error {
+	healStatus, err := loadBackgroundHealStatus(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+	if len(healStatus.Drives) == 0 && atomic.LoadInt32(&globalUnformattedDiskFound) == 0 {
+		return nil
+	}
+
+	// Do not wait for the leader lock, a failure means another node is the leader.
+	zeroDuration := time.Millisecond
+	leaderLock := globalNSMutex.NewNSLock(minioMetaBucket, backgroundHealLeaderLock)
+	if err = leaderLock.GetLock(newDynamicTimeout(zeroDuration, zeroDuration)); err != nil {
+		return err
+	}
+	defer leaderLock.Unlock()
+
+	// Reload the progress saved by the previous leader.
+	if healStatus, err = loadBackgroundHealStatus(ctx, objAPI); err != nil {
+		return err
+	}
+
+	if err = healNewDrives(ctx, objAPI, &healStatus); err != nil {
+		return err
+	}
+	if len(healStatus.Drives) == 0 {
+		return nil
+	}
+
+	return healAllBuckets(ctx, objAPI, &healStatus)
+}
+
+// healNewDrives - formats the fresh drives of all erasure sets and adds them
+// to the drives being healed, the heal starts over as the buckets and objects
+// healed so far are missing on the new drives.
+func healNewDrives(ctx context.Context, objAPI ObjectLayer, healStatus *madmin.BackgroundHealStatus) error {
+	if !atomic.CompareAndSwapInt32(&globalUnformattedDiskFound, 1, 0) {
+		return nil
+	}
+
+	res, err := objAPI.HealFormat(ctx, false)
+	if err != nil {
+		if err == errNoHealRequired {
+			return nil
+		}
+		return err
+	}
+
+	// Notify the peers to reload the format and connect the new drives.
+	for _, nerr := range globalNotificationSys.ReloadFormat(false) {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+
+	drives := healStatus.Drives
+	for i, drive := range res.Before.Drives {
+		if drive.State == madmin.DriveStateMissing && res.After.Drives[i].State == madmin.DriveStateOk {
+			drives = append(drives, drive.Endpoint)
+		}
+	}
+
+	*healStatus = madmin.BackgroundHealStatus{
+		Drives:       drives,
+		StartTime:    UTCNow(),
+		LastUpdate:   UTCNow(),
+		LastFinished: healStatus.LastFinished,
+	}
+	return saveBackgroundHealStatus(ctx, objAPI, *healStatus)
+}
+
+// healAllBuckets - heals the configuration, then all buckets and their
+// objects in lexical order, starting from the bucket and object where the
+// heal stopped. The progress is persisted every backgroundHealBatch objects.
+func healAllBuckets(ctx context.Context, objAPI ObjectLayer, healStatus *madmin.BackgroundHealStatus) error {
+	// Configuration and bucket metadata are small, they are healed again
+	// when the heal is resumed.
+	for _, prefix := range []string{minioConfigPrefix, bucketConfigPrefix} {
+		if _, err := healObjects(ctx, objAPI, minioMetaBucket, prefix, ""); err != nil {
+			return err
+		}
+	}
+
+	buckets, err := objAPI.ListBucketsHeal(ctx)
+	if err != nil {
+		return err
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Name < buckets[j].Name
+	})
+
+	for _, bucket := range buckets {
+		if bucket.Name < healStatus.Bucket {
+			continue
+		}
+		if bucket.Name != healStatus.Bucket {
+			healStatus.Bucket = bucket.Name
+			healStatus.Object = ""
+		}
+
+		waitForLowHTTPReq()
+		if _, err = objAPI.HealBucket(ctx, bucket.Name, false, false); err != nil {
+			// Bucket removed while healing.
+			if _, ok := err.(BucketNotFound); ok {
+				continue
+			}
+			return err
+		}
+
+		for {
+			// Drives replaced in the meantime need the objects healed so far.
+			drives := len(healStatus.Drives)
+			if err = healNewDrives(ctx, objAPI, healStatus); err != nil {
+				return err
+			}
+			if len(healStatus.Drives) != drives {
+				return healAllBuckets(ctx, objAPI, healStatus)
+			}
+
+			result, err := healObjects(ctx, objAPI, bucket.Name, "", healStatus.Object)
+			if err != nil {
+				if _, ok := err.(BucketNotFound); ok {
+					break
+				}
+				return err
+			}
+
+			healStatus.Object = result.marker
+			healStatus.ObjectsHealed += result.healed
+			healStatus.ObjectsFailed += result.failed
+			healStatus.LastUpdate = UTCNow()
+			if err = saveBackgroundHealStatus(ctx, objAPI, *healStatus); err != nil {
+				return err
+			}
+			if !result.truncated {
+				break
+			}
+		}
+
+		healStatus.BucketsHealed++
+	}
+
+	// All drives are healed, keep the counters of the last heal.
+	healStatus.Drives = nil
+	healStatus.Bucket = ""
+	healStatus.Object = ""
+	healStatus.LastUpdate = UTCNow()
+	healStatus.LastFinished = healStatus.LastUpdate
+	return saveBackgroundHealStatus(ctx, objAPI, *healStatus)
+}
+
+// healObjectsResult - outcome of healing a batch of objects.
+type healObjectsResult struct {
+	healed, failed uint64
+	marker         string
+	truncated      bool
+}
+
+// healObjects - heals the next backgroundHealBatch objects of a bucket after
+// marker, or all objects under prefix when marker and prefix are empty. Objects
+// are healed one at a time, waiting for the in-progress requests.
+func healObjects(ctx context.Context, objAPI ObjectLayer, bucket, prefix, marker string) (result healObjectsResult, err error) {
+	for {
+		objectInfos, err := objAPI.ListObjectsHeal(ctx, bucket, prefix, marker, "", backgroundHealBatch)
+		if err != nil {
+			return result, err
+		}
+
+		for _, object := range objectInfos.Objects {
+			waitForLowHTTPReq()
+			_, err = objAPI.HealObject(ctx, bucket, object.Name, false, false)
+			switch {
+			case err == nil:
+				result.healed++
+			case isErrObjectNotFound(err):
+				// Object removed while healing.
+			default:
+				logger.GetReqInfo(ctx).AppendTags("object", pathJoin(bucket, object.Name))
+				logger.LogIf(ctx, err)
+				result.failed++
+			}
+		}
+
+		marker = objectInfos.NextMarker
+		result.marker = marker
+		result.truncated = objectInfos.IsTruncated
+
+		// Objects of buckets are healed in batches, metadata at once.
+		if prefix == "" || !objectInfos.IsTruncated {
+			return result, nil
+		}
+	}
+}
+
+// loadBackgroundHealStatus - returns the persisted progress of the background heal.
+func loadBackgroundHealStatus(ctx context.Context, objAPI ObjectLayer) (madmin.BackgroundHealStatus, error) {
+	var healStatus madmin.BackgroundHealStatus
+
+	data, err := readConfig(ctx, objAPI, path.Join(minioConfigPrefix, backgroundHealFile))
+	if err != nil {
+		if err == errConfigNotFound {
+			return healStatus, nil
+		}
+		return healStatus, err
+	}
+
+	if err = json.Unmarshal(data, &healStatus); err != nil {
+		return healStatus, err
+	}
+	return healStatus, nil
+}
+
+// saveBackgroundHealStatus - persists the progress of the background heal
+// under the minio meta bucket.
+func saveBackgroundHealStatus(ctx context.Context, objAPI ObjectLayer, healStatus madmin.BackgroundHealStatus) error {
+	data, err := json.Marshal(healStatus)
+	if err != nil {
+		return err
+	}
+
+	return saveConfig(ctx, objAPI, path.Join(minioConfigPrefix, backgroundHealFile), data)
+}
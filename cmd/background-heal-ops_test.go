@@ -0,0 +1,111 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestBackgroundHealRound(t *testing.T) {
+	initNSLock(false)
+
+	nDisks := 16
+	disks, err := getRandomDisks(nDisks)
+	if err != nil {
+		t.Fatal("Failed to create disks for the backend")
+	}
+	defer removeRoots(disks)
+
+	obj, err := newObjectLayer(EndpointPools{
+		{SetCount: 1, DrivesPerSet: 16, Endpoints: mustGetNewEndpointList(disks...)},
+	})
+	if err != nil {
+		t.Fatal("Unexpected object layer initialization error", err)
+	}
+	defer obj.Shutdown(context.Background())
+
+	ctx := context.Background()
+	buckets := []string{"bucket1", "bucket2"}
+	objects := []string{"object", "prefix/object"}
+	data := []byte("hello")
+	for _, bucket := range buckets {
+		if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+			t.Fatal(err)
+		}
+		for _, object := range objects {
+			if _, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	// Nothing to heal.
+	if err = backgroundHealRound(ctx, obj); err != nil {
+		t.Fatal(err)
+	}
+	healStatus, err := loadBackgroundHealStatus(ctx, obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !healStatus.LastFinished.IsZero() {
+		t.Fatalf("Expected no background heal, got: %v", healStatus.LastFinished)
+	}
+
+	// Replace the first drive with an empty one.
+	if err = os.RemoveAll(disks[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.MkdirAll(disks[0], 0777); err != nil {
+		t.Fatal(err)
+	}
+
+	globalNotificationSys = NewNotificationSys(globalServerConfig, mustGetNewEndpointList(disks...))
+	signalUnformattedDisk()
+	if err = backgroundHealRound(ctx, obj); err != nil {
+		t.Fatal(err)
+	}
+	if atomic.LoadInt32(&globalUnformattedDiskFound) != 0 {
+		t.Fatal("Expected the unformatted disk signal to be reset")
+	}
+
+	if _, err = os.Stat(filepath.Join(disks[0], minioMetaBucket, formatConfigFile)); err != nil {
+		t.Fatalf("Expected the replaced drive to be formatted: %v", err)
+	}
+	for _, bucket := range buckets {
+		for _, object := range objects {
+			if _, err = os.Stat(filepath.Join(disks[0], bucket, object, xlMetaJSONFile)); err != nil {
+				t.Fatalf("Expected %s/%s to be healed: %v", bucket, object, err)
+			}
+		}
+	}
+
+	healStatus, err = loadBackgroundHealStatus(ctx, obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(healStatus.Drives) != 0 || healStatus.LastFinished.IsZero() {
+		t.Fatalf("Expected the background heal to be finished, got: %v", healStatus)
+	}
+	if healStatus.BucketsHealed != uint64(len(buckets)) || healStatus.ObjectsHealed != uint64(len(buckets)*len(objects)) {
+		t.Fatalf("Expected %d buckets and %d objects healed, got: %v", len(buckets), len(buckets)*len(objects), healStatus)
+	}
+}
@@ -0,0 +1,302 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// Minimum interval between the start of two scrubs of all objects.
+	bitrotScrubInterval = 24 * time.Hour
+
+	// Interval at which every node checks if a scrub is due.
+	bitrotScrubTick = time.Minute
+
+	// Lock held by the node scrubbing the objects.
+	bitrotScrubLeaderLock = "leader-bitrot-scrub.lock"
+
+	// Holds the progress and the counters of the scrubber.
+	bitrotScrubFile = "bitrot-scrub.json"
+
+	// Number of objects scrubbed between two progress updates.
+	bitrotScrubBatch = 100
+)
+
+// bitrotScrubThrottle - limits the number of shards verified and the
+// number of bytes read per second by the scrubber, a nil limiter means
+// no limit.
+type bitrotScrubThrottle struct {
+	iops      *rate.Limiter
+	bandwidth *rate.Limiter
+}
+
+// newBitrotScrubThrottle - returns a throttle allowing iops shards and
+// bandwidth bytes per second, zero disables the limit.
+func newBitrotScrubThrottle(iops int, bandwidth uint64) *bitrotScrubThrottle {
+	throttle := &bitrotScrubThrottle{}
+	if iops > 0 {
+		throttle.iops = rate.NewLimiter(rate.Limit(iops), iops)
+	}
+	if bandwidth > 0 {
+		throttle.bandwidth = rate.NewLimiter(rate.Limit(bandwidth), int(bandwidth))
+	}
+	return throttle
+}
+
+// wait - blocks until shards verified and size bytes read are allowed.
+func (t *bitrotScrubThrottle) wait(ctx context.Context, shards int, size int64) {
+	waitLimiter(ctx, t.iops, int64(shards))
+	waitLimiter(ctx, t.bandwidth, size)
+}
+
+// waitLimiter - waits for n tokens of the limiter, in chunks as a single
+// wait cannot exceed the burst of the limiter.
+func waitLimiter(ctx context.Context, limiter *rate.Limiter, n int64) {
+	if limiter == nil {
+		return
+	}
+	for n > 0 {
+		chunk := n
+		if burst := int64(limiter.Burst()); chunk > burst {
+			chunk = burst
+		}
+		if err := limiter.WaitN(ctx, int(chunk)); err != nil {
+			return
+		}
+		n -= chunk
+	}
+}
+
+// startBitrotScrubber - verifies the checksums of all objects and heals
+// corrupted shards in background until the server stops, should be run
+// in a go-routine.
+func startBitrotScrubber(objAPI ObjectLayer) {
+	// Only erasure coded backends keep checksums of the shards.
+	if !globalIsXL && !globalIsDistXL || !globalIsBitrotScrubEnabled {
+		return
+	}
+
+	ctx := context.Background()
+
+	ticker := time.NewTicker(bitrotScrubTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-GlobalServiceDoneCh:
+			return
+		case <-ticker.C:
+			if err := bitrotScrubRound(ctx, objAPI); err != nil {
+				// Another node holds the leader lock and scrubs the objects.
+				if _, ok := err.(OperationTimedOut); ok {
+					continue
+				}
+				logger.LogIf(ctx, err)
+			}
+		}
+	}
+}
+
+// bitrotScrubRound - resumes the scrub of all buckets where the last round
+// stopped, or starts a new scrub if the last one finished more than
+// bitrotScrubInterval ago, only one node of the cluster scrubs at a time.
+func bitrotScrubRound(ctx context.Context, objAPI ObjectLayer) error {
+	// Do not wait for the leader lock, a failure means another node is the leader.
+	zeroDuration := time.Millisecond
+	leaderLock := globalNSMutex.NewNSLock(minioMetaBucket, bitrotScrubLeaderLock)
+	if err := leaderLock.GetLock(newDynamicTimeout(zeroDuration, zeroDuration)); err != nil {
+		return err
+	}
+	defer leaderLock.Unlock()
+
+	scrubStatus, err := loadBitrotScrubStatus(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+
+	if !scrubStatus.StartTime.After(scrubStatus.LastFinished) {
+		if UTCNow().Sub(scrubStatus.LastFinished) < bitrotScrubInterval {
+			return nil
+		}
+		scrubStatus.StartTime = UTCNow()
+		scrubStatus.Bucket = ""
+		scrubStatus.Object = ""
+	}
+
+	buckets, err := objAPI.ListBucketsHeal(ctx)
+	if err != nil {
+		return err
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Name < buckets[j].Name
+	})
+
+	throttle := newBitrotScrubThrottle(globalBitrotScrubMaxIOPS, globalBitrotScrubMaxBandwidth)
+	for _, bucket := range buckets {
+		if bucket.Name < scrubStatus.Bucket {
+			continue
+		}
+		if bucket.Name != scrubStatus.Bucket {
+			scrubStatus.Bucket = bucket.Name
+			scrubStatus.Object = ""
+		}
+
+		for {
+			// The progress is saved, the scrub resumes after a restart.
+			select {
+			case <-GlobalServiceDoneCh:
+				return nil
+			default:
+			}
+
+			objectInfos, err := objAPI.ListObjectsHeal(ctx, bucket.Name, "", scrubStatus.Object, "", bitrotScrubBatch)
+			if err != nil {
+				// Bucket removed while scrubbing.
+				if _, ok := err.(BucketNotFound); ok {
+					break
+				}
+				return err
+			}
+
+			for _, object := range objectInfos.Objects {
+				waitForLowHTTPReq()
+				if err = scrubObject(ctx, objAPI, bucket.Name, object.Name, &scrubStatus, throttle); err != nil {
+					// Object removed while scrubbing.
+					if isErrObjectNotFound(err) {
+						continue
+					}
+					logger.GetReqInfo(ctx).AppendTags("object", pathJoin(bucket.Name, object.Name))
+					logger.LogIf(ctx, err)
+				}
+			}
+
+			scrubStatus.Object = objectInfos.NextMarker
+			scrubStatus.LastUpdate = UTCNow()
+			if err = saveBitrotScrubStatus(ctx, objAPI, scrubStatus); err != nil {
+				return err
+			}
+			if !objectInfos.IsTruncated {
+				break
+			}
+		}
+	}
+
+	// All objects are scrubbed.
+	scrubStatus.Bucket = ""
+	scrubStatus.Object = ""
+	scrubStatus.LastUpdate = UTCNow()
+	scrubStatus.LastFinished = scrubStatus.LastUpdate
+	return saveBitrotScrubStatus(ctx, objAPI, scrubStatus)
+}
+
+// scrubObject - verifies the checksums of all shards of an object with
+// its bitrot algorithm and heals the corrupted shards.
+func scrubObject(ctx context.Context, objAPI ObjectLayer, bucket, object string, scrubStatus *madmin.BitrotScrubStatus, throttle *bitrotScrubThrottle) error {
+	// Tokens are reserved before the shards are read, from the size
+	// of the object and the redundancy of its storage class.
+	reservedShards := globalXLSetDriveCount
+	var reservedSize int64
+	if objInfo, err := objAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{}); err == nil {
+		dataBlocks, _ := getRedundancyCount(objInfo.StorageClass, reservedShards)
+		reservedSize = getScrubSize(objInfo.Size, dataBlocks, reservedShards)
+	}
+	throttle.wait(ctx, reservedShards, reservedSize)
+
+	// A dry run heal reads and verifies every shard without healing.
+	res, err := objAPI.HealObject(ctx, bucket, object, true, false)
+	if err != nil {
+		return err
+	}
+
+	// Shards of all drives are read, tokens read beyond the reservation
+	// are waited for afterwards.
+	shards := len(res.Before.Drives)
+	size := getScrubSize(res.ObjectSize, res.DataBlocks, res.DataBlocks+res.ParityBlocks)
+	scrubStatus.ObjectsScanned++
+	scrubStatus.BytesScanned += uint64(size)
+	if shards > reservedShards || size > reservedSize {
+		throttle.wait(ctx, shards-reservedShards, size-reservedSize)
+	}
+
+	var corrupted uint64
+	for _, drive := range res.Before.Drives {
+		if drive.State == madmin.DriveStateCorrupt {
+			corrupted++
+		}
+	}
+	if corrupted == 0 {
+		return nil
+	}
+	scrubStatus.CorruptionsFound += corrupted
+
+	if res, err = objAPI.HealObject(ctx, bucket, object, false, false); err != nil {
+		return err
+	}
+	for i, drive := range res.Before.Drives {
+		if drive.State == madmin.DriveStateCorrupt && res.After.Drives[i].State == madmin.DriveStateOk {
+			scrubStatus.CorruptionsFixed++
+		}
+	}
+	return nil
+}
+
+// getScrubSize - returns the number of bytes read to verify all the
+// shards of an object of the given size.
+func getScrubSize(objectSize int64, dataBlocks, totalBlocks int) int64 {
+	if objectSize <= 0 || dataBlocks <= 0 {
+		return 0
+	}
+	return ceilFrac(objectSize, int64(dataBlocks)) * int64(totalBlocks)
+}
+
+// loadBitrotScrubStatus - returns the persisted progress and counters of the scrubber.
+func loadBitrotScrubStatus(ctx context.Context, objAPI ObjectLayer) (madmin.BitrotScrubStatus, error) {
+	var scrubStatus madmin.BitrotScrubStatus
+
+	data, err := readConfig(ctx, objAPI, path.Join(minioConfigPrefix, bitrotScrubFile))
+	if err != nil {
+		if err == errConfigNotFound {
+			return scrubStatus, nil
+		}
+		return scrubStatus, err
+	}
+
+	if err = json.Unmarshal(data, &scrubStatus); err != nil {
+		return scrubStatus, err
+	}
+	return scrubStatus, nil
+}
+
+// saveBitrotScrubStatus - persists the progress and counters of the scrubber
+// under the minio meta bucket.
+func saveBitrotScrubStatus(ctx context.Context, objAPI ObjectLayer, scrubStatus madmin.BitrotScrubStatus) error {
+	data, err := json.Marshal(scrubStatus)
+	if err != nil {
+		return err
+	}
+
+	return saveConfig(ctx, objAPI, path.Join(minioConfigPrefix, bitrotScrubFile), data)
+}
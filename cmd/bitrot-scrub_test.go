@@ -0,0 +1,128 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBitrotScrubRound(t *testing.T) {
+	initNSLock(false)
+
+	// Store the objects in part files to corrupt a shard on disk.
+	defer func(threshold int64) { globalXLInlineThreshold = threshold }(globalXLInlineThreshold)
+	globalXLInlineThreshold = 0
+
+	nDisks := 16
+	disks, err := getRandomDisks(nDisks)
+	if err != nil {
+		t.Fatal("Failed to create disks for the backend")
+	}
+	defer removeRoots(disks)
+
+	obj, err := newObjectLayer(EndpointPools{
+		{SetCount: 1, DrivesPerSet: 16, Endpoints: mustGetNewEndpointList(disks...)},
+	})
+	if err != nil {
+		t.Fatal("Unexpected object layer initialization error", err)
+	}
+	defer obj.Shutdown(context.Background())
+
+	ctx := context.Background()
+	bucket := "bucket"
+	objects := []string{"object1", "object2", "prefix/object"}
+	data := bytes.Repeat([]byte("a"), 1024)
+	if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+	for _, object := range objects {
+		if _, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Corrupt the shard of object2 on the first drive.
+	var shard string
+	err = filepath.Walk(filepath.Join(disks[0], bucket, "object2"), func(path string, info os.FileInfo, err error) error {
+		if err == nil && info.Name() == "part.1" {
+			shard = path
+		}
+		return err
+	})
+	if err != nil || shard == "" {
+		t.Fatalf("Failed to find the shard of the object: %v", err)
+	}
+	healthyShard, err := ioutil.ReadFile(shard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(shard, bytes.Repeat([]byte("b"), len(healthyShard)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = bitrotScrubRound(ctx, obj); err != nil {
+		t.Fatal(err)
+	}
+
+	scrubStatus, err := loadBitrotScrubStatus(ctx, obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scrubStatus.LastFinished.IsZero() || scrubStatus.Bucket != "" {
+		t.Fatalf("Expected the scrub to be finished, got: %v", scrubStatus)
+	}
+	if scrubStatus.ObjectsScanned != uint64(len(objects)) {
+		t.Fatalf("Expected %d objects scanned, got: %d", len(objects), scrubStatus.ObjectsScanned)
+	}
+	if scrubStatus.CorruptionsFound != 1 || scrubStatus.CorruptionsFixed != 1 {
+		t.Fatalf("Expected 1 corruption found and fixed, got: %d and %d", scrubStatus.CorruptionsFound, scrubStatus.CorruptionsFixed)
+	}
+
+	// Look up the healed shard again, its data directory may have changed.
+	err = filepath.Walk(filepath.Join(disks[0], bucket, "object2"), func(path string, info os.FileInfo, err error) error {
+		if err == nil && info.Name() == "part.1" {
+			shard = path
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	shardData, err := ioutil.ReadFile(shard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(shardData, healthyShard) {
+		t.Fatal("Expected the corrupted shard to be healed")
+	}
+
+	// The next scrub is not due yet.
+	if err = bitrotScrubRound(ctx, obj); err != nil {
+		t.Fatal(err)
+	}
+	if scrubStatus, err = loadBitrotScrubStatus(ctx, obj); err != nil {
+		t.Fatal(err)
+	}
+	if scrubStatus.ObjectsScanned != uint64(len(objects)) {
+		t.Fatalf("Expected no new scrub, got %d objects scanned", scrubStatus.ObjectsScanned)
+	}
+}
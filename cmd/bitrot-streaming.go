@@ -22,6 +22,7 @@ import (
 	"encoding/hex"
 	"hash"
 	"io"
+	"io/ioutil"
 
 	"github.com/minio/minio/cmd/logger"
 )
@@ -37,6 +38,9 @@ type streamingBitrotWriter struct {
 	// len(p) is always the block size except the last block, i.e prevent programmer errors.
 	currentBlockIdx int
 	verifyTillIdx   int
+
+	// Holds the shard in memory for objects stored inline in `xl.json`.
+	data *bytes.Buffer
 }
 
 func (b *streamingBitrotWriter) Write(p []byte) (int, error) {
@@ -78,7 +82,7 @@ func (b *streamingBitrotWriter) Close() error {
 func newStreamingBitrotWriter(disk StorageAPI, volume, filePath string, length int64, algo BitrotAlgorithm, shardSize int64) io.WriteCloser {
 	r, w := io.Pipe()
 	h := algo.New()
-	bw := &streamingBitrotWriter{w, h, shardSize, make(chan struct{}), 0, int(length / shardSize)}
+	bw := &streamingBitrotWriter{w, h, shardSize, make(chan struct{}), 0, int(length / shardSize), nil}
 	go func() {
 		bitrotSumsTotalSize := ceilFrac(length, shardSize) * int64(h.Size()) // Size used for storing bitrot checksums.
 		totalFileSize := bitrotSumsTotalSize + length
@@ -92,6 +96,32 @@ func newStreamingBitrotWriter(disk StorageAPI, volume, filePath string, length i
 	return bw
 }
 
+// Returns streaming bitrot writer implementation keeping the shard in
+// memory, for objects stored inline in `xl.json`.
+func newInlineBitrotWriter(length int64, algo BitrotAlgorithm, shardSize int64) *streamingBitrotWriter {
+	r, w := io.Pipe()
+	h := algo.New()
+	data := &bytes.Buffer{}
+	data.Grow(int(ceilFrac(length, shardSize)*int64(h.Size()) + length))
+	bw := &streamingBitrotWriter{w, h, shardSize, make(chan struct{}), 0, int(length / shardSize), data}
+	go func() {
+		if _, err := data.ReadFrom(r); err != nil {
+			r.CloseWithError(err)
+		}
+		close(bw.canClose)
+	}()
+	return bw
+}
+
+// Returns the shard written by an inline bitrot writer once closed, nil
+// for other writers.
+func inlineBitrotWriterData(w io.Writer) []byte {
+	if bw, ok := w.(*streamingBitrotWriter); ok && bw.data != nil {
+		return bw.data.Bytes()
+	}
+	return nil
+}
+
 // ReadAt() implementation which verifies the bitrot hash available as part of the stream.
 type streamingBitrotReader struct {
 	disk       StorageAPI
@@ -103,6 +133,7 @@ type streamingBitrotReader struct {
 	h          hash.Hash
 	shardSize  int64
 	hashBytes  []byte
+	data       []byte // Shard stored inline in `xl.json`, used when disk is nil.
 }
 
 func (b *streamingBitrotReader) Close() error {
@@ -123,10 +154,14 @@ func (b *streamingBitrotReader) ReadAt(buf []byte, offset int64) (int, error) {
 		// For the first ReadAt() call we need to open the stream for reading.
 		b.currOffset = offset
 		streamOffset := (offset/b.shardSize)*int64(b.h.Size()) + offset
-		b.rc, err = b.disk.ReadFileStream(b.volume, b.filePath, streamOffset, b.tillOffset-streamOffset)
-		if err != nil {
-			logger.LogIf(context.Background(), err)
-			return 0, err
+		if b.disk == nil {
+			b.rc = ioutil.NopCloser(io.NewSectionReader(bytes.NewReader(b.data), streamOffset, b.tillOffset-streamOffset))
+		} else {
+			b.rc, err = b.disk.ReadFileStream(b.volume, b.filePath, streamOffset, b.tillOffset-streamOffset)
+			if err != nil {
+				logger.LogIf(context.Background(), err)
+				return 0, err
+			}
 		}
 	}
 	if offset != b.currOffset {
@@ -168,5 +203,14 @@ func newStreamingBitrotReader(disk StorageAPI, volume, filePath string, tillOffs
 		h,
 		shardSize,
 		make([]byte, h.Size()),
+		nil,
 	}
 }
+
+// Returns streaming bitrot reader implementation reading a shard
+// stored inline in `xl.json`.
+func newInlineBitrotReader(data []byte, tillOffset int64, algo BitrotAlgorithm, shardSize int64) *streamingBitrotReader {
+	r := newStreamingBitrotReader(nil, "", "", tillOffset, algo, shardSize)
+	r.data = data
+	return r
+}
@@ -164,8 +164,17 @@ func bitrotCheckFile(disk StorageAPI, volume string, filePath string, tillOffset
 		_, err = disk.ReadFile(volume, filePath, 0, buf, NewBitrotVerifier(algo, sum))
 		return err
 	}
+	return bitrotCheckStream(newStreamingBitrotReader(disk, volume, filePath, tillOffset, algo, shardSize), tillOffset, shardSize)
+}
+
+// Verify if a shard stored inline in `xl.json` has bitrot error.
+func bitrotCheckInline(data []byte, tillOffset int64, algo BitrotAlgorithm, shardSize int64) error {
+	return bitrotCheckStream(newInlineBitrotReader(data, tillOffset, algo, shardSize), tillOffset, shardSize)
+}
+
+// Reads a streaming-bitrot shard till tillOffset, verifying every block.
+func bitrotCheckStream(r *streamingBitrotReader, tillOffset int64, shardSize int64) (err error) {
 	buf := make([]byte, shardSize)
-	r := newStreamingBitrotReader(disk, volume, filePath, tillOffset, algo, shardSize)
 	defer closeBitrotReaders([]io.ReaderAt{r})
 	var offset int64
 	for {
@@ -0,0 +1,303 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/cors"
+)
+
+const (
+	// CORS configuration file.
+	bucketCorsConfig = "cors.xml"
+)
+
+// BucketCorsSys - Bucket CORS subsystem.
+type BucketCorsSys struct {
+	sync.RWMutex
+	bucketCorsMap map[string]cors.Config
+}
+
+// removeDeletedBuckets - to handle a corner case where we have cached the CORS
+// configuration for a deleted bucket. i.e if we miss a delete-bucket notification we
+// should delete the corresponding CORS configuration during sys.refresh()
+func (sys *BucketCorsSys) removeDeletedBuckets(bucketInfos []BucketInfo) {
+	buckets := set.NewStringSet()
+	for _, info := range bucketInfos {
+		buckets.Add(info.Name)
+	}
+	sys.Lock()
+	defer sys.Unlock()
+
+	for bucket := range sys.bucketCorsMap {
+		if !buckets.Contains(bucket) {
+			delete(sys.bucketCorsMap, bucket)
+		}
+	}
+}
+
+// Set - sets CORS configuration to given bucket name.
+func (sys *BucketCorsSys) Set(bucketName string, config cors.Config) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.bucketCorsMap[bucketName] = config
+}
+
+// Remove - removes CORS configuration for given bucket name.
+func (sys *BucketCorsSys) Remove(bucketName string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	delete(sys.bucketCorsMap, bucketName)
+}
+
+// Get - returns CORS configuration of given bucket name.
+func (sys *BucketCorsSys) Get(bucketName string) (config cors.Config, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	config, ok = sys.bucketCorsMap[bucketName]
+	return config, ok
+}
+
+// Refresh BucketCorsSys.
+func (sys *BucketCorsSys) refresh(objAPI ObjectLayer) error {
+	buckets, err := objAPI.ListBuckets(context.Background())
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		return err
+	}
+	sys.removeDeletedBuckets(buckets)
+	for _, bucket := range buckets {
+		config, err := getCorsConfig(objAPI, bucket.Name)
+		if err != nil {
+			if err == errConfigNotFound {
+				sys.Remove(bucket.Name)
+			}
+			continue
+		}
+		sys.Set(bucket.Name, *config)
+	}
+	return nil
+}
+
+// Init - initializes CORS system from cors.xml of all buckets.
+func (sys *BucketCorsSys) Init(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	defer func() {
+		// Refresh BucketCorsSys in background.
+		go func() {
+			ticker := time.NewTicker(globalRefreshBucketPolicyInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-GlobalServiceDoneCh:
+					return
+				case <-ticker.C:
+					sys.refresh(objAPI)
+				}
+			}
+		}()
+	}()
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	// Initializing CORS needs a retry mechanism for
+	// the following reasons:
+	//  - Read quorum is lost just after the initialization
+	//    of the object layer.
+	for range newRetryTimerSimple(doneCh) {
+		// Load BucketCorsSys once during boot.
+		if err := sys.refresh(objAPI); err != nil {
+			if err == errDiskNotFound ||
+				strings.Contains(err.Error(), InsufficientReadQuorum{}.Error()) ||
+				strings.Contains(err.Error(), InsufficientWriteQuorum{}.Error()) {
+				logger.Info("Waiting for CORS subsystem to be initialized..")
+				continue
+			}
+			return err
+		}
+		break
+	}
+	return nil
+}
+
+// NewBucketCorsSys - creates new CORS system.
+func NewBucketCorsSys() *BucketCorsSys {
+	return &BucketCorsSys{
+		bucketCorsMap: make(map[string]cors.Config),
+	}
+}
+
+// getCorsConfig - get CORS config for given bucket name.
+func getCorsConfig(objAPI ObjectLayer, bucketName string) (*cors.Config, error) {
+	// Construct path to cors.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketCorsConfig)
+
+	configData, err := readConfig(context.Background(), objAPI, configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return cors.ParseConfig(bytes.NewReader(configData))
+}
+
+func saveCorsConfig(ctx context.Context, objAPI ObjectLayer, bucketName string, config *cors.Config) error {
+	data, err := xml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	// Construct path to cors.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketCorsConfig)
+
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+// removeCorsConfig - removes cors.xml for a given bucket.
+func removeCorsConfig(ctx context.Context, objAPI ObjectLayer, bucketName string) error {
+	// Construct path to cors.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketCorsConfig)
+
+	if err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return BucketCorsNotFound{Bucket: bucketName}
+		}
+		return err
+	}
+	return nil
+}
+
+// CORS request and response headers.
+const (
+	corsOrigin           = "Origin"
+	corsRequestMethod    = "Access-Control-Request-Method"
+	corsRequestHeaders   = "Access-Control-Request-Headers"
+	corsAllowOrigin      = "Access-Control-Allow-Origin"
+	corsAllowMethods     = "Access-Control-Allow-Methods"
+	corsAllowHeaders     = "Access-Control-Allow-Headers"
+	corsAllowCredentials = "Access-Control-Allow-Credentials"
+	corsExposeHeaders    = "Access-Control-Expose-Headers"
+	corsMaxAge           = "Access-Control-Max-Age"
+	corsVary             = "Vary"
+)
+
+// getRequestCorsConfig - returns the CORS configuration of the bucket
+// addressed by a cross origin request. Requests to the reserved buckets,
+// like browser and admin requests, never use a bucket CORS configuration.
+func getRequestCorsConfig(r *http.Request) (cors.Config, bool) {
+	if r.Header.Get(corsOrigin) == "" {
+		return cors.Config{}, false
+	}
+
+	bucket := getRequestBucketName(r)
+	if bucket == "" || isMinioReservedBucket(bucket) || isMinioMetaBucket(bucket) {
+		return cors.Config{}, false
+	}
+
+	return globalBucketCorsSys.Get(bucket)
+}
+
+// isCorsPreflightReq - returns true if the request is a CORS preflight request.
+func isCorsPreflightReq(r *http.Request) bool {
+	return r.Method == http.MethodOptions && r.Header.Get(corsRequestMethod) != ""
+}
+
+// parseCorsRequestHeaders - parses the comma separated header names of
+// Access-Control-Request-Headers.
+func parseCorsRequestHeaders(value string) (headers []string) {
+	for _, header := range strings.Split(value, ",") {
+		if header = strings.TrimSpace(header); header != "" {
+			headers = append(headers, header)
+		}
+	}
+	return headers
+}
+
+// setCorsHeaders - sets the response headers common to preflight and
+// actual requests allowed by the given rule.
+func setCorsHeaders(h http.Header, origin string, rule cors.Rule) {
+	if rule.AllowsAnyOrigin() {
+		h.Set(corsAllowOrigin, "*")
+	} else {
+		h.Set(corsAllowOrigin, origin)
+		h.Set(corsAllowCredentials, "true")
+	}
+	if len(rule.ExposeHeaders) > 0 {
+		h.Set(corsExposeHeaders, strings.Join(rule.ExposeHeaders, ", "))
+	}
+}
+
+// writeCorsPreflightResponse - answers a preflight request as per the first
+// matching rule of the bucket CORS configuration, a preflight request not
+// matching any rule is forbidden.
+func writeCorsPreflightResponse(w http.ResponseWriter, r *http.Request, config cors.Config) {
+	origin := r.Header.Get(corsOrigin)
+	headers := parseCorsRequestHeaders(r.Header.Get(corsRequestHeaders))
+
+	h := w.Header()
+	h.Add(corsVary, corsOrigin)
+	h.Add(corsVary, corsRequestMethod)
+	h.Add(corsVary, corsRequestHeaders)
+
+	rule, ok := config.Match(origin, r.Header.Get(corsRequestMethod), headers)
+	if !ok {
+		writeErrorResponse(context.Background(), w, errorCodes.ToAPIErr(ErrCORSNotAllowed), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	setCorsHeaders(h, origin, rule)
+	h.Set(corsAllowMethods, strings.Join(rule.AllowedMethods, ", "))
+	if len(headers) > 0 {
+		h.Set(corsAllowHeaders, strings.Join(headers, ", "))
+	}
+	if rule.MaxAgeSeconds != nil {
+		h.Set(corsMaxAge, strconv.Itoa(*rule.MaxAgeSeconds))
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// setCorsResponseHeaders - sets the CORS response headers of an actual
+// request as per the first matching rule of the bucket CORS configuration.
+// No CORS headers are set if no rule matches, so browsers reject the response.
+func setCorsResponseHeaders(w http.ResponseWriter, r *http.Request, config cors.Config) {
+	origin := r.Header.Get(corsOrigin)
+
+	h := w.Header()
+	h.Add(corsVary, corsOrigin)
+
+	if rule, ok := config.Match(origin, r.Method, nil); ok {
+		setCorsHeaders(h, origin, rule)
+	}
+}
@@ -217,3 +217,80 @@ func (api objectAPIHandlers) ListObjectsV1Handler(w http.ResponseWriter, r *http
 	// Write success response.
 	writeSuccessResponseXML(w, encodeResponse(response))
 }
+
+// ListObjectVersionsHandler - GET Bucket versions.
+// --------------------------
+// This implementation of the GET operation returns some or all (up to 1000)
+// of the versions of the objects in a bucket, including delete markers.
+func (api objectAPIHandlers) ListObjectVersionsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListObjectVersions")
+
+	defer logger.AuditLog(w, r, "ListObjectVersions", mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.ListBucketVersionsAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Extract all the listObjectVersions query params to their native values.
+	prefix, keyMarker, versionIDMarker, delimiter, maxKeys, encodingType, s3Error := getListObjectVersionsArgs(r.URL.Query())
+	if s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Validate all the query params before beginning to serve the request.
+	if s3Error := validateListObjectsArgs(prefix, keyMarker, delimiter, encodingType, maxKeys); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// A version ID marker is only valid along with a key marker.
+	if versionIDMarker != "" && keyMarker == "" {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidVersionIDMarker), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Inititate a list object versions operation based on the input params.
+	// On success would return back ListObjectVersionsInfo object to be
+	// marshaled into S3 compatible XML header.
+	listObjectVersionsInfo, err := objectAPI.ListObjectVersions(ctx, bucket, prefix, keyMarker, versionIDMarker, delimiter, maxKeys)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	for i := range listObjectVersionsInfo.Objects {
+		var actualSize int64
+		if listObjectVersionsInfo.Objects[i].IsCompressed() {
+			// Read the decompressed size from the meta.json.
+			actualSize = listObjectVersionsInfo.Objects[i].GetActualSize()
+			if actualSize < 0 {
+				writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidDecompressedSize), r.URL, guessIsBrowserReq(r))
+				return
+			}
+			// Set the info.Size to the actualSize.
+			listObjectVersionsInfo.Objects[i].Size = actualSize
+		} else if crypto.IsEncrypted(listObjectVersionsInfo.Objects[i].UserDefined) {
+			listObjectVersionsInfo.Objects[i].ETag = getDecryptedETag(r.Header, listObjectVersionsInfo.Objects[i], false)
+			listObjectVersionsInfo.Objects[i].Size, err = listObjectVersionsInfo.Objects[i].DecryptedSize()
+			if err != nil {
+				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+				return
+			}
+		}
+	}
+	response := generateListVersionsResponse(bucket, prefix, keyMarker, versionIDMarker, delimiter, encodingType, maxKeys, listObjectVersionsInfo)
+
+	// Write success response.
+	writeSuccessResponseXML(w, encodeResponse(response))
+}
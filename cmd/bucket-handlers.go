@@ -325,6 +325,9 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 		deleteObject = api.CacheAPI().DeleteObject
 	}
 
+	// Objects in buckets with versioning are removed by adding a delete marker.
+	versioned := globalBucketVersioningSys.Configured(bucket)
+
 	var dErrs = make([]error, len(deleteObjects.Objects))
 	for index, object := range deleteObjects.Objects {
 		// If the request is denied access, each item
@@ -336,7 +339,35 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 			}
 			continue
 		}
-		dErrs[index] = deleteObject(ctx, bucket, object.ObjectName)
+		if object.VersionID != "" && object.VersionID != nullVersionID && !objectAPI.IsVersioningSupported() {
+			dErrs[index] = VersionNotFound{
+				Bucket:    bucket,
+				Object:    object.ObjectName,
+				VersionID: object.VersionID,
+			}
+			continue
+		}
+		// A deletion releases the size of the version it removes.
+		prevSize := getQuotaObjectSize(ctx, objectAPI, bucket, object.ObjectName, object.VersionID)
+		bypassGovernance := isBypassGovernanceReq(r, bucket, object.ObjectName)
+		if object.VersionID == "" && !versioned && !bypassGovernance {
+			if dErrs[index] = deleteObject(ctx, bucket, object.ObjectName); dErrs[index] == nil {
+				globalBucketQuotaSys.update(objectAPI, bucket, -prevSize)
+			}
+			continue
+		}
+		opts := ObjectOptions{VersionID: object.VersionID, BypassGovernance: bypassGovernance}
+		setVersioningOpts(&opts, bucket)
+		objInfo, err := objectAPI.DeleteObjectVersion(ctx, bucket, object.ObjectName, opts)
+		if err == nil {
+			globalBucketQuotaSys.update(objectAPI, bucket, -prevSize)
+		}
+		if err == nil && objInfo.DeleteMarker {
+			// Report the delete marker which was added or removed.
+			deleteObjects.Objects[index].DeleteMarker = true
+			deleteObjects.Objects[index].DeleteMarkerVersionID = getVersionID(objInfo.VersionID)
+		}
+		dErrs[index] = err
 	}
 
 	// Collect deleted objects and errors if any.
@@ -358,9 +389,10 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 		apiErr := toAPIError(ctx, err)
 		// Error during delete should be collected separately.
 		deleteErrors = append(deleteErrors, DeleteError{
-			Code:    apiErr.Code,
-			Message: apiErr.Description,
-			Key:     object.ObjectName,
+			Code:      apiErr.Code,
+			Message:   apiErr.Description,
+			Key:       object.ObjectName,
+			VersionID: object.VersionID,
 		})
 	}
 
@@ -380,11 +412,17 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 
 	// Notify deleted event for objects.
 	for _, dobj := range deletedObjects {
+		queueDeleteReplication(ctx, objectAPI, r, bucket, dobj.ObjectName, dobj.VersionID)
+		eventName := event.ObjectRemovedDelete
+		if dobj.VersionID == "" && dobj.DeleteMarker {
+			eventName = event.ObjectRemovedDeleteMarkerCreated
+		}
 		sendEvent(eventArgs{
-			EventName:  event.ObjectRemovedDelete,
+			EventName:  eventName,
 			BucketName: bucket,
 			Object: ObjectInfo{
-				Name: dobj.ObjectName,
+				Name:      dobj.ObjectName,
+				VersionID: dobj.VersionID,
 			},
 			ReqParams:    extractReqParams(r),
 			RespElements: extractRespElements(w),
@@ -431,6 +469,13 @@ func (api objectAPIHandlers) PutBucketHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// Object lock configuration is stored in the minio meta bucket which gateways do not have.
+	objectLockEnabled := strings.EqualFold(r.Header.Get(amzBucketObjectLockEnabled), "true")
+	if objectLockEnabled && globalIsGateway {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	if globalDNSConfig != nil {
 		if _, err := globalDNSConfig.Get(bucket); err != nil {
 			if err == dns.ErrNoEntriesFound {
@@ -439,6 +484,13 @@ func (api objectAPIHandlers) PutBucketHandler(w http.ResponseWriter, r *http.Req
 					writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 					return
 				}
+				if objectLockEnabled {
+					if err = enableObjectLock(ctx, objectAPI, bucket); err != nil {
+						objectAPI.DeleteBucket(ctx, bucket)
+						writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+						return
+					}
+				}
 				if err = globalDNSConfig.Put(bucket); err != nil {
 					objectAPI.DeleteBucket(ctx, bucket)
 					writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
@@ -449,6 +501,20 @@ func (api objectAPIHandlers) PutBucketHandler(w http.ResponseWriter, r *http.Req
 				w.Header().Set("Location", getObjectLocation(r, globalDomainNames, bucket, ""))
 
 				writeSuccessResponseHeadersOnly(w)
+
+				// Get host and port from Request.RemoteAddr.
+				host, port, _ := net.SplitHostPort(handlers.GetSourceIP(r))
+
+				// Notify bucket created event.
+				sendEvent(eventArgs{
+					EventName:    event.BucketCreated,
+					BucketName:   bucket,
+					ReqParams:    extractReqParams(r),
+					RespElements: extractRespElements(w),
+					UserAgent:    r.UserAgent(),
+					Host:         host,
+					Port:         port,
+				})
 				return
 			}
 			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
@@ -466,10 +532,32 @@ func (api objectAPIHandlers) PutBucketHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if objectLockEnabled {
+		if err = enableObjectLock(ctx, objectAPI, bucket); err != nil {
+			objectAPI.DeleteBucket(ctx, bucket)
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	}
+
 	// Make sure to add Location information here only for bucket
 	w.Header().Set("Location", path.Clean(r.URL.Path)) // Clean any trailing slashes.
 
 	writeSuccessResponseHeadersOnly(w)
+
+	// Get host and port from Request.RemoteAddr.
+	host, port, _ := net.SplitHostPort(handlers.GetSourceIP(r))
+
+	// Notify bucket created event.
+	sendEvent(eventArgs{
+		EventName:    event.BucketCreated,
+		BucketName:   bucket,
+		ReqParams:    extractReqParams(r),
+		RespElements: extractRespElements(w),
+		UserAgent:    r.UserAgent(),
+		Host:         host,
+		Port:         port,
+	})
 }
 
 // PostPolicyBucketHandler - POST policy
@@ -624,6 +712,9 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 		return
 	}
 
+	// Browser uploads are never replicas of another bucket.
+	setReplicationMetadata(false, bucket, object, metadata)
+
 	hashReader, err := hash.NewReader(fileBody, fileSize, "", "", fileSize)
 	if err != nil {
 		logger.LogIf(ctx, err)
@@ -645,6 +736,7 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 		writeErrorResponseHeadersOnly(w, toAPIError(ctx, err))
 		return
 	}
+	setVersioningOpts(&opts, bucket)
 	if objectAPI.IsEncryptionSupported() {
 		if hasServerSideEncryptionHeader(formValues) && !hasSuffix(object, slashSeparator) { // handle SSE-C and SSE-S3 requests
 			var reader io.Reader
@@ -671,15 +763,28 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 		}
 	}
 
+	// Deny writes beyond the hard quota of the bucket, an overwrite
+	// releases the size of the object it replaces.
+	prevSize := getQuotaObjectSize(ctx, objectAPI, bucket, object, "")
+	if err = globalBucketQuotaSys.enforce(ctx, objectAPI, bucket, fileSize-prevSize); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	objInfo, err := objectAPI.PutObject(ctx, bucket, object, pReader, opts)
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
+	globalBucketQuotaSys.update(objectAPI, bucket, objInfo.Size-prevSize)
+
+	// Replicate the object asynchronously.
+	queueObjectReplication(ctx, objectAPI, objInfo)
 
 	location := getObjectLocation(r, globalDomainNames, bucket, object)
 	w.Header().Set("ETag", `"`+objInfo.ETag+`"`)
 	w.Header().Set("Location", location)
+	setVersionHeaders(w, objInfo)
 
 	// Get host and port from Request.RemoteAddr.
 	host, port, err := net.SplitHostPort(handlers.GetSourceIP(r))
@@ -790,8 +895,31 @@ func (api objectAPIHandlers) DeleteBucketHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
+	// Get host and port from Request.RemoteAddr.
+	host, port, _ := net.SplitHostPort(handlers.GetSourceIP(r))
+
+	// Notify bucket removed event, before its notification
+	// configuration is removed.
+	sendEvent(eventArgs{
+		EventName:    event.BucketRemoved,
+		BucketName:   bucket,
+		ReqParams:    extractReqParams(r),
+		RespElements: extractRespElements(w),
+		UserAgent:    r.UserAgent(),
+		Host:         host,
+		Port:         port,
+	})
+
 	globalNotificationSys.RemoveNotification(bucket)
 	globalPolicySys.Remove(bucket)
+	globalBucketVersioningSys.Remove(bucket)
+	globalLifecycleSys.Remove(bucket)
+	globalBucketCorsSys.Remove(bucket)
+	globalBucketWebsiteSys.Remove(bucket)
+	globalBucketLoggingSys.Remove(bucket)
+	globalReplicationSys.Remove(bucket)
+	globalBucketObjectLockSys.Remove(bucket)
+	globalBucketQuotaSys.Remove(bucket)
 	globalNotificationSys.DeleteBucket(ctx, bucket)
 
 	if globalDNSConfig != nil {
@@ -0,0 +1,517 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/xml"
+	"net"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/accesslog"
+	"github.com/minio/minio/pkg/handlers"
+	"github.com/minio/minio/pkg/hash"
+)
+
+const (
+	// Logging configuration file.
+	bucketLoggingConfig = "logging.xml"
+
+	// Interval at which buffered access log records are delivered.
+	accessLogDeliveryInterval = 5 * time.Minute
+
+	// Size of the buffered records of a target bucket which triggers
+	// their delivery before the end of the interval.
+	maxAccessLogObjectSize = 5 * humanize.MiByte
+
+	// Maximum size of an error response read to find its error code.
+	maxAccessLogErrorSize = 4 * humanize.KiByte
+)
+
+// accessLogTarget - target bucket and prefix of access log objects.
+type accessLogTarget struct {
+	bucket string
+	prefix string
+}
+
+// BucketLoggingSys - Bucket logging subsystem.
+type BucketLoggingSys struct {
+	sync.RWMutex
+	bucketLoggingMap map[string]accesslog.Config
+
+	// Access log records waiting to be delivered, by target.
+	recordsMutex sync.Mutex
+	records      map[accessLogTarget]*bytes.Buffer
+}
+
+// removeDeletedBuckets - to handle a corner case where we have cached the logging
+// configuration for a deleted bucket. i.e if we miss a delete-bucket notification we
+// should delete the corresponding logging configuration during sys.refresh()
+func (sys *BucketLoggingSys) removeDeletedBuckets(bucketInfos []BucketInfo) {
+	buckets := set.NewStringSet()
+	for _, info := range bucketInfos {
+		buckets.Add(info.Name)
+	}
+	sys.Lock()
+	defer sys.Unlock()
+
+	for bucket := range sys.bucketLoggingMap {
+		if !buckets.Contains(bucket) {
+			delete(sys.bucketLoggingMap, bucket)
+		}
+	}
+}
+
+// Set - sets logging configuration to given bucket name.
+func (sys *BucketLoggingSys) Set(bucketName string, config accesslog.Config) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.bucketLoggingMap[bucketName] = config
+}
+
+// Remove - removes logging configuration for given bucket name.
+func (sys *BucketLoggingSys) Remove(bucketName string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	delete(sys.bucketLoggingMap, bucketName)
+}
+
+// Get - returns logging configuration of given bucket name.
+func (sys *BucketLoggingSys) Get(bucketName string) (config accesslog.Config, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	config, ok = sys.bucketLoggingMap[bucketName]
+	return config, ok
+}
+
+// Log - buffers the access log record of a request on a bucket with the
+// given logging configuration until it is delivered to the target bucket.
+func (sys *BucketLoggingSys) Log(config accesslog.Config, record accesslog.Record) {
+	if !config.Enabled() {
+		return
+	}
+
+	target := accessLogTarget{
+		bucket: config.LoggingEnabled.TargetBucket,
+		prefix: config.LoggingEnabled.TargetPrefix,
+	}
+
+	sys.recordsMutex.Lock()
+	defer sys.recordsMutex.Unlock()
+
+	buffer, ok := sys.records[target]
+	if !ok {
+		buffer = new(bytes.Buffer)
+		sys.records[target] = buffer
+	}
+	buffer.WriteString(record.String())
+	buffer.WriteByte('\n')
+
+	if buffer.Len() >= maxAccessLogObjectSize {
+		delete(sys.records, target)
+		go func() {
+			if objAPI := newObjectLayerFn(); objAPI != nil {
+				logger.LogIf(context.Background(), deliverAccessLog(context.Background(), objAPI, target, buffer.Bytes()))
+			}
+		}()
+	}
+}
+
+// deliver - delivers all buffered access log records to their target buckets.
+func (sys *BucketLoggingSys) deliver(objAPI ObjectLayer) {
+	sys.recordsMutex.Lock()
+	records := sys.records
+	sys.records = make(map[accessLogTarget]*bytes.Buffer)
+	sys.recordsMutex.Unlock()
+
+	ctx := context.Background()
+	for target, buffer := range records {
+		if err := deliverAccessLog(ctx, objAPI, target, buffer.Bytes()); err != nil {
+			logger.GetReqInfo(ctx).AppendTags("targetBucket", target.bucket)
+			logger.LogIf(ctx, err)
+		}
+	}
+}
+
+// Refresh BucketLoggingSys.
+func (sys *BucketLoggingSys) refresh(objAPI ObjectLayer) error {
+	buckets, err := objAPI.ListBuckets(context.Background())
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		return err
+	}
+	sys.removeDeletedBuckets(buckets)
+	for _, bucket := range buckets {
+		config, err := getLoggingConfig(objAPI, bucket.Name)
+		if err != nil {
+			if err == errConfigNotFound {
+				sys.Remove(bucket.Name)
+			}
+			continue
+		}
+		sys.Set(bucket.Name, *config)
+	}
+	return nil
+}
+
+// Init - initializes logging system from logging.xml of all buckets.
+func (sys *BucketLoggingSys) Init(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	defer func() {
+		// Refresh BucketLoggingSys and deliver access logs in background.
+		go func() {
+			ticker := time.NewTicker(globalRefreshBucketPolicyInterval)
+			defer ticker.Stop()
+			deliveryTicker := time.NewTicker(accessLogDeliveryInterval)
+			defer deliveryTicker.Stop()
+			for {
+				select {
+				case <-GlobalServiceDoneCh:
+					sys.deliver(objAPI)
+					return
+				case <-ticker.C:
+					sys.refresh(objAPI)
+				case <-deliveryTicker.C:
+					sys.deliver(objAPI)
+				}
+			}
+		}()
+	}()
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	// Initializing logging needs a retry mechanism for
+	// the following reasons:
+	//  - Read quorum is lost just after the initialization
+	//    of the object layer.
+	for range newRetryTimerSimple(doneCh) {
+		// Load BucketLoggingSys once during boot.
+		if err := sys.refresh(objAPI); err != nil {
+			if err == errDiskNotFound ||
+				strings.Contains(err.Error(), InsufficientReadQuorum{}.Error()) ||
+				strings.Contains(err.Error(), InsufficientWriteQuorum{}.Error()) {
+				logger.Info("Waiting for logging subsystem to be initialized..")
+				continue
+			}
+			return err
+		}
+		break
+	}
+	return nil
+}
+
+// NewBucketLoggingSys - creates new logging system.
+func NewBucketLoggingSys() *BucketLoggingSys {
+	return &BucketLoggingSys{
+		bucketLoggingMap: make(map[string]accesslog.Config),
+		records:          make(map[accessLogTarget]*bytes.Buffer),
+	}
+}
+
+// getLoggingConfig - get logging config for given bucket name.
+func getLoggingConfig(objAPI ObjectLayer, bucketName string) (*accesslog.Config, error) {
+	// Construct path to logging.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketLoggingConfig)
+
+	configData, err := readConfig(context.Background(), objAPI, configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return accesslog.ParseConfig(bytes.NewReader(configData))
+}
+
+func saveLoggingConfig(ctx context.Context, objAPI ObjectLayer, bucketName string, config *accesslog.Config) error {
+	data, err := xml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	// Construct path to logging.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketLoggingConfig)
+
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+// removeLoggingConfig - removes logging.xml for a given bucket, disabling
+// logging of a bucket without logging configuration is not an error.
+func removeLoggingConfig(ctx context.Context, objAPI ObjectLayer, bucketName string) error {
+	// Construct path to logging.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketLoggingConfig)
+
+	if err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// deliverAccessLog - writes access log records as a new object under the
+// prefix of the target bucket.
+func deliverAccessLog(ctx context.Context, objAPI ObjectLayer, target accessLogTarget, data []byte) error {
+	hashReader, err := hash.NewReader(bytes.NewReader(data), int64(len(data)), "", getSHA256Hash(data), int64(len(data)))
+	if err != nil {
+		return err
+	}
+
+	// Log objects of a versioned target bucket are versioned as well.
+	opts := ObjectOptions{UserDefined: map[string]string{"content-type": "text/plain"}}
+	setVersioningOpts(&opts, target.bucket)
+
+	uniqueID := strings.ToUpper(strings.Replace(mustGetUUID(), "-", "", -1))[:16]
+	object := accesslog.ObjectKey(target.prefix, UTCNow(), uniqueID)
+
+	_, err = objAPI.PutObject(ctx, target.bucket, object, NewPutObjReader(hashReader, nil, nil), opts)
+	return err
+}
+
+// accessLogRecorder - wraps http.ResponseWriter to record the response data
+// written to the access log.
+type accessLogRecorder struct {
+	http.ResponseWriter
+	statusCode    int
+	bytesSent     int64
+	firstByteTime time.Time
+	errorResponse bytes.Buffer
+}
+
+// Wraps ResponseWriter's WriteHeader() and records the response status code.
+func (rec *accessLogRecorder) WriteHeader(statusCode int) {
+	if rec.statusCode == 0 {
+		rec.statusCode = statusCode
+		rec.firstByteTime = UTCNow()
+	}
+	rec.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Wraps ResponseWriter's Write() and records the response size, as well as
+// the beginning of error responses.
+func (rec *accessLogRecorder) Write(b []byte) (int, error) {
+	if rec.statusCode == 0 {
+		rec.statusCode = http.StatusOK
+		rec.firstByteTime = UTCNow()
+	}
+
+	if rec.statusCode >= http.StatusBadRequest {
+		if n := maxAccessLogErrorSize - rec.errorResponse.Len(); n > 0 {
+			if n > len(b) {
+				n = len(b)
+			}
+			rec.errorResponse.Write(b[:n])
+		}
+	}
+
+	n, err := rec.ResponseWriter.Write(b)
+	rec.bytesSent += int64(n)
+	return n, err
+}
+
+// Wraps ResponseWriter's Flush()
+func (rec *accessLogRecorder) Flush() {
+	rec.ResponseWriter.(http.Flusher).Flush()
+}
+
+func (rec *accessLogRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return rec.ResponseWriter.(http.Hijacker).Hijack()
+}
+
+// errorCode - returns the S3 error code of the recorded error response.
+func (rec *accessLogRecorder) errorCode() string {
+	if rec.errorResponse.Len() == 0 {
+		return ""
+	}
+
+	var errResp APIErrorResponse
+	if err := xml.Unmarshal(rec.errorResponse.Bytes(), &errResp); err != nil {
+		return ""
+	}
+	return errResp.Code
+}
+
+// Resource names of bucket operations in access log records, by sub-resource.
+var accessLogBucketResources = map[string]string{
+	"acl":          "ACL",
+	"cors":         "CORS",
+	"delete":       "MULTI_OBJECT_DELETE",
+	"lifecycle":    "LIFECYCLE",
+	"location":     "LOCATION",
+	"logging":      "LOGGING_STATUS",
+	"notification": "NOTIFICATION",
+	"policy":       "BUCKETPOLICY",
+	"tagging":      "TAGGING",
+	"uploads":      "UPLOADS",
+	"versioning":   "VERSIONING",
+	"versions":     "BUCKETVERSIONS",
+	"website":      "WEBSITE",
+}
+
+// Resource names of object operations in access log records, by sub-resource.
+var accessLogObjectResources = map[string]string{
+	"acl":      "ACL",
+	"tagging":  "OBJECT_TAGGING",
+	"uploadId": "UPLOAD",
+	"uploads":  "UPLOADS",
+}
+
+// getAccessLogOperation - returns the operation of a request in the access
+// log format, e.g. REST.GET.OBJECT or WEBSITE.GET.OBJECT.
+func getAccessLogOperation(r *http.Request, object string) string {
+	if isWebsiteReq(r, nil) {
+		return "WEBSITE." + r.Method + ".OBJECT"
+	}
+
+	resources := accessLogBucketResources
+	resource := "BUCKET"
+	if object != "" {
+		resources = accessLogObjectResources
+		resource = "OBJECT"
+	}
+
+	for name := range r.URL.Query() {
+		if value, ok := resources[name]; ok {
+			resource = value
+			break
+		}
+	}
+
+	switch {
+	case resource == "UPLOAD" && r.Method == http.MethodPut:
+		resource = "PART"
+	case resource == "OBJECT" && r.Method == http.MethodPut && r.Header.Get("X-Amz-Copy-Source") != "":
+		return "REST.COPY.OBJECT"
+	}
+
+	return "REST." + r.Method + "." + resource
+}
+
+// getAccessLogObjectSize - returns the size of the object of a request, or
+// zero if the request is not on an object.
+func getAccessLogObjectSize(r *http.Request, w http.ResponseWriter, object string) int64 {
+	if object == "" {
+		return 0
+	}
+
+	if r.Method == http.MethodPut {
+		return r.ContentLength
+	}
+
+	// Ranged requests return the size of the object after a slash, i.e. bytes 0-99/1000
+	if contentRange := w.Header().Get("Content-Range"); contentRange != "" {
+		if i := strings.LastIndex(contentRange, "/"); i >= 0 {
+			size, _ := strconv.ParseInt(contentRange[i+1:], 10, 64)
+			return size
+		}
+	}
+
+	size, _ := strconv.ParseInt(w.Header().Get("Content-Length"), 10, 64)
+	return size
+}
+
+// tlsVersionNames - TLS versions as written to access log records.
+var tlsVersionNames = map[uint16]string{
+	tls.VersionTLS10: "TLSv1",
+	tls.VersionTLS11: "TLSv1.1",
+	tls.VersionTLS12: "TLSv1.2",
+}
+
+// newAccessLogRecord - returns the access log record of a request served
+// by the given recorder. The turn-around time is measured from the start
+// of the request, as the end of the request body is not tracked.
+func newAccessLogRecord(r *http.Request, rec *accessLogRecorder, startTime time.Time) accesslog.Record {
+	endTime := UTCNow()
+
+	bucket, object := "", ""
+	if resource, err := getResource(r.URL.Path, r.Host, globalDomainNames); err == nil {
+		bucket, object = urlPath2BucketObjectName(resource)
+	}
+
+	statusCode := rec.statusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+
+	firstByteTime := rec.firstByteTime
+	if firstByteTime.IsZero() {
+		firstByteTime = endTime
+	}
+
+	record := accesslog.Record{
+		Bucket:         bucket,
+		Time:           startTime,
+		RemoteIP:       handlers.GetSourceIP(r),
+		RequestID:      rec.Header().Get(responseRequestIDKey),
+		Operation:      getAccessLogOperation(r, object),
+		Key:            object,
+		RequestURI:     r.Method + " " + r.URL.RequestURI() + " " + r.Proto,
+		HTTPStatus:     statusCode,
+		ErrorCode:      rec.errorCode(),
+		BytesSent:      rec.bytesSent,
+		ObjectSize:     getAccessLogObjectSize(r, rec, object),
+		TotalTime:      endTime.Sub(startTime),
+		TurnAroundTime: firstByteTime.Sub(startTime),
+		Referrer:       r.Referer(),
+		UserAgent:      r.UserAgent(),
+		VersionID:      rec.Header().Get(amzVersionID),
+		HostHeader:     r.Host,
+	}
+
+	if globalServerConfig != nil {
+		record.BucketOwner = globalServerConfig.GetCredential().AccessKey
+	}
+
+	region := globalServerConfig.GetRegion()
+	switch getRequestAuthType(r) {
+	case authTypeSigned, authTypeStreamingSigned:
+		cred, _, _ := getReqAccessKeyV4(r, region, serviceS3)
+		record.Requester, record.SignatureVersion, record.AuthType = cred.AccessKey, "SigV4", "AuthHeader"
+	case authTypePresigned:
+		cred, _, _ := getReqAccessKeyV4(r, region, serviceS3)
+		record.Requester, record.SignatureVersion, record.AuthType = cred.AccessKey, "SigV4", "QueryString"
+	case authTypeSignedV2:
+		cred, _, _ := getReqAccessKeyV2(r)
+		record.Requester, record.SignatureVersion, record.AuthType = cred.AccessKey, "SigV2", "AuthHeader"
+	case authTypePresignedV2:
+		cred, _, _ := getReqAccessKeyV2(r)
+		record.Requester, record.SignatureVersion, record.AuthType = cred.AccessKey, "SigV2", "QueryString"
+	}
+
+	if r.TLS != nil {
+		record.TLSVersion = tlsVersionNames[r.TLS.Version]
+	}
+
+	return record
+}
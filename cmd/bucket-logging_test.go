@@ -0,0 +1,111 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/pkg/accesslog"
+)
+
+// Wrapper for calling access log delivery tests for both XL multiple disks and single node setup.
+func TestBucketLoggingDelivery(t *testing.T) {
+	ExecObjectLayerTest(t, testBucketLoggingDelivery)
+}
+
+// Unit test for recording access logs of requests and delivering them to the target bucket.
+func testBucketLoggingDelivery(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	for _, bucket := range []string{"bucket", "logs"} {
+		if err := obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+	}
+
+	globalBucketLoggingSys = NewBucketLoggingSys()
+	globalBucketLoggingSys.Set("bucket", accesslog.Config{
+		LoggingEnabled: &accesslog.LoggingEnabled{TargetBucket: "logs", TargetPrefix: "access/"},
+	})
+
+	var handler http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(responseRequestIDKey, "15A87D4C3E6B2A1F")
+		if strings.HasSuffix(r.URL.Path, "missing") {
+			writeErrorResponse(context.Background(), w, errorCodes.ToAPIErr(ErrNoSuchKey), r.URL, false)
+			return
+		}
+		w.Write([]byte("data"))
+	}
+
+	testCases := []struct {
+		path           string
+		expectedRecord string
+	}{
+		{"/bucket/object", `REST.GET.OBJECT object "GET /bucket/object HTTP/1.1" 200 - 4`},
+		{"/bucket/missing", `REST.GET.OBJECT missing "GET /bucket/missing HTTP/1.1" 404 NoSuchKey`},
+		{"/bucket?versioning", `REST.GET.VERSIONING - "GET /bucket?versioning HTTP/1.1" 200 - 4`},
+		// Requests on buckets without logging are not recorded.
+		{"/logs/object", ""},
+	}
+
+	for _, testCase := range testCases {
+		r := httptest.NewRequest(http.MethodGet, testCase.path, nil)
+		setBucketLoggingHandler(handler).ServeHTTP(httptest.NewRecorder(), r)
+	}
+
+	globalBucketLoggingSys.deliver(obj)
+
+	result, err := obj.ListObjects(ctx, "logs", "access/", "", "", 1000)
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if len(result.Objects) != 1 {
+		t.Fatalf("%s: expected one log object, got %d", instanceType, len(result.Objects))
+	}
+
+	var buffer bytes.Buffer
+	if err = obj.GetObject(ctx, "logs", result.Objects[0].Name, 0, -1, &buffer, "", ObjectOptions{}); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("%s: expected 3 records, got %d: %s", instanceType, len(lines), buffer.String())
+	}
+
+	for i, testCase := range testCases[:3] {
+		if !strings.Contains(lines[i], " bucket [") {
+			t.Fatalf("%s: test %d: expected record of bucket, got %s", instanceType, i+1, lines[i])
+		}
+		if !strings.Contains(lines[i], " 15A87D4C3E6B2A1F "+testCase.expectedRecord+" ") {
+			t.Fatalf("%s: test %d: expected record to contain %s, got %s", instanceType, i+1, testCase.expectedRecord, lines[i])
+		}
+	}
+
+	// Nothing is left to deliver.
+	globalBucketLoggingSys.deliver(obj)
+	if result, err = obj.ListObjects(ctx, "logs", "access/", "", "", 1000); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if len(result.Objects) != 1 {
+		t.Fatalf("%s: expected one log object, got %d", instanceType, len(result.Objects))
+	}
+}
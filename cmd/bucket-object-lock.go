@@ -0,0 +1,339 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/objectlock"
+	"github.com/minio/minio/pkg/policy"
+)
+
+const (
+	// object lock configuration file.
+	bucketObjectLockConfig = "object-lock.xml"
+
+	// Header enabling object lock on a bucket being created.
+	amzBucketObjectLockEnabled = "X-Amz-Bucket-Object-Lock-Enabled"
+
+	// Header requesting to overwrite or delete an object version in GOVERNANCE mode.
+	amzBypassGovernanceRetention = "X-Amz-Bypass-Governance-Retention"
+)
+
+// BucketObjectLockSys - Bucket object lock subsystem.
+type BucketObjectLockSys struct {
+	sync.RWMutex
+	bucketObjectLockMap map[string]objectlock.Config
+}
+
+// removeDeletedBuckets - to handle a corner case where we have cached the object lock
+// configuration for a deleted bucket. i.e if we miss a delete-bucket notification we
+// should delete the corresponding object lock configuration during sys.refresh()
+func (sys *BucketObjectLockSys) removeDeletedBuckets(bucketInfos []BucketInfo) {
+	buckets := set.NewStringSet()
+	for _, info := range bucketInfos {
+		buckets.Add(info.Name)
+	}
+	sys.Lock()
+	defer sys.Unlock()
+
+	for bucket := range sys.bucketObjectLockMap {
+		if !buckets.Contains(bucket) {
+			delete(sys.bucketObjectLockMap, bucket)
+		}
+	}
+}
+
+// Set - sets object lock configuration to given bucket name.
+func (sys *BucketObjectLockSys) Set(bucketName string, config objectlock.Config) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.bucketObjectLockMap[bucketName] = config
+}
+
+// Remove - removes object lock configuration for given bucket name.
+func (sys *BucketObjectLockSys) Remove(bucketName string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	delete(sys.bucketObjectLockMap, bucketName)
+}
+
+// Get - returns object lock configuration of given bucket name.
+func (sys *BucketObjectLockSys) Get(bucketName string) (config objectlock.Config, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	config, ok = sys.bucketObjectLockMap[bucketName]
+	return config, ok
+}
+
+// Enabled - returns true if object lock is enabled on given bucket name.
+func (sys *BucketObjectLockSys) Enabled(bucketName string) bool {
+	_, ok := sys.Get(bucketName)
+	return ok
+}
+
+// Refresh BucketObjectLockSys.
+func (sys *BucketObjectLockSys) refresh(objAPI ObjectLayer) error {
+	buckets, err := objAPI.ListBuckets(context.Background())
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		return err
+	}
+	sys.removeDeletedBuckets(buckets)
+	for _, bucket := range buckets {
+		config, err := getObjectLockConfig(objAPI, bucket.Name)
+		if err != nil {
+			if err == errConfigNotFound {
+				sys.Remove(bucket.Name)
+			}
+			continue
+		}
+		sys.Set(bucket.Name, *config)
+	}
+	return nil
+}
+
+// Init - initializes object lock system from object-lock.xml of all buckets.
+func (sys *BucketObjectLockSys) Init(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	defer func() {
+		// Refresh BucketObjectLockSys in background.
+		go func() {
+			ticker := time.NewTicker(globalRefreshBucketPolicyInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-GlobalServiceDoneCh:
+					return
+				case <-ticker.C:
+					sys.refresh(objAPI)
+				}
+			}
+		}()
+	}()
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	// Initializing object lock needs a retry mechanism for
+	// the following reasons:
+	//  - Read quorum is lost just after the initialization
+	//    of the object layer.
+	for range newRetryTimerSimple(doneCh) {
+		// Load BucketObjectLockSys once during boot.
+		if err := sys.refresh(objAPI); err != nil {
+			if err == errDiskNotFound ||
+				strings.Contains(err.Error(), InsufficientReadQuorum{}.Error()) ||
+				strings.Contains(err.Error(), InsufficientWriteQuorum{}.Error()) {
+				logger.Info("Waiting for object lock subsystem to be initialized..")
+				continue
+			}
+			return err
+		}
+		break
+	}
+	return nil
+}
+
+// NewBucketObjectLockSys - creates new object lock system.
+func NewBucketObjectLockSys() *BucketObjectLockSys {
+	return &BucketObjectLockSys{
+		bucketObjectLockMap: make(map[string]objectlock.Config),
+	}
+}
+
+// getObjectLockConfig - get object lock config for given bucket name.
+func getObjectLockConfig(objAPI ObjectLayer, bucketName string) (*objectlock.Config, error) {
+	// Construct path to object-lock.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketObjectLockConfig)
+
+	configData, err := readConfig(context.Background(), objAPI, configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return objectlock.ParseConfig(bytes.NewReader(configData))
+}
+
+func saveObjectLockConfig(ctx context.Context, objAPI ObjectLayer, bucketName string, config *objectlock.Config) error {
+	data, err := xml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	// Construct path to object-lock.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketObjectLockConfig)
+
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+// removeObjectLockConfig - removes object-lock.xml for a given bucket,
+// object lock is only disabled along with its bucket.
+func removeObjectLockConfig(ctx context.Context, objAPI ObjectLayer, bucketName string) error {
+	// Construct path to object-lock.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketObjectLockConfig)
+
+	if err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return BucketObjectLockConfigNotFound{Bucket: bucketName}
+		}
+		return err
+	}
+	return nil
+}
+
+// enableObjectLock - enables object lock on a newly created bucket, without
+// default retention.
+func enableObjectLock(ctx context.Context, objAPI ObjectLayer, bucketName string) error {
+	config := &objectlock.Config{ObjectLockEnabled: objectlock.Enabled}
+	if err := saveObjectLockConfig(ctx, objAPI, bucketName, config); err != nil {
+		return err
+	}
+
+	globalBucketObjectLockSys.Set(bucketName, *config)
+	globalNotificationSys.SetBucketObjectLock(ctx, bucketName, config)
+	return nil
+}
+
+// enforceObjectLock - returns ObjectLocked if the object version with the
+// given metadata cannot be overwritten or deleted. Retention in GOVERNANCE
+// mode is bypassed on request, a legal hold never is.
+func enforceObjectLock(bucket, object string, metadata map[string]string, bypassGovernance bool) error {
+	if objectlock.GetLegalHold(metadata).IsOn() {
+		return ObjectLocked{Bucket: bucket, Object: object}
+	}
+
+	retention := objectlock.GetRetention(metadata)
+	if !retention.IsActive(UTCNow()) {
+		return nil
+	}
+	if retention.Mode == objectlock.Governance && bypassGovernance {
+		return nil
+	}
+	return ObjectLocked{Bucket: bucket, Object: object}
+}
+
+// enforceRetentionUpdate - returns ObjectLocked if the retention of an object
+// version cannot be replaced by the given retention. Retention in COMPLIANCE
+// mode can only be extended, retention in GOVERNANCE mode can be shortened
+// or removed on request.
+func enforceRetentionUpdate(bucket, object string, current, updated objectlock.Retention, bypassGovernance bool) error {
+	if !current.IsActive(UTCNow()) {
+		return nil
+	}
+
+	if !updated.IsEmpty() && !updated.RetainUntilDate.Before(current.RetainUntilDate) {
+		if current.Mode == objectlock.Governance || updated.Mode == objectlock.Compliance {
+			return nil
+		}
+	}
+
+	if current.Mode == objectlock.Governance && bypassGovernance {
+		return nil
+	}
+	return ObjectLocked{Bucket: bucket, Object: object}
+}
+
+// isBypassGovernanceReq - returns true if the request asks to bypass
+// GOVERNANCE mode and is allowed to do so.
+func isBypassGovernanceReq(r *http.Request, bucket, object string) bool {
+	if !strings.EqualFold(r.Header.Get(amzBypassGovernanceRetention), "true") {
+		return false
+	}
+	return isPutActionAllowed(getRequestAuthType(r), bucket, object, r, policy.BypassGovernanceRetentionAction) == ErrNone
+}
+
+// setObjectLockMetadata - sets the retention and the legal hold requested in
+// the headers of a request creating an object into the object metadata, the
+// default retention of the bucket applies if no retention is requested.
+func setObjectLockMetadata(r *http.Request, bucket, object string, metadata map[string]string) APIErrorCode {
+	retention, legalHold, err := objectlock.ParseHeaders(r.Header, UTCNow())
+	if err != nil {
+		return toObjectLockAPIErrorCode(err)
+	}
+
+	config, ok := globalBucketObjectLockSys.Get(bucket)
+	if !ok {
+		if !retention.IsEmpty() || legalHold.Status != "" {
+			return ErrObjectLockMissingBucketConfig
+		}
+		return ErrNone
+	}
+
+	rAuthType := getRequestAuthType(r)
+	if retention.IsEmpty() {
+		retention, _ = config.DefaultRetention(UTCNow())
+	} else if s3Err := isPutActionAllowed(rAuthType, bucket, object, r, policy.PutObjectRetentionAction); s3Err != ErrNone {
+		return s3Err
+	}
+	if legalHold.Status != "" {
+		if s3Err := isPutActionAllowed(rAuthType, bucket, object, r, policy.PutObjectLegalHoldAction); s3Err != ErrNone {
+			return s3Err
+		}
+	}
+
+	if !retention.IsEmpty() {
+		for k, v := range retention.Metadata() {
+			metadata[k] = v
+		}
+	}
+	if legalHold.IsOn() {
+		metadata[objectlock.AmzObjectLockLegalHold] = string(legalHold.Status)
+	}
+	return ErrNone
+}
+
+// removeObjectLockMetadata - removes the retention and the legal hold from
+// the given object metadata.
+func removeObjectLockMetadata(metadata map[string]string) {
+	delete(metadata, objectlock.AmzObjectLockMode)
+	delete(metadata, objectlock.AmzObjectLockRetainUntilDate)
+	delete(metadata, objectlock.AmzObjectLockLegalHold)
+}
+
+// toObjectLockAPIErrorCode - converts object lock errors of retention and
+// legal hold headers to API error codes.
+func toObjectLockAPIErrorCode(err error) APIErrorCode {
+	switch err {
+	case objectlock.ErrInvalidRetention:
+		return ErrObjectLockInvalidHeaders
+	case objectlock.ErrInvalidRetainUntilDate:
+		return ErrInvalidRetentionDate
+	case objectlock.ErrPastRetainUntilDate:
+		return ErrPastObjectLockRetainDate
+	case objectlock.ErrInvalidMode:
+		return ErrUnknownObjectLockMode
+	case objectlock.ErrInvalidLegalHoldStatus:
+		return ErrInvalidLegalHoldStatus
+	}
+	return ErrMalformedXML
+}
@@ -0,0 +1,140 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/objectlock"
+)
+
+// Wrapper for calling object lock tests for both XL multiple disks and single node setup.
+func TestObjectLock(t *testing.T) {
+	ExecObjectLayerTest(t, testObjectLock)
+}
+
+// Unit test for denying overwrites and deletions of retained objects.
+func testObjectLock(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	if err := obj.MakeBucketWithLocation(ctx, "bucket", ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	globalBucketObjectLockSys = NewBucketObjectLockSys()
+	globalBucketObjectLockSys.Set("bucket", objectlock.Config{ObjectLockEnabled: objectlock.Enabled})
+
+	retainUntil := UTCNow().Add(time.Hour)
+	testCases := []struct {
+		object   string
+		metadata map[string]string
+		bypass   bool
+		locked   bool
+	}{
+		{"none", map[string]string{}, false, false},
+		{"governance", objectlock.Retention{Mode: objectlock.Governance, RetainUntilDate: retainUntil}.Metadata(), false, true},
+		// Users allowed to bypass governance retention may remove it.
+		{"governance-bypass", objectlock.Retention{Mode: objectlock.Governance, RetainUntilDate: retainUntil}.Metadata(), true, false},
+		{"compliance", objectlock.Retention{Mode: objectlock.Compliance, RetainUntilDate: retainUntil}.Metadata(), true, true},
+		{"expired", objectlock.Retention{Mode: objectlock.Compliance, RetainUntilDate: UTCNow().Add(-time.Hour)}.Metadata(), false, false},
+		// Legal holds are never bypassed.
+		{"legal-hold", objectlock.LegalHold{Status: objectlock.LegalHoldOn}.Metadata(), true, true},
+	}
+
+	putObject := func(object string, metadata map[string]string, bypass bool) error {
+		_, err := obj.PutObject(ctx, "bucket", object, mustGetPutObjReader(t, bytes.NewReader([]byte("data")), 4, "", ""),
+			ObjectOptions{UserDefined: metadata, BypassGovernance: bypass})
+		return err
+	}
+
+	for i, testCase := range testCases {
+		if err := putObject(testCase.object, testCase.metadata, false); err != nil {
+			t.Fatalf("%s: test %d: %s", instanceType, i+1, err)
+		}
+
+		if err := putObject(testCase.object, testCase.metadata, testCase.bypass); err != nil {
+			if _, ok := err.(ObjectLocked); !ok || !testCase.locked {
+				t.Fatalf("%s: test %d: unexpected overwrite error %s", instanceType, i+1, err)
+			}
+		} else if testCase.locked {
+			t.Fatalf("%s: test %d: expected overwrite to be denied", instanceType, i+1)
+		}
+
+		_, err := obj.DeleteObjectVersion(ctx, "bucket", testCase.object, ObjectOptions{BypassGovernance: testCase.bypass})
+		if err != nil {
+			if _, ok := err.(ObjectLocked); !ok || !testCase.locked {
+				t.Fatalf("%s: test %d: unexpected deletion error %s", instanceType, i+1, err)
+			}
+		} else if testCase.locked {
+			t.Fatalf("%s: test %d: expected deletion to be denied", instanceType, i+1)
+		}
+
+		// Plain deletions never bypass governance retention.
+		if testCase.locked {
+			if _, ok := obj.DeleteObject(ctx, "bucket", testCase.object).(ObjectLocked); !ok {
+				t.Fatalf("%s: test %d: expected deletion to be denied", instanceType, i+1)
+			}
+		}
+	}
+
+	// Buckets without object lock are never enforced.
+	globalBucketObjectLockSys.Remove("bucket")
+	if err := obj.DeleteObject(ctx, "bucket", "compliance"); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+}
+
+func TestEnforceRetentionUpdate(t *testing.T) {
+	now := UTCNow()
+	governance := objectlock.Retention{Mode: objectlock.Governance, RetainUntilDate: now.Add(time.Hour)}
+	compliance := objectlock.Retention{Mode: objectlock.Compliance, RetainUntilDate: now.Add(time.Hour)}
+	extended := func(r objectlock.Retention) objectlock.Retention {
+		r.RetainUntilDate = r.RetainUntilDate.Add(time.Hour)
+		return r
+	}
+	shortened := func(r objectlock.Retention) objectlock.Retention {
+		r.RetainUntilDate = r.RetainUntilDate.Add(-time.Minute)
+		return r
+	}
+
+	testCases := []struct {
+		current, updated objectlock.Retention
+		bypass           bool
+		expectedErr      bool
+	}{
+		{objectlock.Retention{}, governance, false, false},
+		{governance, extended(governance), false, false},
+		{governance, compliance, false, false},
+		{governance, shortened(governance), false, true},
+		{governance, shortened(governance), true, false},
+		{governance, objectlock.Retention{}, false, true},
+		{governance, objectlock.Retention{}, true, false},
+		{compliance, extended(compliance), false, false},
+		{compliance, extended(governance), true, true},
+		{compliance, shortened(compliance), true, true},
+		{compliance, objectlock.Retention{}, true, true},
+	}
+
+	for i, testCase := range testCases {
+		err := enforceRetentionUpdate("bucket", "object", testCase.current, testCase.updated, testCase.bypass)
+		if (err != nil) != testCase.expectedErr {
+			t.Fatalf("test %d: expected error: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+	}
+}
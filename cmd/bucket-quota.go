@@ -0,0 +1,549 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// quota configuration file.
+	bucketQuotaConfig = "quota.json"
+)
+
+// Sizes written to a bucket with a quota are kept for that long to
+// seed its usage again from the next data usage crawl.
+const bucketUsageDeltasExpiry = 24 * time.Hour
+
+// bucketQuotaUsage - usage of a bucket with a quota.
+type bucketQuotaUsage struct {
+	// Size of all object versions of the bucket, valid once seeded.
+	size   int64
+	seeded bool
+
+	// Last update of the data usage crawl the size is seeded from.
+	crawled time.Time
+
+	// Sizes written to the bucket by all servers, summed up per
+	// minute, to seed the size again from a newer data usage crawl.
+	deltas map[time.Time]int64
+}
+
+// BucketQuotaSys - Bucket quota subsystem.
+type BucketQuotaSys struct {
+	sync.RWMutex
+	bucketQuotaMap map[string]madmin.BucketQuota
+
+	// Usage of buckets with a quota, seeded from the last data usage
+	// crawl and kept up to date by the writes of all servers.
+	bucketUsageMap map[string]*bucketQuotaUsage
+
+	// Buckets with a FIFO quota whose oldest objects are being evicted.
+	evicting set.StringSet
+}
+
+// removeDeletedBuckets - to handle a corner case where we have cached the quota
+// configuration for a deleted bucket. i.e if we miss a delete-bucket notification we
+// should delete the corresponding quota configuration during sys.refresh()
+func (sys *BucketQuotaSys) removeDeletedBuckets(bucketInfos []BucketInfo) {
+	buckets := set.NewStringSet()
+	for _, info := range bucketInfos {
+		buckets.Add(info.Name)
+	}
+	sys.Lock()
+	defer sys.Unlock()
+
+	for bucket := range sys.bucketQuotaMap {
+		if !buckets.Contains(bucket) {
+			delete(sys.bucketQuotaMap, bucket)
+			delete(sys.bucketUsageMap, bucket)
+		}
+	}
+}
+
+// Set - sets quota configuration to given bucket name.
+func (sys *BucketQuotaSys) Set(bucketName string, quota madmin.BucketQuota) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.bucketQuotaMap[bucketName] = quota
+	if _, ok := sys.bucketUsageMap[bucketName]; !ok {
+		sys.bucketUsageMap[bucketName] = &bucketQuotaUsage{deltas: make(map[time.Time]int64)}
+	}
+}
+
+// Remove - removes quota configuration for given bucket name.
+func (sys *BucketQuotaSys) Remove(bucketName string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	delete(sys.bucketQuotaMap, bucketName)
+	delete(sys.bucketUsageMap, bucketName)
+}
+
+// Get - returns quota configuration of given bucket name.
+func (sys *BucketQuotaSys) Get(bucketName string) (quota madmin.BucketQuota, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	quota, ok = sys.bucketQuotaMap[bucketName]
+	return quota, ok
+}
+
+// seedUsage - seeds the usage of the buckets with a quota from a data usage
+// crawl newer than the one they are seeded from, the sizes written since the
+// crawl are accounted for.
+func (sys *BucketQuotaSys) seedUsage(dataUsageInfo madmin.DataUsageInfo) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	// Writes of the minute the crawl finished may be counted twice.
+	since := dataUsageInfo.LastUpdate.Truncate(time.Minute)
+	expiry := UTCNow().Add(-bucketUsageDeltasExpiry)
+	for bucket, usage := range sys.bucketUsageMap {
+		bucketUsage, ok := dataUsageInfo.BucketsUsage[bucket]
+		if ok && dataUsageInfo.LastUpdate.After(usage.crawled) {
+			usage.size = int64(bucketUsage.Size)
+			usage.seeded = true
+			usage.crawled = dataUsageInfo.LastUpdate
+			for minute, size := range usage.deltas {
+				if minute.Before(since) {
+					delete(usage.deltas, minute)
+					continue
+				}
+				usage.size += size
+			}
+		}
+		for minute := range usage.deltas {
+			if minute.Before(expiry) {
+				delete(usage.deltas, minute)
+			}
+		}
+	}
+}
+
+// Refresh BucketQuotaSys.
+func (sys *BucketQuotaSys) refresh(objAPI ObjectLayer) error {
+	buckets, err := objAPI.ListBuckets(context.Background())
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		return err
+	}
+	sys.removeDeletedBuckets(buckets)
+	for _, bucket := range buckets {
+		quota, err := getQuotaConfig(objAPI, bucket.Name)
+		if err != nil {
+			if err == errConfigNotFound {
+				sys.Remove(bucket.Name)
+			}
+			continue
+		}
+		sys.Set(bucket.Name, *quota)
+	}
+
+	dataUsageInfo, err := loadDataUsage(context.Background(), objAPI)
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		return err
+	}
+	sys.seedUsage(dataUsageInfo)
+	return nil
+}
+
+// Init - initializes quota system from quota.json of all buckets.
+func (sys *BucketQuotaSys) Init(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	defer func() {
+		// Refresh BucketQuotaSys in background.
+		go func() {
+			ticker := time.NewTicker(globalRefreshBucketPolicyInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-GlobalServiceDoneCh:
+					return
+				case <-ticker.C:
+					sys.refresh(objAPI)
+				}
+			}
+		}()
+	}()
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	// Initializing quota needs a retry mechanism for
+	// the following reasons:
+	//  - Read quorum is lost just after the initialization
+	//    of the object layer.
+	for range newRetryTimerSimple(doneCh) {
+		// Load BucketQuotaSys once during boot.
+		if err := sys.refresh(objAPI); err != nil {
+			if err == errDiskNotFound ||
+				strings.Contains(err.Error(), InsufficientReadQuorum{}.Error()) ||
+				strings.Contains(err.Error(), InsufficientWriteQuorum{}.Error()) {
+				logger.Info("Waiting for quota subsystem to be initialized..")
+				continue
+			}
+			return err
+		}
+		break
+	}
+	return nil
+}
+
+// NewBucketQuotaSys - creates new quota system.
+func NewBucketQuotaSys() *BucketQuotaSys {
+	return &BucketQuotaSys{
+		bucketQuotaMap: make(map[string]madmin.BucketQuota),
+		bucketUsageMap: make(map[string]*bucketQuotaUsage),
+		evicting:       set.NewStringSet(),
+	}
+}
+
+// getUsage - returns the size of all object versions of a bucket with a quota,
+// the bucket is listed only if it is not part of the last data usage crawl.
+func (sys *BucketQuotaSys) getUsage(ctx context.Context, objAPI ObjectLayer, bucketName string) (int64, error) {
+	sys.RLock()
+	usage, ok := sys.bucketUsageMap[bucketName]
+	if ok && usage.seeded {
+		size := usage.size
+		sys.RUnlock()
+		return size, nil
+	}
+	sys.RUnlock()
+
+	size, err := getBucketUsage(ctx, objAPI, bucketName)
+	if err != nil {
+		return 0, err
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+	if usage, ok = sys.bucketUsageMap[bucketName]; ok && !usage.seeded {
+		// Seeded again from the next data usage crawl.
+		usage.size = size
+		usage.seeded = true
+	}
+	return size, nil
+}
+
+// enforce - returns BucketQuotaExceeded if writing size bytes to a bucket
+// with a hard quota exceeds its quota. size is negative if the write
+// replaces a larger object. Writes to buckets with a FIFO quota are
+// never denied.
+func (sys *BucketQuotaSys) enforce(ctx context.Context, objAPI ObjectLayer, bucketName string, size int64) error {
+	quota, ok := sys.Get(bucketName)
+	if !ok {
+		return nil
+	}
+
+	// The usage of buckets with a FIFO quota is needed as well to
+	// evict their oldest objects once the write is done.
+	usage, err := sys.getUsage(ctx, objAPI, bucketName)
+	if err != nil {
+		return err
+	}
+
+	if quota.Type == madmin.HardQuota && usage+size > int64(quota.Quota) {
+		return BucketQuotaExceeded{Bucket: bucketName}
+	}
+	return nil
+}
+
+// addUsage - accounts size bytes written to a bucket with a quota at the
+// given time, returns the usage of the bucket and whether it is known.
+func (sys *BucketQuotaSys) addUsage(bucketName string, size int64, at time.Time) (int64, bool) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	usage, ok := sys.bucketUsageMap[bucketName]
+	if !ok {
+		return 0, false
+	}
+
+	usage.deltas[at.Truncate(time.Minute)] += size
+	if !usage.seeded {
+		// Usage is computed on the next write.
+		return 0, false
+	}
+
+	usage.size += size
+	if usage.size < 0 {
+		usage.size = 0
+	}
+	return usage.size, true
+}
+
+// update - accounts size bytes written to a bucket with a quota, size is
+// negative if objects were deleted. The size is accounted for by all
+// servers. The oldest objects of a bucket with a FIFO quota are evicted
+// in background once its quota is exceeded.
+func (sys *BucketQuotaSys) update(objAPI ObjectLayer, bucketName string, size int64) {
+	quota, ok := sys.Get(bucketName)
+	if !ok || size == 0 {
+		return
+	}
+
+	now := UTCNow()
+	usage, ok := sys.addUsage(bucketName, size, now)
+	if globalNotificationSys != nil {
+		globalNotificationSys.UpdateBucketUsage(context.Background(), bucketName, size, now)
+	}
+	if !ok || quota.Type != madmin.FIFOQuota || usage <= int64(quota.Quota) {
+		return
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+	if !sys.evicting.Contains(bucketName) {
+		sys.evicting.Add(bucketName)
+		go sys.evict(objAPI, bucketName)
+	}
+}
+
+// evict - deletes the oldest objects of a bucket with a FIFO quota until
+// it fits in its quota again. Noncurrent versions of objects are evicted
+// first, oldest first, before the latest versions.
+func (sys *BucketQuotaSys) evict(objAPI ObjectLayer, bucketName string) {
+	defer func() {
+		sys.Lock()
+		sys.evicting.Remove(bucketName)
+		sys.Unlock()
+	}()
+
+	ctx := logger.SetReqInfo(context.Background(), &logger.ReqInfo{BucketName: bucketName})
+
+	var versions []ObjectInfo
+	var usage int64
+	err := walkBucketVersions(ctx, objAPI, bucketName, func(objInfo ObjectInfo) {
+		usage += objInfo.Size
+		// Delete markers take no space.
+		if !objInfo.DeleteMarker {
+			versions = append(versions, objInfo)
+		}
+	})
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	quota, ok := sys.Get(bucketName)
+	if !ok || quota.Type != madmin.FIFOQuota {
+		return
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool {
+		if versions[i].IsLatest != versions[j].IsLatest {
+			return !versions[i].IsLatest
+		}
+		return versions[i].ModTime.Before(versions[j].ModTime)
+	})
+
+	for _, objInfo := range versions {
+		if usage <= int64(quota.Quota) {
+			break
+		}
+		// Versions are evicted one by one, a deletion of the latest
+		// version of a versioned bucket must not add a delete marker.
+		var opts ObjectOptions
+		setVersioningOpts(&opts, bucketName)
+		if opts.Versioned || opts.VersionSuspended {
+			opts.VersionID = getVersionID(objInfo.VersionID)
+		}
+		if err = deleteExpiredObject(ctx, objAPI, bucketName, objInfo.Name, opts); err != nil {
+			// Objects protected by object lock are never evicted.
+			if _, ok := err.(ObjectLocked); !ok {
+				logger.GetReqInfo(ctx).AppendTags("object", objInfo.Name)
+				logger.GetReqInfo(ctx).AppendTags("versionId", opts.VersionID)
+				logger.LogIf(ctx, err)
+			}
+			continue
+		}
+		usage -= objInfo.Size
+		sys.update(objAPI, bucketName, -objInfo.Size)
+	}
+}
+
+// walkBucketObjects - calls fn with every object of a bucket.
+func walkBucketObjects(ctx context.Context, objAPI ObjectLayer, bucketName string, fn func(ObjectInfo)) error {
+	marker := ""
+	for {
+		result, err := objAPI.ListObjects(ctx, bucketName, "", marker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+
+		for _, objInfo := range result.Objects {
+			fn(objInfo)
+		}
+
+		if !result.IsTruncated {
+			return nil
+		}
+		marker = result.NextMarker
+	}
+}
+
+// walkBucketVersions - calls fn with every version and delete marker of the
+// objects of a bucket, only the objects are walked if the object layer does
+// not support versioning.
+func walkBucketVersions(ctx context.Context, objAPI ObjectLayer, bucketName string, fn func(ObjectInfo)) error {
+	if !objAPI.IsVersioningSupported() {
+		return walkBucketObjects(ctx, objAPI, bucketName, func(objInfo ObjectInfo) {
+			objInfo.IsLatest = true
+			fn(objInfo)
+		})
+	}
+
+	keyMarker, versionIDMarker := "", ""
+	for {
+		result, err := objAPI.ListObjectVersions(ctx, bucketName, "", keyMarker, versionIDMarker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+
+		for _, objInfo := range result.Objects {
+			fn(objInfo)
+		}
+
+		if !result.IsTruncated {
+			return nil
+		}
+		keyMarker, versionIDMarker = result.NextKeyMarker, result.NextVersionIDMarker
+	}
+}
+
+// getBucketUsage - returns the size of all object versions of a bucket.
+func getBucketUsage(ctx context.Context, objAPI ObjectLayer, bucketName string) (usage int64, err error) {
+	err = walkBucketVersions(ctx, objAPI, bucketName, func(objInfo ObjectInfo) {
+		usage += objInfo.Size
+	})
+	return usage, err
+}
+
+// getQuotaObjectSize - returns the size of the object version replaced or
+// deleted by a request to a bucket with a quota, zero if there is no such
+// version. An empty versionID selects the version the request replaces:
+// none on a versioned bucket, which adds a new version or a delete marker,
+// the "null" version on a bucket with versioning suspended and the object
+// itself otherwise.
+func getQuotaObjectSize(ctx context.Context, objAPI ObjectLayer, bucketName, objectName, versionID string) int64 {
+	if _, ok := globalBucketQuotaSys.Get(bucketName); !ok {
+		return 0
+	}
+
+	if versionID == "" {
+		switch {
+		case globalBucketVersioningSys.Enabled(bucketName):
+			return 0
+		case globalBucketVersioningSys.Suspended(bucketName):
+			versionID = nullVersionID
+		}
+	}
+
+	objInfo, err := objAPI.GetObjectInfo(ctx, bucketName, objectName, ObjectOptions{VersionID: versionID})
+	if err != nil {
+		return 0
+	}
+	return objInfo.Size
+}
+
+// getCompletePartsSize - returns the size of the object created by a request
+// completing a multipart upload to a bucket with a quota, zero otherwise.
+func getCompletePartsSize(ctx context.Context, objAPI ObjectLayer, bucketName, objectName, uploadID string, parts []CompletePart) (size int64, err error) {
+	if _, ok := globalBucketQuotaSys.Get(bucketName); !ok {
+		return 0, nil
+	}
+
+	partSizes := make(map[int]int64)
+	partNumberMarker := 0
+	for {
+		result, err := objAPI.ListObjectParts(ctx, bucketName, objectName, uploadID, partNumberMarker, maxPartsList, ObjectOptions{})
+		if err != nil {
+			return 0, err
+		}
+		for _, part := range result.Parts {
+			partSizes[part.PartNumber] = part.Size
+		}
+		if !result.IsTruncated {
+			break
+		}
+		partNumberMarker = result.NextPartNumberMarker
+	}
+
+	for _, part := range parts {
+		size += partSizes[part.PartNumber]
+	}
+	return size, nil
+}
+
+// getQuotaConfig - get quota config for given bucket name.
+func getQuotaConfig(objAPI ObjectLayer, bucketName string) (*madmin.BucketQuota, error) {
+	// Construct path to quota.json for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketQuotaConfig)
+
+	configData, err := readConfig(context.Background(), objAPI, configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var quota madmin.BucketQuota
+	if err = json.Unmarshal(configData, &quota); err != nil {
+		return nil, err
+	}
+	return &quota, nil
+}
+
+func saveQuotaConfig(ctx context.Context, objAPI ObjectLayer, bucketName string, quota *madmin.BucketQuota) error {
+	data, err := json.Marshal(quota)
+	if err != nil {
+		return err
+	}
+
+	// Construct path to quota.json for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketQuotaConfig)
+
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+// removeQuotaConfig - removes quota.json for a given bucket.
+func removeQuotaConfig(ctx context.Context, objAPI ObjectLayer, bucketName string) error {
+	// Construct path to quota.json for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketQuotaConfig)
+
+	if err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return BucketQuotaConfigNotFound{Bucket: bucketName}
+		}
+		return err
+	}
+	return nil
+}
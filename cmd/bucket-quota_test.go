@@ -0,0 +1,273 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/minio/pkg/versioning"
+)
+
+// Wrapper for calling bucket quota tests for both XL multiple disks and single node setup.
+func TestBucketQuota(t *testing.T) {
+	ExecObjectLayerTest(t, testBucketQuota)
+}
+
+// Unit test for enforcing hard quotas and evicting objects of FIFO quotas.
+func testBucketQuota(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	for _, bucket := range []string{"hard", "fifo"} {
+		if err := obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+	}
+
+	putObject := func(bucket, object string) {
+		if err := globalBucketQuotaSys.enforce(ctx, obj, bucket, 4); err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+		objInfo, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte("data")), 4, "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+		globalBucketQuotaSys.update(obj, bucket, objInfo.Size)
+	}
+
+	// Existing objects are accounted for once a quota is set.
+	putObject("hard", "object1")
+
+	globalBucketQuotaSys = NewBucketQuotaSys()
+	globalBucketQuotaSys.Set("hard", madmin.BucketQuota{Quota: 10, Type: madmin.HardQuota})
+	globalBucketQuotaSys.Set("fifo", madmin.BucketQuota{Quota: 10, Type: madmin.FIFOQuota})
+
+	putObject("hard", "object2")
+	if _, ok := globalBucketQuotaSys.enforce(ctx, obj, "hard", 4).(BucketQuotaExceeded); !ok {
+		t.Fatalf("%s: expected hard quota to be exceeded", instanceType)
+	}
+	// Overwrites of objects of the same size do not grow the bucket.
+	if err := globalBucketQuotaSys.enforce(ctx, obj, "hard", 4-getQuotaObjectSize(ctx, obj, "hard", "object2", "")); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	globalBucketQuotaSys.update(obj, "hard", -4)
+	if err := globalBucketQuotaSys.enforce(ctx, obj, "hard", 4); err != nil {
+		t.Fatalf("%s: expected deletion to release quota, got %s", instanceType, err)
+	}
+
+	// FIFO quotas never deny writes, the oldest objects are evicted instead.
+	for _, object := range []string{"object1", "object2", "object3"} {
+		putObject("fifo", object)
+		time.Sleep(10 * time.Millisecond)
+	}
+	for i := 0; i < 100; i++ {
+		globalBucketQuotaSys.RLock()
+		evicting := globalBucketQuotaSys.evicting.Contains("fifo")
+		globalBucketQuotaSys.RUnlock()
+		if !evicting {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	result, err := obj.ListObjects(ctx, "fifo", "", "", "", maxObjectList)
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if len(result.Objects) != 2 || result.Objects[0].Name != "object2" || result.Objects[1].Name != "object3" {
+		t.Fatalf("%s: expected oldest object to be evicted, got %v", instanceType, result.Objects)
+	}
+}
+
+// Wrapper for calling versioned bucket quota tests for both XL multiple disks and single node setup.
+func TestBucketQuotaVersioned(t *testing.T) {
+	ExecObjectLayerTest(t, testBucketQuotaVersioned)
+}
+
+// Unit test for accounting and evicting object versions of FIFO quotas.
+func testBucketQuotaVersioned(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	bucket := "fifo-versioned"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	globalBucketVersioningSys.Set(bucket, versioning.Versioning{Status: versioning.Enabled})
+	defer globalBucketVersioningSys.Remove(bucket)
+
+	globalBucketQuotaSys = NewBucketQuotaSys()
+	globalBucketQuotaSys.Set(bucket, madmin.BucketQuota{Quota: 10, Type: madmin.FIFOQuota})
+
+	var versionIDs []string
+	for _, object := range []string{"object1", "object1", "object2"} {
+		prevSize := getQuotaObjectSize(ctx, obj, bucket, object, "")
+		if err := globalBucketQuotaSys.enforce(ctx, obj, bucket, 4-prevSize); err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+		opts := ObjectOptions{}
+		setVersioningOpts(&opts, bucket)
+		objInfo, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte("data")), 4, "", ""), opts)
+		if err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+		versionIDs = append(versionIDs, objInfo.VersionID)
+		globalBucketQuotaSys.update(obj, bucket, objInfo.Size-prevSize)
+		time.Sleep(10 * time.Millisecond)
+	}
+	for i := 0; i < 100; i++ {
+		globalBucketQuotaSys.RLock()
+		evicting := globalBucketQuotaSys.evicting.Contains(bucket)
+		globalBucketQuotaSys.RUnlock()
+		if !evicting {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Only the noncurrent version is evicted, no delete marker is added.
+	result, err := obj.ListObjectVersions(ctx, bucket, "", "", "", "", maxObjectList)
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if len(result.Objects) != 2 {
+		t.Fatalf("%s: expected 2 versions, got %v", instanceType, result.Objects)
+	}
+	for i, versionID := range versionIDs[1:] {
+		objInfo := result.Objects[i]
+		if objInfo.VersionID != versionID || !objInfo.IsLatest || objInfo.DeleteMarker {
+			t.Fatalf("%s: expected latest version %s to be kept, got %v", instanceType, versionID, objInfo)
+		}
+	}
+
+	usage, err := getBucketUsage(ctx, obj, bucket)
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if usage != 8 {
+		t.Fatalf("%s: expected usage of 8 bytes, got %d", instanceType, usage)
+	}
+}
+
+// Tests seeding the usage of buckets with a quota from data usage crawls.
+func TestBucketQuotaSeedUsage(t *testing.T) {
+	sys := NewBucketQuotaSys()
+	sys.Set("bucket", madmin.BucketQuota{Quota: 100, Type: madmin.HardQuota})
+
+	crawled := UTCNow().Add(-time.Hour)
+	dataUsageInfo := madmin.DataUsageInfo{
+		LastUpdate:   crawled,
+		BucketsUsage: map[string]madmin.BucketUsageInfo{"bucket": {Size: 10}},
+	}
+
+	// Sizes written before the crawl are part of the crawl.
+	sys.addUsage("bucket", 3, crawled.Add(-10*time.Minute))
+	sys.seedUsage(dataUsageInfo)
+	// Sizes written by other servers are accounted for as well.
+	sys.addUsage("bucket", 5, crawled.Add(10*time.Minute))
+
+	// The usage of a seeded bucket is known without listing it.
+	usage, err := sys.getUsage(context.Background(), nil, "bucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage != 15 {
+		t.Fatalf("expected usage of 15 bytes, got %d", usage)
+	}
+
+	// An older crawl is ignored.
+	sys.seedUsage(madmin.DataUsageInfo{
+		LastUpdate:   crawled.Add(-time.Hour),
+		BucketsUsage: map[string]madmin.BucketUsageInfo{"bucket": {Size: 1}},
+	})
+	if usage, _ = sys.getUsage(context.Background(), nil, "bucket"); usage != 15 {
+		t.Fatalf("expected usage of 15 bytes, got %d", usage)
+	}
+
+	// A newer crawl seeds the usage again, sizes written since are kept.
+	sys.seedUsage(madmin.DataUsageInfo{
+		LastUpdate:   crawled.Add(5 * time.Minute),
+		BucketsUsage: map[string]madmin.BucketUsageInfo{"bucket": {Size: 12}},
+	})
+	if usage, _ = sys.getUsage(context.Background(), nil, "bucket"); usage != 17 {
+		t.Fatalf("expected usage of 17 bytes, got %d", usage)
+	}
+}
+
+// TestBucketQuotaHandlers - tests setting, getting and removing bucket quota.
+func TestBucketQuotaHandlers(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	if err = adminTestBed.objLayer.MakeBucketWithLocation(context.Background(), "bucket", ""); err != nil {
+		t.Fatal(err)
+	}
+	globalBucketQuotaSys = NewBucketQuotaSys()
+
+	queryVal := url.Values{}
+	queryVal.Set("bucket", "bucket")
+
+	testCases := []struct {
+		method       string
+		path         string
+		body         string
+		expectedCode int
+	}{
+		{http.MethodGet, "/get-bucket-quota", "", http.StatusNotFound},
+		{http.MethodPut, "/set-bucket-quota", `{"quota":1024,"quotatype":"unknown"}`, http.StatusBadRequest},
+		{http.MethodPut, "/set-bucket-quota", `{"quota":0,"quotatype":"hard"}`, http.StatusBadRequest},
+		{http.MethodPut, "/set-bucket-quota", `{"quota":1024,"quotatype":"hard"}`, http.StatusOK},
+		{http.MethodGet, "/get-bucket-quota", "", http.StatusOK},
+		{http.MethodDelete, "/remove-bucket-quota", "", http.StatusOK},
+		{http.MethodDelete, "/remove-bucket-quota", "", http.StatusNotFound},
+	}
+
+	for i, testCase := range testCases {
+		req, err := buildAdminRequest(queryVal, testCase.method, testCase.path,
+			int64(len(testCase.body)), bytes.NewReader([]byte(testCase.body)))
+		if err != nil {
+			t.Fatalf("test %d: %v", i+1, err)
+		}
+
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("test %d: expected %d, got %d: %s", i+1, testCase.expectedCode, rec.Code, rec.Body.String())
+		}
+
+		if testCase.method == http.MethodGet && rec.Code == http.StatusOK {
+			var quota madmin.BucketQuota
+			if err = json.NewDecoder(rec.Body).Decode(&quota); err != nil {
+				t.Fatalf("test %d: %v", i+1, err)
+			}
+			if quota.Quota != 1024 || quota.Type != madmin.HardQuota {
+				t.Fatalf("test %d: unexpected quota %v", i+1, quota)
+			}
+		}
+	}
+
+	if _, ok := globalBucketQuotaSys.Get("bucket"); ok {
+		t.Fatal("expected quota to be removed")
+	}
+}
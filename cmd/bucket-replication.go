@@ -0,0 +1,616 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	miniogo "github.com/minio/minio-go"
+	"github.com/minio/minio-go/pkg/s3utils"
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/replication"
+)
+
+const (
+	// Replication configuration file.
+	bucketReplicationConfig = "replication.xml"
+
+	// Replication status of an object, stored in its metadata.
+	amzReplicationStatus = "X-Amz-Replication-Status"
+
+	// Replication status values.
+	replicationStatusPending   = "PENDING"
+	replicationStatusCompleted = "COMPLETED"
+	replicationStatusFailed    = "FAILED"
+	replicationStatusReplica   = "REPLICA"
+
+	// Prefix of the replication tasks waiting to be retried, under the
+	// config prefix of the minio meta bucket.
+	replicationTasksPrefix = "replication"
+
+	// Number of go-routines replicating objects of all buckets.
+	replicationWorkers = 8
+
+	// Number of replication tasks queued in memory, tasks which do not fit
+	// are picked up by the next retry round.
+	replicationQueueSize = 10000
+
+	// Interval between two retry rounds, tasks not done for at least an
+	// interval are retried.
+	replicationRetryInterval = 10 * time.Minute
+
+	// Lock held by the node running the retry round.
+	replicationLeaderLock = "leader-replication.lock"
+)
+
+// replicationTask - replication of an object version or of the deletion
+// of an object, persisted until done so that it survives restarts.
+type replicationTask struct {
+	Bucket    string `json:"bucket"`
+	Object    string `json:"object"`
+	VersionID string `json:"versionId,omitempty"`
+	Delete    bool   `json:"delete,omitempty"`
+
+	id string
+}
+
+// ReplicationSys - Bucket replication subsystem.
+type ReplicationSys struct {
+	sync.RWMutex
+	bucketReplicationMap map[string]replication.Config
+
+	// Transport of the clients to the destinations.
+	transport http.RoundTripper
+
+	// Tasks waiting to be picked up by the workers.
+	tasksCh chan replicationTask
+}
+
+// removeDeletedBuckets - to handle a corner case where we have cached the replication
+// configuration for a deleted bucket. i.e if we miss a delete-bucket notification we
+// should delete the corresponding replication configuration during sys.refresh()
+func (sys *ReplicationSys) removeDeletedBuckets(bucketInfos []BucketInfo) {
+	buckets := set.NewStringSet()
+	for _, info := range bucketInfos {
+		buckets.Add(info.Name)
+	}
+	sys.Lock()
+	defer sys.Unlock()
+
+	for bucket := range sys.bucketReplicationMap {
+		if !buckets.Contains(bucket) {
+			delete(sys.bucketReplicationMap, bucket)
+		}
+	}
+}
+
+// Set - sets replication configuration to given bucket name.
+func (sys *ReplicationSys) Set(bucketName string, config replication.Config) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.bucketReplicationMap[bucketName] = config
+}
+
+// Remove - removes replication configuration for given bucket name.
+func (sys *ReplicationSys) Remove(bucketName string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	delete(sys.bucketReplicationMap, bucketName)
+}
+
+// Get - returns replication configuration of given bucket name.
+func (sys *ReplicationSys) Get(bucketName string) (config replication.Config, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	config, ok = sys.bucketReplicationMap[bucketName]
+	return config, ok
+}
+
+// Match - returns the replication rule applying to the given object.
+func (sys *ReplicationSys) Match(bucketName, objName string) (rule replication.Rule, ok bool) {
+	config, ok := sys.Get(bucketName)
+	if !ok {
+		return rule, false
+	}
+	return config.Match(objName)
+}
+
+// queue - persists the replication task and hands it over to the workers.
+func (sys *ReplicationSys) queue(ctx context.Context, objAPI ObjectLayer, task replicationTask) {
+	task.id = mustGetUUID()
+	if err := saveReplicationTask(ctx, objAPI, task); err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	select {
+	case sys.tasksCh <- task:
+	default:
+		// Queue is full, the task is picked up by the next retry round.
+	}
+}
+
+// replicate - replicates the queued tasks until the server stops.
+func (sys *ReplicationSys) replicate(objAPI ObjectLayer) {
+	for {
+		select {
+		case <-GlobalServiceDoneCh:
+			return
+		case task := <-sys.tasksCh:
+			sys.run(context.Background(), objAPI, task)
+		}
+	}
+}
+
+// run - runs the replication task, the task is removed once done.
+func (sys *ReplicationSys) run(ctx context.Context, objAPI ObjectLayer, task replicationTask) {
+	ctx = logger.SetReqInfo(ctx, &logger.ReqInfo{BucketName: task.Bucket, ObjectName: task.Object})
+
+	var err error
+	if task.Delete {
+		err = sys.replicateDelete(ctx, task)
+	} else {
+		err = sys.replicateObject(ctx, objAPI, task)
+	}
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	logger.LogIf(ctx, deleteReplicationTask(ctx, objAPI, task))
+}
+
+// retry - retries the tasks which are not done for at least
+// replicationRetryInterval, including those queued before a restart,
+// only one node of the cluster runs a retry round at a time.
+func (sys *ReplicationSys) retry(ctx context.Context, objAPI ObjectLayer) error {
+	// Do not wait for the leader lock, a failure means another node is the leader.
+	zeroDuration := time.Millisecond
+	leaderLock := globalNSMutex.NewNSLock(minioMetaBucket, replicationLeaderLock)
+	if err := leaderLock.GetLock(newDynamicTimeout(zeroDuration, zeroDuration)); err != nil {
+		return err
+	}
+	defer leaderLock.Unlock()
+
+	prefix := path.Join(minioConfigPrefix, replicationTasksPrefix) + "/"
+	marker := ""
+	for {
+		result, err := objAPI.ListObjects(ctx, minioMetaBucket, prefix, marker, "", 1000)
+		if err != nil {
+			return err
+		}
+
+		for _, objInfo := range result.Objects {
+			if UTCNow().Sub(objInfo.ModTime) < replicationRetryInterval {
+				continue
+			}
+
+			task, err := getReplicationTask(ctx, objAPI, objInfo.Name)
+			if err != nil {
+				logger.LogIf(ctx, err)
+				continue
+			}
+			sys.run(ctx, objAPI, task)
+		}
+
+		if !result.IsTruncated {
+			return nil
+		}
+		marker = result.NextMarker
+	}
+}
+
+// Refresh ReplicationSys.
+func (sys *ReplicationSys) refresh(objAPI ObjectLayer) error {
+	buckets, err := objAPI.ListBuckets(context.Background())
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		return err
+	}
+	sys.removeDeletedBuckets(buckets)
+	for _, bucket := range buckets {
+		config, err := getReplicationConfig(objAPI, bucket.Name)
+		if err != nil {
+			if err == errConfigNotFound {
+				sys.Remove(bucket.Name)
+			}
+			continue
+		}
+		sys.Set(bucket.Name, *config)
+	}
+	return nil
+}
+
+// Init - initializes replication system from replication.xml of all buckets.
+func (sys *ReplicationSys) Init(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	defer func() {
+		for i := 0; i < replicationWorkers; i++ {
+			go sys.replicate(objAPI)
+		}
+
+		// Refresh ReplicationSys and retry replication tasks in background.
+		go func() {
+			ticker := time.NewTicker(globalRefreshBucketPolicyInterval)
+			defer ticker.Stop()
+			retryTicker := time.NewTicker(replicationRetryInterval)
+			defer retryTicker.Stop()
+			for {
+				select {
+				case <-GlobalServiceDoneCh:
+					return
+				case <-ticker.C:
+					sys.refresh(objAPI)
+				case <-retryTicker.C:
+					if err := sys.retry(context.Background(), objAPI); err != nil {
+						// Another node holds the leader lock and runs the round.
+						if _, ok := err.(OperationTimedOut); ok {
+							continue
+						}
+						logger.LogIf(context.Background(), err)
+					}
+				}
+			}
+		}()
+	}()
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	// Initializing replication needs a retry mechanism for
+	// the following reasons:
+	//  - Read quorum is lost just after the initialization
+	//    of the object layer.
+	for range newRetryTimerSimple(doneCh) {
+		// Load ReplicationSys once during boot.
+		if err := sys.refresh(objAPI); err != nil {
+			if err == errDiskNotFound ||
+				strings.Contains(err.Error(), InsufficientReadQuorum{}.Error()) ||
+				strings.Contains(err.Error(), InsufficientWriteQuorum{}.Error()) {
+				logger.Info("Waiting for replication subsystem to be initialized..")
+				continue
+			}
+			return err
+		}
+		break
+	}
+	return nil
+}
+
+// NewReplicationSys - creates new replication system.
+func NewReplicationSys() *ReplicationSys {
+	return &ReplicationSys{
+		bucketReplicationMap: make(map[string]replication.Config),
+		transport:            NewCustomHTTPTransport(),
+		tasksCh:              make(chan replicationTask, replicationQueueSize),
+	}
+}
+
+// getReplicationConfig - get replication config for given bucket name.
+func getReplicationConfig(objAPI ObjectLayer, bucketName string) (*replication.Config, error) {
+	// Construct path to replication.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketReplicationConfig)
+
+	configData, err := readConfig(context.Background(), objAPI, configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return replication.ParseConfig(bytes.NewReader(configData))
+}
+
+func saveReplicationConfig(ctx context.Context, objAPI ObjectLayer, bucketName string, config *replication.Config) error {
+	data, err := xml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	// Construct path to replication.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketReplicationConfig)
+
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+func removeReplicationConfig(ctx context.Context, objAPI ObjectLayer, bucketName string) error {
+	// Construct path to replication.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketReplicationConfig)
+
+	if err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return BucketReplicationNotFound{Bucket: bucketName}
+		}
+		return err
+	}
+	return nil
+}
+
+// getReplicationTask - reads the replication task stored in the given file.
+func getReplicationTask(ctx context.Context, objAPI ObjectLayer, taskFile string) (task replicationTask, err error) {
+	data, err := readConfig(ctx, objAPI, taskFile)
+	if err != nil {
+		return task, err
+	}
+
+	if err = json.Unmarshal(data, &task); err != nil {
+		return task, err
+	}
+	task.id = strings.TrimSuffix(path.Base(taskFile), ".json")
+	return task, nil
+}
+
+func saveReplicationTask(ctx context.Context, objAPI ObjectLayer, task replicationTask) error {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+
+	taskFile := path.Join(minioConfigPrefix, replicationTasksPrefix, task.id+".json")
+	return saveConfig(ctx, objAPI, taskFile, data)
+}
+
+func deleteReplicationTask(ctx context.Context, objAPI ObjectLayer, task replicationTask) error {
+	taskFile := path.Join(minioConfigPrefix, replicationTasksPrefix, task.id+".json")
+	if err := deleteConfig(ctx, objAPI, taskFile); err != nil {
+		if _, ok := err.(ObjectNotFound); !ok {
+			return err
+		}
+	}
+	return nil
+}
+
+// replicaTransport - marks all requests sent to a destination as coming
+// from a replication, the destination then stores the objects as replicas
+// and does not replicate them any further. The header is added after the
+// request is signed, which MinIO accepts but Amazon S3 rejects.
+type replicaTransport struct {
+	http.RoundTripper
+}
+
+func (t replicaTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the request.
+	req := new(http.Request)
+	*req = *r
+	req.Header = make(http.Header, len(r.Header)+1)
+	for k, v := range r.Header {
+		req.Header[k] = v
+	}
+	req.Header.Set(amzReplicationStatus, replicationStatusReplica)
+
+	return t.RoundTripper.RoundTrip(req)
+}
+
+// isReplicaReq - returns true if the request is sent by the replication of
+// another bucket, the replica status header is only honoured for requests
+// allowed to perform the replication action, it is ignored otherwise.
+func isReplicaReq(r *http.Request, bucket, object string, action policy.Action) bool {
+	if r.Header.Get(amzReplicationStatus) != replicationStatusReplica {
+		return false
+	}
+	return isPutActionAllowed(getRequestAuthType(r), bucket, object, r, action) == ErrNone
+}
+
+// newReplicationClient - returns a client to the destination of the rule.
+func (sys *ReplicationSys) newReplicationClient(dest replication.Destination) (*miniogo.Client, error) {
+	clnt, err := miniogo.New(dest.Host(), dest.AccessKey, dest.SecretKey, dest.Secure())
+	if err != nil {
+		return nil, err
+	}
+
+	// Amazon S3 rejects unsigned x-amz-* headers, only other destinations
+	// are told that the objects are replicas.
+	if s3utils.IsAmazonEndpoint(url.URL{Host: dest.Host()}) {
+		clnt.SetCustomTransport(sys.transport)
+	} else {
+		clnt.SetCustomTransport(replicaTransport{sys.transport})
+	}
+	return clnt, nil
+}
+
+// replicateObject - copies the object version of the task to the destination
+// of the matching rule and updates its replication status.
+func (sys *ReplicationSys) replicateObject(ctx context.Context, objAPI ObjectLayer, task replicationTask) error {
+	// The rule may have been removed since the task was queued.
+	rule, ok := sys.Match(task.Bucket, task.Object)
+	if !ok {
+		return nil
+	}
+
+	opts := ObjectOptions{VersionID: task.VersionID}
+	gr, err := objAPI.GetObjectNInfo(ctx, task.Bucket, task.Object, nil, http.Header{}, readLock, opts)
+	if err != nil {
+		switch err.(type) {
+		case ObjectNotFound, VersionNotFound:
+			// The object was deleted since the task was queued.
+			return nil
+		}
+		return err
+	}
+	objInfo := gr.ObjInfo
+
+	// Objects encrypted with customer keys cannot be read by the server.
+	if crypto.SSEC.IsEncrypted(objInfo.UserDefined) {
+		gr.Close()
+		return setReplicationStatus(ctx, objAPI, task, replicationStatusFailed)
+	}
+
+	size := objInfo.Size
+	switch {
+	case objInfo.IsCompressed():
+		size = objInfo.GetActualSize()
+	case crypto.IsEncrypted(objInfo.UserDefined):
+		if size, err = objInfo.DecryptedSize(); err != nil {
+			gr.Close()
+			return err
+		}
+	}
+
+	putOpts := miniogo.PutObjectOptions{
+		UserMetadata:    make(map[string]string),
+		ContentType:     objInfo.ContentType,
+		ContentEncoding: objInfo.ContentEncoding,
+		StorageClass:    rule.Destination.StorageClass,
+	}
+	for k, v := range objInfo.UserDefined {
+		switch strings.ToLower(k) {
+		case "content-disposition":
+			putOpts.ContentDisposition = v
+		case "content-language":
+			putOpts.ContentLanguage = v
+		case "cache-control":
+			putOpts.CacheControl = v
+		default:
+			if strings.HasPrefix(strings.ToLower(k), "x-amz-meta-") {
+				putOpts.UserMetadata[k] = v
+			}
+		}
+	}
+
+	clnt, err := sys.newReplicationClient(rule.Destination)
+	if err != nil {
+		gr.Close()
+		return err
+	}
+
+	_, err = clnt.PutObjectWithContext(ctx, rule.Destination.BucketName(), task.Object, gr, size, putOpts)
+	// The object is unlocked before its replication status is updated.
+	gr.Close()
+
+	status := replicationStatusCompleted
+	if err != nil {
+		logger.LogIf(ctx, err)
+		status = replicationStatusFailed
+	}
+	if serr := setReplicationStatus(ctx, objAPI, task, status); serr != nil {
+		return serr
+	}
+	return err
+}
+
+// replicateDelete - deletes the object of the task from the destination of
+// the matching rule, deletions of specific versions are not replicated.
+func (sys *ReplicationSys) replicateDelete(ctx context.Context, task replicationTask) error {
+	rule, ok := sys.Match(task.Bucket, task.Object)
+	if !ok || task.VersionID != "" {
+		return nil
+	}
+
+	clnt, err := sys.newReplicationClient(rule.Destination)
+	if err != nil {
+		return err
+	}
+
+	err = clnt.RemoveObject(rule.Destination.BucketName(), task.Object)
+	sendReplicationEvent(task.Bucket, ObjectInfo{Name: task.Object}, err == nil)
+	return err
+}
+
+// setReplicationStatus - updates the replication status of the object version of the task.
+func setReplicationStatus(ctx context.Context, objAPI ObjectLayer, task replicationTask, status string) error {
+	opts := ObjectOptions{VersionID: task.VersionID}
+	objInfo, err := objAPI.PutObjectMetadata(ctx, task.Bucket, task.Object, map[string]string{amzReplicationStatus: status}, opts)
+	switch err.(type) {
+	case nil:
+		sendReplicationEvent(task.Bucket, objInfo, status == replicationStatusCompleted)
+	case ObjectNotFound, VersionNotFound:
+		return nil
+	}
+	return err
+}
+
+// sendReplicationEvent - notifies the completed or failed replication of
+// an object or of its deletion.
+func sendReplicationEvent(bucket string, objInfo ObjectInfo, completed bool) {
+	eventName := event.ObjectReplicationComplete
+	if !completed {
+		eventName = event.ObjectReplicationFailed
+	}
+	sendEvent(eventArgs{
+		EventName:  eventName,
+		BucketName: bucket,
+		Object:     objInfo,
+	})
+}
+
+// setReplicationMetadata - sets the replication status of an object being
+// written: objects written by the replication of another bucket are
+// replicas, objects matching a replication rule are pending.
+func setReplicationMetadata(replica bool, bucket, object string, metadata map[string]string) {
+	delete(metadata, amzReplicationStatus)
+	if replica {
+		metadata[amzReplicationStatus] = replicationStatusReplica
+		return
+	}
+
+	if _, ok := globalReplicationSys.Match(bucket, object); ok {
+		metadata[amzReplicationStatus] = replicationStatusPending
+	}
+}
+
+// queueObjectReplication - queues the replication of a written object if
+// it is pending.
+func queueObjectReplication(ctx context.Context, objAPI ObjectLayer, objInfo ObjectInfo) {
+	if objInfo.UserDefined[amzReplicationStatus] != replicationStatusPending {
+		return
+	}
+
+	versionID := objInfo.VersionID
+	if versionID == nullVersionID {
+		versionID = ""
+	}
+	globalReplicationSys.queue(ctx, objAPI, replicationTask{
+		Bucket:    objInfo.Bucket,
+		Object:    objInfo.Name,
+		VersionID: versionID,
+	})
+}
+
+// queueDeleteReplication - queues the replication of an object deletion if
+// the object matches a replication rule.
+func queueDeleteReplication(ctx context.Context, objAPI ObjectLayer, r *http.Request, bucket, object, versionID string) {
+	if versionID != "" || isReplicaReq(r, bucket, object, policy.ReplicateDeleteAction) {
+		return
+	}
+
+	if _, ok := globalReplicationSys.Match(bucket, object); !ok {
+		return
+	}
+
+	globalReplicationSys.queue(ctx, objAPI, replicationTask{
+		Bucket: bucket,
+		Object: object,
+		Delete: true,
+	})
+}
@@ -0,0 +1,183 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/replication"
+)
+
+// Wrapper for calling replication tests for both XL multiple disks and single node setup.
+func TestBucketReplication(t *testing.T) {
+	ExecObjectLayerTest(t, testBucketReplication)
+}
+
+// Unit test for replicating objects and deletions to a remote destination.
+func testBucketReplication(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	if err := obj.MakeBucketWithLocation(ctx, "bucket", ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	// Destination accepting all requests but those on the "denied/" prefix.
+	var mutex sync.Mutex
+	received := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["location"]; ok {
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`))
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/replica/denied/") {
+			writeErrorResponse(context.Background(), w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL, false)
+			return
+		}
+		if r.Header.Get(amzReplicationStatus) != replicationStatusReplica {
+			t.Errorf("%s: expected %s request to be marked as replica", instanceType, r.Method)
+		}
+		data, _ := ioutil.ReadAll(r.Body)
+		mutex.Lock()
+		received[r.Method+" "+r.URL.Path] = r.Header.Get("X-Amz-Meta-Color") + " " + string(data)
+		mutex.Unlock()
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	globalReplicationSys = NewReplicationSys()
+	prefix := ""
+	globalReplicationSys.Set("bucket", replication.Config{
+		Rules: []replication.Rule{{
+			Status: replication.Enabled,
+			Prefix: &prefix,
+			Destination: replication.Destination{
+				Bucket:    "arn:aws:s3:::replica",
+				Endpoint:  server.URL,
+				AccessKey: "minio",
+				SecretKey: "minio123",
+			},
+		}},
+	})
+
+	countTasks := func() int {
+		result, err := obj.ListObjects(ctx, minioMetaBucket, path.Join(minioConfigPrefix, replicationTasksPrefix)+"/", "", "", 1000)
+		if err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+		return len(result.Objects)
+	}
+
+	testCases := []struct {
+		object         string
+		expectedStatus string
+		expectedTasks  int
+	}{
+		{"docs/object", replicationStatusCompleted, 0},
+		// Failed replications are kept to be retried.
+		{"denied/object", replicationStatusFailed, 1},
+	}
+
+	for i, testCase := range testCases {
+		r := httptest.NewRequest(http.MethodPut, "/bucket/"+testCase.object, nil)
+		metadata := map[string]string{"X-Amz-Meta-Color": "blue"}
+		setReplicationMetadata(isReplicaReq(r, "bucket", testCase.object, policy.ReplicateObjectAction), "bucket", testCase.object, metadata)
+
+		objInfo, err := obj.PutObject(ctx, "bucket", testCase.object, mustGetPutObjReader(t, bytes.NewReader([]byte("data")), 4, "", ""), ObjectOptions{UserDefined: metadata})
+		if err != nil {
+			t.Fatalf("%s: test %d: %s", instanceType, i+1, err)
+		}
+		if objInfo.UserDefined[amzReplicationStatus] != replicationStatusPending {
+			t.Fatalf("%s: test %d: expected object to be pending replication", instanceType, i+1)
+		}
+
+		queueObjectReplication(ctx, obj, objInfo)
+		if n := countTasks(); n != 1 {
+			t.Fatalf("%s: test %d: expected one persisted task, got %d", instanceType, i+1, n)
+		}
+		globalReplicationSys.run(ctx, obj, <-globalReplicationSys.tasksCh)
+
+		if objInfo, err = obj.GetObjectInfo(ctx, "bucket", testCase.object, ObjectOptions{}); err != nil {
+			t.Fatalf("%s: test %d: %s", instanceType, i+1, err)
+		}
+		if status := objInfo.UserDefined[amzReplicationStatus]; status != testCase.expectedStatus {
+			t.Fatalf("%s: test %d: expected status %s, got %s", instanceType, i+1, testCase.expectedStatus, status)
+		}
+		if n := countTasks(); n != testCase.expectedTasks {
+			t.Fatalf("%s: test %d: expected %d persisted tasks, got %d", instanceType, i+1, testCase.expectedTasks, n)
+		}
+	}
+
+	// Data may be sent with a streaming signature, in chunks.
+	mutex.Lock()
+	data := received["PUT /replica/docs/object"]
+	mutex.Unlock()
+	if !strings.HasPrefix(data, "blue ") || !strings.Contains(data, "data") {
+		t.Fatalf("%s: expected object and metadata to be replicated, got %q", instanceType, data)
+	}
+
+	// Deletions are replicated, unless requested by a replication.
+	r := httptest.NewRequest(http.MethodDelete, "/bucket/docs/object", nil)
+	queueDeleteReplication(ctx, obj, r, "bucket", "docs/object", "")
+	globalReplicationSys.run(ctx, obj, <-globalReplicationSys.tasksCh)
+	mutex.Lock()
+	_, ok := received["DELETE /replica/docs/object"]
+	mutex.Unlock()
+	if !ok {
+		t.Fatalf("%s: expected deletion to be replicated", instanceType)
+	}
+
+	// The replica status is ignored for requests not allowed to replicate.
+	globalPolicySys = NewPolicySys()
+	globalIAMSys = NewIAMSys()
+	r.Header.Set(amzReplicationStatus, replicationStatusReplica)
+	queueDeleteReplication(ctx, obj, r, "bucket", "docs/object", "")
+	if n := countTasks(); n != 2 {
+		t.Fatalf("%s: expected deletion by an anonymous replica to be queued, got %d tasks", instanceType, n)
+	}
+	globalReplicationSys.run(ctx, obj, <-globalReplicationSys.tasksCh)
+
+	metadata := map[string]string{amzReplicationStatus: replicationStatusReplica}
+	setReplicationMetadata(isReplicaReq(r, "bucket", "docs/object", policy.ReplicateObjectAction), "bucket", "docs/object", metadata)
+	if status := metadata[amzReplicationStatus]; status != replicationStatusPending {
+		t.Fatalf("%s: expected object written by an anonymous replica to be pending, got %s", instanceType, status)
+	}
+
+	credentials := globalServerConfig.GetCredential()
+	r, err := newTestSignedRequestV4(http.MethodDelete, "/bucket/docs/object", 0, nil, credentials.AccessKey, credentials.SecretKey,
+		map[string]string{amzReplicationStatus: replicationStatusReplica})
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	queueDeleteReplication(ctx, obj, r, "bucket", "docs/object", "")
+	if n := countTasks(); n != 1 {
+		t.Fatalf("%s: expected deletion of a replica not to be queued, got %d tasks", instanceType, n)
+	}
+	setReplicationMetadata(isReplicaReq(r, "bucket", "docs/object", policy.ReplicateObjectAction), "bucket", "docs/object", metadata)
+	if status := metadata[amzReplicationStatus]; status != replicationStatusReplica {
+		t.Fatalf("%s: expected object written by a replica to be a replica, got %s", instanceType, status)
+	}
+}
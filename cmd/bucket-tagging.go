@@ -0,0 +1,73 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"path"
+
+	"github.com/minio/minio/pkg/tags"
+)
+
+const (
+	// Tagging configuration file.
+	bucketTaggingConfig = "tagging.xml"
+)
+
+// getBucketTaggingConfig - get tagging config for given bucket name.
+func getBucketTaggingConfig(ctx context.Context, objAPI ObjectLayer, bucketName string) (*tags.Tags, error) {
+	// Construct path to tagging.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketTaggingConfig)
+
+	configData, err := readConfig(ctx, objAPI, configFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			err = BucketTaggingNotFound{Bucket: bucketName}
+		}
+		return nil, err
+	}
+
+	return tags.ParseBucketXML(bytes.NewReader(configData))
+}
+
+func saveBucketTaggingConfig(ctx context.Context, objAPI ObjectLayer, bucketName string, t *tags.Tags) error {
+	data, err := xml.Marshal(t)
+	if err != nil {
+		return err
+	}
+
+	// Construct path to tagging.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketTaggingConfig)
+
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+// removeBucketTaggingConfig - removes tagging.xml for a given bucket.
+func removeBucketTaggingConfig(ctx context.Context, objAPI ObjectLayer, bucketName string) error {
+	// Construct path to tagging.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketTaggingConfig)
+
+	if err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return BucketTaggingNotFound{Bucket: bucketName}
+		}
+		return err
+	}
+	return nil
+}
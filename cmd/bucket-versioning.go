@@ -0,0 +1,250 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/versioning"
+)
+
+const (
+	// Versioning configuration file.
+	bucketVersioningConfig = "versioning.xml"
+
+	// Version ID of objects written while versioning was not enabled.
+	nullVersionID = "null"
+
+	// Versioning related request and response headers.
+	amzVersionID           = "X-Amz-Version-Id"
+	amzDeleteMarker        = "X-Amz-Delete-Marker"
+	amzCopySourceVersionID = "X-Amz-Copy-Source-Version-Id"
+)
+
+// getVersionID - returns the version ID reported to clients, "null" for
+// objects written while versioning was not enabled.
+func getVersionID(versionID string) string {
+	if versionID == "" {
+		return nullVersionID
+	}
+	return versionID
+}
+
+// setVersionHeaders - sets the version ID and delete marker headers of the
+// object, the version ID is only reported for buckets with versioning.
+func setVersionHeaders(w http.ResponseWriter, objInfo ObjectInfo) {
+	if objInfo.VersionID != "" || globalBucketVersioningSys.Configured(objInfo.Bucket) {
+		w.Header().Set(amzVersionID, getVersionID(objInfo.VersionID))
+	}
+	if objInfo.DeleteMarker {
+		w.Header().Set(amzDeleteMarker, "true")
+	}
+}
+
+// BucketVersioningSys - versioning subsystem.
+type BucketVersioningSys struct {
+	sync.RWMutex
+	bucketVersioningMap map[string]versioning.Versioning
+}
+
+// removeDeletedBuckets - to handle a corner case where we have cached the versioning
+// configuration for a deleted bucket. i.e if we miss a delete-bucket notification we
+// should delete the corresponding versioning configuration during sys.refresh()
+func (sys *BucketVersioningSys) removeDeletedBuckets(bucketInfos []BucketInfo) {
+	buckets := set.NewStringSet()
+	for _, info := range bucketInfos {
+		buckets.Add(info.Name)
+	}
+	sys.Lock()
+	defer sys.Unlock()
+
+	for bucket := range sys.bucketVersioningMap {
+		if !buckets.Contains(bucket) {
+			delete(sys.bucketVersioningMap, bucket)
+		}
+	}
+}
+
+// Set - sets versioning configuration to given bucket name.
+func (sys *BucketVersioningSys) Set(bucketName string, v versioning.Versioning) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.bucketVersioningMap[bucketName] = v
+}
+
+// Remove - removes versioning configuration for given bucket name.
+func (sys *BucketVersioningSys) Remove(bucketName string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	delete(sys.bucketVersioningMap, bucketName)
+}
+
+// Get - returns versioning configuration of given bucket name.
+func (sys *BucketVersioningSys) Get(bucketName string) (v versioning.Versioning, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	v, ok = sys.bucketVersioningMap[bucketName]
+	return v, ok
+}
+
+// Enabled - returns true if versioning is enabled on given bucket name.
+func (sys *BucketVersioningSys) Enabled(bucketName string) bool {
+	v, ok := sys.Get(bucketName)
+	return ok && v.Enabled()
+}
+
+// Suspended - returns true if versioning is suspended on given bucket name.
+func (sys *BucketVersioningSys) Suspended(bucketName string) bool {
+	v, ok := sys.Get(bucketName)
+	return ok && v.Suspended()
+}
+
+// Configured - returns true if versioning was ever configured on given bucket name.
+func (sys *BucketVersioningSys) Configured(bucketName string) bool {
+	_, ok := sys.Get(bucketName)
+	return ok
+}
+
+// Refresh BucketVersioningSys.
+func (sys *BucketVersioningSys) refresh(objAPI ObjectLayer) error {
+	buckets, err := objAPI.ListBuckets(context.Background())
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		return err
+	}
+	sys.removeDeletedBuckets(buckets)
+	for _, bucket := range buckets {
+		config, err := getVersioningConfig(objAPI, bucket.Name)
+		if err != nil {
+			if err == errConfigNotFound {
+				sys.Remove(bucket.Name)
+			}
+			continue
+		}
+		sys.Set(bucket.Name, *config)
+	}
+	return nil
+}
+
+// Init - initializes versioning system from versioning.xml of all buckets.
+func (sys *BucketVersioningSys) Init(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	// Versioning is not supported by gateways.
+	if !objAPI.IsVersioningSupported() {
+		return nil
+	}
+
+	defer func() {
+		// Refresh BucketVersioningSys in background.
+		go func() {
+			ticker := time.NewTicker(globalRefreshBucketPolicyInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-GlobalServiceDoneCh:
+					return
+				case <-ticker.C:
+					sys.refresh(objAPI)
+				}
+			}
+		}()
+	}()
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	// Initializing versioning needs a retry mechanism for
+	// the following reasons:
+	//  - Read quorum is lost just after the initialization
+	//    of the object layer.
+	for range newRetryTimerSimple(doneCh) {
+		// Load BucketVersioningSys once during boot.
+		if err := sys.refresh(objAPI); err != nil {
+			if err == errDiskNotFound ||
+				strings.Contains(err.Error(), InsufficientReadQuorum{}.Error()) ||
+				strings.Contains(err.Error(), InsufficientWriteQuorum{}.Error()) {
+				logger.Info("Waiting for versioning subsystem to be initialized..")
+				continue
+			}
+			return err
+		}
+		break
+	}
+	return nil
+}
+
+// NewBucketVersioningSys - creates new versioning system.
+func NewBucketVersioningSys() *BucketVersioningSys {
+	return &BucketVersioningSys{
+		bucketVersioningMap: make(map[string]versioning.Versioning),
+	}
+}
+
+// getVersioningConfig - get versioning config for given bucket name.
+func getVersioningConfig(objAPI ObjectLayer, bucketName string) (*versioning.Versioning, error) {
+	// Construct path to versioning.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketVersioningConfig)
+
+	configData, err := readConfig(context.Background(), objAPI, configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return versioning.ParseConfig(bytes.NewReader(configData))
+}
+
+func saveVersioningConfig(ctx context.Context, objAPI ObjectLayer, bucketName string, v *versioning.Versioning) error {
+	data, err := xml.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	// Construct path to versioning.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketVersioningConfig)
+
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+// Removes versioning.xml for a given bucket, only used during DeleteBucket.
+func removeVersioningConfig(ctx context.Context, objAPI ObjectLayer, bucketName string) error {
+	// Construct path to versioning.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketVersioningConfig)
+
+	return objAPI.DeleteObject(ctx, minioMetaBucket, configFile)
+}
+
+// setVersioningOpts - sets the versioning state of the bucket on the
+// object options of a write or delete request.
+func setVersioningOpts(opts *ObjectOptions, bucket string) {
+	opts.Versioned = globalBucketVersioningSys.Enabled(bucket)
+	opts.VersionSuspended = globalBucketVersioningSys.Suspended(bucket)
+}
@@ -0,0 +1,290 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"io"
+	"net"
+	"net/http"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/handlers"
+	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/website"
+)
+
+const (
+	// Website configuration is limited to 50 routing rules, which comfortably fit in 64KiB.
+	maxBucketWebsiteConfigSize = 64 * humanize.KiByte
+)
+
+// PutBucketWebsiteHandler - This HTTP handler stores given bucket website configuration as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTBucketPUTwebsite.html
+func (api objectAPIHandlers) PutBucketWebsiteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketWebsite")
+
+	defer logger.AuditLog(w, r, "PutBucketWebsite", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// website configuration is stored in the minio meta bucket which gateways do not have.
+	if globalIsGateway {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutBucketWebsiteAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// PutBucketWebsite always needs a Content-Length.
+	if r.ContentLength <= 0 {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMissingContentLength), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Error out if Content-Length is beyond allowed size.
+	if r.ContentLength > maxBucketWebsiteConfigSize {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrEntityTooLarge), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	config, err := website.ParseConfig(io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMalformedXML), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if err = saveWebsiteConfig(ctx, objAPI, bucket, config); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	globalBucketWebsiteSys.Set(bucket, *config)
+	globalNotificationSys.SetBucketWebsite(ctx, bucket, config)
+
+	// Success.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketWebsiteHandler - This HTTP handler returns bucket website configuration.
+func (api objectAPIHandlers) GetBucketWebsiteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketWebsite")
+
+	defer logger.AuditLog(w, r, "GetBucketWebsite", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetBucketWebsiteAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Gateways never have a website configuration.
+	if globalIsGateway {
+		writeErrorResponse(ctx, w, toAPIError(ctx, BucketWebsiteNotFound{Bucket: bucket}), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	config, err := getWebsiteConfig(objAPI, bucket)
+	if err != nil {
+		if err == errConfigNotFound {
+			err = BucketWebsiteNotFound{Bucket: bucket}
+		}
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// If xml namespace is empty, set a default value before returning.
+	if config.XMLNS == "" {
+		config.XMLNS = "http://s3.amazonaws.com/doc/2006-03-01/"
+	}
+
+	websiteBytes, err := xml.Marshal(config)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseXML(w, websiteBytes)
+}
+
+// DeleteBucketWebsiteHandler - This HTTP handler removes bucket website configuration.
+func (api objectAPIHandlers) DeleteBucketWebsiteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DeleteBucketWebsite")
+
+	defer logger.AuditLog(w, r, "DeleteBucketWebsite", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if globalIsGateway {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.DeleteBucketWebsiteAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Deleting a missing website configuration is not an error, as per AWS S3 specification.
+	if err := removeWebsiteConfig(ctx, objAPI, bucket); err != nil {
+		if _, ok := err.(BucketWebsiteNotFound); !ok {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	}
+
+	globalBucketWebsiteSys.Remove(bucket)
+	globalNotificationSys.RemoveBucketWebsite(ctx, bucket)
+
+	// Success.
+	writeSuccessNoContent(w)
+}
+
+// GetWebsiteObjectHandler - This HTTP handler serves anonymous GET and HEAD requests
+// on a bucket with a website configuration as a static website. Requests on the
+// bucket or on a prefix serve the index document, failed requests serve the error
+// document and routing rules redirect requests as per
+// https://docs.aws.amazon.com/AmazonS3/latest/dev/WebsiteHosting.html
+func (api objectAPIHandlers) GetWebsiteObjectHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetWebsiteObject")
+
+	defer logger.AuditLog(w, r, "GetWebsiteObject", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := vars["object"]
+
+	config, ok := globalBucketWebsiteSys.Get(bucket)
+	if !ok {
+		writeErrorResponse(ctx, w, toAPIError(ctx, BucketWebsiteNotFound{Bucket: bucket}), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if redirect := config.RedirectAllRequestsTo; redirect != nil {
+		redirectWebsiteReq(w, r, redirect.Protocol, redirect.HostName, bucket, object, http.StatusMovedPermanently)
+		return
+	}
+
+	if rule, ok := config.Route(object, 0); ok {
+		redirectWebsiteReq(w, r, rule.Redirect.Protocol, rule.Redirect.HostName, bucket, rule.RedirectKey(object), rule.RedirectCode())
+		return
+	}
+
+	key := config.IndexKey(object)
+	objInfo, err := writeWebsiteObject(ctx, objAPI, w, r, bucket, key, http.StatusOK)
+	if err == nil {
+		eventName := event.ObjectAccessedGet
+		if r.Method == http.MethodHead {
+			eventName = event.ObjectAccessedHead
+		}
+
+		// Get host and port from Request.RemoteAddr.
+		host, port, err := net.SplitHostPort(handlers.GetSourceIP(r))
+		if err != nil {
+			host, port = "", ""
+		}
+
+		sendEvent(eventArgs{
+			EventName:    eventName,
+			BucketName:   bucket,
+			Object:       objInfo,
+			ReqParams:    extractReqParams(r),
+			RespElements: extractRespElements(w),
+			UserAgent:    r.UserAgent(),
+			Host:         host,
+			Port:         port,
+		})
+		return
+	}
+
+	// A key naming a prefix with an index document is redirected to
+	// the prefix, as per AWS S3 specification.
+	if isErrObjectNotFound(err) && object != "" && key == object {
+		if _, serr := objAPI.GetObjectInfo(ctx, bucket, config.IndexKey(object+slashSeparator), ObjectOptions{}); serr == nil {
+			redirectWebsiteReq(w, r, "", "", bucket, object+slashSeparator, http.StatusFound)
+			return
+		}
+	}
+
+	apiErr := toAPIError(ctx, err)
+	if rule, ok := config.Route(object, apiErr.HTTPStatusCode); ok {
+		redirectWebsiteReq(w, r, rule.Redirect.Protocol, rule.Redirect.HostName, bucket, rule.RedirectKey(object), rule.RedirectCode())
+		return
+	}
+
+	// Client errors serve the error document, with the status code of the error.
+	if config.ErrorDocument != nil && apiErr.HTTPStatusCode >= 400 && apiErr.HTTPStatusCode < 500 {
+		if _, err = writeWebsiteObject(ctx, objAPI, w, r, bucket, config.ErrorDocument.Key, apiErr.HTTPStatusCode); err == nil {
+			return
+		}
+	}
+
+	writeErrorResponse(ctx, w, apiErr, r.URL, guessIsBrowserReq(r))
+}
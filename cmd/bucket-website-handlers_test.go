@@ -0,0 +1,104 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/website"
+)
+
+// Wrapper for calling GetWebsiteObject HTTP handler tests for both XL multiple disks and single node setup.
+func TestGetWebsiteObjectHandler(t *testing.T) {
+	ExecObjectLayerAPITest(t, testGetWebsiteObjectHandler, []string{"GetWebsiteObject"})
+}
+
+func testGetWebsiteObjectHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalPolicySys = NewPolicySys()
+
+	ctx := context.Background()
+	for object, content := range map[string]string{
+		"index.html":      "home",
+		"docs/index.html": "docs",
+		"404.html":        "not found",
+	} {
+		if _, err := obj.PutObject(ctx, bucketName, object, mustGetPutObjReader(t, bytes.NewBufferString(content), int64(len(content)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+	}
+
+	globalBucketWebsiteSys.Set(bucketName, website.Config{
+		IndexDocument: &website.IndexDocument{Suffix: "index.html"},
+		ErrorDocument: &website.ErrorDocument{Key: "404.html"},
+		RoutingRules: []website.RoutingRule{
+			{Condition: &website.Condition{KeyPrefixEquals: "old/"}, Redirect: website.Redirect{ReplaceKeyPrefixWith: "docs/"}},
+		},
+	})
+	defer globalBucketWebsiteSys.Remove(bucketName)
+
+	testCases := []struct {
+		path             string
+		expectedStatus   int
+		expectedBody     string
+		expectedLocation string
+	}{
+		// 1. Website objects are not served without anonymous read access.
+		{"/" + bucketName + "/", http.StatusForbidden, "", ""},
+		// 2. The bucket serves its index document.
+		{"/" + bucketName + "/", http.StatusOK, "home", ""},
+		// 3. A prefix serves its index document.
+		{"/" + bucketName + "/docs/", http.StatusOK, "docs", ""},
+		// 4. A prefix without trailing slash is redirected.
+		{"/" + bucketName + "/docs", http.StatusFound, "", "/" + bucketName + "/docs/"},
+		// 5. A missing key serves the error document.
+		{"/" + bucketName + "/missing.html", http.StatusNotFound, "not found", ""},
+		// 6. A routing rule redirects the key.
+		{"/" + bucketName + "/old/intro.html", http.StatusMovedPermanently, "", "/" + bucketName + "/docs/intro.html"},
+	}
+
+	for i, testCase := range testCases {
+		// Anonymous read access is granted after the first test.
+		if i == 1 {
+			globalPolicySys.Set(bucketName, *getAnonReadOnlyObjectPolicy(bucketName, "*"))
+			defer globalPolicySys.Remove(bucketName)
+		}
+
+		req, err := newTestRequest(http.MethodGet, testCase.path, 0, nil)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request: <ERROR> %v", i+1, instanceType, err)
+		}
+
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+
+		if rec.Code != testCase.expectedStatus {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedStatus, rec.Code)
+		}
+		if testCase.expectedBody != "" && rec.Body.String() != testCase.expectedBody {
+			t.Fatalf("Test %d: %s: Expected the response body to be `%s`, but instead found `%s`", i+1, instanceType, testCase.expectedBody, rec.Body.String())
+		}
+		if location := rec.Header().Get("Location"); location != testCase.expectedLocation {
+			t.Fatalf("Test %d: %s: Expected the location to be `%s`, but instead found `%s`", i+1, instanceType, testCase.expectedLocation, location)
+		}
+	}
+}
@@ -0,0 +1,294 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/handlers"
+	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/website"
+)
+
+const (
+	// website configuration file.
+	bucketWebsiteConfig = "website.xml"
+)
+
+// BucketWebsiteSys - Bucket website subsystem.
+type BucketWebsiteSys struct {
+	sync.RWMutex
+	bucketWebsiteMap map[string]website.Config
+}
+
+// removeDeletedBuckets - to handle a corner case where we have cached the website
+// configuration for a deleted bucket. i.e if we miss a delete-bucket notification we
+// should delete the corresponding website configuration during sys.refresh()
+func (sys *BucketWebsiteSys) removeDeletedBuckets(bucketInfos []BucketInfo) {
+	buckets := set.NewStringSet()
+	for _, info := range bucketInfos {
+		buckets.Add(info.Name)
+	}
+	sys.Lock()
+	defer sys.Unlock()
+
+	for bucket := range sys.bucketWebsiteMap {
+		if !buckets.Contains(bucket) {
+			delete(sys.bucketWebsiteMap, bucket)
+		}
+	}
+}
+
+// Set - sets website configuration to given bucket name.
+func (sys *BucketWebsiteSys) Set(bucketName string, config website.Config) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.bucketWebsiteMap[bucketName] = config
+}
+
+// Remove - removes website configuration for given bucket name.
+func (sys *BucketWebsiteSys) Remove(bucketName string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	delete(sys.bucketWebsiteMap, bucketName)
+}
+
+// Get - returns website configuration of given bucket name.
+func (sys *BucketWebsiteSys) Get(bucketName string) (config website.Config, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	config, ok = sys.bucketWebsiteMap[bucketName]
+	return config, ok
+}
+
+// Refresh BucketWebsiteSys.
+func (sys *BucketWebsiteSys) refresh(objAPI ObjectLayer) error {
+	buckets, err := objAPI.ListBuckets(context.Background())
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		return err
+	}
+	sys.removeDeletedBuckets(buckets)
+	for _, bucket := range buckets {
+		config, err := getWebsiteConfig(objAPI, bucket.Name)
+		if err != nil {
+			if err == errConfigNotFound {
+				sys.Remove(bucket.Name)
+			}
+			continue
+		}
+		sys.Set(bucket.Name, *config)
+	}
+	return nil
+}
+
+// Init - initializes website system from website.xml of all buckets.
+func (sys *BucketWebsiteSys) Init(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errInvalidArgument
+	}
+
+	defer func() {
+		// Refresh BucketWebsiteSys in background.
+		go func() {
+			ticker := time.NewTicker(globalRefreshBucketPolicyInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-GlobalServiceDoneCh:
+					return
+				case <-ticker.C:
+					sys.refresh(objAPI)
+				}
+			}
+		}()
+	}()
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	// Initializing website needs a retry mechanism for
+	// the following reasons:
+	//  - Read quorum is lost just after the initialization
+	//    of the object layer.
+	for range newRetryTimerSimple(doneCh) {
+		// Load BucketWebsiteSys once during boot.
+		if err := sys.refresh(objAPI); err != nil {
+			if err == errDiskNotFound ||
+				strings.Contains(err.Error(), InsufficientReadQuorum{}.Error()) ||
+				strings.Contains(err.Error(), InsufficientWriteQuorum{}.Error()) {
+				logger.Info("Waiting for website subsystem to be initialized..")
+				continue
+			}
+			return err
+		}
+		break
+	}
+	return nil
+}
+
+// NewBucketWebsiteSys - creates new website system.
+func NewBucketWebsiteSys() *BucketWebsiteSys {
+	return &BucketWebsiteSys{
+		bucketWebsiteMap: make(map[string]website.Config),
+	}
+}
+
+// getWebsiteConfig - get website config for given bucket name.
+func getWebsiteConfig(objAPI ObjectLayer, bucketName string) (*website.Config, error) {
+	// Construct path to website.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketWebsiteConfig)
+
+	configData, err := readConfig(context.Background(), objAPI, configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return website.ParseConfig(bytes.NewReader(configData))
+}
+
+func saveWebsiteConfig(ctx context.Context, objAPI ObjectLayer, bucketName string, config *website.Config) error {
+	data, err := xml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	// Construct path to website.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketWebsiteConfig)
+
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+// removeWebsiteConfig - removes website.xml for a given bucket.
+func removeWebsiteConfig(ctx context.Context, objAPI ObjectLayer, bucketName string) error {
+	// Construct path to website.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketWebsiteConfig)
+
+	if err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return BucketWebsiteNotFound{Bucket: bucketName}
+		}
+		return err
+	}
+	return nil
+}
+
+// isWebsiteReq - returns true if the request is an anonymous request without
+// query parameters on a bucket with a website configuration, such requests
+// are served as a static website instead of the S3 API.
+func isWebsiteReq(r *http.Request, _ *mux.RouteMatch) bool {
+	if getRequestAuthType(r) != authTypeAnonymous || r.URL.RawQuery != "" {
+		return false
+	}
+
+	_, ok := globalBucketWebsiteSys.Get(getRequestBucketName(r))
+	return ok
+}
+
+// writeWebsiteObject - writes the given object of a website bucket to the
+// response with the given status code. Website objects are only served if
+// the bucket policy allows anonymous read access.
+func writeWebsiteObject(ctx context.Context, objAPI ObjectLayer, w http.ResponseWriter, r *http.Request, bucket, object string, statusCode int) (ObjectInfo, error) {
+	if !globalPolicySys.IsAllowed(policy.Args{
+		Action:          policy.GetObjectAction,
+		BucketName:      bucket,
+		ConditionValues: getConditionValues(r, "", ""),
+		IsOwner:         false,
+		ObjectName:      object,
+	}) {
+		return ObjectInfo{}, PrefixAccessDenied{Bucket: bucket, Object: object}
+	}
+
+	gr, err := objAPI.GetObjectNInfo(ctx, bucket, object, nil, r.Header, readLock, ObjectOptions{})
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	defer gr.Close()
+
+	objInfo := gr.ObjInfo
+
+	if objAPI.IsEncryptionSupported() {
+		objInfo.UserDefined = CleanMinioInternalMetadataKeys(objInfo.UserDefined)
+		if _, err = DecryptObjectInfo(&objInfo, r.Header); err != nil {
+			return objInfo, err
+		}
+	}
+
+	// Validate pre-conditions if any, error documents are always served.
+	if statusCode == http.StatusOK && checkPreconditions(ctx, w, r, objInfo) {
+		return objInfo, nil
+	}
+
+	if err = setObjectHeaders(w, objInfo, nil); err != nil {
+		return objInfo, err
+	}
+
+	w.WriteHeader(statusCode)
+	if r.Method == http.MethodHead {
+		return objInfo, nil
+	}
+
+	// Headers are already written, errors can only be logged.
+	if _, err = io.Copy(w, gr); err != nil {
+		logger.LogIf(ctx, err)
+	}
+	return objInfo, nil
+}
+
+// redirectWebsiteReq - redirects a website request to the given key, on the
+// given host or on the host of the request if empty.
+func redirectWebsiteReq(w http.ResponseWriter, r *http.Request, protocol, host, bucket, key string, code int) {
+	u := &url.URL{Path: slashSeparator + key}
+	if host == "" {
+		// Path style requests keep the bucket in the path.
+		if resource, err := getResource(r.URL.Path, r.Host, globalDomainNames); err == nil && resource == r.URL.Path {
+			u.Path = slashSeparator + bucket + u.Path
+		}
+
+		// Redirects on the same host with the same protocol are relative.
+		if protocol == "" {
+			http.Redirect(w, r, u.String(), code)
+			return
+		}
+		host = r.Host
+	}
+
+	u.Host = host
+	u.Scheme = protocol
+	if u.Scheme == "" {
+		u.Scheme = handlers.GetSourceScheme(r)
+	}
+	if u.Scheme == "" {
+		u.Scheme = getURLScheme(globalIsSSL)
+	}
+	http.Redirect(w, r, u.String(), code)
+}
@@ -27,6 +27,7 @@ import (
 	"time"
 
 	etcd "github.com/coreos/etcd/clientv3"
+	humanize "github.com/dustin/go-humanize"
 	dns2 "github.com/miekg/dns"
 	"github.com/minio/cli"
 	"github.com/minio/minio-go/pkg/set"
@@ -410,4 +411,39 @@ func handleCommonEnvVars() {
 			globalCompressMimeTypes = contenttypes
 		}
 	}
+
+	// Bitrot scrubber is enabled by default if the MINIO_BITROT_SCRUB is
+	// not set or is not set to 'off'.
+	globalIsBitrotScrubEnabled = !strings.EqualFold(os.Getenv("MINIO_BITROT_SCRUB"), "off")
+
+	if iops := os.Getenv("MINIO_BITROT_SCRUB_IOPS"); iops != "" {
+		maxIOPS, err := strconv.Atoi(iops)
+		if err == nil && maxIOPS < 0 {
+			err = errInvalidArgument
+		}
+		if err != nil {
+			logger.Fatal(err, "Invalid MINIO_BITROT_SCRUB_IOPS value (`%s`)", iops)
+		}
+		globalBitrotScrubMaxIOPS = maxIOPS
+	}
+
+	if bandwidth := os.Getenv("MINIO_BITROT_SCRUB_BANDWIDTH"); bandwidth != "" {
+		maxBandwidth, err := humanize.ParseBytes(bandwidth)
+		if err != nil {
+			logger.Fatal(err, "Invalid MINIO_BITROT_SCRUB_BANDWIDTH value (`%s`)", bandwidth)
+		}
+		globalBitrotScrubMaxBandwidth = maxBandwidth
+	}
+
+	if threshold := os.Getenv("MINIO_XL_INLINE_THRESHOLD"); threshold != "" {
+		inlineThreshold, err := humanize.ParseBytes(threshold)
+		// Inline objects are erasure coded in a single block.
+		if err == nil && inlineThreshold > blockSizeV1 {
+			err = errInvalidArgument
+		}
+		if err != nil {
+			logger.Fatal(err, "Invalid MINIO_XL_INLINE_THRESHOLD value (`%s`)", threshold)
+		}
+		globalXLInlineThreshold = int64(inlineThreshold)
+	}
 }
@@ -235,6 +235,10 @@ func (s *serverConfig) Validate() error {
 		}
 	}
 
+	if err := s.LDAPServerConfig.Validate(); err != nil {
+		return fmt.Errorf("ldap: %s", err)
+	}
+
 	return nil
 }
 
@@ -287,6 +291,10 @@ func (s *serverConfig) loadFromEnvs() {
 		}
 	}
 
+	if ldapConfig, ok := newLDAPConfigFromEnv(); ok {
+		s.LDAPServerConfig = ldapConfig
+	}
+
 	if opaURL, ok := os.LookupEnv("MINIO_IAM_OPA_URL"); ok {
 		if u, err := xnet.ParseURL(opaURL); err == nil {
 			s.Policy.OPA.URL = u
@@ -548,6 +556,8 @@ func (s *serverConfig) loadToCachedConfigs() {
 
 	globalIAMValidators = getAuthValidators(s)
 
+	globalLDAPConfig = s.LDAPServerConfig
+
 	if s.Policy.OPA.URL != nil && s.Policy.OPA.URL.String() != "" {
 		globalPolicyOPA = iampolicy.NewOpa(iampolicy.OpaArgs{
 			URL:         s.Policy.OPA.URL,
@@ -767,8 +777,12 @@ func getNotificationTargets(config *serverConfig) *event.TargetList {
 	for id, args := range config.Notify.Webhook {
 		if args.Enable {
 			args.RootCAs = globalRootCAs
-			newTarget := target.NewWebhookTarget(id, args)
-			if err := targetList.Add(newTarget); err != nil {
+			newTarget, err := target.NewWebhookTarget(id, args)
+			if err != nil {
+				logger.LogIf(context.Background(), err)
+				continue
+			}
+			if err = targetList.Add(newTarget); err != nil {
 				logger.LogIf(context.Background(), err)
 				continue
 			}
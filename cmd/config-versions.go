@@ -919,6 +919,9 @@ type serverConfigV33 struct {
 		JWKS validator.JWKSArgs `json:"jwks"`
 	} `json:"openid"`
 
+	// LDAP identity provider configuration
+	LDAPServerConfig ldapServerConfig `json:"ldapserverconfig"`
+
 	// External policy enforcements.
 	Policy struct {
 		// OPA configuration.
@@ -0,0 +1,200 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/lifecycle"
+)
+
+const (
+	// Interval between two lifecycle rounds.
+	bgLifecycleInterval = 24 * time.Hour
+
+	// Interval at which every node checks if a lifecycle round is due.
+	bgLifecycleTick = time.Hour
+
+	// Lock held by the node running the lifecycle round.
+	lifecycleLeaderLock = "leader-lifecycle.lock"
+
+	// Holds the time of the last lifecycle round of the cluster.
+	lifecycleOpsFile = "lifecycle-ops.json"
+)
+
+// lifecycleOps - state of the lifecycle rounds, shared by all nodes.
+type lifecycleOps struct {
+	LastRun time.Time `json:"lastRun"`
+}
+
+// startDailyLifecycle - runs lifecycle rounds in background until the
+// server stops, should be run in a go-routine.
+func startDailyLifecycle(objAPI ObjectLayer) {
+	ctx := context.Background()
+
+	ticker := time.NewTicker(bgLifecycleTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-GlobalServiceDoneCh:
+			return
+		case <-ticker.C:
+			if err := lifecycleRound(ctx, objAPI); err != nil {
+				// Another node holds the leader lock and runs the round.
+				if _, ok := err.(OperationTimedOut); ok {
+					continue
+				}
+				logger.LogIf(ctx, err)
+			}
+		}
+	}
+}
+
+// lifecycleRound - applies lifecycle rules of all buckets if the last round
+// is older than bgLifecycleInterval, only one node of the cluster runs a
+// round at a time.
+func lifecycleRound(ctx context.Context, objAPI ObjectLayer) error {
+	// Do not wait for the leader lock, a failure means another node is the leader.
+	zeroDuration := time.Millisecond
+	leaderLock := globalNSMutex.NewNSLock(minioMetaBucket, lifecycleLeaderLock)
+	if err := leaderLock.GetLock(newDynamicTimeout(zeroDuration, zeroDuration)); err != nil {
+		return err
+	}
+	defer leaderLock.Unlock()
+
+	configFile := path.Join(minioConfigPrefix, lifecycleOpsFile)
+
+	var ops lifecycleOps
+	data, err := readConfig(ctx, objAPI, configFile)
+	switch {
+	case err == nil:
+		if err = json.Unmarshal(data, &ops); err != nil {
+			return err
+		}
+	case err != errConfigNotFound:
+		return err
+	}
+
+	if UTCNow().Sub(ops.LastRun) < bgLifecycleInterval {
+		return nil
+	}
+	ops.LastRun = UTCNow()
+
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, bucket := range buckets {
+		lc, ok := globalLifecycleSys.Get(bucket.Name)
+		if !ok {
+			continue
+		}
+		logger.LogIf(ctx, applyBucketLifecycle(ctx, objAPI, bucket.Name, lc))
+	}
+
+	if data, err = json.Marshal(ops); err != nil {
+		return err
+	}
+
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+// applyBucketLifecycle - deletes all objects of the bucket expired by the
+// lifecycle configuration. Incomplete multipart uploads are aborted while
+// cleaning up stale uploads, as they are not listed by name.
+func applyBucketLifecycle(ctx context.Context, objAPI ObjectLayer, bucket string, lc lifecycle.Lifecycle) error {
+	// Expiring the current version of an object in a versioned bucket adds a delete marker.
+	var opts ObjectOptions
+	setVersioningOpts(&opts, bucket)
+
+	for _, prefix := range lc.Prefixes() {
+		marker := ""
+		for {
+			result, err := objAPI.ListObjects(ctx, bucket, prefix, marker, "", maxObjectList)
+			if err != nil {
+				return err
+			}
+
+			for _, obj := range result.Objects {
+				if lc.ComputeAction(obj.Name, obj.ModTime) != lifecycle.DeleteAction {
+					continue
+				}
+				// An expiry releases the size of the version it removes.
+				prevSize := getQuotaObjectSize(ctx, objAPI, bucket, obj.Name, "")
+				if err = deleteExpiredObject(ctx, objAPI, bucket, obj.Name, opts); err != nil {
+					// Objects protected by object lock expire once they are released.
+					if _, ok := err.(ObjectLocked); ok {
+						continue
+					}
+					logger.GetReqInfo(ctx).AppendTags("object", obj.Name)
+					logger.LogIf(ctx, err)
+					continue
+				}
+				globalBucketQuotaSys.update(objAPI, bucket, -prevSize)
+			}
+
+			if !result.IsTruncated {
+				break
+			}
+			marker = result.NextMarker
+		}
+	}
+
+	return nil
+}
+
+// deleteExpiredObject - deletes an object expired by a lifecycle rule and
+// notifies the object deleted event.
+func deleteExpiredObject(ctx context.Context, objAPI ObjectLayer, bucket, object string, opts ObjectOptions) error {
+	var objInfo ObjectInfo
+	if opts.Versioned || opts.VersionSuspended {
+		var err error
+		if objInfo, err = objAPI.DeleteObjectVersion(ctx, bucket, object, opts); err != nil {
+			return err
+		}
+	} else {
+		deleteObject := objAPI.DeleteObject
+		if cacheAPI := newCacheObjectsFn(); cacheAPI != nil {
+			deleteObject = cacheAPI.DeleteObject
+		}
+		if err := deleteObject(ctx, bucket, object); err != nil {
+			return err
+		}
+	}
+
+	eventName := event.ObjectRemovedDelete
+	if opts.VersionID == "" && objInfo.DeleteMarker {
+		eventName = event.ObjectRemovedDeleteMarkerCreated
+	}
+	sendEvent(eventArgs{
+		EventName:  eventName,
+		BucketName: bucket,
+		Object: ObjectInfo{
+			Name:      object,
+			VersionID: objInfo.VersionID,
+		},
+	})
+
+	return nil
+}
@@ -0,0 +1,81 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/lifecycle"
+)
+
+// Wrapper for calling lifecycle round tests for both XL multiple disks and single node setup.
+func TestLifecycleRound(t *testing.T) {
+	ExecObjectLayerTest(t, testLifecycleRound)
+}
+
+// Unit test for expiring objects as per bucket lifecycle rules.
+func testLifecycleRound(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	bucket := "bucket"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	putObjects := func(objects ...string) {
+		for _, object := range objects {
+			if _, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewBufferString("data"), int64(len("data")), "", ""), ObjectOptions{}); err != nil {
+				t.Fatalf("%s: %s", instanceType, err)
+			}
+		}
+	}
+	putObjects("logs/a", "logs/b", "data/c")
+
+	// Objects under logs/ are expired since a date in the past.
+	past := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	globalLifecycleSys = NewLifecycleSys()
+	globalLifecycleSys.Set(bucket, lifecycle.Lifecycle{
+		Rules: []lifecycle.Rule{
+			{Status: lifecycle.Enabled, Filter: &lifecycle.Filter{Prefix: "logs/"}, Expiration: &lifecycle.Expiration{Date: &past}},
+			{Status: lifecycle.Enabled, Filter: &lifecycle.Filter{Prefix: "data/"}, Expiration: &lifecycle.Expiration{Days: 1}},
+		},
+	})
+	defer globalLifecycleSys.Remove(bucket)
+
+	if err := lifecycleRound(ctx, obj); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	result, err := obj.ListObjects(ctx, bucket, "", "", "", 1000)
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if len(result.Objects) != 1 || result.Objects[0].Name != "data/c" {
+		t.Fatalf("%s: expected only data/c to remain, got %+v", instanceType, result.Objects)
+	}
+
+	// A second round within the lifecycle interval does nothing.
+	putObjects("logs/d")
+	if err = lifecycleRound(ctx, obj); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if _, err = obj.GetObjectInfo(ctx, bucket, "logs/d", ObjectOptions{}); err != nil {
+		t.Fatalf("%s: expected logs/d to remain, got %v", instanceType, err)
+	}
+}
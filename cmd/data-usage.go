@@ -0,0 +1,206 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"path"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Interval between two data usage crawls.
+	dataUsageCrawlInterval = time.Hour
+
+	// Interval at which every node checks if a data usage crawl is due.
+	dataUsageCrawlTick = 5 * time.Minute
+
+	// Lock held by the node crawling the data usage.
+	dataUsageLeaderLock = "leader-data-usage.lock"
+
+	// Holds the data usage computed by the last crawl of the cluster.
+	dataUsageFile = "data-usage.json"
+)
+
+// objectHistogramInterval - a range of object sizes, bounds are inclusive.
+type objectHistogramInterval struct {
+	name       string
+	start, end int64
+}
+
+// Object size ranges of the object size histograms.
+var objectsHistogramIntervals = []objectHistogramInterval{
+	{"LESS_THAN_1024_B", -1, humanize.KiByte - 1},
+	{"BETWEEN_1024_B_AND_1_MB", humanize.KiByte, humanize.MiByte - 1},
+	{"BETWEEN_1_MB_AND_10_MB", humanize.MiByte, humanize.MiByte*10 - 1},
+	{"BETWEEN_10_MB_AND_64_MB", humanize.MiByte * 10, humanize.MiByte*64 - 1},
+	{"BETWEEN_64_MB_AND_128_MB", humanize.MiByte * 64, humanize.MiByte*128 - 1},
+	{"BETWEEN_128_MB_AND_512_MB", humanize.MiByte * 128, humanize.MiByte*512 - 1},
+	{"GREATER_THAN_512_MB", humanize.MiByte * 512, math.MaxInt64},
+}
+
+// newObjectsHistogram - returns an object size histogram with all ranges set to zero.
+func newObjectsHistogram() map[string]uint64 {
+	histogram := make(map[string]uint64, len(objectsHistogramIntervals))
+	for _, interval := range objectsHistogramIntervals {
+		histogram[interval.name] = 0
+	}
+	return histogram
+}
+
+// updateObjectsHistogram - counts an object of the given size in the histogram.
+func updateObjectsHistogram(histogram map[string]uint64, size int64) {
+	for _, interval := range objectsHistogramIntervals {
+		if size >= interval.start && size <= interval.end {
+			histogram[interval.name]++
+			return
+		}
+	}
+}
+
+// startDataUsageCrawler - crawls the data usage in background until the
+// server stops, should be run in a go-routine.
+func startDataUsageCrawler(objAPI ObjectLayer) {
+	ctx := context.Background()
+
+	ticker := time.NewTicker(dataUsageCrawlTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-GlobalServiceDoneCh:
+			return
+		case <-ticker.C:
+			if err := dataUsageRound(ctx, objAPI); err != nil {
+				// Another node holds the leader lock and crawls the data usage.
+				if _, ok := err.(OperationTimedOut); ok {
+					continue
+				}
+				logger.LogIf(ctx, err)
+			}
+		}
+	}
+}
+
+// dataUsageRound - crawls the data usage of all buckets and persists it if
+// the last crawl is older than dataUsageCrawlInterval, only one node of the
+// cluster crawls at a time.
+func dataUsageRound(ctx context.Context, objAPI ObjectLayer) error {
+	// Do not wait for the leader lock, a failure means another node is the leader.
+	zeroDuration := time.Millisecond
+	leaderLock := globalNSMutex.NewNSLock(minioMetaBucket, dataUsageLeaderLock)
+	if err := leaderLock.GetLock(newDynamicTimeout(zeroDuration, zeroDuration)); err != nil {
+		return err
+	}
+	defer leaderLock.Unlock()
+
+	dataUsageInfo, err := loadDataUsage(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+	if UTCNow().Sub(dataUsageInfo.LastUpdate) < dataUsageCrawlInterval {
+		return nil
+	}
+
+	if dataUsageInfo, err = crawlDataUsage(ctx, objAPI); err != nil {
+		return err
+	}
+
+	return saveDataUsage(ctx, objAPI, dataUsageInfo)
+}
+
+// crawlDataUsage - computes the size, the object count and the object size
+// histogram of every bucket. The size of a bucket includes the noncurrent
+// versions of its objects, the count and the histogram do not.
+func crawlDataUsage(ctx context.Context, objAPI ObjectLayer) (madmin.DataUsageInfo, error) {
+	dataUsageInfo := madmin.DataUsageInfo{
+		ObjectsSizesHistogram: newObjectsHistogram(),
+		BucketsUsage:          make(map[string]madmin.BucketUsageInfo),
+	}
+
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		return dataUsageInfo, err
+	}
+
+	for _, bucket := range buckets {
+		bucketUsage := madmin.BucketUsageInfo{
+			ObjectsSizesHistogram: newObjectsHistogram(),
+		}
+		err = walkBucketVersions(ctx, objAPI, bucket.Name, func(objInfo ObjectInfo) {
+			bucketUsage.Size += uint64(objInfo.Size)
+			if !objInfo.IsLatest || objInfo.DeleteMarker {
+				return
+			}
+			bucketUsage.ObjectsCount++
+			updateObjectsHistogram(bucketUsage.ObjectsSizesHistogram, objInfo.Size)
+		})
+		if err != nil {
+			// Bucket removed while crawling.
+			if _, ok := err.(BucketNotFound); ok {
+				continue
+			}
+			return dataUsageInfo, err
+		}
+
+		dataUsageInfo.BucketsCount++
+		dataUsageInfo.ObjectsCount += bucketUsage.ObjectsCount
+		dataUsageInfo.ObjectsTotalSize += bucketUsage.Size
+		for name, count := range bucketUsage.ObjectsSizesHistogram {
+			dataUsageInfo.ObjectsSizesHistogram[name] += count
+		}
+		dataUsageInfo.BucketsUsage[bucket.Name] = bucketUsage
+	}
+
+	dataUsageInfo.LastUpdate = UTCNow()
+	return dataUsageInfo, nil
+}
+
+// loadDataUsage - returns the data usage persisted by the last crawl, an
+// empty data usage is returned if no crawl has finished yet.
+func loadDataUsage(ctx context.Context, objAPI ObjectLayer) (madmin.DataUsageInfo, error) {
+	var dataUsageInfo madmin.DataUsageInfo
+
+	data, err := readConfig(ctx, objAPI, path.Join(minioConfigPrefix, dataUsageFile))
+	if err != nil {
+		if err == errConfigNotFound {
+			return dataUsageInfo, nil
+		}
+		return dataUsageInfo, err
+	}
+
+	if err = json.Unmarshal(data, &dataUsageInfo); err != nil {
+		return dataUsageInfo, err
+	}
+	return dataUsageInfo, nil
+}
+
+// saveDataUsage - persists the data usage under the minio meta bucket.
+func saveDataUsage(ctx context.Context, objAPI ObjectLayer, dataUsageInfo madmin.DataUsageInfo) error {
+	data, err := json.Marshal(dataUsageInfo)
+	if err != nil {
+		return err
+	}
+
+	return saveConfig(ctx, objAPI, path.Join(minioConfigPrefix, dataUsageFile), data)
+}
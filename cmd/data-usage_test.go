@@ -0,0 +1,115 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	humanize "github.com/dustin/go-humanize"
+)
+
+func TestUpdateObjectsHistogram(t *testing.T) {
+	testCases := []struct {
+		size     int64
+		expected string
+	}{
+		{0, "LESS_THAN_1024_B"},
+		{humanize.KiByte - 1, "LESS_THAN_1024_B"},
+		{humanize.KiByte, "BETWEEN_1024_B_AND_1_MB"},
+		{humanize.MiByte, "BETWEEN_1_MB_AND_10_MB"},
+		{humanize.MiByte * 64, "BETWEEN_64_MB_AND_128_MB"},
+		{humanize.MiByte*512 - 1, "BETWEEN_128_MB_AND_512_MB"},
+		{humanize.GiByte * 5, "GREATER_THAN_512_MB"},
+	}
+
+	for i, testCase := range testCases {
+		histogram := newObjectsHistogram()
+		updateObjectsHistogram(histogram, testCase.size)
+		for name, count := range histogram {
+			if (name == testCase.expected) != (count == 1) {
+				t.Fatalf("test %d: expected size %d to be counted in %s, got %v", i+1, testCase.size, testCase.expected, histogram)
+			}
+		}
+	}
+}
+
+// Wrapper for calling data usage round tests for both XL multiple disks and single node setup.
+func TestDataUsageRound(t *testing.T) {
+	ExecObjectLayerTest(t, testDataUsageRound)
+}
+
+// Unit test for crawling and persisting the data usage of all buckets.
+func testDataUsageRound(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+
+	putObject := func(bucket, object string, size int) {
+		data := bytes.Repeat([]byte("a"), size)
+		if _, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(size), "", ""), ObjectOptions{}); err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+	}
+
+	for _, bucket := range []string{"bucket1", "bucket2"} {
+		if err := obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+	}
+	putObject("bucket1", "small", 10)
+	putObject("bucket1", "dir/medium", humanize.KiByte)
+	putObject("bucket2", "small", 20)
+
+	if err := dataUsageRound(ctx, obj); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	dataUsageInfo, err := loadDataUsage(ctx, obj)
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if dataUsageInfo.LastUpdate.IsZero() {
+		t.Fatalf("%s: expected data usage to be persisted", instanceType)
+	}
+	if dataUsageInfo.BucketsCount != 2 || dataUsageInfo.ObjectsCount != 3 || dataUsageInfo.ObjectsTotalSize != 30+humanize.KiByte {
+		t.Fatalf("%s: unexpected data usage %+v", instanceType, dataUsageInfo)
+	}
+	if dataUsageInfo.ObjectsSizesHistogram["LESS_THAN_1024_B"] != 2 || dataUsageInfo.ObjectsSizesHistogram["BETWEEN_1024_B_AND_1_MB"] != 1 {
+		t.Fatalf("%s: unexpected objects histogram %v", instanceType, dataUsageInfo.ObjectsSizesHistogram)
+	}
+
+	bucketUsage := dataUsageInfo.BucketsUsage["bucket1"]
+	if bucketUsage.Size != 10+humanize.KiByte || bucketUsage.ObjectsCount != 2 {
+		t.Fatalf("%s: unexpected bucket usage %+v", instanceType, bucketUsage)
+	}
+	if bucketUsage.ObjectsSizesHistogram["LESS_THAN_1024_B"] != 1 || bucketUsage.ObjectsSizesHistogram["BETWEEN_1024_B_AND_1_MB"] != 1 {
+		t.Fatalf("%s: unexpected bucket objects histogram %v", instanceType, bucketUsage.ObjectsSizesHistogram)
+	}
+
+	// A second round within the crawl interval does nothing.
+	putObject("bucket2", "other", 20)
+	if err = dataUsageRound(ctx, obj); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	newDataUsageInfo, err := loadDataUsage(ctx, obj)
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if !newDataUsageInfo.LastUpdate.Equal(dataUsageInfo.LastUpdate) || newDataUsageInfo.ObjectsCount != 3 {
+		t.Fatalf("%s: expected data usage not to be crawled again, got %+v", instanceType, newDataUsageInfo)
+	}
+}
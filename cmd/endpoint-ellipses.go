@@ -264,19 +264,76 @@ func getAllSets(args ...string) ([][]string, error) {
 }
 
 // CreateServerEndpoints - validates and creates new endpoints from input args, supports
-// both ellipses and without ellipses transparently.
-func createServerEndpoints(serverAddr string, args ...string) (string, EndpointList, SetupType, int, int, error) {
-	setArgs, err := getAllSets(args...)
-	if err != nil {
-		return serverAddr, nil, -1, 0, 0, err
+// both ellipses and without ellipses transparently. Each of multiple ellipses args
+// forms an independent server pool.
+func createServerEndpoints(serverAddr string, args ...string) (string, EndpointPools, SetupType, error) {
+	if len(args) == 0 {
+		return serverAddr, nil, -1, errInvalidArgument
 	}
 
-	var endpoints EndpointList
+	if len(args) == 1 || !ellipses.HasEllipses(args...) {
+		setArgs, err := getAllSets(args...)
+		if err != nil {
+			return serverAddr, nil, -1, err
+		}
+
+		var endpoints EndpointList
+		var setupType SetupType
+		serverAddr, endpoints, setupType, err = CreateEndpoints(serverAddr, setArgs...)
+		if err != nil {
+			return serverAddr, nil, -1, err
+		}
+
+		return serverAddr, EndpointPools{{
+			SetCount:     len(setArgs),
+			DrivesPerSet: len(setArgs[0]),
+			Endpoints:    endpoints,
+		}}, setupType, nil
+	}
+
+	var pools EndpointPools
 	var setupType SetupType
-	serverAddr, endpoints, setupType, err = CreateEndpoints(serverAddr, setArgs...)
-	if err != nil {
-		return serverAddr, nil, -1, 0, 0, err
+	uniqueEndpoints := set.NewStringSet()
+	foundLocal := false
+	for i, arg := range args {
+		setArgs, err := getAllSets(arg)
+		if err != nil {
+			return serverAddr, nil, -1, err
+		}
+
+		var endpoints EndpointList
+		var poolSetupType SetupType
+		serverAddr, endpoints, poolSetupType, err = createEndpoints(serverAddr, true, setArgs...)
+		if err != nil {
+			return serverAddr, nil, -1, err
+		}
+
+		if i == 0 {
+			setupType = poolSetupType
+		} else if poolSetupType != setupType {
+			return serverAddr, nil, -1, uiErrInvalidErasureEndpoints(nil).Msg("Server pool (%s) has setup type %s, expected %s", arg, poolSetupType, setupType)
+		} else if len(setArgs[0]) != pools[0].DrivesPerSet {
+			return serverAddr, nil, -1, uiErrInvalidErasureEndpoints(nil).Msg("Server pool (%s) has %d drives per set, expected %d", arg, len(setArgs[0]), pools[0].DrivesPerSet)
+		}
+
+		for _, endpoint := range endpoints {
+			if uniqueEndpoints.Contains(endpoint.String()) {
+				return serverAddr, nil, -1, uiErrInvalidErasureEndpoints(nil).Msg("Input args (%s) has duplicate endpoints", args)
+			}
+			uniqueEndpoints.Add(endpoint.String())
+			foundLocal = foundLocal || endpoint.IsLocal
+		}
+
+		pools = append(pools, PoolEndpoints{
+			SetCount:     len(setArgs),
+			DrivesPerSet: len(setArgs[0]),
+			Endpoints:    endpoints,
+		})
+	}
+
+	if !foundLocal {
+		return serverAddr, nil, -1, uiErrInvalidErasureEndpoints(nil).Msg("no endpoint pointing to the local machine is found")
 	}
 
-	return serverAddr, endpoints, setupType, len(setArgs), len(setArgs[0]), nil
+	return serverAddr, pools, setupType, nil
 }
@@ -44,6 +44,10 @@ func TestCreateServerEndpoints(t *testing.T) {
 		{":9000", []string{"/export1{1...32}", "/export1{1...32}"}, false},
 		// Same host cannot export same disk on two ports - special case localhost.
 		{":9001", []string{"http://localhost:900{1...2}/export{1...64}"}, false},
+		// Server pools must have the same number of drives per set.
+		{":9000", []string{"/export1{1...32}", "/export2{1...12}"}, false},
+		// Server pools must not share disks.
+		{":9000", []string{"/export1{1...32}", "/export1{17...48}"}, false},
 
 		// Valid inputs.
 		{":9000", []string{"/export1"}, true},
@@ -53,10 +57,11 @@ func TestCreateServerEndpoints(t *testing.T) {
 		{":9000", []string{"/export1{1...32}", "/export1{33...64}"}, true},
 		{":9001", []string{"http://localhost:9001/export{1...64}"}, true},
 		{":9001", []string{"http://localhost:9001/export{01...64}"}, true},
+		{":9000", []string{"/export1{1...32}", "/export2{1...16}", "/export3{1...64}"}, true},
 	}
 
 	for i, testCase := range testCases {
-		_, _, _, _, _, err := createServerEndpoints(testCase.serverAddr, testCase.args...)
+		_, _, _, err := createServerEndpoints(testCase.serverAddr, testCase.args...)
 		if err != nil && testCase.success {
 			t.Errorf("Test %d: Expected success but failed instead %s", i+1, err)
 		}
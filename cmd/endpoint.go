@@ -321,8 +321,33 @@ func checkCrossDeviceMounts(endpoints EndpointList) (err error) {
 	return mountinfo.CheckCrossDevice(absPaths)
 }
 
+// PoolEndpoints - endpoints of a server pool along with its set layout.
+type PoolEndpoints struct {
+	SetCount     int
+	DrivesPerSet int
+	Endpoints    EndpointList
+}
+
+// EndpointPools - list of server pools, each pool forms independent erasure sets.
+type EndpointPools []PoolEndpoints
+
+// Endpoints - returns endpoints of all server pools.
+func (pools EndpointPools) Endpoints() (endpoints EndpointList) {
+	for _, pool := range pools {
+		endpoints = append(endpoints, pool.Endpoints...)
+	}
+	return endpoints
+}
+
 // CreateEndpoints - validates and creates new endpoints for given args.
 func CreateEndpoints(serverAddr string, args ...[]string) (string, EndpointList, SetupType, error) {
+	return createEndpoints(serverAddr, false, args...)
+}
+
+// createEndpoints - validates and creates new endpoints for given args, when
+// poolEndpoints is set the endpoints may not point to the local machine as
+// long as endpoints of another server pool do.
+func createEndpoints(serverAddr string, poolEndpoints bool, args ...[]string) (string, EndpointList, SetupType, error) {
 	var endpoints EndpointList
 	var setupType SetupType
 	var err error
@@ -405,7 +430,7 @@ func CreateEndpoints(serverAddr string, args ...[]string) (string, EndpointList,
 	}
 
 	// No local endpoint found.
-	if localEndpointCount == 0 {
+	if localEndpointCount == 0 && !poolEndpoints {
 		return serverAddr, endpoints, setupType, uiErrInvalidErasureEndpoints(nil).Msg("no endpoint pointing to the local machine is found")
 	}
 
@@ -447,7 +472,7 @@ func CreateEndpoints(serverAddr string, args ...[]string) (string, EndpointList,
 	}
 
 	// Check whether serverAddrPort matches at least in one of port used in local endpoints.
-	{
+	if localEndpointCount > 0 {
 		if !localPortSet.Contains(serverAddrPort) {
 			if len(localPortSet) > 1 {
 				return serverAddr, endpoints, setupType,
@@ -120,6 +120,13 @@ type formatXLV3 struct {
 		// Distribution algorithm represents the hashing algorithm
 		// to pick the right set index for an object.
 		DistributionAlgo string `json:"distributionAlgo"`
+		// Decommission carries the decommission state of the server
+		// pool the disk belongs to, empty for pools in service.
+		Decommission string `json:"decommission,omitempty"`
+		// SetsDecommission carries the decommission state of each
+		// erasure set of the pool in the order of Sets, empty for
+		// pools with all sets in service.
+		SetsDecommission []string `json:"setsDecommission,omitempty"`
 	} `json:"xl"`
 }
 
@@ -280,7 +287,9 @@ func formatXLMigrateV2ToV3(export string) error {
 
 	formatV3.Version = formatV2.Version
 	formatV3.Format = formatV2.Format
-	formatV3.XL = formatV2.XL
+	formatV3.XL.This = formatV2.XL.This
+	formatV3.XL.Sets = formatV2.XL.Sets
+	formatV3.XL.DistributionAlgo = formatV2.XL.DistributionAlgo
 
 	formatV3.XL.Version = formatXLVersionV3
 
@@ -487,8 +496,9 @@ func formatXLGetDeploymentID(refFormat *formatXLV3, formats []*formatXLV3) (stri
 	return deploymentID, nil
 }
 
-// formatXLFixDeploymentID - Add deployment id if it is not present.
-func formatXLFixDeploymentID(ctx context.Context, storageDisks []StorageAPI, refFormat *formatXLV3) (err error) {
+// formatXLFixDeploymentID - Add deployment id if it is not present,
+// deploymentID is used instead of a new one when it is not empty.
+func formatXLFixDeploymentID(ctx context.Context, endpoints EndpointList, storageDisks []StorageAPI, refFormat *formatXLV3, deploymentID string) (err error) {
 	// Acquire lock on format.json
 	mutex := newNSLock(globalIsDistXL)
 	formatLock := mutex.NewNSLock(minioMetaBucket, formatConfigFile)
@@ -502,7 +512,7 @@ func formatXLFixDeploymentID(ctx context.Context, storageDisks []StorageAPI, ref
 	formats, sErrs := loadFormatXLAll(storageDisks)
 	for i, sErr := range sErrs {
 		if _, ok := formatCriticalErrors[sErr]; ok {
-			return fmt.Errorf("Disk %s: %s", globalEndpoints[i], sErr)
+			return fmt.Errorf("Disk %s: %s", endpoints[i], sErr)
 		}
 	}
 
@@ -527,7 +537,10 @@ func formatXLFixDeploymentID(ctx context.Context, storageDisks []StorageAPI, ref
 
 	// ID is generated for the first time,
 	// We set the ID in all the formats and update.
-	refFormat.ID = mustGetUUID()
+	refFormat.ID = deploymentID
+	if refFormat.ID == "" {
+		refFormat.ID = mustGetUUID()
+	}
 	for _, format := range formats {
 		if format != nil {
 			format.ID = refFormat.ID
@@ -540,11 +553,11 @@ func formatXLFixDeploymentID(ctx context.Context, storageDisks []StorageAPI, ref
 }
 
 // Update only the valid local disks which have not been updated before.
-func formatXLFixLocalDeploymentID(ctx context.Context, storageDisks []StorageAPI, refFormat *formatXLV3) error {
+func formatXLFixLocalDeploymentID(ctx context.Context, endpoints EndpointList, storageDisks []StorageAPI, refFormat *formatXLV3) error {
 	// If this server was down when the deploymentID was updated
 	// then we make sure that we update the local disks with the deploymentID.
 	for index, storageDisk := range storageDisks {
-		if globalEndpoints[index].IsLocal && storageDisk != nil && storageDisk.IsOnline() {
+		if endpoints[index].IsLocal && storageDisk != nil && storageDisk.IsOnline() {
 			format, err := loadFormatXL(storageDisk)
 			if err != nil {
 				// Disk can be offline etc.
@@ -742,9 +755,13 @@ func fixFormatXLV3(storageDisks []StorageAPI, endpoints EndpointList, formats []
 	return nil
 }
 
-// initFormatXL - save XL format configuration on all disks.
-func initFormatXL(ctx context.Context, storageDisks []StorageAPI, setCount, disksPerSet int) (format *formatXLV3, err error) {
+// initFormatXL - save XL format configuration on all disks, with
+// deploymentID as the deployment ID when it is not empty.
+func initFormatXL(ctx context.Context, storageDisks []StorageAPI, setCount, disksPerSet int, deploymentID string) (format *formatXLV3, err error) {
 	format = newFormatXLV3(setCount, disksPerSet)
+	if deploymentID != "" {
+		format.ID = deploymentID
+	}
 	formats := make([]*formatXLV3, len(storageDisks))
 
 	for i := 0; i < setCount; i++ {
@@ -900,6 +917,8 @@ func newHealFormatSets(refFormat *formatXLV3, setCount, disksPerSet int, formats
 				newFormats[i][j].Format = refFormat.Format
 				newFormats[i][j].XL.Version = refFormat.XL.Version
 				newFormats[i][j].XL.DistributionAlgo = refFormat.XL.DistributionAlgo
+				newFormats[i][j].XL.Decommission = refFormat.XL.Decommission
+				newFormats[i][j].XL.SetsDecommission = refFormat.XL.SetsDecommission
 			}
 			if errs[i*disksPerSet+j] == errUnformattedDisk {
 				newFormats[i][j].XL.This = ""
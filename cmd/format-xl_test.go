@@ -354,6 +354,8 @@ func TestCheckFormatXLValue(t *testing.T) {
 					This             string     `json:"this"`
 					Sets             [][]string `json:"sets"`
 					DistributionAlgo string     `json:"distributionAlgo"`
+					Decommission     string     `json:"decommission,omitempty"`
+					SetsDecommission []string   `json:"setsDecommission,omitempty"`
 				}{
 					Version: "2",
 				},
@@ -372,6 +374,8 @@ func TestCheckFormatXLValue(t *testing.T) {
 					This             string     `json:"this"`
 					Sets             [][]string `json:"sets"`
 					DistributionAlgo string     `json:"distributionAlgo"`
+					Decommission     string     `json:"decommission,omitempty"`
+					SetsDecommission []string   `json:"setsDecommission,omitempty"`
 				}{
 					Version: "2",
 				},
@@ -390,6 +394,8 @@ func TestCheckFormatXLValue(t *testing.T) {
 					This             string     `json:"this"`
 					Sets             [][]string `json:"sets"`
 					DistributionAlgo string     `json:"distributionAlgo"`
+					Decommission     string     `json:"decommission,omitempty"`
+					SetsDecommission []string   `json:"setsDecommission,omitempty"`
 				}{
 					Version: "0",
 				},
@@ -114,6 +114,12 @@ type fsMetaV1 struct {
 	Meta map[string]string `json:"meta,omitempty"`
 	// parts info for current object - used in encryption.
 	Parts []ObjectPartInfo `json:"parts,omitempty"`
+	// Version ID of current object, empty for the "null" version.
+	VersionID string `json:"versionId,omitempty"`
+	// Delete marker which is the latest version of the object.
+	DeleteMarker *fsVersionV1 `json:"deleteMarker,omitempty"`
+	// Noncurrent versions of the object, newest first.
+	Versions []fsVersionV1 `json:"versions,omitempty"`
 }
 
 // IsValid - tells if the format is sane by validating the version
@@ -130,6 +136,10 @@ func isFSMetaValid(version string) bool {
 
 // Converts metadata to object info.
 func (m fsMetaV1) ToObjectInfo(bucket, object string, fi os.FileInfo) ObjectInfo {
+	if m.DeleteMarker != nil {
+		return m.DeleteMarker.ToObjectInfo(bucket, object)
+	}
+
 	if len(m.Meta) == 0 {
 		m.Meta = make(map[string]string)
 	}
@@ -145,8 +155,9 @@ func (m fsMetaV1) ToObjectInfo(bucket, object string, fi os.FileInfo) ObjectInfo
 	}
 
 	objInfo := ObjectInfo{
-		Bucket: bucket,
-		Name:   object,
+		Bucket:    bucket,
+		Name:      object,
+		VersionID: m.VersionID,
 	}
 
 	// We set file info only if its valid.
@@ -231,6 +242,30 @@ func parseFSPartsArray(fsMetaBuf []byte) []ObjectPartInfo {
 	return partsArray
 }
 
+func parseFSVersionEntry(version gjson.Result) fsVersionV1 {
+	versionJSON := []byte(version.Raw)
+	return fsVersionV1{
+		VersionID:    gjson.GetBytes(versionJSON, "versionId").String(),
+		DeleteMarker: gjson.GetBytes(versionJSON, "deleteMarker").Bool(),
+		ModTime:      gjson.GetBytes(versionJSON, "modTime").Time(),
+		Size:         gjson.GetBytes(versionJSON, "size").Int(),
+		Meta:         parseFSMetaMap(versionJSON),
+		Parts:        parseFSPartsArray(versionJSON),
+	}
+}
+
+func parseFSVersionsArray(fsMetaBuf []byte) []fsVersionV1 {
+	// Get fsMetaV1.Versions array
+	var versionsArray []fsVersionV1
+
+	versionsArrayResult := gjson.GetBytes(fsMetaBuf, "versions")
+	versionsArrayResult.ForEach(func(key, version gjson.Result) bool {
+		versionsArray = append(versionsArray, parseFSVersionEntry(version))
+		return true
+	})
+	return versionsArray
+}
+
 func (m *fsMetaV1) ReadFrom(ctx context.Context, lk *lock.LockedFile) (n int64, err error) {
 	var fsMetaBuf []byte
 	fi, err := lk.Stat()
@@ -267,6 +302,14 @@ func (m *fsMetaV1) ReadFrom(ctx context.Context, lk *lock.LockedFile) (n int64,
 	// obtain metadata.
 	m.Meta = parseFSMetaMap(fsMetaBuf)
 
+	// obtain versions.
+	m.VersionID = gjson.GetBytes(fsMetaBuf, "versionId").String()
+	if result := gjson.GetBytes(fsMetaBuf, "deleteMarker"); result.Exists() {
+		marker := parseFSVersionEntry(result)
+		m.DeleteMarker = &marker
+	}
+	m.Versions = parseFSVersionsArray(fsMetaBuf)
+
 	// Success.
 	return int64(len(fsMetaBuf)), nil
 }
@@ -231,6 +231,11 @@ func (fs *FSObjects) NewMultipartUpload(ctx context.Context, bucket, object stri
 	// Initialize fs.json values.
 	fsMeta := newFSMetaV1()
 	fsMeta.Meta = opts.UserDefined
+	if fsMeta.Meta == nil {
+		fsMeta.Meta = make(map[string]string)
+	}
+	// Record the object name for lifecycle rules, hashed upload paths do not carry it.
+	fsMeta.Meta[multipartUploadObjectKey] = pathJoin(bucket, object)
 
 	fsMetaBytes, err := json.Marshal(fsMeta)
 	if err != nil {
@@ -664,22 +669,40 @@ func (fs *FSObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 	fsMeta.Meta["etag"] = s3MD5
 	// Save consolidated actual size.
 	fsMeta.Meta[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(objectActualSize, 10)
-	if _, err = fsMeta.WriteTo(metaFile); err != nil {
-		logger.LogIf(ctx, err)
-		return oi, toObjectErr(err, bucket, object)
-	}
+	// The object name is only needed while the upload is incomplete.
+	delete(fsMeta.Meta, multipartUploadObjectKey)
 
-	// Deny if WORM is enabled
-	if globalWORMEnabled {
-		if _, err = fsStatFile(ctx, pathJoin(fs.fsPath, bucket, object)); err == nil {
-			return ObjectInfo{}, ObjectAlreadyExists{Bucket: bucket, Object: object}
+	// Deny replacing a version protected by object lock.
+	if !opts.Versioned {
+		if err = fs.checkObjectLock(ctx, bucket, object, nullVersionID, metaFile, opts.BypassGovernance); err != nil {
+			return oi, err
 		}
 	}
 
-	err = fsRenameFile(ctx, appendFilePath, pathJoin(fs.fsPath, bucket, object))
-	if err != nil {
-		logger.LogIf(ctx, err)
-		return oi, toObjectErr(err, bucket, object)
+	if opts.Versioned || opts.VersionSuspended {
+		// Keep the previous version of the object.
+		if err = fs.putObjectVersion(ctx, bucket, object, appendFilePath, metaFile, &fsMeta, opts); err != nil {
+			logger.LogIf(ctx, err)
+			return oi, toObjectErr(err, bucket, object)
+		}
+	} else {
+		if _, err = fsMeta.WriteTo(metaFile); err != nil {
+			logger.LogIf(ctx, err)
+			return oi, toObjectErr(err, bucket, object)
+		}
+
+		// Deny if WORM is enabled
+		if globalWORMEnabled {
+			if _, err = fsStatFile(ctx, pathJoin(fs.fsPath, bucket, object)); err == nil {
+				return ObjectInfo{}, ObjectAlreadyExists{Bucket: bucket, Object: object}
+			}
+		}
+
+		err = fsRenameFile(ctx, appendFilePath, pathJoin(fs.fsPath, bucket, object))
+		if err != nil {
+			logger.LogIf(ctx, err)
+			return oi, toObjectErr(err, bucket, object)
+		}
 	}
 	fsRemoveAll(ctx, uploadIDDir)
 	// It is safe to ignore any directory not empty error (in case there were multiple uploadIDs on the same object)
@@ -769,7 +792,7 @@ func (fs *FSObjects) cleanupStaleMultipartUploads(ctx context.Context, cleanupIn
 					if err != nil {
 						continue
 					}
-					if now.Sub(fi.ModTime()) > expiry {
+					if now.Sub(fi.ModTime()) > expiry || fs.isUploadAbortedByLifecycle(pathJoin(fs.fsPath, minioMetaMultipartBucket, entry, uploadID), fi.ModTime()) {
 						fsRemoveAll(ctx, pathJoin(fs.fsPath, minioMetaMultipartBucket, entry, uploadID))
 						// It is safe to ignore any directory not empty error (in case there were multiple uploadIDs on the same object)
 						fsRemoveDir(ctx, pathJoin(fs.fsPath, minioMetaMultipartBucket, entry))
@@ -780,3 +803,23 @@ func (fs *FSObjects) cleanupStaleMultipartUploads(ctx context.Context, cleanupIn
 		}
 	}
 }
+
+// Returns true if a lifecycle rule of the bucket aborts the given incomplete multipart upload.
+func (fs *FSObjects) isUploadAbortedByLifecycle(uploadIDDir string, initiated time.Time) bool {
+	// Avoid reading upload metadata when no bucket has lifecycle rules.
+	if globalLifecycleSys.Empty() {
+		return false
+	}
+
+	fsMetaBuf, err := ioutil.ReadFile(pathJoin(uploadIDDir, fs.metaJSONFile))
+	if err != nil {
+		return false
+	}
+
+	var fsMeta fsMetaV1
+	if err = json.Unmarshal(fsMetaBuf, &fsMeta); err != nil {
+		return false
+	}
+
+	return globalLifecycleSys.abortUpload(fsMeta.Meta, initiated)
+}
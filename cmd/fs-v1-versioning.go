@@ -0,0 +1,537 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/lock"
+)
+
+// fsVersionV1 - a version of an object kept in `fs.json`, the data of
+// noncurrent versions is kept next to `fs.json` named after their version ID.
+type fsVersionV1 struct {
+	VersionID    string            `json:"versionId"`
+	DeleteMarker bool              `json:"deleteMarker,omitempty"`
+	ModTime      time.Time         `json:"modTime"`
+	Size         int64             `json:"size"`
+	Meta         map[string]string `json:"meta,omitempty"`
+	Parts        []ObjectPartInfo  `json:"parts,omitempty"`
+}
+
+// Converts version metadata to object info.
+func (v fsVersionV1) ToObjectInfo(bucket, object string) ObjectInfo {
+	m := fsMetaV1{Meta: v.Meta, Parts: v.Parts, VersionID: v.VersionID}
+	objInfo := m.ToObjectInfo(bucket, object, nil)
+	objInfo.ModTime = v.ModTime
+	objInfo.Size = v.Size
+	objInfo.DeleteMarker = v.DeleteMarker
+	return objInfo
+}
+
+// currentVersion - returns the latest version of the object, fi is the
+// stat of the object in the namespace. ok is false if there is none.
+func (m fsMetaV1) currentVersion(fi os.FileInfo) (v fsVersionV1, ok bool) {
+	if m.DeleteMarker != nil {
+		return *m.DeleteMarker, true
+	}
+	if fi == nil {
+		return v, false
+	}
+	return fsVersionV1{
+		VersionID: m.VersionID,
+		ModTime:   fi.ModTime(),
+		Size:      fi.Size(),
+		Meta:      m.Meta,
+		Parts:     m.Parts,
+	}, true
+}
+
+// setCurrentVersion - makes the given version the latest version of the object.
+func (m *fsMetaV1) setCurrentVersion(v fsVersionV1) {
+	if v.DeleteMarker {
+		m.DeleteMarker = &v
+		m.VersionID, m.Meta, m.Parts = "", nil, nil
+		return
+	}
+	m.DeleteMarker = nil
+	m.VersionID, m.Meta, m.Parts = v.VersionID, v.Meta, v.Parts
+}
+
+// fsVersionPath - returns the path of the data of a noncurrent version.
+func (fs *FSObjects) fsVersionPath(bucket, object, versionID string) string {
+	return pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, object, getVersionID(versionID))
+}
+
+// readFSMetaVersions - reads the locked `fs.json` of an object along with the
+// stat of the object in the namespace, fi is nil if the latest version of the
+// object is a delete marker or if the object does not exist.
+func (fs *FSObjects) readFSMetaVersions(ctx context.Context, bucket, object string, lk *lock.LockedFile) (fsMeta fsMetaV1, fi os.FileInfo, err error) {
+	fsMeta = newFSMetaV1()
+	if _, err = fsMeta.ReadFrom(ctx, lk); err != nil {
+		if err != io.EOF {
+			return fsMeta, nil, err
+		}
+		// `fs.json` is empty for pre-existing data.
+		fsMeta = fs.defaultFsJSON(object)
+	}
+
+	if fsMeta.DeleteMarker != nil {
+		return fsMeta, nil, nil
+	}
+
+	fi, err = fsStatFile(ctx, pathJoin(fs.fsPath, bucket, object))
+	if err == errFileNotFound {
+		return fsMeta, nil, nil
+	}
+	return fsMeta, fi, err
+}
+
+// stackFSVersion - moves the latest version of the object down into its
+// noncurrent versions to make room for a new latest version with the given
+// version ID, an existing version with the same version ID is replaced.
+func (fs *FSObjects) stackFSVersion(ctx context.Context, bucket, object string, fsMeta fsMetaV1, fi os.FileInfo, versionID string) (versions []fsVersionV1, err error) {
+	fsNSObjPath := pathJoin(fs.fsPath, bucket, object)
+	if current, ok := fsMeta.currentVersion(fi); ok {
+		switch {
+		case current.VersionID == versionID:
+			if !current.DeleteMarker {
+				if err = fsDeleteFile(ctx, pathJoin(fs.fsPath, bucket), fsNSObjPath); err != nil {
+					return nil, err
+				}
+			}
+		case current.DeleteMarker:
+			versions = append(versions, current)
+		default:
+			if err = fsRenameFile(ctx, fsNSObjPath, fs.fsVersionPath(bucket, object, current.VersionID)); err != nil {
+				return nil, err
+			}
+			versions = append(versions, current)
+		}
+	}
+
+	for _, version := range fsMeta.Versions {
+		if version.VersionID != versionID {
+			versions = append(versions, version)
+			continue
+		}
+		if !version.DeleteMarker {
+			if err = fsRemoveFile(ctx, fs.fsVersionPath(bucket, object, version.VersionID)); err != nil && err != errFileNotFound {
+				return nil, err
+			}
+		}
+	}
+	return versions, nil
+}
+
+// putObjectVersion - commits the object written at fsTmpObjPath as the new
+// latest version of the object, wlk is the locked `fs.json` of the object.
+func (fs *FSObjects) putObjectVersion(ctx context.Context, bucket, object, fsTmpObjPath string, wlk *lock.LockedFile, fsMeta *fsMetaV1, opts ObjectOptions) error {
+	current, fi, err := fs.readFSMetaVersions(ctx, bucket, object, wlk)
+	if err != nil {
+		return err
+	}
+
+	// Objects written while versioning is suspended get the "null" version.
+	if opts.Versioned {
+		fsMeta.VersionID = mustGetUUID()
+	}
+
+	if fsMeta.Versions, err = fs.stackFSVersion(ctx, bucket, object, current, fi, fsMeta.VersionID); err != nil {
+		return err
+	}
+
+	if err = fsRenameFile(ctx, fsTmpObjPath, pathJoin(fs.fsPath, bucket, object)); err != nil {
+		return err
+	}
+
+	_, err = fsMeta.WriteTo(wlk)
+	return err
+}
+
+// addDeleteMarker - adds a delete marker as the latest version of the object.
+func (fs *FSObjects) addDeleteMarker(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error) {
+	fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
+	wlk, err := fs.rwPool.Create(fsMetaPath)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+	// This close will allow for locks to be synchronized on `fs.json`.
+	defer wlk.Close()
+
+	current, fi, err := fs.readFSMetaVersions(ctx, bucket, object, wlk)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	marker := fsVersionV1{
+		DeleteMarker: true,
+		ModTime:      UTCNow(),
+	}
+	// Delete markers added while versioning is suspended get the "null" version.
+	if opts.Versioned {
+		marker.VersionID = mustGetUUID()
+	}
+
+	fsMeta := newFSMetaV1()
+	fsMeta.setCurrentVersion(marker)
+	if fsMeta.Versions, err = fs.stackFSVersion(ctx, bucket, object, current, fi, marker.VersionID); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	if _, err = fsMeta.WriteTo(wlk); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	return marker.ToObjectInfo(bucket, object), nil
+}
+
+// deleteObjectVersion - permanently removes a single version of the object,
+// the next newest version becomes the latest version.
+func (fs *FSObjects) deleteObjectVersion(ctx context.Context, bucket, object, versionID string) (ObjectInfo, error) {
+	minioMetaBucketDir := pathJoin(fs.fsPath, minioMetaBucket)
+	fsMetaPath := pathJoin(minioMetaBucketDir, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
+	fsNSObjPath := pathJoin(fs.fsPath, bucket, object)
+
+	wlk, err := fs.rwPool.Write(fsMetaPath)
+	if err != nil {
+		// Objects without `fs.json` only have the "null" version.
+		if err == errFileNotFound && versionID == nullVersionID {
+			objInfo, err := fs.getObjectInfo(ctx, bucket, object)
+			if err != nil {
+				return ObjectInfo{}, toObjectErr(err, bucket, object)
+			}
+			if err = fsDeleteFile(ctx, pathJoin(fs.fsPath, bucket), fsNSObjPath); err != nil {
+				return ObjectInfo{}, toObjectErr(err, bucket, object)
+			}
+			return objInfo, nil
+		}
+		if err == errFileNotFound {
+			return ObjectInfo{}, VersionNotFound{Bucket: bucket, Object: object, VersionID: versionID}
+		}
+		logger.LogIf(ctx, err)
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+	// This close will allow for locks to be synchronized on `fs.json`.
+	defer wlk.Close()
+
+	fsMeta, fi, err := fs.readFSMetaVersions(ctx, bucket, object, wlk)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	vid := versionID
+	if vid == nullVersionID {
+		vid = ""
+	}
+
+	var versions []fsVersionV1
+	if current, ok := fsMeta.currentVersion(fi); ok {
+		versions = append(versions, current)
+	}
+	versions = append(versions, fsMeta.Versions...)
+
+	index := -1
+	for i, version := range versions {
+		if version.VersionID == vid {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return ObjectInfo{}, VersionNotFound{Bucket: bucket, Object: object, VersionID: versionID}
+	}
+	deleted := versions[index]
+
+	// Remove the data of the version.
+	if !deleted.DeleteMarker {
+		basePath, deletePath := minioMetaBucketDir, fs.fsVersionPath(bucket, object, deleted.VersionID)
+		if index == 0 && fi != nil {
+			basePath, deletePath = pathJoin(fs.fsPath, bucket), fsNSObjPath
+		}
+		if err = fsDeleteFile(ctx, basePath, deletePath); err != nil && err != errFileNotFound {
+			return ObjectInfo{}, toObjectErr(err, bucket, object)
+		}
+	}
+	versions = append(versions[:index], versions[index+1:]...)
+
+	// Removing the only version removes the object.
+	if len(versions) == 0 {
+		tmpDir := pathJoin(fs.fsPath, minioMetaTmpBucket, fs.fsUUID)
+		if err = fsRemoveMeta(ctx, minioMetaBucketDir, fsMetaPath, tmpDir); err != nil && err != errFileNotFound {
+			return ObjectInfo{}, toObjectErr(err, bucket, object)
+		}
+		return deleted.ToObjectInfo(bucket, object), nil
+	}
+
+	// Promote the next newest version when the latest version was removed.
+	if index == 0 && !versions[0].DeleteMarker {
+		if err = fsRenameFile(ctx, fs.fsVersionPath(bucket, object, versions[0].VersionID), fsNSObjPath); err != nil {
+			return ObjectInfo{}, toObjectErr(err, bucket, object)
+		}
+	}
+
+	fsMeta.setCurrentVersion(versions[0])
+	fsMeta.Versions = versions[1:]
+	if _, err = fsMeta.WriteTo(wlk); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	return deleted.ToObjectInfo(bucket, object), nil
+}
+
+// DeleteObjectVersion - removes the requested version of the object, without
+// a version ID a delete marker is added on versioned buckets.
+func (fs *FSObjects) DeleteObjectVersion(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	if opts.VersionID == "" && !opts.Versioned && !opts.VersionSuspended {
+		if err = fs.removeObject(ctx, bucket, object, opts.BypassGovernance); err != nil {
+			return objInfo, err
+		}
+		return ObjectInfo{Bucket: bucket, Name: object}, nil
+	}
+
+	// Acquire a write lock before deleting the object.
+	objectLock := fs.nsMutex.NewNSLock(bucket, object)
+	if err = objectLock.GetLock(globalOperationTimeout); err != nil {
+		return objInfo, err
+	}
+	defer objectLock.Unlock()
+
+	if err = checkDelObjArgs(ctx, bucket, object); err != nil {
+		return objInfo, err
+	}
+
+	if _, err = fs.statBucketDir(ctx, bucket); err != nil {
+		return objInfo, toObjectErr(err, bucket)
+	}
+
+	// Delete markers never remove data, unlike replacing the "null" version.
+	if opts.VersionID == "" && opts.Versioned {
+		return fs.addDeleteMarker(ctx, bucket, object, opts)
+	}
+
+	// Deny removing a version protected by object lock.
+	versionID := opts.VersionID
+	if versionID == "" {
+		versionID = nullVersionID
+	}
+	if err = fs.checkObjectLock(ctx, bucket, object, versionID, nil, opts.BypassGovernance); err != nil {
+		return objInfo, err
+	}
+
+	if opts.VersionID == "" {
+		return fs.addDeleteMarker(ctx, bucket, object, opts)
+	}
+	return fs.deleteObjectVersion(ctx, bucket, object, opts.VersionID)
+}
+
+// getObjectVersion - returns the object info of the requested version of the
+// object along with the path of its data.
+func (fs *FSObjects) getObjectVersion(ctx context.Context, bucket, object, versionID string) (oi ObjectInfo, fsObjPath string, err error) {
+	fsObjPath = pathJoin(fs.fsPath, bucket, object)
+
+	fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
+	rlk, err := fs.rwPool.Open(fsMetaPath)
+	if err != nil {
+		// Objects without `fs.json` only have the "null" version.
+		if err == errFileNotFound && versionID == nullVersionID {
+			oi, err = fs.getObjectInfo(ctx, bucket, object)
+			return oi, fsObjPath, err
+		}
+		if err == errFileNotFound {
+			return oi, "", errFileVersionNotFound
+		}
+		logger.LogIf(ctx, err)
+		return oi, "", err
+	}
+	fsMeta, fi, err := fs.readFSMetaVersions(ctx, bucket, object, rlk.LockedFile)
+	fs.rwPool.Close(fsMetaPath)
+	if err != nil {
+		return oi, "", err
+	}
+
+	vid := versionID
+	if vid == nullVersionID {
+		vid = ""
+	}
+
+	if current, ok := fsMeta.currentVersion(fi); ok && current.VersionID == vid {
+		if current.DeleteMarker {
+			return oi, "", errFileNotFound
+		}
+		return fsMeta.ToObjectInfo(bucket, object, fi), fsObjPath, nil
+	}
+	for _, version := range fsMeta.Versions {
+		if version.VersionID != vid {
+			continue
+		}
+		if version.DeleteMarker {
+			return oi, "", errFileNotFound
+		}
+		return version.ToObjectInfo(bucket, object), fs.fsVersionPath(bucket, object, vid), nil
+	}
+	return oi, "", errFileVersionNotFound
+}
+
+// getObjectVersions - returns all versions of the object, newest first.
+func (fs *FSObjects) getObjectVersions(ctx context.Context, bucket, object string) ([]ObjectInfo, error) {
+	// Protect the entry from concurrent deletes, or renames.
+	objectLock := fs.nsMutex.NewNSLock(bucket, object)
+	if err := objectLock.GetRLock(globalListingTimeout); err != nil {
+		logger.LogIf(ctx, err)
+		return nil, err
+	}
+	defer objectLock.RUnlock()
+
+	fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
+	rlk, err := fs.rwPool.Open(fsMetaPath)
+	if err != nil {
+		if err != errFileNotFound {
+			logger.LogIf(ctx, err)
+			return nil, err
+		}
+		// Objects without `fs.json` only have the "null" version.
+		objInfo, err := fs.getObjectInfo(ctx, bucket, object)
+		if err != nil {
+			return nil, err
+		}
+		objInfo.IsLatest = true
+		return []ObjectInfo{objInfo}, nil
+	}
+	fsMeta, fi, err := fs.readFSMetaVersions(ctx, bucket, object, rlk.LockedFile)
+	fs.rwPool.Close(fsMetaPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var objInfos []ObjectInfo
+	if current, ok := fsMeta.currentVersion(fi); ok {
+		objInfo := fsMeta.ToObjectInfo(bucket, object, fi)
+		if current.DeleteMarker {
+			objInfo = current.ToObjectInfo(bucket, object)
+		}
+		objInfos = append(objInfos, objInfo)
+	}
+	for _, version := range fsMeta.Versions {
+		objInfos = append(objInfos, version.ToObjectInfo(bucket, object))
+	}
+	if len(objInfos) == 0 {
+		return nil, errFileNotFound
+	}
+	objInfos[0].IsLatest = true
+	return objInfos, nil
+}
+
+// Returns function "listDir" of the type listDirFunc for listing versions,
+// objects whose latest version is a delete marker are only found in the
+// metadata tree, entries of both trees are merged.
+func (fs *FSObjects) listDirVersionsFactory(isLeaf isLeafFunc) listDirFunc {
+	listDir := func(bucket, prefixDir, prefixEntry string) (entries []string, delayIsLeaf bool) {
+		entrySet := set.NewStringSet()
+
+		nsEntries, err := readDir(pathJoin(fs.fsPath, bucket, prefixDir))
+		if err != nil && err != errFileNotFound {
+			logger.LogIf(context.Background(), err)
+			return
+		}
+		for _, entry := range nsEntries {
+			entrySet.Add(entry)
+		}
+
+		metaDir := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, prefixDir)
+		metaEntries, err := readDir(metaDir)
+		if err != nil && err != errFileNotFound {
+			logger.LogIf(context.Background(), err)
+			return
+		}
+		for _, entry := range metaEntries {
+			// Skip `fs.json` and the data of noncurrent versions.
+			if !hasSuffix(entry, slashSeparator) {
+				continue
+			}
+			dirEntries, err := readDir(pathJoin(metaDir, entry))
+			if err != nil {
+				continue
+			}
+			for _, dirEntry := range dirEntries {
+				if dirEntry == fs.metaJSONFile {
+					entrySet.Add(strings.TrimSuffix(entry, slashSeparator))
+				} else if hasSuffix(dirEntry, slashSeparator) {
+					entrySet.Add(entry)
+				}
+			}
+		}
+
+		return filterListEntries(bucket, prefixDir, entrySet.ToSlice(), prefixEntry, isLeaf)
+	}
+
+	// Return list factory instance.
+	return listDir
+}
+
+// ListObjectVersions - lists all versions of the objects at prefix, delimited by '/'.
+func (fs *FSObjects) ListObjectVersions(ctx context.Context, bucket, prefix, keyMarker, versionIDMarker, delimiter string, maxKeys int) (loi ListObjectVersionsInfo, e error) {
+	if err := checkListObjsArgs(ctx, bucket, prefix, keyMarker, delimiter, fs); err != nil {
+		return loi, err
+	}
+
+	if _, err := fs.statBucketDir(ctx, bucket); err != nil {
+		return loi, toObjectErr(err, bucket)
+	}
+
+	// With max keys of zero we have reached eof, return right here.
+	if maxKeys == 0 {
+		return loi, nil
+	}
+
+	// For delimiter and prefix as '/' we do not list anything at all.
+	if delimiter == slashSeparator && prefix == slashSeparator {
+		return loi, nil
+	}
+
+	// Over flowing count - reset to maxObjectList.
+	if maxKeys < 0 || maxKeys > maxObjectList {
+		maxKeys = maxObjectList
+	}
+
+	recursive := delimiter != slashSeparator
+
+	endWalkCh := make(chan struct{})
+	defer close(endWalkCh)
+
+	isLeaf := func(bucket, object string) bool {
+		return !hasSuffix(object, slashSeparator)
+	}
+	// Return true if the specified object is an empty directory
+	isLeafDir := func(bucket, object string) bool {
+		if !hasSuffix(object, slashSeparator) {
+			return false
+		}
+		return fs.isObjectDir(bucket, object)
+	}
+	listDir := fs.listDirVersionsFactory(isLeaf)
+	walkResultCh := startTreeWalk(ctx, bucket, prefix, keyMarker, recursive, listDir, isLeaf, isLeafDir, endWalkCh)
+
+	return listObjectVersions(ctx, bucket, keyMarker, versionIDMarker, delimiter, maxKeys, walkResultCh, fs.getObjectVersions)
+}
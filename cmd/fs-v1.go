@@ -418,7 +418,8 @@ func (fs *FSObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBu
 		return oi, toObjectErr(err, srcBucket)
 	}
 
-	if cpSrcDstSame && srcInfo.metadataOnly {
+	// Metadata of a versioned object is updated by adding a new version.
+	if cpSrcDstSame && srcInfo.metadataOnly && srcOpts.VersionID == "" && !dstOpts.Versioned && !dstOpts.VersionSuspended {
 		fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, srcBucket, srcObject, fs.metaJSONFile)
 		wlk, err := fs.rwPool.Write(fsMetaPath)
 		if err != nil {
@@ -428,6 +429,11 @@ func (fs *FSObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBu
 		// This close will allow for locks to be synchronized on `fs.json`.
 		defer wlk.Close()
 
+		// Deny replacing a version protected by object lock.
+		if err = fs.checkObjectLock(ctx, dstBucket, dstObject, nullVersionID, wlk, dstOpts.BypassGovernance); err != nil {
+			return oi, err
+		}
+
 		// Save objects' metadata in `fs.json`.
 		fsMeta := newFSMetaV1()
 		if _, err = fsMeta.ReadFrom(ctx, wlk); err != nil && err != io.EOF {
@@ -449,7 +455,12 @@ func (fs *FSObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBu
 		// Return the new object info.
 		return fsMeta.ToObjectInfo(srcBucket, srcObject, fi), nil
 	}
-	objInfo, err := fs.putObject(ctx, dstBucket, dstObject, srcInfo.PutObjReader, ObjectOptions{ServerSideEncryption: dstOpts.ServerSideEncryption, UserDefined: srcInfo.UserDefined})
+	objInfo, err := fs.putObject(ctx, dstBucket, dstObject, srcInfo.PutObjReader, ObjectOptions{
+		ServerSideEncryption: dstOpts.ServerSideEncryption,
+		UserDefined:          srcInfo.UserDefined,
+		Versioned:            dstOpts.Versioned,
+		VersionSuspended:     dstOpts.VersionSuspended,
+	})
 	if err != nil {
 		return oi, toObjectErr(err, dstBucket, dstObject)
 	}
@@ -492,7 +503,13 @@ func (fs *FSObjects) GetObjectNInfo(ctx context.Context, bucket, object string,
 
 	// Otherwise we get the object info
 	var objInfo ObjectInfo
-	if objInfo, err = fs.getObjectInfo(ctx, bucket, object); err != nil {
+	fsObjPath := pathJoin(fs.fsPath, bucket, object)
+	if opts.VersionID != "" {
+		objInfo, fsObjPath, err = fs.getObjectVersion(ctx, bucket, object, opts.VersionID)
+	} else {
+		objInfo, err = fs.getObjectInfo(ctx, bucket, object)
+	}
+	if err != nil {
 		nsUnlocker()
 		return nil, toObjectErr(err, bucket, object)
 	}
@@ -523,7 +540,6 @@ func (fs *FSObjects) GetObjectNInfo(ctx context.Context, bucket, object string,
 	}
 
 	// Read the object, doesn't exist returns an s3 compatible error.
-	fsObjPath := pathJoin(fs.fsPath, bucket, object)
 	readCloser, size, err := fsOpenFile(ctx, fsObjPath, off)
 	if err != nil {
 		rwPoolUnlocker()
@@ -566,11 +582,11 @@ func (fs *FSObjects) GetObject(ctx context.Context, bucket, object string, offse
 		return err
 	}
 	defer objectLock.RUnlock()
-	return fs.getObject(ctx, bucket, object, offset, length, writer, etag, true)
+	return fs.getObject(ctx, bucket, object, offset, length, writer, etag, true, opts.VersionID)
 }
 
 // getObject - wrapper for GetObject
-func (fs *FSObjects) getObject(ctx context.Context, bucket, object string, offset int64, length int64, writer io.Writer, etag string, lock bool) (err error) {
+func (fs *FSObjects) getObject(ctx context.Context, bucket, object string, offset int64, length int64, writer io.Writer, etag string, lock bool, versionID string) (err error) {
 	if _, err = fs.statBucketDir(ctx, bucket); err != nil {
 		return toObjectErr(err, bucket)
 	}
@@ -606,7 +622,18 @@ func (fs *FSObjects) getObject(ctx context.Context, bucket, object string, offse
 		}
 	}
 
-	if etag != "" && etag != defaultEtag {
+	fsObjPath := pathJoin(fs.fsPath, bucket, object)
+	if versionID != "" {
+		var objInfo ObjectInfo
+		objInfo, fsObjPath, err = fs.getObjectVersion(ctx, bucket, object, versionID)
+		if err != nil {
+			return toObjectErr(err, bucket, object)
+		}
+		if etag != "" && etag != defaultEtag && objInfo.ETag != etag {
+			logger.LogIf(ctx, InvalidETag{})
+			return toObjectErr(InvalidETag{}, bucket, object)
+		}
+	} else if etag != "" && etag != defaultEtag {
 		objEtag, perr := fs.getObjectETag(ctx, bucket, object, lock)
 		if perr != nil {
 			return toObjectErr(perr, bucket, object)
@@ -618,7 +645,6 @@ func (fs *FSObjects) getObject(ctx context.Context, bucket, object string, offse
 	}
 
 	// Read the object, doesn't exist returns an s3 compatible error.
-	fsObjPath := pathJoin(fs.fsPath, bucket, object)
 	reader, size, err := fsOpenFile(ctx, fsObjPath, offset)
 	if err != nil {
 		return toObjectErr(err, bucket, object)
@@ -733,7 +759,7 @@ func (fs *FSObjects) getObjectInfo(ctx context.Context, bucket, object string) (
 }
 
 // getObjectInfoWithLock - reads object metadata and replies back ObjectInfo.
-func (fs *FSObjects) getObjectInfoWithLock(ctx context.Context, bucket, object string) (oi ObjectInfo, e error) {
+func (fs *FSObjects) getObjectInfoWithLock(ctx context.Context, bucket, object, versionID string) (oi ObjectInfo, e error) {
 	// Lock the object before reading.
 	objectLock := fs.nsMutex.NewNSLock(bucket, object)
 	if err := objectLock.GetRLock(globalObjectTimeout); err != nil {
@@ -753,12 +779,16 @@ func (fs *FSObjects) getObjectInfoWithLock(ctx context.Context, bucket, object s
 		return oi, errFileNotFound
 	}
 
+	if versionID != "" {
+		oi, _, e = fs.getObjectVersion(ctx, bucket, object, versionID)
+		return oi, e
+	}
 	return fs.getObjectInfo(ctx, bucket, object)
 }
 
 // GetObjectInfo - reads object metadata and replies back ObjectInfo.
 func (fs *FSObjects) GetObjectInfo(ctx context.Context, bucket, object string, opts ObjectOptions) (oi ObjectInfo, e error) {
-	oi, err := fs.getObjectInfoWithLock(ctx, bucket, object)
+	oi, err := fs.getObjectInfoWithLock(ctx, bucket, object, opts.VersionID)
 	if err == errCorruptedFormat || err == io.EOF {
 		objectLock := fs.nsMutex.NewNSLock(bucket, object)
 		if err = objectLock.GetLock(globalObjectTimeout); err != nil {
@@ -772,7 +802,7 @@ func (fs *FSObjects) GetObjectInfo(ctx context.Context, bucket, object string, o
 			return oi, toObjectErr(err, bucket, object)
 		}
 
-		oi, err = fs.getObjectInfoWithLock(ctx, bucket, object)
+		oi, err = fs.getObjectInfoWithLock(ctx, bucket, object, opts.VersionID)
 	}
 	return oi, toObjectErr(err, bucket, object)
 }
@@ -883,8 +913,13 @@ func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string
 		// This close will allow for locks to be synchronized on `fs.json`.
 		defer wlk.Close()
 		defer func() {
-			// Remove meta file when PutObject encounters any error
-			if retErr != nil {
+			// Remove meta file when PutObject encounters any error,
+			// `fs.json` of versioned objects carries all the versions,
+			// as does the one of objects protected by object lock.
+			if _, ok := retErr.(ObjectLocked); ok {
+				return
+			}
+			if retErr != nil && !opts.Versioned && !opts.VersionSuspended {
 				tmpDir := pathJoin(fs.fsPath, minioMetaTmpBucket, fs.fsUUID)
 				fsRemoveMeta(ctx, bucketMetaDir, fsMetaPath, tmpDir)
 			}
@@ -931,15 +966,28 @@ func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string
 			return ObjectInfo{}, ObjectAlreadyExists{Bucket: bucket, Object: object}
 		}
 	}
-	if err = fsRenameFile(ctx, fsTmpObjPath, fsNSObjPath); err != nil {
-		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	// Deny replacing a version protected by object lock.
+	if bucket != minioMetaBucket && !opts.Versioned {
+		if err = fs.checkObjectLock(ctx, bucket, object, nullVersionID, wlk, opts.BypassGovernance); err != nil {
+			return ObjectInfo{}, err
+		}
 	}
-
-	if bucket != minioMetaBucket {
-		// Write FS metadata after a successful namespace operation.
-		if _, err = fsMeta.WriteTo(wlk); err != nil {
+	if bucket != minioMetaBucket && (opts.Versioned || opts.VersionSuspended) {
+		// Keep the previous version of the object.
+		if err = fs.putObjectVersion(ctx, bucket, object, fsTmpObjPath, wlk, &fsMeta, opts); err != nil {
 			return ObjectInfo{}, toObjectErr(err, bucket, object)
 		}
+	} else {
+		if err = fsRenameFile(ctx, fsTmpObjPath, fsNSObjPath); err != nil {
+			return ObjectInfo{}, toObjectErr(err, bucket, object)
+		}
+
+		if bucket != minioMetaBucket {
+			// Write FS metadata after a successful namespace operation.
+			if _, err = fsMeta.WriteTo(wlk); err != nil {
+				return ObjectInfo{}, toObjectErr(err, bucket, object)
+			}
+		}
 	}
 
 	// Stat the file to fetch timestamp, size.
@@ -949,12 +997,20 @@ func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string
 	}
 
 	// Success.
-	return fsMeta.ToObjectInfo(bucket, object, fi), nil
+	objInfo = fsMeta.ToObjectInfo(bucket, object, fi)
+	objInfo.IsLatest = true
+	return objInfo, nil
 }
 
 // DeleteObject - deletes an object from a bucket, this operation is destructive
 // and there are no rollbacks supported.
 func (fs *FSObjects) DeleteObject(ctx context.Context, bucket, object string) error {
+	return fs.removeObject(ctx, bucket, object, false)
+}
+
+// removeObject - deletes the object along with all its versions, unless
+// one of them is protected by object lock.
+func (fs *FSObjects) removeObject(ctx context.Context, bucket, object string, bypassGovernance bool) error {
 	// Acquire a write lock before deleting the object.
 	objectLock := fs.nsMutex.NewNSLock(bucket, object)
 	if err := objectLock.GetLock(globalOperationTimeout); err != nil {
@@ -982,6 +1038,12 @@ func (fs *FSObjects) DeleteObject(ctx context.Context, bucket, object string) er
 			logger.LogIf(ctx, lerr)
 			return toObjectErr(lerr, bucket, object)
 		}
+		// Objects without `fs.json` have no object lock metadata.
+		if lerr == nil {
+			if err := fs.checkObjectLock(ctx, bucket, object, "", rwlk, bypassGovernance); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Delete the object.
@@ -999,6 +1061,139 @@ func (fs *FSObjects) DeleteObject(ctx context.Context, bucket, object string) er
 	return nil
 }
 
+// checkObjectLock - returns ObjectLocked if the requested version of the
+// object, any of its versions for an empty versionID, is protected by
+// object lock. lk is the locked `fs.json` of the object, it is opened
+// for reading if nil. Missing objects and versions are not protected.
+func (fs *FSObjects) checkObjectLock(ctx context.Context, bucket, object, versionID string, lk *lock.LockedFile, bypassGovernance bool) error {
+	if !globalBucketObjectLockSys.Enabled(bucket) {
+		return nil
+	}
+
+	if lk == nil {
+		fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
+		rlk, err := fs.rwPool.Open(fsMetaPath)
+		if err != nil {
+			if err == errFileNotFound {
+				return nil
+			}
+			return toObjectErr(err, bucket, object)
+		}
+		defer fs.rwPool.Close(fsMetaPath)
+		lk = rlk.LockedFile
+	}
+
+	// Newly created `fs.json` is empty.
+	if fi, err := lk.Stat(); err != nil || fi.Size() == 0 {
+		return err
+	}
+
+	fsMeta, fi, err := fs.readFSMetaVersions(ctx, bucket, object, lk)
+	if err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+
+	versions := fsMeta.Versions
+	if current, ok := fsMeta.currentVersion(fi); ok {
+		versions = append([]fsVersionV1{current}, versions...)
+	}
+
+	vid := versionID
+	if vid == nullVersionID {
+		vid = ""
+	}
+	for _, version := range versions {
+		if versionID != "" && version.VersionID != vid {
+			continue
+		}
+		if err = enforceObjectLock(bucket, object, version.Meta, bypassGovernance); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PutObjectTags - replaces the tags of the requested version of the object
+// in `fs.json`, empty tags remove all tags of the version.
+func (fs *FSObjects) PutObjectTags(ctx context.Context, bucket, object, tags string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	return fs.PutObjectMetadata(ctx, bucket, object, map[string]string{amzObjectTagging: tags}, opts)
+}
+
+// PutObjectMetadata - merges the given metadata into the metadata of the
+// requested version of the object in `fs.json`.
+func (fs *FSObjects) PutObjectMetadata(ctx context.Context, bucket, object string, meta map[string]string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	// Acquire a write lock before updating the object metadata.
+	objectLock := fs.nsMutex.NewNSLock(bucket, object)
+	if err = objectLock.GetLock(globalObjectTimeout); err != nil {
+		return objInfo, err
+	}
+	defer objectLock.Unlock()
+
+	if err = checkGetObjArgs(ctx, bucket, object); err != nil {
+		return objInfo, err
+	}
+
+	if _, err = fs.statBucketDir(ctx, bucket); err != nil {
+		return objInfo, toObjectErr(err, bucket)
+	}
+
+	// Make sure the version exists before creating `fs.json` of pre-existing data.
+	if opts.VersionID != "" {
+		_, _, err = fs.getObjectVersion(ctx, bucket, object, opts.VersionID)
+	} else {
+		_, err = fs.getObjectInfo(ctx, bucket, object)
+	}
+	if err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
+	wlk, err := fs.rwPool.Create(fsMetaPath)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+	// This close will allow for locks to be synchronized on `fs.json`.
+	defer wlk.Close()
+
+	fsMeta, fi, err := fs.readFSMetaVersions(ctx, bucket, object, wlk)
+	if err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	vid := opts.VersionID
+	if vid == nullVersionID {
+		vid = ""
+	}
+
+	switch {
+	case fi != nil && (opts.VersionID == "" || fsMeta.VersionID == vid):
+		fsMeta.Meta = updateObjectMetadata(fsMeta.Meta, meta)
+		objInfo = fsMeta.ToObjectInfo(bucket, object, fi)
+	case opts.VersionID != "":
+		index := -1
+		for i, version := range fsMeta.Versions {
+			if version.VersionID == vid && !version.DeleteMarker {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return objInfo, VersionNotFound{Bucket: bucket, Object: object, VersionID: opts.VersionID}
+		}
+		fsMeta.Versions[index].Meta = updateObjectMetadata(fsMeta.Versions[index].Meta, meta)
+		objInfo = fsMeta.Versions[index].ToObjectInfo(bucket, object)
+	default:
+		return objInfo, ObjectNotFound{Bucket: bucket, Object: object}
+	}
+
+	if _, err = fsMeta.WriteTo(wlk); err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	return objInfo, nil
+}
+
 // Returns function "listDir" of the type listDirFunc.
 // isLeaf - is used by listDir function to check if an entry
 // is a leaf or non-leaf entry.
@@ -1323,3 +1518,8 @@ func (fs *FSObjects) IsEncryptionSupported() bool {
 func (fs *FSObjects) IsCompressionSupported() bool {
 	return true
 }
+
+// IsVersioningSupported returns whether bucket versioning is applicable for this layer.
+func (fs *FSObjects) IsVersioningSupported() bool {
+	return true
+}
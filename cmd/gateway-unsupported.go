@@ -116,6 +116,26 @@ func (a GatewayUnsupported) ListObjectsHeal(ctx context.Context, bucket, prefix,
 	return loi, NotImplemented{}
 }
 
+// ListObjectVersions - Not implemented stub
+func (a GatewayUnsupported) ListObjectVersions(ctx context.Context, bucket, prefix, keyMarker, versionIDMarker, delimiter string, maxKeys int) (result ListObjectVersionsInfo, err error) {
+	return result, NotImplemented{}
+}
+
+// DeleteObjectVersion - Not implemented stub
+func (a GatewayUnsupported) DeleteObjectVersion(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	return objInfo, NotImplemented{}
+}
+
+// PutObjectTags - Not implemented stub
+func (a GatewayUnsupported) PutObjectTags(ctx context.Context, bucket, object, tags string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	return objInfo, NotImplemented{}
+}
+
+// PutObjectMetadata - Not implemented stub
+func (a GatewayUnsupported) PutObjectMetadata(ctx context.Context, bucket, object string, meta map[string]string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	return objInfo, NotImplemented{}
+}
+
 // CopyObject copies a blob from source container to destination container.
 func (a GatewayUnsupported) CopyObject(ctx context.Context, srcBucket string, srcObject string, destBucket string, destObject string,
 	srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (objInfo ObjectInfo, err error) {
@@ -146,3 +166,8 @@ func (a GatewayUnsupported) IsEncryptionSupported() bool {
 func (a GatewayUnsupported) IsCompressionSupported() bool {
 	return false
 }
+
+// IsVersioningSupported returns whether bucket versioning is applicable for this layer.
+func (a GatewayUnsupported) IsVersioningSupported() bool {
+	return false
+}
@@ -381,10 +381,61 @@ type resourceHandler struct {
 	handler http.Handler
 }
 
-// setCorsHandler handler for CORS (Cross Origin Resource Sharing)
+type corsHandler struct {
+	handler  http.Handler
+	allowAll http.Handler
+}
+
+// setCorsHandler handler for CORS (Cross Origin Resource Sharing), requests
+// to a bucket with a CORS configuration are evaluated against its rules,
+// all other requests are allowed from any origin.
 func setCorsHandler(h http.Handler) http.Handler {
-	c := cors.AllowAll()
-	return c.Handler(h)
+	return corsHandler{handler: h, allowAll: cors.AllowAll().Handler(h)}
+}
+
+func (h corsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	config, ok := getRequestCorsConfig(r)
+	if !ok {
+		h.allowAll.ServeHTTP(w, r)
+		return
+	}
+
+	if isCorsPreflightReq(r) {
+		writeCorsPreflightResponse(w, r, config)
+		return
+	}
+
+	setCorsResponseHeaders(w, r, config)
+	h.handler.ServeHTTP(w, r)
+}
+
+// bucketLoggingHandler - records access logs of requests on buckets with
+// access logging enabled.
+type bucketLoggingHandler struct {
+	handler http.Handler
+}
+
+func setBucketLoggingHandler(h http.Handler) http.Handler {
+	return bucketLoggingHandler{handler: h}
+}
+
+func (h bucketLoggingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	bucket := getRequestBucketName(r)
+	if bucket == "" || isMinioReservedBucket(bucket) || isMinioMetaBucket(bucket) {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	config, ok := globalBucketLoggingSys.Get(bucket)
+	if !ok || !config.Enabled() {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	rec := &accessLogRecorder{ResponseWriter: w}
+	startTime := UTCNow()
+	h.handler.ServeHTTP(rec, r)
+	globalBucketLoggingSys.Log(config, newAccessLogRecord(r, rec, startTime))
 }
 
 // setIgnoreResourcesHandler -
@@ -398,24 +449,11 @@ func setIgnoreResourcesHandler(h http.Handler) http.Handler {
 // Checks requests for not implemented Bucket resources
 func ignoreNotImplementedBucketResources(req *http.Request) bool {
 	for name := range req.URL.Query() {
-		// Enable GetBucketACL, GetBucketCors, GetBucketWebsite,
-		// GetBucketAcccelerate, GetBucketRequestPayment,
-		// GetBucketLogging, GetBucketLifecycle,
-		// GetBucketReplication, GetBucketTagging,
-		// GetBucketVersioning, DeleteBucketTagging,
-		// and DeleteBucketWebsite dummy calls specifically.
-		if ((name == "acl" ||
-			name == "cors" ||
-			name == "website" ||
+		// Enable GetBucketACL, GetBucketAcccelerate and
+		// GetBucketRequestPayment dummy calls specifically.
+		if (name == "acl" ||
 			name == "accelerate" ||
-			name == "requestPayment" ||
-			name == "logging" ||
-			name == "lifecycle" ||
-			name == "replication" ||
-			name == "tagging" ||
-			name == "versioning") && req.Method == http.MethodGet) ||
-			((name == "tagging" ||
-				name == "website") && req.Method == http.MethodDelete) {
+			name == "requestPayment") && req.Method == http.MethodGet {
 			return false
 		}
 
@@ -429,8 +467,8 @@ func ignoreNotImplementedBucketResources(req *http.Request) bool {
 // Checks requests for not implemented Object resources
 func ignoreNotImplementedObjectResources(req *http.Request) bool {
 	for name := range req.URL.Query() {
-		// Enable GetObjectACL and GetObjectTagging dummy calls specifically.
-		if (name == "acl" || name == "tagging") && req.Method == http.MethodGet {
+		// Enable GetObjectACL dummy call specifically.
+		if name == "acl" && req.Method == http.MethodGet {
 			return false
 		}
 		if notimplementedObjectResourceNames[name] {
@@ -444,17 +482,9 @@ func ignoreNotImplementedObjectResources(req *http.Request) bool {
 var notimplementedBucketResourceNames = map[string]bool{
 	"accelerate":     true,
 	"acl":            true,
-	"cors":           true,
 	"inventory":      true,
-	"lifecycle":      true,
-	"logging":        true,
 	"metrics":        true,
-	"replication":    true,
 	"requestPayment": true,
-	"tagging":        true,
-	"versioning":     true,
-	"versions":       true,
-	"website":        true,
 }
 
 // List of not implemented object queries
@@ -462,7 +492,6 @@ var notimplementedObjectResourceNames = map[string]bool{
 	"acl":     true,
 	"policy":  true,
 	"restore": true,
-	"tagging": true,
 	"torrent": true,
 }
 
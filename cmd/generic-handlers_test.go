@@ -24,6 +24,7 @@ import (
 	"testing"
 
 	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/pkg/cors"
 )
 
 // Tests getRedirectLocation function for all its criteria.
@@ -228,3 +229,56 @@ func TestSSETLSHandler(t *testing.T) {
 		}
 	}
 }
+
+func TestCorsHandler(t *testing.T) {
+	defer func(sys *BucketCorsSys) { globalBucketCorsSys = sys }(globalBucketCorsSys) // reset globalBucketCorsSys after test
+
+	globalBucketCorsSys = NewBucketCorsSys()
+	globalBucketCorsSys.Set("bucket", cors.Config{
+		CORSRules: []cors.Rule{
+			{AllowedOrigins: []string{"https://app.example.com"}, AllowedMethods: []string{"GET", "PUT"}, AllowedHeaders: []string{"x-amz-*"}, ExposeHeaders: []string{"ETag"}},
+		},
+	})
+
+	var okHandler http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	testCases := []struct {
+		method         string
+		path           string
+		header         http.Header
+		expectedStatus int
+		expectedOrigin string
+	}{
+		// 1. Preflight request matching the bucket rule.
+		{http.MethodOptions, "/bucket/object", http.Header{corsOrigin: {"https://app.example.com"}, corsRequestMethod: {"PUT"}, corsRequestHeaders: {"X-Amz-Date"}}, http.StatusOK, "https://app.example.com"},
+		// 2. Preflight request from an origin not allowed by the bucket rule.
+		{http.MethodOptions, "/bucket/object", http.Header{corsOrigin: {"https://evil.example.com"}, corsRequestMethod: {"PUT"}}, http.StatusForbidden, ""},
+		// 3. Preflight request with a header not allowed by the bucket rule.
+		{http.MethodOptions, "/bucket/object", http.Header{corsOrigin: {"https://app.example.com"}, corsRequestMethod: {"PUT"}, corsRequestHeaders: {"Authorization"}}, http.StatusForbidden, ""},
+		// 4. Actual request matching the bucket rule.
+		{http.MethodGet, "/bucket/object", http.Header{corsOrigin: {"https://app.example.com"}}, http.StatusOK, "https://app.example.com"},
+		// 5. Actual request with a method not allowed by the bucket rule.
+		{http.MethodDelete, "/bucket/object", http.Header{corsOrigin: {"https://app.example.com"}}, http.StatusOK, ""},
+		// 6. Bucket without CORS configuration allows all origins.
+		{http.MethodGet, "/other/object", http.Header{corsOrigin: {"https://evil.example.com"}}, http.StatusOK, "*"},
+	}
+
+	for i, test := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(test.method, test.path, nil)
+		for k, v := range test.header {
+			r.Header[k] = v
+		}
+
+		setCorsHandler(okHandler).ServeHTTP(w, r)
+
+		if w.Code != test.expectedStatus {
+			t.Errorf("Test %d: expected status %d, got %d", i+1, test.expectedStatus, w.Code)
+		}
+		if origin := w.Header().Get(corsAllowOrigin); origin != test.expectedOrigin {
+			t.Errorf("Test %d: expected allowed origin %q, got %q", i+1, test.expectedOrigin, origin)
+		}
+	}
+}
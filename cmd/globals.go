@@ -99,10 +99,7 @@ var globalCLIContext = struct {
 }{}
 
 var (
-	// Indicates the total number of erasure coded sets configured.
-	globalXLSetCount int
-
-	// Indicates set drive count.
+	// Indicates set drive count, same for all server pools.
 	globalXLSetDriveCount int
 
 	// Indicates if the running minio server is distributed setup.
@@ -111,6 +108,9 @@ var (
 	// Indicates if the running minio server is an erasure-code backend.
 	globalIsXL = false
 
+	// Indicates if the running minio is in gateway mode.
+	globalIsGateway = false
+
 	// This flag is set to 'true' by default
 	globalIsBrowserEnabled = true
 
@@ -146,6 +146,15 @@ var (
 	globalPolicySys       *PolicySys
 	globalIAMSys          *IAMSys
 
+	globalBucketVersioningSys = NewBucketVersioningSys()
+	globalLifecycleSys        = NewLifecycleSys()
+	globalBucketCorsSys       = NewBucketCorsSys()
+	globalBucketWebsiteSys    = NewBucketWebsiteSys()
+	globalBucketLoggingSys    = NewBucketLoggingSys()
+	globalReplicationSys      = NewReplicationSys()
+	globalBucketObjectLockSys = NewBucketObjectLockSys()
+	globalBucketQuotaSys      = NewBucketQuotaSys()
+
 	// CA root certificates, a nil value means system certs pool will be used
 	globalRootCAs *x509.CertPool
 
@@ -163,6 +172,10 @@ var (
 
 	globalEndpoints EndpointList
 
+	// Server pools as passed on the command line, globalEndpoints
+	// holds the endpoints of all the pools.
+	globalEndpointPools EndpointPools
+
 	// Global server's network statistics
 	globalConnStats = newConnStats()
 
@@ -213,8 +226,9 @@ var (
 	// RPC V2 - format.json XL version changed to 2
 	// RPC V3 - format.json XL version changed to 3
 	// RPC V4 - ReadFile() arguments signature changed
+	// RPC V5 - Bucket notification rules carry object filters
 	// Current RPC version
-	globalRPCAPIVersion = RPCVersion{4, 0, 0}
+	globalRPCAPIVersion = RPCVersion{5, 0, 0}
 
 	// Allocated etcd endpoint for config and bucket DNS.
 	globalEtcdClient *etcd.Client
@@ -244,6 +258,17 @@ var (
 	// Is compression enabeld.
 	globalIsCompressionEnabled = false
 
+	// Is the background bitrot scrubber enabled.
+	globalIsBitrotScrubEnabled = true
+
+	// Maximum number of shards verified and bytes read per
+	// second by the bitrot scrubber, zero means no limit.
+	globalBitrotScrubMaxIOPS             = 100
+	globalBitrotScrubMaxBandwidth uint64 = 10 * humanize.MiByte
+
+	// Objects up to this size are stored inline in `xl.json`, zero disables it.
+	globalXLInlineThreshold int64 = 128 * humanize.KiByte
+
 	// Include-list for compression.
 	globalCompressExtensions = []string{".txt", ".log", ".csv", ".json"}
 	globalCompressMimeTypes  = []string{"text/csv", "text/plain", "application/json"}
@@ -260,6 +285,9 @@ var (
 	// OPA policy system.
 	globalPolicyOPA *iampolicy.Opa
 
+	// LDAP identity provider of AssumeRoleWithLDAPIdentity.
+	globalLDAPConfig ldapServerConfig
+
 	// Deployment ID - unique per deployment
 	globalDeploymentID string
 
@@ -17,9 +17,12 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"path"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -45,19 +48,39 @@ const (
 	// IAM sts directory.
 	iamConfigSTSPrefix = iamConfigPrefix + "/sts/"
 
+	// IAM groups directory.
+	iamConfigGroupsPrefix = iamConfigPrefix + "/groups/"
+
+	// IAM service accounts directory.
+	iamConfigServiceAccountsPrefix = iamConfigPrefix + "/service-accounts/"
+
 	// IAM identity file which captures identity credentials.
 	iamIdentityFile = "identity.json"
 
 	// IAM policy file which provides policies for each users.
 	iamPolicyFile = "policy.json"
+
+	// IAM group members file which captures the members of each group.
+	iamGroupMembersFile = "members.json"
 )
 
+// groupInfo - members of a group.
+type groupInfo struct {
+	Members []string `json:"members"`
+}
+
 // IAMSys - config system.
 type IAMSys struct {
 	sync.RWMutex
 	iamUsersMap        map[string]auth.Credentials
-	iamPolicyMap       map[string]string
+	iamPolicyMap       map[string][]string
 	iamCannedPolicyMap map[string]iampolicy.Policy
+	iamGroupsMap       map[string]groupInfo
+	iamGroupPolicyMap  map[string][]string
+	// Groups of each user, derived from iamGroupsMap.
+	iamUserGroupMemberships map[string]set.StringSet
+	// Embedded policies of service accounts.
+	iamServiceAccountPolicyMap map[string]iampolicy.Policy
 }
 
 // Load - loads iam subsystem
@@ -166,7 +189,9 @@ func (sys *IAMSys) SetCannedPolicy(policyName string, p iampolicy.Policy) error
 		return errServerNotInitialized
 	}
 
-	if p.IsEmpty() || policyName == "" {
+	// Policy names are attached to users and groups as a comma
+	// separated list.
+	if p.IsEmpty() || policyName == "" || strings.Contains(policyName, ",") {
 		return errInvalidArgument
 	}
 
@@ -195,6 +220,13 @@ func (sys *IAMSys) SetCannedPolicy(policyName string, p iampolicy.Policy) error
 
 // SetUserPolicy - sets policy to given user name.
 func (sys *IAMSys) SetUserPolicy(accessKey, policyName string) error {
+	return sys.SetPolicy(accessKey, policyName, false)
+}
+
+// SetPolicy - attaches the comma separated list of canned policies to the
+// given user or group, replacing all policies attached so far. An empty
+// list detaches all policies.
+func (sys *IAMSys) SetPolicy(name, policyName string, isGroup bool) error {
 	objectAPI := newObjectLayerFn()
 	if objectAPI == nil {
 		return errServerNotInitialized
@@ -203,33 +235,201 @@ func (sys *IAMSys) SetUserPolicy(accessKey, policyName string) error {
 	sys.Lock()
 	defer sys.Unlock()
 
-	if _, ok := sys.iamUsersMap[accessKey]; !ok {
+	prefix, policyMap := iamConfigUsersPrefix, sys.iamPolicyMap
+	if isGroup {
+		if _, ok := sys.iamGroupsMap[name]; !ok {
+			return errNoSuchGroup
+		}
+		prefix, policyMap = iamConfigGroupsPrefix, sys.iamGroupPolicyMap
+	} else if cred, ok := sys.iamUsersMap[name]; !ok || cred.IsServiceAccount() {
 		return errNoSuchUser
 	}
 
-	if _, ok := sys.iamCannedPolicyMap[policyName]; !ok {
-		return errNoSuchPolicy
+	policies := parsePolicyNames(policyName)
+	for _, p := range policies {
+		if _, ok := sys.iamCannedPolicyMap[p]; !ok {
+			return errNoSuchPolicy
+		}
 	}
 
-	data, err := json.Marshal(policyName)
+	configFile := pathJoin(prefix, name, iamPolicyFile)
+	if len(policies) == 0 {
+		if err := deleteIAMConfig(objectAPI, configFile); err != nil && err != errConfigNotFound {
+			return err
+		}
+		delete(policyMap, name)
+		return nil
+	}
+
+	data, err := json.Marshal(strings.Join(policies, ","))
 	if err != nil {
 		return err
 	}
 
-	configFile := pathJoin(iamConfigUsersPrefix, accessKey, iamPolicyFile)
-	if globalEtcdClient != nil {
-		err = saveConfigEtcd(context.Background(), globalEtcdClient, configFile, data)
-	} else {
-		err = saveConfig(context.Background(), objectAPI, configFile, data)
+	if err = saveIAMConfig(objectAPI, configFile, data); err != nil {
+		return err
 	}
-	if err != nil {
+
+	policyMap[name] = policies
+	return nil
+}
+
+// AddUsersToGroup - adds the given users to a group, the group is created
+// if it does not exist yet.
+func (sys *IAMSys) AddUsersToGroup(group string, members []string) error {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return errServerNotInitialized
+	}
+
+	if !isValidGroupName(group) {
+		return errInvalidArgument
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	// Only existing users can be members of a group.
+	for _, member := range members {
+		if cred, ok := sys.iamUsersMap[member]; !ok || cred.IsServiceAccount() {
+			return errNoSuchUser
+		}
+	}
+
+	gi := sys.iamGroupsMap[group]
+	gi.Members = set.CreateStringSet(gi.Members...).Union(set.CreateStringSet(members...)).ToSlice()
+	if err := saveGroupInfo(objectAPI, group, gi); err != nil {
 		return err
 	}
 
-	sys.iamPolicyMap[accessKey] = policyName
+	sys.iamGroupsMap[group] = gi
+	sys.iamUserGroupMemberships = newUserGroupMemberships(sys.iamGroupsMap)
 	return nil
 }
 
+// RemoveUsersFromGroup - removes the given users from a group. An empty
+// list of users deletes the group, which is only allowed if the group
+// has no members.
+func (sys *IAMSys) RemoveUsersFromGroup(group string, members []string) error {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return errServerNotInitialized
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	gi, ok := sys.iamGroupsMap[group]
+	if !ok {
+		return errNoSuchGroup
+	}
+
+	if len(members) == 0 {
+		if len(gi.Members) != 0 {
+			return errGroupNotEmpty
+		}
+
+		// It is okay to ignore errors when deleting policy.json for the group.
+		_ = deleteIAMConfig(objectAPI, pathJoin(iamConfigGroupsPrefix, group, iamPolicyFile))
+		err := deleteIAMConfig(objectAPI, pathJoin(iamConfigGroupsPrefix, group, iamGroupMembersFile))
+		if err != nil && err != errConfigNotFound {
+			return err
+		}
+
+		delete(sys.iamGroupsMap, group)
+		delete(sys.iamGroupPolicyMap, group)
+		return nil
+	}
+
+	gi.Members = set.CreateStringSet(gi.Members...).Difference(set.CreateStringSet(members...)).ToSlice()
+	if err := saveGroupInfo(objectAPI, group, gi); err != nil {
+		return err
+	}
+
+	sys.iamGroupsMap[group] = gi
+	sys.iamUserGroupMemberships = newUserGroupMemberships(sys.iamGroupsMap)
+	return nil
+}
+
+// GetGroupDescription - returns the members and the policies of a group.
+func (sys *IAMSys) GetGroupDescription(group string) (madmin.GroupDesc, error) {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return madmin.GroupDesc{}, errServerNotInitialized
+	}
+
+	sys.RLock()
+	defer sys.RUnlock()
+
+	gi, ok := sys.iamGroupsMap[group]
+	if !ok {
+		return madmin.GroupDesc{}, errNoSuchGroup
+	}
+
+	return madmin.GroupDesc{
+		Name:    group,
+		Members: gi.Members,
+		Policy:  strings.Join(sys.iamGroupPolicyMap[group], ","),
+	}, nil
+}
+
+// GetGroupsPolicies - returns the policies attached to any of the given
+// groups as a comma separated list.
+func (sys *IAMSys) GetGroupsPolicies(groups []string) string {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	policies := set.NewStringSet()
+	for _, group := range groups {
+		for _, p := range sys.iamGroupPolicyMap[group] {
+			// Skip policies removed after being attached.
+			if _, ok := sys.iamCannedPolicyMap[p]; ok {
+				policies.Add(p)
+			}
+		}
+	}
+	return strings.Join(policies.ToSlice(), ",")
+}
+
+// GetUserPolicies - returns the policies attached to the user and to
+// all groups of the user as a comma separated list.
+func (sys *IAMSys) GetUserPolicies(accessKey string) string {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	names := append([]string{}, sys.iamPolicyMap[accessKey]...)
+	for _, group := range sys.iamUserGroupMemberships[accessKey].ToSlice() {
+		names = append(names, sys.iamGroupPolicyMap[group]...)
+	}
+
+	policies := set.NewStringSet()
+	for _, p := range names {
+		// Skip policies removed after being attached.
+		if _, ok := sys.iamCannedPolicyMap[p]; ok {
+			policies.Add(p)
+		}
+	}
+	return strings.Join(policies.ToSlice(), ",")
+}
+
+// ListGroups - lists all groups.
+func (sys *IAMSys) ListGroups() ([]string, error) {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return nil, errServerNotInitialized
+	}
+
+	sys.RLock()
+	defer sys.RUnlock()
+
+	groups := set.NewStringSet()
+	for group := range sys.iamGroupsMap {
+		groups.Add(group)
+	}
+
+	return groups.ToSlice(), nil
+}
+
 // DeleteUser - set user credentials.
 func (sys *IAMSys) DeleteUser(accessKey string) error {
 	objectAPI := newObjectLayerFn()
@@ -262,10 +462,142 @@ func (sys *IAMSys) DeleteUser(accessKey string) error {
 	delete(sys.iamUsersMap, accessKey)
 	delete(sys.iamPolicyMap, accessKey)
 
+	// Remove the user from all its groups.
+	for _, group := range sys.iamUserGroupMemberships[accessKey].ToSlice() {
+		gi := sys.iamGroupsMap[group]
+		gi.Members = set.CreateStringSet(gi.Members...).Difference(set.CreateStringSet(accessKey)).ToSlice()
+		if gerr := saveGroupInfo(objectAPI, group, gi); gerr != nil {
+			logger.LogIf(context.Background(), gerr)
+		}
+		sys.iamGroupsMap[group] = gi
+	}
+	sys.iamUserGroupMemberships = newUserGroupMemberships(sys.iamGroupsMap)
+
+	// Service accounts are removed along with their parent user.
+	for k, v := range sys.iamUsersMap {
+		if v.ParentUser == accessKey {
+			if serr := sys.deleteServiceAccount(objectAPI, k); serr != nil {
+				logger.LogIf(context.Background(), serr)
+			}
+		}
+	}
+
 	return err
 }
 
+// NewServiceAccount - creates a service account for the given parent
+// user. The service account inherits the policies of its parent user,
+// an optional embedded policy narrows them further.
+func (sys *IAMSys) NewServiceAccount(parentUser string, embeddedPolicy *iampolicy.Policy) (auth.Credentials, error) {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return auth.Credentials{}, errServerNotInitialized
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	if !sys.isParentUser(parentUser) {
+		return auth.Credentials{}, errNoSuchUser
+	}
+
+	cred, err := auth.GetNewCredentials()
+	if err != nil {
+		return auth.Credentials{}, err
+	}
+	cred.ParentUser = parentUser
+
+	if embeddedPolicy != nil {
+		data, err := json.Marshal(embeddedPolicy)
+		if err != nil {
+			return auth.Credentials{}, err
+		}
+		if err = saveIAMConfig(objectAPI, pathJoin(iamConfigServiceAccountsPrefix, cred.AccessKey, iamPolicyFile), data); err != nil {
+			return auth.Credentials{}, err
+		}
+	}
+
+	data, err := json.Marshal(cred)
+	if err != nil {
+		return auth.Credentials{}, err
+	}
+	if err = saveIAMConfig(objectAPI, pathJoin(iamConfigServiceAccountsPrefix, cred.AccessKey, iamIdentityFile), data); err != nil {
+		return auth.Credentials{}, err
+	}
+
+	sys.iamUsersMap[cred.AccessKey] = cred
+	if embeddedPolicy != nil {
+		sys.iamServiceAccountPolicyMap[cred.AccessKey] = *embeddedPolicy
+	}
+	return cred, nil
+}
+
+// ListServiceAccounts - lists the access keys of all service accounts
+// of a parent user.
+func (sys *IAMSys) ListServiceAccounts(parentUser string) ([]string, error) {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return nil, errServerNotInitialized
+	}
+
+	sys.RLock()
+	defer sys.RUnlock()
+
+	if !sys.isParentUser(parentUser) {
+		return nil, errNoSuchUser
+	}
+
+	serviceAccounts := []string{}
+	for k, v := range sys.iamUsersMap {
+		if v.ParentUser == parentUser {
+			serviceAccounts = append(serviceAccounts, k)
+		}
+	}
+	sort.Strings(serviceAccounts)
+	return serviceAccounts, nil
+}
+
+// DeleteServiceAccount - deletes a service account.
+func (sys *IAMSys) DeleteServiceAccount(accessKey string) error {
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil {
+		return errServerNotInitialized
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	if cred, ok := sys.iamUsersMap[accessKey]; !ok || !cred.IsServiceAccount() {
+		return errNoSuchServiceAccount
+	}
+	return sys.deleteServiceAccount(objectAPI, accessKey)
+}
+
+// deleteServiceAccount - deletes a service account and its embedded
+// policy, caller must hold the lock.
+func (sys *IAMSys) deleteServiceAccount(objectAPI ObjectLayer, accessKey string) error {
+	// It is okay to ignore errors when deleting policy.json for the service account.
+	_ = deleteIAMConfig(objectAPI, pathJoin(iamConfigServiceAccountsPrefix, accessKey, iamPolicyFile))
+	err := deleteIAMConfig(objectAPI, pathJoin(iamConfigServiceAccountsPrefix, accessKey, iamIdentityFile))
+	if err != nil && err != errConfigNotFound {
+		return err
+	}
+
+	delete(sys.iamUsersMap, accessKey)
+	delete(sys.iamServiceAccountPolicyMap, accessKey)
+	return nil
+}
+
+// isParentUser - returns whether a long term user exists with the given
+// access key, only those can be parents of service accounts. Caller
+// must hold the lock.
+func (sys *IAMSys) isParentUser(accessKey string) bool {
+	cred, ok := sys.iamUsersMap[accessKey]
+	return ok && !cred.IsServiceAccount() && cred.SessionToken == ""
+}
+
 // SetTempUser - set temporary user credentials, these credentials have an expiry.
+// policyName is a comma separated list of canned policies.
 func (sys *IAMSys) SetTempUser(accessKey string, cred auth.Credentials, policyName string) error {
 	objectAPI := newObjectLayerFn()
 	if objectAPI == nil {
@@ -279,16 +611,21 @@ func (sys *IAMSys) SetTempUser(accessKey string, cred auth.Credentials, policyNa
 	// temporary user which match with pre-configured canned
 	// policies for this server.
 	if globalPolicyOPA == nil && policyName != "" {
-		p, ok := sys.iamCannedPolicyMap[policyName]
-		if !ok {
-			return errInvalidArgument
+		policies := parsePolicyNames(policyName)
+		var combinedPolicy iampolicy.Policy
+		for _, name := range policies {
+			p, ok := sys.iamCannedPolicyMap[name]
+			if !ok {
+				return errInvalidArgument
+			}
+			combinedPolicy = combinedPolicy.Merge(p)
 		}
-		if p.IsEmpty() {
+		if combinedPolicy.IsEmpty() {
 			delete(sys.iamPolicyMap, accessKey)
 			return nil
 		}
 
-		data, err := json.Marshal(policyName)
+		data, err := json.Marshal(strings.Join(policies, ","))
 		if err != nil {
 			return err
 		}
@@ -303,7 +640,7 @@ func (sys *IAMSys) SetTempUser(accessKey string, cred auth.Credentials, policyNa
 			return err
 		}
 
-		sys.iamPolicyMap[accessKey] = policyName
+		sys.iamPolicyMap[accessKey] = policies
 	}
 
 	configFile := pathJoin(iamConfigSTSPrefix, accessKey, iamIdentityFile)
@@ -339,9 +676,14 @@ func (sys *IAMSys) ListUsers() (map[string]madmin.UserInfo, error) {
 	defer sys.RUnlock()
 
 	for k, v := range sys.iamUsersMap {
+		// Service accounts are listed per parent user.
+		if v.IsServiceAccount() {
+			continue
+		}
 		users[k] = madmin.UserInfo{
-			PolicyName: sys.iamPolicyMap[k],
+			PolicyName: strings.Join(sys.iamPolicyMap[k], ","),
 			Status:     madmin.AccountStatus(v.Status),
+			MemberOf:   sys.iamUserGroupMemberships[k].ToSlice(),
 		}
 	}
 
@@ -363,7 +705,7 @@ func (sys *IAMSys) SetUserStatus(accessKey string, status madmin.AccountStatus)
 	defer sys.Unlock()
 
 	cred, ok := sys.iamUsersMap[accessKey]
-	if !ok {
+	if !ok || cred.IsServiceAccount() {
 		return errNoSuchUser
 	}
 
@@ -438,6 +780,12 @@ func (sys *IAMSys) GetUser(accessKey string) (cred auth.Credentials, ok bool) {
 	defer sys.RUnlock()
 
 	cred, ok = sys.iamUsersMap[accessKey]
+	if ok && cred.IsServiceAccount() {
+		// Service accounts are revoked when their parent user
+		// is disabled or removed.
+		parent, pok := sys.iamUsersMap[cred.ParentUser]
+		ok = pok && parent.IsValid()
+	}
 	return cred, ok && cred.IsValid()
 }
 
@@ -451,20 +799,73 @@ func (sys *IAMSys) IsAllowed(args iampolicy.Args) bool {
 		return globalPolicyOPA.IsAllowed(args)
 	}
 
-	// If policy is available for given user, check the policy.
-	if name, found := sys.iamPolicyMap[args.AccountName]; found {
-		p, ok := sys.iamCannedPolicyMap[name]
-		return ok && p.IsAllowed(args)
+	// Temporary credentials with a session policy are restricted
+	// to what both the session policy and their policies allow.
+	if !isAllowedBySessionPolicy(args) {
+		return false
+	}
+
+	// Service accounts are restricted to what both their embedded
+	// policy, if any, and the policies of their parent user allow,
+	// policy variables of the latter resolving to the parent user.
+	if cred, ok := sys.iamUsersMap[args.AccountName]; ok && cred.IsServiceAccount() {
+		if p, ok := sys.iamServiceAccountPolicyMap[args.AccountName]; ok && !p.IsAllowed(args) {
+			return false
+		}
+		args.AccountName = cred.ParentUser
+	}
+	accountName := args.AccountName
+
+	// Policies attached to the user and to all groups of the user.
+	policies := append([]string{}, sys.iamPolicyMap[accountName]...)
+	for _, group := range sys.iamUserGroupMemberships[accountName].ToSlice() {
+		policies = append(policies, sys.iamGroupPolicyMap[group]...)
+	}
+
+	// If policies are available for given user, check the union of them.
+	if len(policies) > 0 {
+		var combinedPolicy iampolicy.Policy
+		for _, name := range policies {
+			if p, ok := sys.iamCannedPolicyMap[name]; ok {
+				combinedPolicy = combinedPolicy.Merge(p)
+			}
+		}
+		return !combinedPolicy.IsEmpty() && combinedPolicy.IsAllowed(args)
 	}
 
 	// As policy is not available and OPA is not configured, return the owner value.
 	return args.IsOwner
 }
 
+// isAllowedBySessionPolicy - checks the session policy carried by the
+// claims of temporary credentials, if any.
+func isAllowedBySessionPolicy(args iampolicy.Args) bool {
+	v, ok := args.Claims[iampolicy.SessionPolicyName]
+	if !ok {
+		return true
+	}
+
+	spolicyStr, ok := v.(string)
+	if !ok {
+		return false
+	}
+
+	spolicy, err := base64.StdEncoding.DecodeString(spolicyStr)
+	if err != nil {
+		return false
+	}
+
+	subPolicy, err := iampolicy.ParseConfig(bytes.NewReader(spolicy))
+	if err != nil {
+		return false
+	}
+	return subPolicy.IsAllowed(args)
+}
+
 var defaultContextTimeout = 30 * time.Second
 
 // Similar to reloadUsers but updates users, policies maps from etcd server,
-func reloadEtcdUsers(prefix string, usersMap map[string]auth.Credentials, policyMap map[string]string) error {
+func reloadEtcdUsers(prefix string, usersMap map[string]auth.Credentials, policyMap map[string][]string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultContextTimeout)
 	defer cancel()
 	r, err := globalEtcdClient.Get(ctx, prefix, etcd.WithPrefix(), etcd.WithKeysOnly())
@@ -525,7 +926,7 @@ func reloadEtcdUsers(prefix string, usersMap map[string]auth.Credentials, policy
 			if err = json.Unmarshal(pdata, &policyName); err != nil {
 				return err
 			}
-			policyMap[user] = policyName
+			policyMap[user] = parsePolicyNames(policyName)
 		}
 	}
 	return nil
@@ -606,7 +1007,7 @@ func reloadPolicies(objectAPI ObjectLayer, prefix string, cannedPolicyMap map[st
 }
 
 // reloadUsers reads an updates users, policies from object layer into user and policy maps.
-func reloadUsers(objectAPI ObjectLayer, prefix string, usersMap map[string]auth.Credentials, policyMap map[string]string) error {
+func reloadUsers(objectAPI ObjectLayer, prefix string, usersMap map[string]auth.Credentials, policyMap map[string][]string) error {
 	marker := ""
 	for {
 		var lo ListObjectsInfo
@@ -650,7 +1051,7 @@ func reloadUsers(objectAPI ObjectLayer, prefix string, usersMap map[string]auth.
 				if err = json.Unmarshal(pdata, &policyName); err != nil {
 					return err
 				}
-				policyMap[path.Base(prefix)] = policyName
+				policyMap[path.Base(prefix)] = parsePolicyNames(policyName)
 			}
 		}
 		if !lo.IsTruncated {
@@ -660,6 +1061,264 @@ func reloadUsers(objectAPI ObjectLayer, prefix string, usersMap map[string]auth.
 	return nil
 }
 
+// loadGroup - updates group and policy maps with the members and the
+// policies of a group, as read from members.json and policy.json.
+func loadGroup(group string, mdata []byte, merr error, pdata []byte, perr error,
+	groupsMap map[string]groupInfo, policyMap map[string][]string) error {
+	if merr != nil && merr != errConfigNotFound {
+		return merr
+	}
+	if perr != nil && perr != errConfigNotFound {
+		return perr
+	}
+	// Policies of a deleted group are ignored.
+	if merr == errConfigNotFound {
+		return nil
+	}
+
+	var gi groupInfo
+	if err := json.Unmarshal(mdata, &gi); err != nil {
+		return err
+	}
+	groupsMap[group] = gi
+
+	if perr == nil {
+		var policyName string
+		if err := json.Unmarshal(pdata, &policyName); err != nil {
+			return err
+		}
+		policyMap[group] = parsePolicyNames(policyName)
+	}
+	return nil
+}
+
+// Similar to reloadGroups but updates groups, policies maps from etcd server,
+func reloadEtcdGroups(prefix string, groupsMap map[string]groupInfo, policyMap map[string][]string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultContextTimeout)
+	defer cancel()
+	r, err := globalEtcdClient.Get(ctx, prefix, etcd.WithPrefix(), etcd.WithKeysOnly())
+	if err != nil {
+		return err
+	}
+	// No groups are created yet.
+	if r.Count == 0 {
+		return nil
+	}
+
+	groups := set.NewStringSet()
+	for _, kv := range r.Kvs {
+		// Extract group by stripping off the `prefix` value as suffix,
+		// then strip off the remaining basename to obtain the prefix
+		// value, usually in the following form.
+		//
+		//  key := "config/iam/groups/newgroup/members.json"
+		//  prefix := "config/iam/groups/"
+		//  v := trim(trim(key, prefix), base(key)) == "newgroup"
+		//
+		group := path.Clean(strings.TrimSuffix(strings.TrimPrefix(string(kv.Key), prefix), path.Base(string(kv.Key))))
+		if !groups.Contains(group) {
+			groups.Add(group)
+		}
+	}
+
+	// Reload members and policies for all groups.
+	for _, group := range groups.ToSlice() {
+		mdata, merr := readConfigEtcd(ctx, globalEtcdClient, pathJoin(prefix, group, iamGroupMembersFile))
+		pdata, perr := readConfigEtcd(ctx, globalEtcdClient, pathJoin(prefix, group, iamPolicyFile))
+		if err = loadGroup(group, mdata, merr, pdata, perr, groupsMap, policyMap); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reloadGroups reads and updates groups, policies from object layer into group and policy maps.
+func reloadGroups(objectAPI ObjectLayer, prefix string, groupsMap map[string]groupInfo, policyMap map[string][]string) error {
+	marker := ""
+	for {
+		var lo ListObjectsInfo
+		var err error
+		lo, err = objectAPI.ListObjects(context.Background(), minioMetaBucket, prefix, marker, "/", 1000)
+		if err != nil {
+			return err
+		}
+		marker = lo.NextMarker
+		for _, prefix := range lo.Prefixes {
+			mdata, merr := readConfig(context.Background(), objectAPI, pathJoin(prefix, iamGroupMembersFile))
+			pdata, perr := readConfig(context.Background(), objectAPI, pathJoin(prefix, iamPolicyFile))
+			if err = loadGroup(path.Base(prefix), mdata, merr, pdata, perr, groupsMap, policyMap); err != nil {
+				return err
+			}
+		}
+		if !lo.IsTruncated {
+			break
+		}
+	}
+	return nil
+}
+
+// loadServiceAccount - updates users and policy maps with a service
+// account and its embedded policy, as read from identity.json and
+// policy.json.
+func loadServiceAccount(accessKey string, cdata []byte, cerr error, pdata []byte, perr error,
+	usersMap map[string]auth.Credentials, policyMap map[string]iampolicy.Policy) error {
+	if cerr != nil && cerr != errConfigNotFound {
+		return cerr
+	}
+	if perr != nil && perr != errConfigNotFound {
+		return perr
+	}
+	// Embedded policies of a deleted service account are ignored.
+	if cerr == errConfigNotFound {
+		return nil
+	}
+
+	var cred auth.Credentials
+	if err := json.Unmarshal(cdata, &cred); err != nil {
+		return err
+	}
+	cred.AccessKey = accessKey
+	usersMap[accessKey] = cred
+
+	if perr == nil {
+		var p iampolicy.Policy
+		if err := json.Unmarshal(pdata, &p); err != nil {
+			return err
+		}
+		policyMap[accessKey] = p
+	}
+	return nil
+}
+
+// Similar to reloadServiceAccounts but updates users, policies maps from etcd server,
+func reloadEtcdServiceAccounts(prefix string, usersMap map[string]auth.Credentials, policyMap map[string]iampolicy.Policy) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultContextTimeout)
+	defer cancel()
+	r, err := globalEtcdClient.Get(ctx, prefix, etcd.WithPrefix(), etcd.WithKeysOnly())
+	if err != nil {
+		return err
+	}
+	// No service accounts are created yet.
+	if r.Count == 0 {
+		return nil
+	}
+
+	serviceAccounts := set.NewStringSet()
+	for _, kv := range r.Kvs {
+		// Extract service account by stripping off the `prefix` value
+		// as suffix, then strip off the remaining basename to obtain
+		// the prefix value, usually in the following form.
+		//
+		//  key := "config/iam/service-accounts/accesskey/identity.json"
+		//  prefix := "config/iam/service-accounts/"
+		//  v := trim(trim(key, prefix), base(key)) == "accesskey"
+		//
+		accessKey := path.Clean(strings.TrimSuffix(strings.TrimPrefix(string(kv.Key), prefix), path.Base(string(kv.Key))))
+		if !serviceAccounts.Contains(accessKey) {
+			serviceAccounts.Add(accessKey)
+		}
+	}
+
+	// Reload identities and embedded policies for all service accounts.
+	for _, accessKey := range serviceAccounts.ToSlice() {
+		cdata, cerr := readConfigEtcd(ctx, globalEtcdClient, pathJoin(prefix, accessKey, iamIdentityFile))
+		pdata, perr := readConfigEtcd(ctx, globalEtcdClient, pathJoin(prefix, accessKey, iamPolicyFile))
+		if err = loadServiceAccount(accessKey, cdata, cerr, pdata, perr, usersMap, policyMap); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reloadServiceAccounts reads and updates service accounts, embedded policies from object layer into user and policy maps.
+func reloadServiceAccounts(objectAPI ObjectLayer, prefix string, usersMap map[string]auth.Credentials, policyMap map[string]iampolicy.Policy) error {
+	marker := ""
+	for {
+		var lo ListObjectsInfo
+		var err error
+		lo, err = objectAPI.ListObjects(context.Background(), minioMetaBucket, prefix, marker, "/", 1000)
+		if err != nil {
+			return err
+		}
+		marker = lo.NextMarker
+		for _, prefix := range lo.Prefixes {
+			cdata, cerr := readConfig(context.Background(), objectAPI, pathJoin(prefix, iamIdentityFile))
+			pdata, perr := readConfig(context.Background(), objectAPI, pathJoin(prefix, iamPolicyFile))
+			if err = loadServiceAccount(path.Base(prefix), cdata, cerr, pdata, perr, usersMap, policyMap); err != nil {
+				return err
+			}
+		}
+		if !lo.IsTruncated {
+			break
+		}
+	}
+	return nil
+}
+
+// newUserGroupMemberships - returns the groups of each user.
+func newUserGroupMemberships(groupsMap map[string]groupInfo) map[string]set.StringSet {
+	memberships := make(map[string]set.StringSet)
+	for group, gi := range groupsMap {
+		for _, member := range gi.Members {
+			if _, ok := memberships[member]; !ok {
+				memberships[member] = set.NewStringSet()
+			}
+			memberships[member].Add(group)
+		}
+	}
+	return memberships
+}
+
+// saveGroupInfo - persists the members of a group.
+func saveGroupInfo(objectAPI ObjectLayer, group string, gi groupInfo) error {
+	data, err := json.Marshal(gi)
+	if err != nil {
+		return err
+	}
+	return saveIAMConfig(objectAPI, pathJoin(iamConfigGroupsPrefix, group, iamGroupMembersFile), data)
+}
+
+// saveIAMConfig - saves an IAM config file to etcd if configured,
+// otherwise to the object layer.
+func saveIAMConfig(objectAPI ObjectLayer, configFile string, data []byte) error {
+	if globalEtcdClient != nil {
+		return saveConfigEtcd(context.Background(), globalEtcdClient, configFile, data)
+	}
+	return saveConfig(context.Background(), objectAPI, configFile, data)
+}
+
+// deleteIAMConfig - deletes an IAM config file from etcd if configured,
+// otherwise from the object layer.
+func deleteIAMConfig(objectAPI ObjectLayer, configFile string) error {
+	var err error
+	if globalEtcdClient != nil {
+		err = deleteConfigEtcd(context.Background(), globalEtcdClient, configFile)
+	} else {
+		err = deleteConfig(context.Background(), objectAPI, configFile)
+	}
+	if _, ok := err.(ObjectNotFound); ok {
+		return errConfigNotFound
+	}
+	return err
+}
+
+// parsePolicyNames - returns the canned policy names of a comma separated list.
+func parsePolicyNames(policyName string) []string {
+	var policies []string
+	for _, p := range strings.Split(policyName, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			policies = append(policies, p)
+		}
+	}
+	return policies
+}
+
+// isValidGroupName - group names are used as a path element of the IAM
+// configuration directory.
+func isValidGroupName(group string) bool {
+	return group != "" && group != "." && group != ".." && !strings.ContainsAny(group, "/\\")
+}
+
 // Set default canned policies only if not already overridden by users.
 func setDefaultCannedPolicies(policies map[string]iampolicy.Policy) {
 	_, ok := policies["writeonly"]
@@ -679,8 +1338,11 @@ func setDefaultCannedPolicies(policies map[string]iampolicy.Policy) {
 // Refresh IAMSys.
 func (sys *IAMSys) refresh(objAPI ObjectLayer) error {
 	iamUsersMap := make(map[string]auth.Credentials)
-	iamPolicyMap := make(map[string]string)
+	iamPolicyMap := make(map[string][]string)
 	iamCannedPolicyMap := make(map[string]iampolicy.Policy)
+	iamGroupsMap := make(map[string]groupInfo)
+	iamGroupPolicyMap := make(map[string][]string)
+	iamServiceAccountPolicyMap := make(map[string]iampolicy.Policy)
 
 	if globalEtcdClient != nil {
 		if err := reloadEtcdPolicies(iamConfigPoliciesPrefix, iamCannedPolicyMap); err != nil {
@@ -692,6 +1354,12 @@ func (sys *IAMSys) refresh(objAPI ObjectLayer) error {
 		if err := reloadEtcdUsers(iamConfigSTSPrefix, iamUsersMap, iamPolicyMap); err != nil {
 			return err
 		}
+		if err := reloadEtcdGroups(iamConfigGroupsPrefix, iamGroupsMap, iamGroupPolicyMap); err != nil {
+			return err
+		}
+		if err := reloadEtcdServiceAccounts(iamConfigServiceAccountsPrefix, iamUsersMap, iamServiceAccountPolicyMap); err != nil {
+			return err
+		}
 	} else {
 		if err := reloadPolicies(objAPI, iamConfigPoliciesPrefix, iamCannedPolicyMap); err != nil {
 			return err
@@ -702,6 +1370,12 @@ func (sys *IAMSys) refresh(objAPI ObjectLayer) error {
 		if err := reloadUsers(objAPI, iamConfigSTSPrefix, iamUsersMap, iamPolicyMap); err != nil {
 			return err
 		}
+		if err := reloadGroups(objAPI, iamConfigGroupsPrefix, iamGroupsMap, iamGroupPolicyMap); err != nil {
+			return err
+		}
+		if err := reloadServiceAccounts(objAPI, iamConfigServiceAccountsPrefix, iamUsersMap, iamServiceAccountPolicyMap); err != nil {
+			return err
+		}
 	}
 
 	// Sets default canned policies, if none set.
@@ -713,6 +1387,10 @@ func (sys *IAMSys) refresh(objAPI ObjectLayer) error {
 	sys.iamUsersMap = iamUsersMap
 	sys.iamPolicyMap = iamPolicyMap
 	sys.iamCannedPolicyMap = iamCannedPolicyMap
+	sys.iamGroupsMap = iamGroupsMap
+	sys.iamGroupPolicyMap = iamGroupPolicyMap
+	sys.iamUserGroupMemberships = newUserGroupMemberships(iamGroupsMap)
+	sys.iamServiceAccountPolicyMap = iamServiceAccountPolicyMap
 
 	return nil
 }
@@ -720,8 +1398,12 @@ func (sys *IAMSys) refresh(objAPI ObjectLayer) error {
 // NewIAMSys - creates new config system object.
 func NewIAMSys() *IAMSys {
 	return &IAMSys{
-		iamUsersMap:        make(map[string]auth.Credentials),
-		iamPolicyMap:       make(map[string]string),
-		iamCannedPolicyMap: make(map[string]iampolicy.Policy),
+		iamUsersMap:                make(map[string]auth.Credentials),
+		iamPolicyMap:               make(map[string][]string),
+		iamCannedPolicyMap:         make(map[string]iampolicy.Policy),
+		iamGroupsMap:               make(map[string]groupInfo),
+		iamGroupPolicyMap:          make(map[string][]string),
+		iamUserGroupMemberships:    make(map[string]set.StringSet),
+		iamServiceAccountPolicyMap: make(map[string]iampolicy.Policy),
 	}
 }
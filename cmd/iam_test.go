@@ -0,0 +1,375 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// TestIAMSysGroups - tests group membership and the union of the
+// policies attached to a user and its groups.
+func TestIAMSysGroups(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	for _, user := range []string{"user1", "user2"} {
+		if err = globalIAMSys.SetUser(user, madmin.UserInfo{SecretKey: "secretkey", Status: madmin.AccountEnabled}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	isAllowed := func(user string, action iampolicy.Action) bool {
+		return globalIAMSys.IsAllowed(iampolicy.Args{
+			AccountName: user,
+			Action:      action,
+			BucketName:  "bucket",
+			ObjectName:  "object",
+		})
+	}
+
+	if err = globalIAMSys.AddUsersToGroup("group", []string{"user1", "unknown"}); err != errNoSuchUser {
+		t.Fatalf("expected %v, got %v", errNoSuchUser, err)
+	}
+	if err = globalIAMSys.AddUsersToGroup("group", []string{"user1", "user2"}); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.SetPolicy("group", "readonly", true); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.SetPolicy("user1", "writeonly,unknown", false); err != errNoSuchPolicy {
+		t.Fatalf("expected %v, got %v", errNoSuchPolicy, err)
+	}
+	if err = globalIAMSys.SetPolicy("user1", "writeonly", false); err != nil {
+		t.Fatal(err)
+	}
+
+	// Group and user policies are persisted.
+	if err = globalIAMSys.Load(adminTestBed.objLayer); err != nil {
+		t.Fatal(err)
+	}
+
+	if !isAllowed("user1", iampolicy.GetObjectAction) || !isAllowed("user1", iampolicy.PutObjectAction) {
+		t.Fatal("expected user1 to be allowed by the union of its policies")
+	}
+	if !isAllowed("user2", iampolicy.GetObjectAction) || isAllowed("user2", iampolicy.PutObjectAction) {
+		t.Fatal("expected user2 to be only allowed by the group policy")
+	}
+
+	gdesc, err := globalIAMSys.GetGroupDescription("group")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(gdesc, madmin.GroupDesc{Name: "group", Members: []string{"user1", "user2"}, Policy: "readonly"}) {
+		t.Fatalf("unexpected group description %+v", gdesc)
+	}
+
+	// Deleting a user removes it from its groups.
+	if err = globalIAMSys.DeleteUser("user2"); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.Load(adminTestBed.objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if gdesc, err = globalIAMSys.GetGroupDescription("group"); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(gdesc.Members, []string{"user1"}) {
+		t.Fatalf("expected user2 to be removed from group, got %v", gdesc.Members)
+	}
+
+	// Only empty groups can be deleted.
+	if err = globalIAMSys.RemoveUsersFromGroup("group", nil); err != errGroupNotEmpty {
+		t.Fatalf("expected %v, got %v", errGroupNotEmpty, err)
+	}
+	if err = globalIAMSys.RemoveUsersFromGroup("group", []string{"user1"}); err != nil {
+		t.Fatal(err)
+	}
+	if isAllowed("user1", iampolicy.GetObjectAction) {
+		t.Fatal("expected user1 to lose the group policy")
+	}
+	if err = globalIAMSys.RemoveUsersFromGroup("group", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.Load(adminTestBed.objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if groups, _ := globalIAMSys.ListGroups(); len(groups) != 0 {
+		t.Fatalf("expected no groups, got %v", groups)
+	}
+}
+
+// TestGroupHandlers - tests updating group members, getting and listing groups.
+func TestGroupHandlers(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	if err = globalIAMSys.SetUser("user", madmin.UserInfo{SecretKey: "secretkey", Status: madmin.AccountEnabled}); err != nil {
+		t.Fatal(err)
+	}
+
+	groupUpdate := func(isRemove bool, members ...string) string {
+		data, _ := json.Marshal(madmin.GroupAddRemove{Group: "group", Members: members, IsRemove: isRemove})
+		return string(data)
+	}
+	groupQuery := url.Values{"group": []string{"group"}}
+	policyQuery := url.Values{"policyName": []string{"readonly,writeonly"}, "userOrGroup": []string{"group"}, "isGroup": []string{"true"}}
+
+	testCases := []struct {
+		method       string
+		path         string
+		queryVal     url.Values
+		body         string
+		expectedCode int
+	}{
+		{http.MethodGet, "/group", groupQuery, "", http.StatusNotFound},
+		{http.MethodPut, "/set-user-or-group-policy", policyQuery, "", http.StatusNotFound},
+		{http.MethodPut, "/update-group-members", url.Values{}, "{", http.StatusBadRequest},
+		{http.MethodPut, "/update-group-members", url.Values{}, groupUpdate(false, "user"), http.StatusOK},
+		{http.MethodPut, "/set-user-or-group-policy", policyQuery, "", http.StatusOK},
+		{http.MethodGet, "/group", groupQuery, "", http.StatusOK},
+		{http.MethodGet, "/groups", url.Values{}, "", http.StatusOK},
+		{http.MethodPut, "/update-group-members", url.Values{}, groupUpdate(true), http.StatusBadRequest},
+		{http.MethodPut, "/update-group-members", url.Values{}, groupUpdate(true, "user"), http.StatusOK},
+		{http.MethodPut, "/update-group-members", url.Values{}, groupUpdate(true), http.StatusOK},
+		{http.MethodGet, "/group", groupQuery, "", http.StatusNotFound},
+	}
+
+	for i, testCase := range testCases {
+		req, err := buildAdminRequest(testCase.queryVal, testCase.method, testCase.path,
+			int64(len(testCase.body)), bytes.NewReader([]byte(testCase.body)))
+		if err != nil {
+			t.Fatalf("test %d: %v", i+1, err)
+		}
+
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("test %d: expected %d, got %d: %s", i+1, testCase.expectedCode, rec.Code, rec.Body.String())
+		}
+
+		if testCase.path == "/group" && rec.Code == http.StatusOK {
+			var gdesc madmin.GroupDesc
+			if err = json.NewDecoder(rec.Body).Decode(&gdesc); err != nil {
+				t.Fatalf("test %d: %v", i+1, err)
+			}
+			if gdesc.Policy != "readonly,writeonly" || !reflect.DeepEqual(gdesc.Members, []string{"user"}) {
+				t.Fatalf("test %d: unexpected group description %+v", i+1, gdesc)
+			}
+		}
+		if testCase.path == "/groups" {
+			var groups []string
+			if err = json.NewDecoder(rec.Body).Decode(&groups); err != nil {
+				t.Fatalf("test %d: %v", i+1, err)
+			}
+			if !reflect.DeepEqual(groups, []string{"group"}) {
+				t.Fatalf("test %d: unexpected groups %v", i+1, groups)
+			}
+		}
+	}
+}
+
+// TestIAMSysServiceAccounts - tests the policies of service accounts and
+// their revocation along with their parent user.
+func TestIAMSysServiceAccounts(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	if err = globalIAMSys.SetUser("user", madmin.UserInfo{SecretKey: "secretkey", Status: madmin.AccountEnabled}); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.SetPolicy("user", "readwrite", false); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = globalIAMSys.NewServiceAccount("unknown", nil); err != errNoSuchUser {
+		t.Fatalf("expected %v, got %v", errNoSuchUser, err)
+	}
+	svc1, err := globalIAMSys.NewServiceAccount("user", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	embeddedPolicy, err := iampolicy.ParseConfig(bytes.NewReader([]byte(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::bucket/*"]}]}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc2, err := globalIAMSys.NewServiceAccount("user", embeddedPolicy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = globalIAMSys.NewServiceAccount(svc1.AccessKey, nil); err != errNoSuchUser {
+		t.Fatalf("expected %v, got %v", errNoSuchUser, err)
+	}
+
+	// Service accounts and their embedded policies are persisted.
+	if err = globalIAMSys.Load(adminTestBed.objLayer); err != nil {
+		t.Fatal(err)
+	}
+
+	isAllowed := func(accessKey string, action iampolicy.Action) bool {
+		return globalIAMSys.IsAllowed(iampolicy.Args{
+			AccountName: accessKey,
+			Action:      action,
+			BucketName:  "bucket",
+			ObjectName:  "object",
+		})
+	}
+	if !isAllowed(svc1.AccessKey, iampolicy.GetObjectAction) || !isAllowed(svc1.AccessKey, iampolicy.PutObjectAction) {
+		t.Fatal("expected service account to inherit the policies of its parent user")
+	}
+	if !isAllowed(svc2.AccessKey, iampolicy.GetObjectAction) || isAllowed(svc2.AccessKey, iampolicy.PutObjectAction) {
+		t.Fatal("expected service account to be restricted by its embedded policy")
+	}
+
+	serviceAccounts, err := globalIAMSys.ListServiceAccounts("user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(serviceAccounts) != 2 {
+		t.Fatalf("expected 2 service accounts, got %v", serviceAccounts)
+	}
+	if users, _ := globalIAMSys.ListUsers(); len(users) != 1 {
+		t.Fatalf("expected service accounts not to be listed as users, got %v", users)
+	}
+
+	// Service accounts are revoked when their parent user is disabled.
+	if err = globalIAMSys.SetUserStatus("user", madmin.AccountDisabled); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := globalIAMSys.GetUser(svc1.AccessKey); ok {
+		t.Fatal("expected service account of a disabled user to be revoked")
+	}
+	if err = globalIAMSys.SetUserStatus("user", madmin.AccountEnabled); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := globalIAMSys.GetUser(svc1.AccessKey); !ok {
+		t.Fatal("expected service account to be valid again")
+	}
+
+	if err = globalIAMSys.DeleteServiceAccount(svc1.AccessKey); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.DeleteServiceAccount(svc1.AccessKey); err != errNoSuchServiceAccount {
+		t.Fatalf("expected %v, got %v", errNoSuchServiceAccount, err)
+	}
+
+	// Service accounts are removed along with their parent user.
+	if err = globalIAMSys.DeleteUser("user"); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.Load(adminTestBed.objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := globalIAMSys.GetUser(svc2.AccessKey); ok {
+		t.Fatal("expected service account to be removed along with its parent user")
+	}
+}
+
+// TestServiceAccountHandlers - tests adding, listing and deleting service accounts.
+func TestServiceAccountHandlers(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	if err = globalIAMSys.SetUser("user", madmin.UserInfo{SecretKey: "secretkey", Status: madmin.AccountEnabled}); err != nil {
+		t.Fatal(err)
+	}
+
+	addRequest := func(parent, policy string) string {
+		data, _ := json.Marshal(madmin.AddServiceAccountReq{Parent: parent, Policy: policy})
+		return string(data)
+	}
+
+	serveHTTP := func(method, path string, queryVal url.Values, body string) *httptest.ResponseRecorder {
+		req, err := buildAdminRequest(queryVal, method, path, int64(len(body)), bytes.NewReader([]byte(body)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	testCases := []struct {
+		body         string
+		expectedCode int
+	}{
+		{"{", http.StatusBadRequest},
+		{addRequest("unknown", ""), http.StatusNotFound},
+		{addRequest("user", "policy"), http.StatusBadRequest},
+		{addRequest("user", `{"Statement":[]}`), http.StatusBadRequest},
+		{addRequest("user", ""), http.StatusOK},
+	}
+
+	var cred auth.Credentials
+	for i, testCase := range testCases {
+		rec := serveHTTP(http.MethodPut, "/add-service-account", url.Values{}, testCase.body)
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("test %d: expected %d, got %d: %s", i+1, testCase.expectedCode, rec.Code, rec.Body.String())
+		}
+		if rec.Code != http.StatusOK {
+			continue
+		}
+		data, err := madmin.DecryptData(globalServerConfig.GetCredential().SecretKey, rec.Body)
+		if err != nil {
+			t.Fatalf("test %d: %v", i+1, err)
+		}
+		if err = json.Unmarshal(data, &cred); err != nil {
+			t.Fatalf("test %d: %v", i+1, err)
+		}
+		if cred.ParentUser != "user" || !cred.IsValid() {
+			t.Fatalf("test %d: unexpected credentials %+v", i+1, cred)
+		}
+	}
+
+	rec := serveHTTP(http.MethodGet, "/list-service-accounts", url.Values{"user": []string{"user"}}, "")
+	var serviceAccounts []string
+	if err = json.NewDecoder(rec.Body).Decode(&serviceAccounts); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(serviceAccounts, []string{cred.AccessKey}) {
+		t.Fatalf("unexpected service accounts %v", serviceAccounts)
+	}
+
+	deleteQuery := url.Values{"accessKey": []string{cred.AccessKey}}
+	if rec = serveHTTP(http.MethodDelete, "/delete-service-account", deleteQuery, ""); rec.Code != http.StatusOK {
+		t.Fatalf("expected %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if rec = serveHTTP(http.MethodDelete, "/delete-service-account", deleteQuery, ""); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected %d, got %d: %s", http.StatusNotFound, rec.Code, rec.Body.String())
+	}
+}
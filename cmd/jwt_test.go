@@ -34,6 +34,9 @@ func testAuthenticate(authType string, t *testing.T) {
 		t.Fatal(err)
 	}
 
+	// Users are looked up in IAM when the credentials mismatch.
+	globalIAMSys = NewIAMSys()
+
 	cred, err := auth.GetNewCredentials()
 	if err != nil {
 		t.Fatalf("Error getting new credentials: %s", err)
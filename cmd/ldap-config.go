@@ -0,0 +1,195 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/tls"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	ldap "gopkg.in/ldap.v3"
+)
+
+const (
+	// Default validity of credentials issued to LDAP users.
+	defaultLDAPExpiry = time.Hour
+
+	// Bounds of the validity of credentials requested with DurationSeconds.
+	minLDAPExpiry = 15 * time.Minute
+	maxLDAPExpiry = 12 * time.Hour
+)
+
+// ldapServerConfig - LDAP identity provider of AssumeRoleWithLDAPIdentity.
+type ldapServerConfig struct {
+	Enabled bool `json:"enabled"`
+
+	// Address of the LDAP server as host:port.
+	ServerAddr string `json:"serverAddr"`
+
+	// DN of a user, "%s" is replaced by the username, for
+	// example "uid=%s,cn=accounts,dc=myldapserver,dc=com".
+	UsernameFormat string `json:"usernameFormat"`
+
+	// Filter of the groups of a user, "%s" is replaced by the
+	// username and "%d" by the DN of the user, for example
+	// "(&(objectclass=groupOfNames)(member=%d))".
+	GroupSearchFilter string `json:"groupSearchFilter"`
+
+	// Base DN of the group search.
+	GroupSearchBaseDN string `json:"groupSearchBaseDN"`
+
+	// Default validity of issued credentials, for example "1h".
+	STSExpiry string `json:"stsExpiry"`
+
+	// Skip verification of the TLS certificate of the LDAP server.
+	TLSSkipVerify bool `json:"tlsSkipVerify"`
+
+	// Connect without TLS, only meant for testing.
+	Insecure bool `json:"insecure"`
+}
+
+// Validate - validates the LDAP configuration.
+func (l ldapServerConfig) Validate() error {
+	if !l.Enabled {
+		return nil
+	}
+	if l.ServerAddr == "" {
+		return errors.New("serverAddr is not set")
+	}
+	if !strings.Contains(l.UsernameFormat, "%s") {
+		return errors.New("usernameFormat must contain %s")
+	}
+	if l.GroupSearchFilter != "" && l.GroupSearchBaseDN == "" {
+		return errors.New("groupSearchBaseDN is not set")
+	}
+	if l.STSExpiry != "" {
+		if _, err := time.ParseDuration(l.STSExpiry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetExpiryDuration - returns the validity of issued credentials, the
+// DurationSeconds request parameter overrides the configured one.
+func (l ldapServerConfig) GetExpiryDuration(dsecs string) (time.Duration, error) {
+	if dsecs != "" {
+		expirySecs, err := strconv.ParseInt(dsecs, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		expiry := time.Duration(expirySecs) * time.Second
+		if expiry < minLDAPExpiry || expiry > maxLDAPExpiry {
+			return 0, errors.New("invalid duration")
+		}
+		return expiry, nil
+	}
+
+	if l.STSExpiry == "" {
+		return defaultLDAPExpiry, nil
+	}
+	return time.ParseDuration(l.STSExpiry)
+}
+
+// Connect - dials the LDAP server.
+func (l ldapServerConfig) Connect() (*ldap.Conn, error) {
+	if l.Insecure {
+		return ldap.Dial("tcp", l.ServerAddr)
+	}
+	return ldap.DialTLS("tcp", l.ServerAddr, &tls.Config{
+		RootCAs:            globalRootCAs,
+		InsecureSkipVerify: l.TLSSkipVerify,
+	})
+}
+
+// Authenticate - binds as the given user and returns the DN of the user
+// along with the DNs of all its groups.
+func (l ldapServerConfig) Authenticate(username, password string) (userDN string, groups []string, err error) {
+	// An empty password performs an unauthenticated bind, which
+	// most servers accept for any DN.
+	if username == "" || password == "" {
+		return "", nil, errAuthentication
+	}
+
+	conn, err := l.Connect()
+	if err != nil {
+		return "", nil, err
+	}
+	defer conn.Close()
+
+	userDN = strings.Replace(l.UsernameFormat, "%s", escapeLDAPDN(username), -1)
+	if err = conn.Bind(userDN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return "", nil, errAuthentication
+		}
+		return "", nil, err
+	}
+
+	if l.GroupSearchFilter == "" {
+		return userDN, nil, nil
+	}
+
+	filter := strings.Replace(l.GroupSearchFilter, "%s", ldap.EscapeFilter(username), -1)
+	filter = strings.Replace(filter, "%d", ldap.EscapeFilter(userDN), -1)
+	searchRequest := ldap.NewSearchRequest(l.GroupSearchBaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		0, 0, false, filter, []string{"dn"}, nil)
+
+	result, err := conn.Search(searchRequest)
+	if err != nil {
+		return "", nil, err
+	}
+
+	for _, entry := range result.Entries {
+		groups = append(groups, entry.DN)
+	}
+	return userDN, groups, nil
+}
+
+// escapeLDAPDN - escapes the special characters of an attribute value
+// of a DN as per RFC 4514.
+func escapeLDAPDN(value string) string {
+	var escaped strings.Builder
+	for i, c := range value {
+		switch {
+		case strings.ContainsRune(`,+"\<>;=`, c),
+			i == 0 && (c == ' ' || c == '#'),
+			i == len(value)-1 && c == ' ':
+			escaped.WriteRune('\\')
+		}
+		escaped.WriteRune(c)
+	}
+	return escaped.String()
+}
+
+// newLDAPConfigFromEnv - returns the LDAP configuration set by the
+// MINIO_IDENTITY_LDAP_* environment variables, ok is false if the
+// LDAP server address is not set.
+func newLDAPConfigFromEnv() (l ldapServerConfig, ok bool) {
+	if l.ServerAddr, ok = os.LookupEnv("MINIO_IDENTITY_LDAP_SERVER_ADDR"); !ok {
+		return l, false
+	}
+	l.Enabled = true
+	l.UsernameFormat = os.Getenv("MINIO_IDENTITY_LDAP_USERNAME_FORMAT")
+	l.GroupSearchFilter = os.Getenv("MINIO_IDENTITY_LDAP_GROUP_SEARCH_FILTER")
+	l.GroupSearchBaseDN = os.Getenv("MINIO_IDENTITY_LDAP_GROUP_SEARCH_BASE_DN")
+	l.STSExpiry = os.Getenv("MINIO_IDENTITY_LDAP_STS_EXPIRY")
+	l.TLSSkipVerify = os.Getenv("MINIO_IDENTITY_LDAP_TLS_SKIP_VERIFY") == "on"
+	return l, true
+}
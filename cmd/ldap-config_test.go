@@ -0,0 +1,230 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	ber "gopkg.in/asn1-ber.v1"
+	ldap "gopkg.in/ldap.v3"
+)
+
+// ldapTestServer - minimal LDAP server supporting simple binds and
+// searches of groups by member, stands in for OpenLDAP in tests.
+type ldapTestServer struct {
+	listener net.Listener
+	// Password of each user DN.
+	users map[string]string
+	// Member DNs of each group DN.
+	groups map[string][]string
+}
+
+func newLDAPTestServer(t *testing.T, users map[string]string, groups map[string][]string) *ldapTestServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &ldapTestServer{listener: listener, users: users, groups: groups}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	return server
+}
+
+func (server *ldapTestServer) Addr() string {
+	return server.listener.Addr().String()
+}
+
+func (server *ldapTestServer) Close() {
+	server.listener.Close()
+}
+
+func (server *ldapTestServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	var boundDN string
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+		messageID := packet.Children[0].Value.(int64)
+		op := packet.Children[1]
+
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			dn := op.Children[1].Value.(string)
+			password := op.Children[2].Data.String()
+			resultCode := ldap.LDAPResultInvalidCredentials
+			if expected, ok := server.users[dn]; ok && password != "" && password == expected {
+				resultCode = ldap.LDAPResultSuccess
+				boundDN = dn
+			}
+			server.writeResult(conn, messageID, ldap.ApplicationBindResponse, resultCode)
+		case ldap.ApplicationSearchRequest:
+			if boundDN == "" {
+				server.writeResult(conn, messageID, ldap.ApplicationSearchResultDone, ldap.LDAPResultInsufficientAccessRights)
+				continue
+			}
+			filter, err := ldap.DecompileFilter(op.Children[6])
+			if err != nil {
+				server.writeResult(conn, messageID, ldap.ApplicationSearchResultDone, ldap.LDAPResultProtocolError)
+				continue
+			}
+			for groupDN, members := range server.groups {
+				for _, member := range members {
+					if strings.Contains(filter, "(member="+member+")") {
+						server.writeEntry(conn, messageID, groupDN)
+					}
+				}
+			}
+			server.writeResult(conn, messageID, ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess)
+		default:
+			return
+		}
+	}
+}
+
+func (server *ldapTestServer) writeEntry(conn net.Conn, messageID int64, dn string) {
+	entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "Search Result Entry")
+	entry.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, dn, "Object Name"))
+	entry.AppendChild(ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes"))
+	server.write(conn, messageID, entry)
+}
+
+func (server *ldapTestServer) writeResult(conn net.Conn, messageID int64, tag ber.Tag, resultCode int) {
+	result := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Result")
+	result.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, uint64(resultCode), "Result Code"))
+	result.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	result.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+	server.write(conn, messageID, result)
+}
+
+func (server *ldapTestServer) write(conn net.Conn, messageID int64, op *ber.Packet) {
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, "Message ID"))
+	packet.AppendChild(op)
+	conn.Write(packet.Bytes())
+}
+
+// newTestLDAPConfig - returns an LDAP configuration pointing to a test
+// server with the users "alice" and "bob", only alice is a member of the
+// "admins" group.
+func newTestLDAPConfig(t *testing.T) (ldapServerConfig, *ldapTestServer) {
+	server := newLDAPTestServer(t,
+		map[string]string{
+			"uid=alice,ou=people,dc=example,dc=com": "alice-password",
+			"uid=bob,ou=people,dc=example,dc=com":   "bob-password",
+		},
+		map[string][]string{
+			"cn=admins,ou=groups,dc=example,dc=com": {"uid=alice,ou=people,dc=example,dc=com"},
+		})
+
+	return ldapServerConfig{
+		Enabled:           true,
+		ServerAddr:        server.Addr(),
+		UsernameFormat:    "uid=%s,ou=people,dc=example,dc=com",
+		GroupSearchFilter: "(&(objectclass=groupOfNames)(member=%d))",
+		GroupSearchBaseDN: "ou=groups,dc=example,dc=com",
+		Insecure:          true,
+	}, server
+}
+
+func TestLDAPAuthenticate(t *testing.T) {
+	config, server := newTestLDAPConfig(t)
+	defer server.Close()
+
+	testCases := []struct {
+		username       string
+		password       string
+		expectedDN     string
+		expectedGroups []string
+		expectedErr    error
+	}{
+		{"alice", "alice-password", "uid=alice,ou=people,dc=example,dc=com", []string{"cn=admins,ou=groups,dc=example,dc=com"}, nil},
+		{"bob", "bob-password", "uid=bob,ou=people,dc=example,dc=com", nil, nil},
+		{"bob", "alice-password", "", nil, errAuthentication},
+		{"alice", "", "", nil, errAuthentication},
+		{"alice,ou=people,dc=example,dc=com", "alice-password", "", nil, errAuthentication},
+	}
+
+	for i, testCase := range testCases {
+		userDN, groups, err := config.Authenticate(testCase.username, testCase.password)
+		if err != testCase.expectedErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+		if userDN != testCase.expectedDN || !reflect.DeepEqual(groups, testCase.expectedGroups) {
+			t.Fatalf("case %v: unexpected user %v with groups %v", i+1, userDN, groups)
+		}
+	}
+}
+
+func TestLDAPGetExpiryDuration(t *testing.T) {
+	testCases := []struct {
+		stsExpiry        string
+		dsecs            string
+		expectedDuration time.Duration
+		expectErr        bool
+	}{
+		{"", "", defaultLDAPExpiry, false},
+		{"30m", "", 30 * time.Minute, false},
+		{"30m", "3600", time.Hour, false},
+		{"", "60", 0, true},
+		{"", "86400", 0, true},
+		{"", "one-hour", 0, true},
+	}
+
+	for i, testCase := range testCases {
+		config := ldapServerConfig{STSExpiry: testCase.stsExpiry}
+		duration, err := config.GetExpiryDuration(testCase.dsecs)
+		if (err != nil) != testCase.expectErr {
+			t.Fatalf("case %v: expected error: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+		if duration != testCase.expectedDuration {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedDuration, duration)
+		}
+	}
+}
+
+func TestEscapeLDAPDN(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected string
+	}{
+		{"alice", "alice"},
+		{"alice,ou=admins", `alice\,ou\=admins`},
+		{" #alice ", `\ #alice\ `},
+		{"#alice", `\#alice`},
+		{`a+b"c\d<e>f;g`, `a\+b\"c\\d\<e\>f\;g`},
+	}
+
+	for i, testCase := range testCases {
+		if escaped := escapeLDAPDN(testCase.value); escaped != testCase.expected {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expected, escaped)
+		}
+	}
+}
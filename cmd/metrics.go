@@ -84,6 +84,21 @@ func (c *minioCollector) Collect(ch chan<- prometheus.Metric) {
 		float64(globalConnStats.getTotalInputBytes()),
 	)
 
+	// Events pending to be sent by notification targets
+	if globalNotificationSys != nil {
+		for id, count := range globalNotificationSys.targetList.PendingEvents() {
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName("minio", "notify", "pending_events"),
+					"Number of events pending to be sent by a notification target",
+					[]string{"target_id", "target_name"}, nil),
+				prometheus.GaugeValue,
+				float64(count),
+				id.ID, id.Name,
+			)
+		}
+	}
+
 	// Expose cache stats only if available
 	cacheObjLayer := newCacheObjectsFn()
 	if cacheObjLayer != nil {
@@ -159,6 +174,92 @@ func (c *minioCollector) Collect(ch chan<- prometheus.Metric) {
 		prometheus.GaugeValue,
 		float64(offlineDisks),
 	)
+
+	// Bitrot scrubber counters of the cluster
+	if s.Backend.Type == BackendErasure {
+		scrubStatus, err := loadBitrotScrubStatus(context.Background(), objLayer)
+		logger.LogIf(context.Background(), err)
+		if err == nil {
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName("minio", "bitrot", "objects_scanned_total"),
+					"Total number of objects verified by the bitrot scrubber",
+					nil, nil),
+				prometheus.CounterValue,
+				float64(scrubStatus.ObjectsScanned),
+			)
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName("minio", "bitrot", "bytes_scanned_total"),
+					"Total number of bytes read by the bitrot scrubber",
+					nil, nil),
+				prometheus.CounterValue,
+				float64(scrubStatus.BytesScanned),
+			)
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName("minio", "bitrot", "corruptions_found_total"),
+					"Total number of corrupted shards found by the bitrot scrubber",
+					nil, nil),
+				prometheus.CounterValue,
+				float64(scrubStatus.CorruptionsFound),
+			)
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName("minio", "bitrot", "corruptions_fixed_total"),
+					"Total number of corrupted shards healed by the bitrot scrubber",
+					nil, nil),
+				prometheus.CounterValue,
+				float64(scrubStatus.CorruptionsFixed),
+			)
+		}
+	}
+
+	// Fetch data usage computed by the last crawl
+	dataUsageInfo, err := loadDataUsage(context.Background(), objLayer)
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		return
+	}
+
+	// No crawl has finished yet
+	if dataUsageInfo.LastUpdate.IsZero() {
+		return
+	}
+
+	for bucket, usageInfo := range dataUsageInfo.BucketsUsage {
+		// Total space used by bucket
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("minio", "bucket", "usage_size"),
+				"Total bucket size",
+				[]string{"bucket"}, nil),
+			prometheus.GaugeValue,
+			float64(usageInfo.Size),
+			bucket,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("minio", "bucket", "objects_count"),
+				"Total number of objects in a bucket",
+				[]string{"bucket"}, nil),
+			prometheus.GaugeValue,
+			float64(usageInfo.ObjectsCount),
+			bucket,
+		)
+		for sizeRange, count := range usageInfo.ObjectsSizesHistogram {
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName("minio", "bucket", "objects_histogram"),
+					"Total number of objects of different sizes in a bucket",
+					[]string{"bucket", "object_size"}, nil),
+				prometheus.GaugeValue,
+				float64(count),
+				bucket,
+				sizeRange,
+			)
+		}
+	}
 }
 
 func metricsHandler() http.Handler {
@@ -32,11 +32,36 @@ import (
 	"time"
 
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/accesslog"
+	"github.com/minio/minio/pkg/cors"
 	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/lifecycle"
+	"github.com/minio/minio/pkg/madmin"
 	xnet "github.com/minio/minio/pkg/net"
+	"github.com/minio/minio/pkg/objectlock"
 	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/replication"
+	"github.com/minio/minio/pkg/versioning"
+	"github.com/minio/minio/pkg/website"
 )
 
+// allBucketsListener - bucket name under which listeners of events of all
+// buckets are registered.
+const allBucketsListener = ""
+
+// allBucketsListenerConfig - listener.json of the listeners of events of
+// all buckets, kept out of the bucket config namespace.
+const allBucketsListenerConfig = "listener-all.json"
+
+// getListenerConfigFile - returns the path to listener.json for the given
+// bucket, or to the listeners of all buckets.
+func getListenerConfigFile(bucketName string) string {
+	if bucketName == allBucketsListener {
+		return path.Join(minioConfigPrefix, allBucketsListenerConfig)
+	}
+	return path.Join(bucketConfigPrefix, bucketName, bucketListenerConfig)
+}
+
 // NotificationSys - notification system.
 type NotificationSys struct {
 	sync.RWMutex
@@ -417,6 +442,276 @@ func (sys *NotificationSys) RemoveBucketPolicy(ctx context.Context, bucketName s
 	}()
 }
 
+// SetBucketVersioning - calls SetBucketVersioning RPC call on all peers.
+func (sys *NotificationSys) SetBucketVersioning(ctx context.Context, bucketName string, v *versioning.Versioning) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.SetBucketVersioning(bucketName, v); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
+// SetBucketLifecycle - calls SetBucketLifecycle RPC call on all peers.
+func (sys *NotificationSys) SetBucketLifecycle(ctx context.Context, bucketName string, lc *lifecycle.Lifecycle) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.SetBucketLifecycle(bucketName, lc); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
+// RemoveBucketLifecycle - calls RemoveBucketLifecycle RPC call on all peers.
+func (sys *NotificationSys) RemoveBucketLifecycle(ctx context.Context, bucketName string) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.RemoveBucketLifecycle(bucketName); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
+// SetBucketCors - calls SetBucketCors RPC call on all peers.
+func (sys *NotificationSys) SetBucketCors(ctx context.Context, bucketName string, config *cors.Config) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.SetBucketCors(bucketName, config); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
+// RemoveBucketCors - calls RemoveBucketCors RPC call on all peers.
+func (sys *NotificationSys) RemoveBucketCors(ctx context.Context, bucketName string) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.RemoveBucketCors(bucketName); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
+// SetBucketWebsite - calls SetBucketWebsite RPC call on all peers.
+func (sys *NotificationSys) SetBucketWebsite(ctx context.Context, bucketName string, config *website.Config) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.SetBucketWebsite(bucketName, config); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
+// RemoveBucketWebsite - calls RemoveBucketWebsite RPC call on all peers.
+func (sys *NotificationSys) RemoveBucketWebsite(ctx context.Context, bucketName string) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.RemoveBucketWebsite(bucketName); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
+// SetBucketLogging - calls SetBucketLogging RPC call on all peers.
+func (sys *NotificationSys) SetBucketLogging(ctx context.Context, bucketName string, config *accesslog.Config) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.SetBucketLogging(bucketName, config); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
+// RemoveBucketLogging - calls RemoveBucketLogging RPC call on all peers.
+func (sys *NotificationSys) RemoveBucketLogging(ctx context.Context, bucketName string) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.RemoveBucketLogging(bucketName); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
+// SetBucketReplication - calls SetBucketReplication RPC call on all peers.
+func (sys *NotificationSys) SetBucketReplication(ctx context.Context, bucketName string, config *replication.Config) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.SetBucketReplication(bucketName, config); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
+// SetBucketObjectLock - calls SetBucketObjectLock RPC call on all peers.
+func (sys *NotificationSys) SetBucketObjectLock(ctx context.Context, bucketName string, config *objectlock.Config) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.SetBucketObjectLock(bucketName, config); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
+// SetBucketQuota - calls SetBucketQuota RPC call on all peers.
+func (sys *NotificationSys) SetBucketQuota(ctx context.Context, bucketName string, quota *madmin.BucketQuota) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.SetBucketQuota(bucketName, quota); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
+// UpdateBucketUsage - calls UpdateBucketUsage RPC call on all peers.
+func (sys *NotificationSys) UpdateBucketUsage(ctx context.Context, bucketName string, size int64, at time.Time) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.UpdateBucketUsage(bucketName, size, at); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
+// RemoveBucketQuota - calls RemoveBucketQuota RPC call on all peers.
+func (sys *NotificationSys) RemoveBucketQuota(ctx context.Context, bucketName string) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.RemoveBucketQuota(bucketName); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
+// RemoveBucketReplication - calls RemoveBucketReplication RPC call on all peers.
+func (sys *NotificationSys) RemoveBucketReplication(ctx context.Context, bucketName string) {
+	go func() {
+		var wg sync.WaitGroup
+		for addr, client := range sys.peerRPCClientMap {
+			wg.Add(1)
+			go func(addr xnet.Host, client *PeerRPCClient) {
+				defer wg.Done()
+				if err := client.RemoveBucketReplication(bucketName); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("remotePeer", addr.Name)
+					logger.LogIf(ctx, err)
+				}
+			}(addr, client)
+		}
+		wg.Wait()
+	}()
+}
+
 // PutBucketNotification - calls PutBucketNotification RPC call on all peers.
 func (sys *NotificationSys) PutBucketNotification(ctx context.Context, bucketName string, rulesMap event.RulesMap) {
 	go func() {
@@ -500,7 +795,7 @@ func (sys *NotificationSys) initListeners(ctx context.Context, objAPI ObjectLaye
 	}
 
 	// Construct path to listener.json for the given bucket.
-	configFile := path.Join(bucketConfigPrefix, bucketName, bucketListenerConfig)
+	configFile := getListenerConfigFile(bucketName)
 	transactionConfigFile := configFile + ".transaction"
 
 	// As object layer's GetObject() and PutObject() take respective lock on minioMetaBucket
@@ -589,7 +884,7 @@ func (sys *NotificationSys) refresh(objAPI ObjectLayer) error {
 			return err
 		}
 	}
-	return nil
+	return sys.initListeners(context.Background(), objAPI, allBucketsListener)
 }
 
 // Init - initializes notification system from notification.xml and listener.json of all buckets.
@@ -697,8 +992,10 @@ func (sys *NotificationSys) send(bucketName string, eventData event.Event, targe
 	errCh := sys.targetList.Send(eventData, targetIDs...)
 	for terr := range errCh {
 		errs = append(errs, terr)
-		if sys.RemoteTargetExist(bucketName, terr.ID) {
-			sys.RemoveRemoteTarget(bucketName, terr.ID)
+		for _, name := range []string{bucketName, allBucketsListener} {
+			if sys.RemoteTargetExist(name, terr.ID) {
+				sys.RemoveRemoteTarget(name, terr.ID)
+			}
 		}
 	}
 
@@ -707,8 +1004,11 @@ func (sys *NotificationSys) send(bucketName string, eventData event.Event, targe
 
 // Send - sends event data to all matching targets.
 func (sys *NotificationSys) Send(args eventArgs) []event.TargetIDErr {
+	object := args.ToObjectInfo()
+
 	sys.RLock()
-	targetIDSet := sys.bucketRulesMap[args.BucketName].Match(args.EventName, args.Object.Name)
+	targetIDSet := sys.bucketRulesMap[args.BucketName].Match(args.EventName, object)
+	targetIDSet = targetIDSet.Union(sys.bucketRulesMap[allBucketsListener].Match(args.EventName, object))
 	sys.RUnlock()
 
 	if len(targetIDSet) == 0 {
@@ -875,7 +1175,7 @@ func (args eventArgs) ToEvent() event.Event {
 		},
 	}
 
-	if args.EventName != event.ObjectRemovedDelete {
+	if args.EventName != event.ObjectRemovedDelete && args.EventName != event.ObjectRemovedDeleteMarkerCreated {
 		newEvent.S3.Object.ETag = args.Object.ETag
 		newEvent.S3.Object.Size = args.Object.Size
 		if args.Object.IsCompressed() {
@@ -888,6 +1188,23 @@ func (args eventArgs) ToEvent() event.Event {
 	return newEvent
 }
 
+// ToObjectInfo - returns object properties notification rules are matched with.
+func (args eventArgs) ToObjectInfo() event.ObjectInfo {
+	object := event.ObjectInfo{
+		Name:     args.Object.Name,
+		Size:     args.Object.Size,
+		Metadata: args.Object.UserDefined,
+	}
+	if args.Object.IsCompressed() {
+		object.Size = args.Object.GetActualSize()
+	}
+	if t, err := getObjectTags(args.Object); err == nil {
+		object.Tags = t.ToMap()
+	}
+
+	return object
+}
+
 func sendEvent(args eventArgs) {
 	// globalNotificationSys is not initialized in gateway mode.
 	if globalNotificationSys == nil {
@@ -943,7 +1260,7 @@ func SaveListener(objAPI ObjectLayer, bucketName string, eventNames []event.Name
 	ctx := logger.SetReqInfo(context.Background(), &logger.ReqInfo{BucketName: bucketName})
 
 	// Construct path to listener.json for the given bucket.
-	configFile := path.Join(bucketConfigPrefix, bucketName, bucketListenerConfig)
+	configFile := getListenerConfigFile(bucketName)
 	transactionConfigFile := configFile + ".transaction"
 
 	// As object layer's GetObject() and PutObject() take respective lock on minioMetaBucket
@@ -994,7 +1311,7 @@ func RemoveListener(objAPI ObjectLayer, bucketName string, targetID event.Target
 	ctx := logger.SetReqInfo(context.Background(), &logger.ReqInfo{BucketName: bucketName})
 
 	// Construct path to listener.json for the given bucket.
-	configFile := path.Join(bucketConfigPrefix, bucketName, bucketListenerConfig)
+	configFile := getListenerConfigFile(bucketName)
 	transactionConfigFile := configFile + ".transaction"
 
 	// As object layer's GetObject() and PutObject() take respective lock on minioMetaBucket
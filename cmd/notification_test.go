@@ -0,0 +1,137 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/objectlock"
+)
+
+// eventRecorder - target recording the events sent to it.
+type eventRecorder struct {
+	sync.Mutex
+	events []string
+}
+
+func (target *eventRecorder) ID() event.TargetID {
+	return event.TargetID{ID: "1", Name: "recorder"}
+}
+
+func (target *eventRecorder) Send(eventData event.Event) error {
+	target.Lock()
+	defer target.Unlock()
+	target.events = append(target.events, eventData.EventName.String()+" "+eventData.S3.Bucket.Name+"/"+eventData.S3.Object.Key)
+	return nil
+}
+
+func (target *eventRecorder) Close() error {
+	return nil
+}
+
+// Wrapper for calling event notification handler tests for both XL multiple disks and single node setup.
+func TestNotificationEvents(t *testing.T) {
+	ExecObjectLayerAPITest(t, testNotificationEvents, nil)
+}
+
+// Tests the events notified by the bucket, tagging, retention and legal hold handlers.
+func testNotificationEvents(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	globalObjLayerMutex.Lock()
+	globalObjectAPI = obj
+	globalObjLayerMutex.Unlock()
+
+	globalPolicySys = NewPolicySys()
+	globalBucketVersioningSys = NewBucketVersioningSys()
+	globalLifecycleSys = NewLifecycleSys()
+	globalBucketCorsSys = NewBucketCorsSys()
+	globalBucketWebsiteSys = NewBucketWebsiteSys()
+	globalBucketLoggingSys = NewBucketLoggingSys()
+	globalReplicationSys = NewReplicationSys()
+	globalBucketObjectLockSys = NewBucketObjectLockSys()
+	globalBucketQuotaSys = NewBucketQuotaSys()
+	globalNotificationSys = NewNotificationSys(globalServerConfig, EndpointList{})
+	recorder := &eventRecorder{}
+	rulesMap := event.NewRulesMap([]event.Name{event.BucketCreated, event.BucketRemoved, event.ObjectCreatedAll}, "*", recorder.ID())
+	if err := globalNotificationSys.AddRemoteTarget(allBucketsListener, recorder, rulesMap); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	defer globalNotificationSys.RemoveRemoteTarget(allBucketsListener, recorder.ID())
+
+	globalBucketObjectLockSys.Set(bucketName, objectlock.Config{ObjectLockEnabled: objectlock.Enabled})
+	defer globalBucketObjectLockSys.Remove(bucketName)
+
+	objectName := "object"
+	data := []byte("hello")
+	if _, err := obj.PutObject(context.Background(), bucketName, objectName, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	retention, err := xml.Marshal(objectlock.Retention{Mode: objectlock.Governance, RetainUntilDate: UTCNow().Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	legalHold, err := xml.Marshal(objectlock.LegalHold{Status: objectlock.LegalHoldOn})
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	tagging := []byte("<Tagging><TagSet><Tag><Key>color</Key><Value>blue</Value></Tag></TagSet></Tagging>")
+
+	testCases := []struct {
+		method        string
+		urlStr        string
+		body          []byte
+		expectedEvent string
+	}{
+		{http.MethodPut, "/newbucket", nil, "s3:BucketCreated newbucket/"},
+		{http.MethodPut, "/" + bucketName + "/" + objectName + "?tagging", tagging, "s3:ObjectCreated:PutTagging " + bucketName + "/" + objectName},
+		{http.MethodPut, "/" + bucketName + "/" + objectName + "?retention", retention, "s3:ObjectCreated:PutRetention " + bucketName + "/" + objectName},
+		{http.MethodPut, "/" + bucketName + "/" + objectName + "?legal-hold", legalHold, "s3:ObjectCreated:PutLegalHold " + bucketName + "/" + objectName},
+		{http.MethodDelete, "/newbucket", nil, "s3:BucketRemoved newbucket/"},
+	}
+
+	for i, testCase := range testCases {
+		req, err := newTestSignedRequestV4(testCase.method, testCase.urlStr, int64(len(testCase.body)), bytes.NewReader(testCase.body),
+			credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("%s: test %d: %s", instanceType, i+1, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK && rec.Code != http.StatusNoContent {
+			t.Fatalf("%s: test %d: expected success, got %d: %s", instanceType, i+1, rec.Code, rec.Body.String())
+		}
+
+		recorder.Lock()
+		events := recorder.events
+		recorder.events = nil
+		recorder.Unlock()
+		if !reflect.DeepEqual(events, []string{testCase.expectedEvent}) {
+			t.Fatalf("%s: test %d: expected event %q, got %q", instanceType, i+1, testCase.expectedEvent, events)
+		}
+	}
+}
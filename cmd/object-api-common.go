@@ -92,6 +92,33 @@ func deleteBucketMetadata(ctx context.Context, bucket string, objAPI ObjectLayer
 
 	// Delete listener config, if present - ignore any errors.
 	removeListenerConfig(ctx, objAPI, bucket)
+
+	// Delete versioning config, if present - ignore any errors.
+	removeVersioningConfig(ctx, objAPI, bucket)
+
+	// Delete lifecycle config, if present - ignore any errors.
+	removeLifecycleConfig(ctx, objAPI, bucket)
+
+	// Delete CORS config, if present - ignore any errors.
+	removeCorsConfig(ctx, objAPI, bucket)
+
+	// Delete website config, if present - ignore any errors.
+	removeWebsiteConfig(ctx, objAPI, bucket)
+
+	// Delete logging config, if present - ignore any errors.
+	removeLoggingConfig(ctx, objAPI, bucket)
+
+	// Delete replication config, if present - ignore any errors.
+	removeReplicationConfig(ctx, objAPI, bucket)
+
+	// Delete object lock config, if present - ignore any errors.
+	removeObjectLockConfig(ctx, objAPI, bucket)
+
+	// Delete quota config, if present - ignore any errors.
+	removeQuotaConfig(ctx, objAPI, bucket)
+
+	// Delete tagging config, if present - ignore any errors.
+	removeBucketTaggingConfig(ctx, objAPI, bucket)
 }
 
 // Depending on the disk type network or local, initialize storage API.
@@ -161,3 +188,80 @@ func removeListenerConfig(ctx context.Context, objAPI ObjectLayer, bucket string
 	lcPath := path.Join(bucketConfigPrefix, bucket, bucketListenerConfig)
 	return objAPI.DeleteObject(ctx, minioMetaBucket, lcPath)
 }
+
+// listObjectVersions - lists the versions of the objects sent by the tree
+// walk, getVersions returns all versions of a single object newest first.
+func listObjectVersions(ctx context.Context, bucket, keyMarker, versionIDMarker, delimiter string, maxKeys int,
+	walkResultCh chan treeWalkResult, getVersions func(context.Context, string, string) ([]ObjectInfo, error)) (result ListObjectVersionsInfo, err error) {
+
+	var versions []ObjectInfo
+	var count int
+
+	// Resume listing right after the version marker of the key marker.
+	if keyMarker != "" && versionIDMarker != "" {
+		versions, err = getVersions(ctx, bucket, keyMarker)
+		if err != nil && err != errFileNotFound && err != errXLReadQuorum {
+			return result, toObjectErr(err, bucket, keyMarker)
+		}
+		for index, version := range versions {
+			if getVersionID(version.VersionID) == versionIDMarker {
+				versions = versions[index+1:]
+				break
+			}
+		}
+	}
+
+	for {
+		for _, version := range versions {
+			if count == maxKeys {
+				result.IsTruncated = true
+				return result, nil
+			}
+			result.Objects = append(result.Objects, version)
+			result.NextKeyMarker = version.Name
+			result.NextVersionIDMarker = getVersionID(version.VersionID)
+			count++
+		}
+		versions = nil
+
+		walkResult, ok := <-walkResultCh
+		if !ok {
+			// Closed channel, the listing is complete.
+			result.NextKeyMarker = ""
+			result.NextVersionIDMarker = ""
+			return result, nil
+		}
+
+		// For any walk error return right away.
+		if walkResult.err != nil {
+			return result, toObjectErr(walkResult.err, bucket)
+		}
+
+		if hasSuffix(walkResult.entry, slashSeparator) {
+			// Directory objects carry no versions.
+			if delimiter != slashSeparator {
+				continue
+			}
+			if count == maxKeys {
+				result.IsTruncated = true
+				return result, nil
+			}
+			result.Prefixes = append(result.Prefixes, walkResult.entry)
+			result.NextKeyMarker = walkResult.entry
+			result.NextVersionIDMarker = ""
+			count++
+			continue
+		}
+
+		versions, err = getVersions(ctx, bucket, walkResult.entry)
+		if err != nil {
+			// Ignore errFileNotFound as the object might have got
+			// deleted in the interim period of listing and getVersions(),
+			// ignore quorum error as it might be an entry from an outdated disk.
+			if err == errFileNotFound || err == errXLReadQuorum {
+				continue
+			}
+			return result, toObjectErr(err, bucket, walkResult.entry)
+		}
+	}
+}
@@ -99,6 +99,15 @@ type ObjectInfo struct {
 	// Specify object storage class
 	StorageClass string
 
+	// VersionID of the object, empty for the "null" version.
+	VersionID string
+
+	// IsLatest indicates if this is the current version of the object.
+	IsLatest bool
+
+	// DeleteMarker indicates if this version is a delete marker.
+	DeleteMarker bool
+
 	// User-Defined metadata
 	UserDefined map[string]string
 
@@ -226,6 +235,24 @@ type ListObjectsInfo struct {
 	Prefixes []string
 }
 
+// ListObjectVersionsInfo - container for list object versions.
+type ListObjectVersionsInfo struct {
+	// Indicates whether the returned list is truncated.
+	IsTruncated bool
+
+	// When response is truncated, NextKeyMarker and NextVersionIDMarker
+	// are used as key-marker and version-id-marker in the subsequent request.
+	NextKeyMarker       string
+	NextVersionIDMarker string
+
+	// List of object versions and delete markers for this request,
+	// sorted by key and from newest to oldest version.
+	Objects []ObjectInfo
+
+	// List of prefixes for this request.
+	Prefixes []string
+}
+
 // ListObjectsV2Info - container for list objects version 2.
 type ListObjectsV2Info struct {
 	// Indicates whether the returned list objects response is truncated. A
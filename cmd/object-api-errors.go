@@ -61,6 +61,13 @@ func toObjectErr(err error, params ...string) error {
 				Object: params[1],
 			}
 		}
+	case errFileVersionNotFound:
+		if len(params) >= 2 {
+			err = VersionNotFound{
+				Bucket: params[0],
+				Object: params[1],
+			}
+		}
 	case errFileNameTooLong:
 		if len(params) >= 2 {
 			err = ObjectNameInvalid{
@@ -161,6 +168,17 @@ func (e ObjectNotFound) Error() string {
 	return "Object not found: " + e.Bucket + "#" + e.Object
 }
 
+// VersionNotFound object version does not exist.
+type VersionNotFound struct {
+	Bucket    string
+	Object    string
+	VersionID string
+}
+
+func (e VersionNotFound) Error() string {
+	return "Version not found: " + e.Bucket + "#" + e.Object + " (" + e.VersionID + ")"
+}
+
 // ObjectAlreadyExists object already exists.
 type ObjectAlreadyExists GenericError
 
@@ -168,6 +186,13 @@ func (e ObjectAlreadyExists) Error() string {
 	return "Object: " + e.Bucket + "#" + e.Object + " already exists"
 }
 
+// ObjectLocked object is protected by object lock.
+type ObjectLocked GenericError
+
+func (e ObjectLocked) Error() string {
+	return "Object: " + e.Bucket + "#" + e.Object + " is protected by object lock"
+}
+
 // ObjectExistsAsDirectory object already exists as a directory.
 type ObjectExistsAsDirectory GenericError
 
@@ -223,6 +248,62 @@ func (e BucketPolicyNotFound) Error() string {
 	return "No bucket policy found for bucket: " + e.Bucket
 }
 
+// BucketLifecycleNotFound - no bucket lifecycle found.
+type BucketLifecycleNotFound GenericError
+
+func (e BucketLifecycleNotFound) Error() string {
+	return "No bucket lifecycle found for bucket: " + e.Bucket
+}
+
+// BucketTaggingNotFound - no bucket tags found.
+type BucketTaggingNotFound GenericError
+
+func (e BucketTaggingNotFound) Error() string {
+	return "No bucket tags found for bucket: " + e.Bucket
+}
+
+// BucketCorsNotFound - no bucket CORS configuration found.
+type BucketCorsNotFound GenericError
+
+func (e BucketCorsNotFound) Error() string {
+	return "No bucket CORS configuration found for bucket: " + e.Bucket
+}
+
+// BucketWebsiteNotFound - no bucket website configuration found.
+type BucketWebsiteNotFound GenericError
+
+func (e BucketWebsiteNotFound) Error() string {
+	return "No bucket website configuration found for bucket: " + e.Bucket
+}
+
+// BucketReplicationNotFound - no bucket replication configuration found.
+type BucketReplicationNotFound GenericError
+
+func (e BucketReplicationNotFound) Error() string {
+	return "No bucket replication configuration found for bucket: " + e.Bucket
+}
+
+// BucketObjectLockConfigNotFound - no bucket object lock configuration found.
+type BucketObjectLockConfigNotFound GenericError
+
+func (e BucketObjectLockConfigNotFound) Error() string {
+	return "No bucket object lock configuration found for bucket: " + e.Bucket
+}
+
+// BucketQuotaConfigNotFound - no bucket quota configuration found.
+type BucketQuotaConfigNotFound GenericError
+
+func (e BucketQuotaConfigNotFound) Error() string {
+	return "No quota config found for bucket : " + e.Bucket
+}
+
+// BucketQuotaExceeded - bucket quota exceeded.
+type BucketQuotaExceeded GenericError
+
+func (e BucketQuotaExceeded) Error() string {
+	return "Bucket quota exceeded for bucket: " + e.Bucket
+}
+
 /// Bucket related errors.
 
 // BucketNameInvalid - bucketname provided is invalid.
@@ -30,6 +30,10 @@ import (
 type ObjectOptions struct {
 	ServerSideEncryption encrypt.ServerSide
 	UserDefined          map[string]string
+	VersionID            string // Requested object version, empty for the latest version.
+	Versioned            bool   // Bucket has versioning enabled.
+	VersionSuspended     bool   // Bucket has versioning suspended.
+	BypassGovernance     bool   // Object versions in GOVERNANCE mode may be overwritten or deleted.
 }
 
 // LockType represents required locking for ObjectLayer operations
@@ -70,6 +74,17 @@ type ObjectLayer interface {
 	CopyObject(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (objInfo ObjectInfo, err error)
 	DeleteObject(ctx context.Context, bucket, object string) error
 
+	// Object version operations.
+	ListObjectVersions(ctx context.Context, bucket, prefix, keyMarker, versionIDMarker, delimiter string, maxKeys int) (result ListObjectVersionsInfo, err error)
+	DeleteObjectVersion(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error)
+
+	// Object tagging operations, empty tags remove all tags of the object.
+	PutObjectTags(ctx context.Context, bucket, object, tags string, opts ObjectOptions) (objInfo ObjectInfo, err error)
+
+	// Object metadata operations, the given metadata is merged into the
+	// metadata of the object and empty values remove their key.
+	PutObjectMetadata(ctx context.Context, bucket, object string, meta map[string]string, opts ObjectOptions) (objInfo ObjectInfo, err error)
+
 	// Multipart operations.
 	ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (result ListMultipartsInfo, err error)
 	NewMultipartUpload(ctx context.Context, bucket, object string, opts ObjectOptions) (uploadID string, err error)
@@ -100,4 +115,7 @@ type ObjectLayer interface {
 
 	// Compression support check.
 	IsCompressionSupported() bool
+
+	// Versioning support check.
+	IsVersioningSupported() bool
 }
@@ -233,6 +233,22 @@ func cleanMetadataKeys(metadata map[string]string, keyNames ...string) map[strin
 	return newMeta
 }
 
+// updateObjectMetadata merges the updates into the given metadata,
+// updates with an empty value remove their key.
+func updateObjectMetadata(metadata map[string]string, updates map[string]string) map[string]string {
+	if metadata == nil {
+		metadata = make(map[string]string)
+	}
+	for k, v := range updates {
+		if v == "" {
+			delete(metadata, k)
+		} else {
+			metadata[k] = v
+		}
+	}
+	return metadata
+}
+
 // Extracts etag value from the metadata.
 func extractETag(metadata map[string]string) string {
 	// md5Sum tag is kept for backward compatibility.
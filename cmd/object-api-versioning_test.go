@@ -0,0 +1,134 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+// Wrapper for calling object versioning tests for both XL multiple disks and single node setup.
+func TestObjectVersioning(t *testing.T) {
+	ExecObjectLayerTest(t, testObjectVersioning)
+}
+
+// Unit test for writing, reading, listing and deleting object versions.
+func testObjectVersioning(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	bucket, object := "bucket", "object"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	// Object written before versioning was enabled gets the "null" version.
+	contents := []string{"null version", "first version", "second version"}
+	opts := ObjectOptions{}
+	var versionIDs []string
+	for i, content := range contents {
+		objInfo, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewBufferString(content), int64(len(content)), "", ""), opts)
+		if err != nil {
+			t.Fatalf("%s: put %d: %s", instanceType, i+1, err)
+		}
+		if i > 0 && objInfo.VersionID == "" {
+			t.Fatalf("%s: put %d: expected a version ID", instanceType, i+1)
+		}
+		versionIDs = append(versionIDs, getVersionID(objInfo.VersionID))
+		opts = ObjectOptions{Versioned: true}
+	}
+
+	// Every version must be readable by its version ID.
+	for i, versionID := range versionIDs {
+		var buffer bytes.Buffer
+		if err := obj.GetObject(ctx, bucket, object, 0, -1, &buffer, "", ObjectOptions{VersionID: versionID}); err != nil {
+			t.Fatalf("%s: get version %s: %s", instanceType, versionID, err)
+		}
+		if buffer.String() != contents[i] {
+			t.Fatalf("%s: get version %s: expected %q, got %q", instanceType, versionID, contents[i], buffer.String())
+		}
+	}
+
+	if _, err := obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{VersionID: "unknown"}); toAPIErrorCode(ctx, err) != ErrNoSuchVersion {
+		t.Fatalf("%s: expected VersionNotFound, got %v", instanceType, err)
+	}
+
+	// Deleting without a version ID adds a delete marker.
+	marker, err := obj.DeleteObjectVersion(ctx, bucket, object, ObjectOptions{Versioned: true})
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if !marker.DeleteMarker || marker.VersionID == "" {
+		t.Fatalf("%s: expected a delete marker with a version ID, got %+v", instanceType, marker)
+	}
+	if _, err = obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("%s: expected ObjectNotFound, got %v", instanceType, err)
+	}
+
+	result, err := obj.ListObjects(ctx, bucket, "", "", "", 1000)
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if len(result.Objects) != 0 {
+		t.Fatalf("%s: expected no objects, got %d", instanceType, len(result.Objects))
+	}
+
+	versions, err := obj.ListObjectVersions(ctx, bucket, "", "", "", "", 1000)
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	expectedIDs := []string{marker.VersionID, versionIDs[2], versionIDs[1], versionIDs[0]}
+	if len(versions.Objects) != len(expectedIDs) {
+		t.Fatalf("%s: expected %d versions, got %d", instanceType, len(expectedIDs), len(versions.Objects))
+	}
+	for i, version := range versions.Objects {
+		if getVersionID(version.VersionID) != expectedIDs[i] {
+			t.Fatalf("%s: version %d: expected %s, got %s", instanceType, i+1, expectedIDs[i], version.VersionID)
+		}
+		if version.IsLatest != (i == 0) || version.DeleteMarker != (i == 0) {
+			t.Fatalf("%s: version %d: unexpected latest/delete marker state %+v", instanceType, i+1, version)
+		}
+	}
+
+	// Removing the delete marker restores the previous version.
+	if _, err = obj.DeleteObjectVersion(ctx, bucket, object, ObjectOptions{VersionID: marker.VersionID}); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	objInfo, err := obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if objInfo.VersionID != versionIDs[2] {
+		t.Fatalf("%s: expected latest version %s, got %s", instanceType, versionIDs[2], objInfo.VersionID)
+	}
+
+	// Removing all versions removes the object.
+	for _, versionID := range versionIDs {
+		if _, err = obj.DeleteObjectVersion(ctx, bucket, object, ObjectOptions{VersionID: versionID}); err != nil {
+			t.Fatalf("%s: delete version %s: %s", instanceType, versionID, err)
+		}
+	}
+	if _, err = obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("%s: expected ObjectNotFound, got %v", instanceType, err)
+	}
+	versions, err = obj.ListObjectVersions(ctx, bucket, "", "", "", "", 1000)
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if len(versions.Objects) != 0 {
+		t.Fatalf("%s: expected no versions, got %d", instanceType, len(versions.Objects))
+	}
+}
@@ -229,26 +229,45 @@ func isETagEqual(left, right string) bool {
 
 // deleteObject is a convenient wrapper to delete an object, this
 // is a common function to be called from object handlers and
-// web handlers.
-func deleteObject(ctx context.Context, obj ObjectLayer, cache CacheObjectLayer, bucket, object string, r *http.Request) (err error) {
-	deleteObject := obj.DeleteObject
-	if cache != nil {
-		deleteObject = cache.DeleteObject
-	}
-	// Proceed to delete the object.
-	if err = deleteObject(ctx, bucket, object); err != nil {
-		return err
+// web handlers. On buckets with versioning a delete marker is
+// added instead, unless a version ID is requested.
+func deleteObject(ctx context.Context, obj ObjectLayer, cache CacheObjectLayer, bucket, object string, opts ObjectOptions, r *http.Request) (objInfo ObjectInfo, err error) {
+	// A deletion releases the size of the version it removes.
+	prevSize := getQuotaObjectSize(ctx, obj, bucket, object, opts.VersionID)
+
+	if opts.VersionID != "" || opts.Versioned || opts.VersionSuspended || opts.BypassGovernance {
+		// Disk cache validates the latest version against the backend.
+		if objInfo, err = obj.DeleteObjectVersion(ctx, bucket, object, opts); err != nil {
+			return objInfo, err
+		}
+	} else {
+		deleteObject := obj.DeleteObject
+		if cache != nil {
+			deleteObject = cache.DeleteObject
+		}
+		// Proceed to delete the object.
+		if err = deleteObject(ctx, bucket, object); err != nil {
+			return objInfo, err
+		}
+		objInfo = ObjectInfo{Bucket: bucket, Name: object}
 	}
+	globalBucketQuotaSys.update(obj, bucket, -prevSize)
 
 	// Get host and port from Request.RemoteAddr.
 	host, port, _ := net.SplitHostPort(handlers.GetSourceIP(r))
 
-	// Notify object deleted event.
+	// Notify object deleted event, or delete marker created
+	// event if the deletion added a delete marker.
+	eventName := event.ObjectRemovedDelete
+	if opts.VersionID == "" && objInfo.DeleteMarker {
+		eventName = event.ObjectRemovedDeleteMarkerCreated
+	}
 	sendEvent(eventArgs{
-		EventName:  event.ObjectRemovedDelete,
+		EventName:  eventName,
 		BucketName: bucket,
 		Object: ObjectInfo{
-			Name: object,
+			Name:      object,
+			VersionID: objInfo.VersionID,
 		},
 		ReqParams: extractReqParams(r),
 		UserAgent: r.UserAgent(),
@@ -256,5 +275,5 @@ func deleteObject(ctx context.Context, obj ObjectLayer, cache CacheObjectLayer,
 		Port:      port,
 	})
 
-	return nil
+	return objInfo, nil
 }
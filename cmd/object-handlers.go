@@ -267,7 +267,9 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 	bucket := vars["bucket"]
 	object := vars["object"]
 
-	if vid := r.URL.Query().Get("versionId"); vid != "" && vid != "null" {
+	// Only the "null" version is available when versioning is not supported.
+	vid := r.URL.Query().Get("versionId")
+	if vid != "" && vid != nullVersionID && !objectAPI.IsVersioningSupported() {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchVersion), r.URL, guessIsBrowserReq(r))
 		return
 	}
@@ -278,10 +280,18 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 		writeErrorResponseHeadersOnly(w, toAPIError(ctx, err))
 		return
 	}
+	opts.VersionID = vid
+
+	var getObjectAction policy.Action = policy.GetObjectAction
+	if vid != "" {
+		getObjectAction = policy.GetObjectVersionAction
+	}
 
 	// Check for auth type to return S3 compatible error.
 	// type to return the correct error (NoSuchKey vs AccessDenied)
-	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, bucket, object); s3Error != ErrNone {
+	// Tags of the object are needed to evaluate "s3:ExistingObjectTag/<key>" conditions.
+	objectTags := getExistingObjectTags(ctx, objectAPI, bucket, object, opts)
+	if s3Error := checkRequestAuthTypeWithTags(ctx, r, getObjectAction, bucket, object, objectTags); s3Error != ErrNone {
 		if getRequestAuthType(r) == authTypeAnonymous {
 			// As per "Permission" section in
 			// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectGET.html
@@ -303,7 +313,7 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 				IsOwner:         false,
 			}) {
 				getObjectInfo := objectAPI.GetObjectInfo
-				if api.CacheAPI() != nil {
+				if api.CacheAPI() != nil && vid == "" {
 					getObjectInfo = api.CacheAPI().GetObjectInfo
 				}
 
@@ -317,8 +327,9 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// Disk cache only holds the latest version of an object.
 	getObjectNInfo := objectAPI.GetObjectNInfo
-	if api.CacheAPI() != nil {
+	if api.CacheAPI() != nil && vid == "" {
 		getObjectNInfo = api.CacheAPI().GetObjectNInfo
 	}
 
@@ -447,13 +458,16 @@ func (api objectAPIHandlers) HeadObjectHandler(w http.ResponseWriter, r *http.Re
 	bucket := vars["bucket"]
 	object := vars["object"]
 
-	if vid := r.URL.Query().Get("versionId"); vid != "" && vid != "null" {
+	// Only the "null" version is available when versioning is not supported.
+	vid := r.URL.Query().Get("versionId")
+	if vid != "" && vid != nullVersionID && !objectAPI.IsVersioningSupported() {
 		writeErrorResponseHeadersOnly(w, errorCodes.ToAPIErr(ErrNoSuchVersion))
 		return
 	}
 
+	// Disk cache only holds the latest version of an object.
 	getObjectInfo := objectAPI.GetObjectInfo
-	if api.CacheAPI() != nil {
+	if api.CacheAPI() != nil && vid == "" {
 		getObjectInfo = api.CacheAPI().GetObjectInfo
 	}
 
@@ -462,8 +476,16 @@ func (api objectAPIHandlers) HeadObjectHandler(w http.ResponseWriter, r *http.Re
 		writeErrorResponseHeadersOnly(w, toAPIError(ctx, err))
 		return
 	}
+	opts.VersionID = vid
 
-	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, bucket, object); s3Error != ErrNone {
+	var getObjectAction policy.Action = policy.GetObjectAction
+	if vid != "" {
+		getObjectAction = policy.GetObjectVersionAction
+	}
+
+	// Tags of the object are needed to evaluate "s3:ExistingObjectTag/<key>" conditions.
+	objectTags := getExistingObjectTags(ctx, objectAPI, bucket, object, opts)
+	if s3Error := checkRequestAuthTypeWithTags(ctx, r, getObjectAction, bucket, object, objectTags); s3Error != ErrNone {
 		if getRequestAuthType(r) == authTypeAnonymous {
 			// As per "Permission" section in
 			// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectHEAD.html
@@ -679,25 +701,21 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 	// has a version ID. If you have not enabled versioning, Amazon S3 sets the value
 	// of the version ID to null. If you have enabled versioning, Amazon S3 assigns a
 	// unique version ID value for the object.
+	var srcVersionID string
 	if u, err := url.Parse(cpSrcPath); err == nil {
-		// Check if versionId query param was added, if yes then check if
-		// its non "null" value, we should error out since we do not support
-		// any versions other than "null".
-		if vid := u.Query().Get("versionId"); vid != "" && vid != "null" {
-			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchVersion), r.URL, guessIsBrowserReq(r))
-			return
-		}
+		srcVersionID = u.Query().Get("versionId")
 		// Note that url.Parse does the unescaping
 		cpSrcPath = u.Path
 	}
-	if vid := r.Header.Get("X-Amz-Copy-Source-Version-Id"); vid != "" {
-		// Check if versionId header was added, if yes then check if
-		// its non "null" value, we should error out since we do not support
-		// any versions other than "null".
-		if vid != "null" {
-			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchVersion), r.URL, guessIsBrowserReq(r))
-			return
-		}
+	if vid := r.Header.Get(amzCopySourceVersionID); vid != "" {
+		srcVersionID = vid
+	}
+	// Check if a version was requested, if yes then check if its non "null"
+	// value, we should error out if the object layer does not support any
+	// versions other than "null".
+	if srcVersionID != "" && srcVersionID != nullVersionID && !objectAPI.IsVersioningSupported() {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchVersion), r.URL, guessIsBrowserReq(r))
+		return
 	}
 
 	srcBucket, srcObject := path2BucketAndObject(cpSrcPath)
@@ -707,7 +725,17 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, srcBucket, srcObject); s3Error != ErrNone {
+	// The "null" version is the only version of objects in buckets
+	// which never had versioning configured.
+	if srcVersionID == nullVersionID && !globalBucketVersioningSys.Configured(srcBucket) {
+		srcVersionID = ""
+	}
+
+	var getObjectAction policy.Action = policy.GetObjectAction
+	if srcVersionID != "" {
+		getObjectAction = policy.GetObjectVersionAction
+	}
+	if s3Error := checkRequestAuthType(ctx, r, getObjectAction, srcBucket, srcObject); s3Error != ErrNone {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
 		return
 	}
@@ -717,6 +745,21 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidMetadataDirective), r.URL, guessIsBrowserReq(r))
 		return
 	}
+
+	// Check if tagging directive is valid.
+	if !isTagDirectiveValid(r.Header) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidTagDirective), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Replacing the tags of the object also needs the permission to put object tags.
+	isTagReplace := r.Header.Get(amzObjectTagDirective) == amzTagDirectiveReplace
+	if isTagReplace {
+		if s3Error := checkRequestAuthType(ctx, r, policy.PutObjectTaggingAction, dstBucket, dstObject); s3Error != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	}
 	// This request header needs to be set prior to setting ObjectOptions
 	if globalAutoEncryption && !crypto.SSEC.IsRequested(r.Header) {
 		r.Header.Add(crypto.SSEHeader, crypto.SSEAlgorithmAES256)
@@ -729,8 +772,9 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
+	srcOpts.VersionID = srcVersionID
 	// convert copy src encryption options for GET calls
-	var getOpts = ObjectOptions{}
+	var getOpts = ObjectOptions{VersionID: srcVersionID}
 	getSSE := encrypt.SSE(srcOpts.ServerSideEncryption)
 	if getSSE != srcOpts.ServerSideEncryption {
 		getOpts.ServerSideEncryption = getSSE
@@ -741,6 +785,8 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
+	setVersioningOpts(&dstOpts, dstBucket)
+	dstOpts.BypassGovernance = isBypassGovernanceReq(r, dstBucket, dstObject)
 
 	// Deny if WORM is enabled
 	if globalWORMEnabled {
@@ -752,8 +798,9 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 
 	cpSrcDstSame := isStringEqual(pathJoin(srcBucket, srcObject), pathJoin(dstBucket, dstObject))
 
+	// Disk cache only holds the latest version of an object.
 	getObjectNInfo := objectAPI.GetObjectNInfo
-	if api.CacheAPI() != nil {
+	if api.CacheAPI() != nil && srcVersionID == "" {
 		getObjectNInfo = api.CacheAPI().GetObjectNInfo
 	}
 
@@ -783,8 +830,9 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 	}
 
 	// We have to copy metadata only if source and destination are same.
-	// this changes for encryption which can be observed below.
-	if cpSrcDstSame {
+	// this changes for encryption which can be observed below. Copying
+	// an older version onto the same object restores it as a new object.
+	if cpSrcDstSame && srcVersionID == "" {
 		srcInfo.metadataOnly = true
 	}
 
@@ -972,12 +1020,33 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 
 	srcInfo.PutObjReader = pReader
 
+	srcTagging := srcInfo.UserDefined[amzObjectTagging]
 	srcInfo.UserDefined, err = getCpObjMetadataFromHeader(ctx, r, srcInfo.UserDefined)
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
 
+	// Tags of the source object are copied unless x-amz-tagging-directive says REPLACE.
+	if isTagReplace {
+		if s3Error := extractObjectTags(r.Header, srcInfo.UserDefined); s3Error != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	} else {
+		setObjectTags(srcInfo.UserDefined, srcTagging)
+	}
+
+	// The replication status of the source object does not apply to the copy.
+	setReplicationMetadata(isReplicaReq(r, dstBucket, dstObject, policy.ReplicateObjectAction), dstBucket, dstObject, srcInfo.UserDefined)
+
+	// The retention and legal hold of the source object do not apply to the copy.
+	removeObjectLockMetadata(srcInfo.UserDefined)
+	if s3Error := setObjectLockMetadata(r, dstBucket, dstObject, srcInfo.UserDefined); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	// Store the preserved compression metadata.
 	for k, v := range compressMetadata {
 		srcInfo.UserDefined[k] = v
@@ -1028,6 +1097,13 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		objInfo.ETag = remoteObjInfo.ETag
 		objInfo.ModTime = remoteObjInfo.LastModified
 	} else {
+		// Deny copies beyond the hard quota of the destination bucket.
+		prevSize := getQuotaObjectSize(ctx, objectAPI, dstBucket, dstObject, "")
+		if err = globalBucketQuotaSys.enforce(ctx, objectAPI, dstBucket, actualSize-prevSize); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+
 		// Copy source object to destination, if source and destination
 		// object is same then only metadata is updated.
 		objInfo, err = objectAPI.CopyObject(ctx, srcBucket, srcObject, dstBucket, dstObject, srcInfo, srcOpts, dstOpts)
@@ -1035,14 +1111,23 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 			return
 		}
+		globalBucketQuotaSys.update(objectAPI, dstBucket, objInfo.Size-prevSize)
 	}
 
 	response := generateCopyObjectResponse(getDecryptedETag(r.Header, objInfo, false), objInfo.ModTime)
 	encodedSuccessResponse := encodeResponse(response)
 
+	setVersionHeaders(w, objInfo)
+	if srcVersionID != "" {
+		w.Header().Set(amzCopySourceVersionID, srcVersionID)
+	}
+
 	// Write success response.
 	writeSuccessResponseXML(w, encodedSuccessResponse)
 
+	// Replicate the copy asynchronously.
+	queueObjectReplication(ctx, objectAPI, objInfo)
+
 	// Get host and port from Request.RemoteAddr.
 	host, port, err := net.SplitHostPort(handlers.GetSourceIP(r))
 	if err != nil {
@@ -1149,6 +1234,18 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if s3Error := extractObjectTags(r.Header, metadata); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	setReplicationMetadata(isReplicaReq(r, bucket, object, policy.ReplicateObjectAction), bucket, object, metadata)
+
+	if s3Error := setObjectLockMetadata(r, bucket, object, metadata); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	if rAuthType == authTypeStreamingSigned {
 		if contentEncoding, ok := metadata["content-encoding"]; ok {
 			contentEncoding = trimAwsChunkedContentEncoding(contentEncoding)
@@ -1179,11 +1276,27 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 	reader = r.Body
 
 	// Check if put is allowed
-	if s3Err = isPutAllowed(rAuthType, bucket, object, r); s3Err != ErrNone {
+	if s3Err = isPutActionAllowed(rAuthType, bucket, object, r, policy.PutObjectAction); s3Err != ErrNone {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL, guessIsBrowserReq(r))
 		return
 	}
 
+	// Deny writes beyond the hard quota of the bucket, an overwrite
+	// releases the size of the object it replaces.
+	prevSize := getQuotaObjectSize(ctx, objectAPI, bucket, object, "")
+	if err = globalBucketQuotaSys.enforce(ctx, objectAPI, bucket, size-prevSize); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Tagging the object also needs the permission to put object tags.
+	if _, ok := r.Header[amzObjectTagging]; ok {
+		if s3Err = isPutActionAllowed(rAuthType, bucket, object, r, policy.PutObjectTaggingAction); s3Err != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	}
+
 	switch rAuthType {
 	case authTypeStreamingSigned:
 		// Initialize stream signature verifier.
@@ -1200,7 +1313,7 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		}
 
 	case authTypePresigned, authTypeSigned:
-		if s3Err = reqSignatureV4Verify(r, globalServerConfig.GetRegion()); s3Err != ErrNone {
+		if s3Err = reqSignatureV4Verify(r, globalServerConfig.GetRegion(), serviceS3); s3Err != ErrNone {
 			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL, guessIsBrowserReq(r))
 			return
 		}
@@ -1260,6 +1373,8 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		writeErrorResponseHeadersOnly(w, toAPIError(ctx, err))
 		return
 	}
+	setVersioningOpts(&opts, bucket)
+	opts.BypassGovernance = isBypassGovernanceReq(r, bucket, object)
 
 	// Deny if WORM is enabled
 	if globalWORMEnabled {
@@ -1300,6 +1415,7 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
+	globalBucketQuotaSys.update(objectAPI, bucket, objInfo.Size-prevSize)
 
 	etag := objInfo.ETag
 	if objInfo.IsCompressed() {
@@ -1309,6 +1425,7 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		etag = getDecryptedETag(r.Header, objInfo, false)
 	}
 	w.Header().Set("ETag", "\""+etag+"\"")
+	setVersionHeaders(w, objInfo)
 
 	if objectAPI.IsEncryptionSupported() {
 		if crypto.IsEncrypted(objInfo.UserDefined) {
@@ -1325,6 +1442,9 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 
 	writeSuccessResponseHeadersOnly(w)
 
+	// Replicate the object asynchronously.
+	queueObjectReplication(ctx, objectAPI, objInfo)
+
 	// Get host and port from Request.RemoteAddr.
 	host, port, err := net.SplitHostPort(handlers.GetSourceIP(r))
 	if err != nil {
@@ -1379,6 +1499,14 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 		return
 	}
 
+	// Tagging the object also needs the permission to put object tags.
+	if _, ok := r.Header[amzObjectTagging]; ok {
+		if s3Error := checkRequestAuthType(ctx, r, policy.PutObjectTaggingAction, bucket, object); s3Error != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	}
+
 	// This request header needs to be set prior to setting ObjectOptions
 	if globalAutoEncryption && !crypto.SSEC.IsRequested(r.Header) {
 		r.Header.Add(crypto.SSEHeader, crypto.SSEAlgorithmAES256)
@@ -1431,6 +1559,18 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 		return
 	}
 
+	if s3Error := extractObjectTags(r.Header, metadata); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	setReplicationMetadata(isReplicaReq(r, bucket, object, policy.ReplicateObjectAction), bucket, object, metadata)
+
+	if s3Error := setObjectLockMetadata(r, bucket, object, metadata); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	// We need to preserve the encryption headers set in EncryptRequest,
 	// so we do not want to override them, copy them instead.
 	for k, v := range encMetadata {
@@ -1504,25 +1644,21 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 	// has a version ID. If you have not enabled versioning, Amazon S3 sets the value
 	// of the version ID to null. If you have enabled versioning, Amazon S3 assigns a
 	// unique version ID value for the object.
+	var srcVersionID string
 	if u, err := url.Parse(cpSrcPath); err == nil {
-		// Check if versionId query param was added, if yes then check if
-		// its non "null" value, we should error out since we do not support
-		// any versions other than "null".
-		if vid := u.Query().Get("versionId"); vid != "" && vid != "null" {
-			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchVersion), r.URL, guessIsBrowserReq(r))
-			return
-		}
+		srcVersionID = u.Query().Get("versionId")
 		// Note that url.Parse does the unescaping
 		cpSrcPath = u.Path
 	}
-	if vid := r.Header.Get("X-Amz-Copy-Source-Version-Id"); vid != "" {
-		// Check if X-Amz-Copy-Source-Version-Id header was added, if yes then check if
-		// its non "null" value, we should error out since we do not support
-		// any versions other than "null".
-		if vid != "null" {
-			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchVersion), r.URL, guessIsBrowserReq(r))
-			return
-		}
+	if vid := r.Header.Get(amzCopySourceVersionID); vid != "" {
+		srcVersionID = vid
+	}
+	// Check if a version was requested, if yes then check if its non "null"
+	// value, we should error out if the object layer does not support any
+	// versions other than "null".
+	if srcVersionID != "" && srcVersionID != nullVersionID && !objectAPI.IsVersioningSupported() {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchVersion), r.URL, guessIsBrowserReq(r))
+		return
 	}
 
 	srcBucket, srcObject := path2BucketAndObject(cpSrcPath)
@@ -1532,7 +1668,17 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 		return
 	}
 
-	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, srcBucket, srcObject); s3Error != ErrNone {
+	// The "null" version is the only version of objects in buckets
+	// which never had versioning configured.
+	if srcVersionID == nullVersionID && !globalBucketVersioningSys.Configured(srcBucket) {
+		srcVersionID = ""
+	}
+
+	var getObjectAction policy.Action = policy.GetObjectAction
+	if srcVersionID != "" {
+		getObjectAction = policy.GetObjectVersionAction
+	}
+	if s3Error := checkRequestAuthType(ctx, r, getObjectAction, srcBucket, srcObject); s3Error != ErrNone {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
 		return
 	}
@@ -1558,8 +1704,9 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
+	srcOpts.VersionID = srcVersionID
 	// convert copy src and dst encryption options for GET/PUT calls
-	var getOpts = ObjectOptions{}
+	var getOpts = ObjectOptions{VersionID: srcVersionID}
 	if srcOpts.ServerSideEncryption != nil {
 		getOpts.ServerSideEncryption = encrypt.SSE(srcOpts.ServerSideEncryption)
 	}
@@ -1577,8 +1724,9 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 		}
 	}
 
+	// Disk cache only holds the latest version of an object.
 	getObjectNInfo := objectAPI.GetObjectNInfo
-	if api.CacheAPI() != nil {
+	if api.CacheAPI() != nil && srcVersionID == "" {
 		getObjectNInfo = api.CacheAPI().GetObjectNInfo
 	}
 
@@ -1758,6 +1906,10 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 	response := generateCopyObjectPartResponse(partInfo.ETag, partInfo.LastModified)
 	encodedSuccessResponse := encodeResponse(response)
 
+	if srcVersionID != "" {
+		w.Header().Set(amzCopySourceVersionID, srcVersionID)
+	}
+
 	// Write success response.
 	writeSuccessResponseXML(w, encodedSuccessResponse)
 }
@@ -1849,7 +2001,7 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 		s3Error   APIErrorCode
 	)
 	reader = r.Body
-	if s3Error = isPutAllowed(rAuthType, bucket, object, r); s3Error != ErrNone {
+	if s3Error = isPutActionAllowed(rAuthType, bucket, object, r, policy.PutObjectAction); s3Error != ErrNone {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
 		return
 	}
@@ -1868,7 +2020,7 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 			return
 		}
 	case authTypePresigned, authTypeSigned:
-		if s3Error = reqSignatureV4Verify(r, globalServerConfig.GetRegion()); s3Error != ErrNone {
+		if s3Error = reqSignatureV4Verify(r, globalServerConfig.GetRegion(), serviceS3); s3Error != ErrNone {
 			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
 			return
 		}
@@ -2330,6 +2482,21 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 		completeParts = append(completeParts, part)
 	}
 
+	// Deny completing uploads beyond the hard quota of the bucket, an
+	// overwrite releases the size of the object it replaces.
+	size, err := getCompletePartsSize(ctx, objectAPI, bucket, object, uploadID, completeParts)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	prevSize := getQuotaObjectSize(ctx, objectAPI, bucket, object, "")
+	if err = globalBucketQuotaSys.enforce(ctx, objectAPI, bucket, size-prevSize); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	setVersioningOpts(&opts, bucket)
+	opts.BypassGovernance = isBypassGovernanceReq(r, bucket, object)
 	completeMultiPartUpload := objectAPI.CompleteMultipartUpload
 	if api.CacheAPI() != nil {
 		completeMultiPartUpload = api.CacheAPI().CompleteMultipartUpload
@@ -2368,6 +2535,7 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 		}
 		return
 	}
+	globalBucketQuotaSys.update(objectAPI, bucket, objInfo.Size-prevSize)
 
 	// Get object location.
 	location := getObjectLocation(r, globalDomainNames, bucket, object)
@@ -2386,10 +2554,14 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 
 	// Set etag.
 	w.Header().Set("ETag", "\""+objInfo.ETag+"\"")
+	setVersionHeaders(w, objInfo)
 
 	// Write success response.
 	writeSuccessResponseXML(w, encodedSuccessResponse)
 
+	// Replicate the object asynchronously.
+	queueObjectReplication(ctx, objectAPI, objInfo)
+
 	// Get host and port from Request.RemoteAddr.
 	host, port, err := net.SplitHostPort(handlers.GetSourceIP(r))
 	if err != nil {
@@ -2432,12 +2604,19 @@ func (api objectAPIHandlers) DeleteObjectHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
-	if s3Error := checkRequestAuthType(ctx, r, policy.DeleteObjectAction, bucket, object); s3Error != ErrNone {
+	vid := r.URL.Query().Get("versionId")
+	var deleteObjectAction policy.Action = policy.DeleteObjectAction
+	if vid != "" {
+		deleteObjectAction = policy.DeleteObjectVersionAction
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, deleteObjectAction, bucket, object); s3Error != ErrNone {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
 		return
 	}
 
-	if vid := r.URL.Query().Get("versionId"); vid != "" && vid != "null" {
+	// Only the "null" version is available when versioning is not supported.
+	if vid != "" && vid != nullVersionID && !objectAPI.IsVersioningSupported() {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchVersion), r.URL, guessIsBrowserReq(r))
 		return
 	}
@@ -2458,15 +2637,22 @@ func (api objectAPIHandlers) DeleteObjectHandler(w http.ResponseWriter, r *http.
 		}
 	}
 
+	opts := ObjectOptions{VersionID: vid, BypassGovernance: isBypassGovernanceReq(r, bucket, object)}
+	setVersioningOpts(&opts, bucket)
+
 	// http://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectDELETE.html
-	if err := deleteObject(ctx, objectAPI, api.CacheAPI(), bucket, object, r); err != nil {
+	objInfo, err := deleteObject(ctx, objectAPI, api.CacheAPI(), bucket, object, opts, r)
+	if err != nil {
 		switch err.(type) {
-		case BucketNotFound:
-			// When bucket doesn't exist specially handle it.
+		case BucketNotFound, ObjectLocked:
+			// When bucket doesn't exist or object is protected by object lock specially handle it.
 			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 			return
 		}
 		// Ignore delete object errors while replying to client, since we are suppposed to reply only 204.
+	} else {
+		setVersionHeaders(w, objInfo)
+		queueDeleteReplication(ctx, objectAPI, r, bucket, object, vid)
 	}
 	writeSuccessNoContent(w)
 }
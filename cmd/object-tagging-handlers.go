@@ -0,0 +1,233 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"io"
+	"net"
+	"net/http"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/handlers"
+	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/tags"
+)
+
+const (
+	// Tagging configuration is limited to 50 tags, which comfortably fit in 1MiB.
+	maxTaggingConfigSize = 1 * humanize.MiByte
+)
+
+// getObjectTaggingOpts - returns the object options and the policy action of
+// an object tagging request, the versioned action is used with a version ID.
+func getObjectTaggingOpts(r *http.Request, action, versionAction policy.Action) (ObjectOptions, policy.Action) {
+	vid := r.URL.Query().Get("versionId")
+	if vid != "" {
+		action = versionAction
+	}
+	return ObjectOptions{VersionID: vid}, action
+}
+
+// PutObjectTaggingHandler - This HTTP handler replaces the tags of an object as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectPUTtagging.html
+func (api objectAPIHandlers) PutObjectTaggingHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutObjectTagging")
+
+	defer logger.AuditLog(w, r, "PutObjectTagging", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := vars["object"]
+
+	opts, action := getObjectTaggingOpts(r, policy.PutObjectTaggingAction, policy.PutObjectVersionTaggingAction)
+	if opts.VersionID != "" && opts.VersionID != nullVersionID && !objAPI.IsVersioningSupported() {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchVersion), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	objectTags := getExistingObjectTags(ctx, objAPI, bucket, object, opts)
+	if s3Error := checkRequestAuthTypeWithTags(ctx, r, action, bucket, object, objectTags); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// PutObjectTagging always needs a Content-Length.
+	if r.ContentLength <= 0 {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMissingContentLength), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Error out if Content-Length is beyond allowed size.
+	if r.ContentLength > maxTaggingConfigSize {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrEntityTooLarge), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	t, err := tags.ParseObjectXML(io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(toTagsAPIErrorCode(err)), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	objInfo, err := objAPI.PutObjectTags(ctx, bucket, object, t.String(), opts)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	setVersionHeaders(w, objInfo)
+
+	// Success.
+	writeSuccessResponseHeadersOnly(w)
+
+	// Get host and port from Request.RemoteAddr.
+	host, port, _ := net.SplitHostPort(handlers.GetSourceIP(r))
+
+	// Notify object metadata changed event.
+	sendEvent(eventArgs{
+		EventName:    event.ObjectCreatedPutTagging,
+		BucketName:   bucket,
+		Object:       objInfo,
+		ReqParams:    extractReqParams(r),
+		RespElements: extractRespElements(w),
+		UserAgent:    r.UserAgent(),
+		Host:         host,
+		Port:         port,
+	})
+}
+
+// GetObjectTaggingHandler - This HTTP handler returns the tags of an object.
+func (api objectAPIHandlers) GetObjectTaggingHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetObjectTagging")
+
+	defer logger.AuditLog(w, r, "GetObjectTagging", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := vars["object"]
+
+	opts, action := getObjectTaggingOpts(r, policy.GetObjectTaggingAction, policy.GetObjectVersionTaggingAction)
+	if opts.VersionID != "" && opts.VersionID != nullVersionID && !objAPI.IsVersioningSupported() {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchVersion), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	objectTags := getExistingObjectTags(ctx, objAPI, bucket, object, opts)
+	if s3Error := checkRequestAuthTypeWithTags(ctx, r, action, bucket, object, objectTags); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	objInfo, err := objAPI.GetObjectInfo(ctx, bucket, object, opts)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	t, err := getObjectTags(objInfo)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// If xml namespace is empty, set a default value before returning.
+	if t.XMLNS == "" {
+		t.XMLNS = "http://s3.amazonaws.com/doc/2006-03-01/"
+	}
+
+	tagsBytes, err := xml.Marshal(t)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	setVersionHeaders(w, objInfo)
+
+	// Write success response.
+	writeSuccessResponseXML(w, tagsBytes)
+}
+
+// DeleteObjectTaggingHandler - This HTTP handler removes all tags of an object.
+func (api objectAPIHandlers) DeleteObjectTaggingHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DeleteObjectTagging")
+
+	defer logger.AuditLog(w, r, "DeleteObjectTagging", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := vars["object"]
+
+	opts, action := getObjectTaggingOpts(r, policy.DeleteObjectTaggingAction, policy.DeleteObjectVersionTaggingAction)
+	if opts.VersionID != "" && opts.VersionID != nullVersionID && !objAPI.IsVersioningSupported() {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchVersion), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	objectTags := getExistingObjectTags(ctx, objAPI, bucket, object, opts)
+	if s3Error := checkRequestAuthTypeWithTags(ctx, r, action, bucket, object, objectTags); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	objInfo, err := objAPI.PutObjectTags(ctx, bucket, object, "", opts)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	setVersionHeaders(w, objInfo)
+
+	// Success.
+	writeSuccessNoContent(w)
+
+	// Get host and port from Request.RemoteAddr.
+	host, port, _ := net.SplitHostPort(handlers.GetSourceIP(r))
+
+	// Notify object metadata changed event.
+	sendEvent(eventArgs{
+		EventName:    event.ObjectCreatedDeleteTagging,
+		BucketName:   bucket,
+		Object:       objInfo,
+		ReqParams:    extractReqParams(r),
+		RespElements: extractRespElements(w),
+		UserAgent:    r.UserAgent(),
+		Host:         host,
+		Port:         port,
+	})
+}
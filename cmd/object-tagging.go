@@ -0,0 +1,109 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/minio/minio/pkg/policy/condition"
+	"github.com/minio/minio/pkg/tags"
+)
+
+const (
+	// Tagging related request and response headers, the URL encoded tags
+	// of an object are stored in its metadata under amzObjectTagging.
+	amzObjectTagging       = "X-Amz-Tagging"
+	amzObjectTaggingCount  = "X-Amz-Tagging-Count"
+	amzObjectTagDirective  = "X-Amz-Tagging-Directive"
+	amzTagDirectiveCopy    = "COPY"
+	amzTagDirectiveReplace = "REPLACE"
+)
+
+// getObjectTags - returns the tags stored in the metadata of the object.
+func getObjectTags(objInfo ObjectInfo) (*tags.Tags, error) {
+	return tags.ParseObjectTags(objInfo.UserDefined[amzObjectTagging])
+}
+
+// setObjectTags - sets the URL encoded tags in the given object metadata,
+// empty tags remove all tags.
+func setObjectTags(meta map[string]string, tagging string) map[string]string {
+	if meta == nil {
+		meta = make(map[string]string)
+	}
+	if tagging == "" {
+		delete(meta, amzObjectTagging)
+	} else {
+		meta[amzObjectTagging] = tagging
+	}
+	return meta
+}
+
+// extractObjectTags - validates the tags sent in x-amz-tagging header and
+// adds them to the given object metadata.
+func extractObjectTags(h http.Header, metadata map[string]string) APIErrorCode {
+	t, err := tags.ParseObjectTags(h.Get(amzObjectTagging))
+	if err != nil {
+		return ErrInvalidTag
+	}
+	setObjectTags(metadata, t.String())
+	return ErrNone
+}
+
+// isTagDirectiveValid - checks if x-amz-tagging-directive is valid, a
+// missing directive copies the tags of the source object.
+func isTagDirectiveValid(h http.Header) bool {
+	if _, ok := h[amzObjectTagDirective]; !ok {
+		return true
+	}
+	directive := h.Get(amzObjectTagDirective)
+	return directive == amzTagDirectiveCopy || directive == amzTagDirectiveReplace
+}
+
+// getExistingObjectTags - returns the tags of the requested version of the
+// object, to evaluate "s3:ExistingObjectTag/<key>" policy conditions. Errors
+// are ignored, the handler reports them once the request is authorized.
+func getExistingObjectTags(ctx context.Context, objAPI ObjectLayer, bucket, object string, opts ObjectOptions) map[string]string {
+	objInfo, err := objAPI.GetObjectInfo(ctx, bucket, object, opts)
+	if err != nil {
+		return nil
+	}
+	t, err := getObjectTags(objInfo)
+	if err != nil {
+		return nil
+	}
+	return t.ToMap()
+}
+
+// addExistingObjectTags - adds the tags of the existing object to the
+// condition values of a policy evaluation.
+func addExistingObjectTags(conditionValues map[string][]string, objectTags map[string]string) map[string][]string {
+	for key, value := range objectTags {
+		conditionValues[condition.ExistingObjectTagKey(key).Name()] = []string{value}
+	}
+	return conditionValues
+}
+
+// toTagsAPIErrorCode - converts tagging configuration parsing errors to
+// API error codes.
+func toTagsAPIErrorCode(err error) APIErrorCode {
+	switch err {
+	case tags.ErrTooManyTags, tags.ErrInvalidTagKey, tags.ErrInvalidTagValue, tags.ErrDuplicateTagKey:
+		return ErrInvalidTag
+	}
+	return ErrMalformedXML
+}
@@ -0,0 +1,81 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+// Wrapper for calling object tagging tests for both XL multiple disks and single node setup.
+func TestPutObjectTags(t *testing.T) {
+	ExecObjectLayerTest(t, testPutObjectTags)
+}
+
+// Unit test for setting and removing tags of objects and object versions.
+func testPutObjectTags(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	bucket, object := "bucket", "object"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	if _, err := obj.PutObjectTags(ctx, bucket, object, "env=dev", ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("%s: expected ObjectNotFound, got %v", instanceType, err)
+	}
+
+	// Tags sent while creating the object are stored in its metadata.
+	metadata := map[string]string{amzObjectTagging: "env=dev"}
+	first, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewBufferString("first"), int64(len("first")), "", ""), ObjectOptions{UserDefined: metadata, Versioned: true})
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	if _, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewBufferString("second"), int64(len("second")), "", ""), ObjectOptions{Versioned: true}); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	expectTags := func(opts ObjectOptions, expected string) {
+		objInfo, err := obj.GetObjectInfo(ctx, bucket, object, opts)
+		if err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+		if objInfo.UserDefined[amzObjectTagging] != expected {
+			t.Fatalf("%s: version %q: expected tags %q, got %q", instanceType, opts.VersionID, expected, objInfo.UserDefined[amzObjectTagging])
+		}
+	}
+	expectTags(ObjectOptions{}, "")
+	expectTags(ObjectOptions{VersionID: first.VersionID}, "env=dev")
+
+	// Tags of the latest version do not change the tags of older versions.
+	if _, err = obj.PutObjectTags(ctx, bucket, object, "env=prod&team=storage", ObjectOptions{}); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	expectTags(ObjectOptions{}, "env=prod&team=storage")
+	expectTags(ObjectOptions{VersionID: first.VersionID}, "env=dev")
+
+	// Empty tags remove all tags of a version.
+	if _, err = obj.PutObjectTags(ctx, bucket, object, "", ObjectOptions{VersionID: first.VersionID}); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	expectTags(ObjectOptions{VersionID: first.VersionID}, "")
+	expectTags(ObjectOptions{}, "env=prod&team=storage")
+
+	if _, err = obj.PutObjectTags(ctx, bucket, object, "env=dev", ObjectOptions{VersionID: "unknown"}); toAPIErrorCode(ctx, err) != ErrNoSuchVersion {
+		t.Fatalf("%s: expected VersionNotFound, got %v", instanceType, err)
+	}
+}
@@ -19,11 +19,20 @@ package cmd
 import (
 	"context"
 	"crypto/tls"
+	"time"
 
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/accesslog"
+	"github.com/minio/minio/pkg/cors"
 	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/lifecycle"
+	"github.com/minio/minio/pkg/madmin"
 	xnet "github.com/minio/minio/pkg/net"
+	"github.com/minio/minio/pkg/objectlock"
 	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/replication"
+	"github.com/minio/minio/pkg/versioning"
+	"github.com/minio/minio/pkg/website"
 )
 
 // PeerRPCClient - peer RPC client talks to peer RPC server.
@@ -57,6 +66,151 @@ func (rpcClient *PeerRPCClient) RemoveBucketPolicy(bucketName string) error {
 	return rpcClient.Call(peerServiceName+".RemoveBucketPolicy", &args, &reply)
 }
 
+// SetBucketVersioning - calls set bucket versioning RPC.
+func (rpcClient *PeerRPCClient) SetBucketVersioning(bucketName string, v *versioning.Versioning) error {
+	args := SetBucketVersioningArgs{
+		BucketName: bucketName,
+		Versioning: *v,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".SetBucketVersioning", &args, &reply)
+}
+
+// SetBucketLifecycle - calls set bucket lifecycle RPC.
+func (rpcClient *PeerRPCClient) SetBucketLifecycle(bucketName string, lc *lifecycle.Lifecycle) error {
+	args := SetBucketLifecycleArgs{
+		BucketName: bucketName,
+		Lifecycle:  *lc,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".SetBucketLifecycle", &args, &reply)
+}
+
+// RemoveBucketLifecycle - calls remove bucket lifecycle RPC.
+func (rpcClient *PeerRPCClient) RemoveBucketLifecycle(bucketName string) error {
+	args := RemoveBucketLifecycleArgs{
+		BucketName: bucketName,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".RemoveBucketLifecycle", &args, &reply)
+}
+
+// SetBucketCors - calls set bucket CORS RPC.
+func (rpcClient *PeerRPCClient) SetBucketCors(bucketName string, config *cors.Config) error {
+	args := SetBucketCorsArgs{
+		BucketName: bucketName,
+		Config:     *config,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".SetBucketCors", &args, &reply)
+}
+
+// RemoveBucketCors - calls remove bucket CORS RPC.
+func (rpcClient *PeerRPCClient) RemoveBucketCors(bucketName string) error {
+	args := RemoveBucketCorsArgs{
+		BucketName: bucketName,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".RemoveBucketCors", &args, &reply)
+}
+
+// SetBucketWebsite - calls set bucket website RPC.
+func (rpcClient *PeerRPCClient) SetBucketWebsite(bucketName string, config *website.Config) error {
+	args := SetBucketWebsiteArgs{
+		BucketName: bucketName,
+		Config:     *config,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".SetBucketWebsite", &args, &reply)
+}
+
+// RemoveBucketWebsite - calls remove bucket website RPC.
+func (rpcClient *PeerRPCClient) RemoveBucketWebsite(bucketName string) error {
+	args := RemoveBucketWebsiteArgs{
+		BucketName: bucketName,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".RemoveBucketWebsite", &args, &reply)
+}
+
+// SetBucketLogging - calls set bucket logging RPC.
+func (rpcClient *PeerRPCClient) SetBucketLogging(bucketName string, config *accesslog.Config) error {
+	args := SetBucketLoggingArgs{
+		BucketName: bucketName,
+		Config:     *config,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".SetBucketLogging", &args, &reply)
+}
+
+// RemoveBucketLogging - calls remove bucket logging RPC.
+func (rpcClient *PeerRPCClient) RemoveBucketLogging(bucketName string) error {
+	args := RemoveBucketLoggingArgs{
+		BucketName: bucketName,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".RemoveBucketLogging", &args, &reply)
+}
+
+// SetBucketReplication - calls set bucket replication RPC.
+func (rpcClient *PeerRPCClient) SetBucketReplication(bucketName string, config *replication.Config) error {
+	args := SetBucketReplicationArgs{
+		BucketName: bucketName,
+		Config:     *config,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".SetBucketReplication", &args, &reply)
+}
+
+// SetBucketObjectLock - calls set bucket object lock RPC.
+func (rpcClient *PeerRPCClient) SetBucketObjectLock(bucketName string, config *objectlock.Config) error {
+	args := SetBucketObjectLockArgs{
+		BucketName: bucketName,
+		Config:     *config,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".SetBucketObjectLock", &args, &reply)
+}
+
+// SetBucketQuota - calls set bucket quota RPC.
+func (rpcClient *PeerRPCClient) SetBucketQuota(bucketName string, quota *madmin.BucketQuota) error {
+	args := SetBucketQuotaArgs{
+		BucketName: bucketName,
+		Quota:      *quota,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".SetBucketQuota", &args, &reply)
+}
+
+// UpdateBucketUsage - calls update bucket usage RPC.
+func (rpcClient *PeerRPCClient) UpdateBucketUsage(bucketName string, size int64, at time.Time) error {
+	args := UpdateBucketUsageArgs{
+		BucketName: bucketName,
+		Size:       size,
+		Time:       at,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".UpdateBucketUsage", &args, &reply)
+}
+
+// RemoveBucketQuota - calls remove bucket quota RPC.
+func (rpcClient *PeerRPCClient) RemoveBucketQuota(bucketName string) error {
+	args := RemoveBucketQuotaArgs{
+		BucketName: bucketName,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".RemoveBucketQuota", &args, &reply)
+}
+
+// RemoveBucketReplication - calls remove bucket replication RPC.
+func (rpcClient *PeerRPCClient) RemoveBucketReplication(bucketName string) error {
+	args := RemoveBucketReplicationArgs{
+		BucketName: bucketName,
+	}
+	reply := VoidReply{}
+	return rpcClient.Call(peerServiceName+".RemoveBucketReplication", &args, &reply)
+}
+
 // PutBucketNotification - calls put bukcet notification RPC.
 func (rpcClient *PeerRPCClient) PutBucketNotification(bucketName string, rulesMap event.RulesMap) error {
 	args := PutBucketNotificationArgs{
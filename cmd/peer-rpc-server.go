@@ -26,9 +26,17 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/minio/minio/cmd/logger"
 	xrpc "github.com/minio/minio/cmd/rpc"
+	"github.com/minio/minio/pkg/accesslog"
+	"github.com/minio/minio/pkg/cors"
 	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/lifecycle"
+	"github.com/minio/minio/pkg/madmin"
 	xnet "github.com/minio/minio/pkg/net"
+	"github.com/minio/minio/pkg/objectlock"
 	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/replication"
+	"github.com/minio/minio/pkg/versioning"
+	"github.com/minio/minio/pkg/website"
 )
 
 const peerServiceName = "Peer"
@@ -54,6 +62,14 @@ func (receiver *peerRPCReceiver) DeleteBucket(args *DeleteBucketArgs, reply *Voi
 
 	globalNotificationSys.RemoveNotification(args.BucketName)
 	globalPolicySys.Remove(args.BucketName)
+	globalBucketVersioningSys.Remove(args.BucketName)
+	globalLifecycleSys.Remove(args.BucketName)
+	globalBucketCorsSys.Remove(args.BucketName)
+	globalBucketWebsiteSys.Remove(args.BucketName)
+	globalBucketLoggingSys.Remove(args.BucketName)
+	globalReplicationSys.Remove(args.BucketName)
+	globalBucketObjectLockSys.Remove(args.BucketName)
+	globalBucketQuotaSys.Remove(args.BucketName)
 	return nil
 }
 
@@ -92,6 +108,271 @@ func (receiver *peerRPCReceiver) RemoveBucketPolicy(args *RemoveBucketPolicyArgs
 	return nil
 }
 
+// SetBucketVersioningArgs - set bucket versioning RPC arguments.
+type SetBucketVersioningArgs struct {
+	AuthArgs
+	BucketName string
+	Versioning versioning.Versioning
+}
+
+// SetBucketVersioning - handles set bucket versioning RPC call which adds bucket versioning to globalBucketVersioningSys.
+func (receiver *peerRPCReceiver) SetBucketVersioning(args *SetBucketVersioningArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	globalBucketVersioningSys.Set(args.BucketName, args.Versioning)
+	return nil
+}
+
+// SetBucketLifecycleArgs - set bucket lifecycle RPC arguments.
+type SetBucketLifecycleArgs struct {
+	AuthArgs
+	BucketName string
+	Lifecycle  lifecycle.Lifecycle
+}
+
+// SetBucketLifecycle - handles set bucket lifecycle RPC call which adds bucket lifecycle to globalLifecycleSys.
+func (receiver *peerRPCReceiver) SetBucketLifecycle(args *SetBucketLifecycleArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	globalLifecycleSys.Set(args.BucketName, args.Lifecycle)
+	return nil
+}
+
+// RemoveBucketLifecycleArgs - delete bucket lifecycle RPC arguments.
+type RemoveBucketLifecycleArgs struct {
+	AuthArgs
+	BucketName string
+}
+
+// RemoveBucketLifecycle - handles delete bucket lifecycle RPC call which removes bucket lifecycle from globalLifecycleSys.
+func (receiver *peerRPCReceiver) RemoveBucketLifecycle(args *RemoveBucketLifecycleArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	globalLifecycleSys.Remove(args.BucketName)
+	return nil
+}
+
+// SetBucketCorsArgs - set bucket CORS RPC arguments.
+type SetBucketCorsArgs struct {
+	AuthArgs
+	BucketName string
+	Config     cors.Config
+}
+
+// SetBucketCors - handles set bucket CORS RPC call which adds bucket CORS configuration to globalBucketCorsSys.
+func (receiver *peerRPCReceiver) SetBucketCors(args *SetBucketCorsArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	globalBucketCorsSys.Set(args.BucketName, args.Config)
+	return nil
+}
+
+// RemoveBucketCorsArgs - delete bucket CORS RPC arguments.
+type RemoveBucketCorsArgs struct {
+	AuthArgs
+	BucketName string
+}
+
+// RemoveBucketCors - handles delete bucket CORS RPC call which removes bucket CORS configuration from globalBucketCorsSys.
+func (receiver *peerRPCReceiver) RemoveBucketCors(args *RemoveBucketCorsArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	globalBucketCorsSys.Remove(args.BucketName)
+	return nil
+}
+
+// SetBucketWebsiteArgs - set bucket website RPC arguments.
+type SetBucketWebsiteArgs struct {
+	AuthArgs
+	BucketName string
+	Config     website.Config
+}
+
+// SetBucketWebsite - handles set bucket website RPC call which adds bucket website configuration to globalBucketWebsiteSys.
+func (receiver *peerRPCReceiver) SetBucketWebsite(args *SetBucketWebsiteArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	globalBucketWebsiteSys.Set(args.BucketName, args.Config)
+	return nil
+}
+
+// RemoveBucketWebsiteArgs - delete bucket website RPC arguments.
+type RemoveBucketWebsiteArgs struct {
+	AuthArgs
+	BucketName string
+}
+
+// RemoveBucketWebsite - handles delete bucket website RPC call which removes bucket website configuration from globalBucketWebsiteSys.
+func (receiver *peerRPCReceiver) RemoveBucketWebsite(args *RemoveBucketWebsiteArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	globalBucketWebsiteSys.Remove(args.BucketName)
+	return nil
+}
+
+// SetBucketLoggingArgs - set bucket logging RPC arguments.
+type SetBucketLoggingArgs struct {
+	AuthArgs
+	BucketName string
+	Config     accesslog.Config
+}
+
+// SetBucketLogging - handles set bucket logging RPC call which adds bucket logging configuration to globalBucketLoggingSys.
+func (receiver *peerRPCReceiver) SetBucketLogging(args *SetBucketLoggingArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	globalBucketLoggingSys.Set(args.BucketName, args.Config)
+	return nil
+}
+
+// RemoveBucketLoggingArgs - delete bucket logging RPC arguments.
+type RemoveBucketLoggingArgs struct {
+	AuthArgs
+	BucketName string
+}
+
+// RemoveBucketLogging - handles delete bucket logging RPC call which removes bucket logging configuration from globalBucketLoggingSys.
+func (receiver *peerRPCReceiver) RemoveBucketLogging(args *RemoveBucketLoggingArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	globalBucketLoggingSys.Remove(args.BucketName)
+	return nil
+}
+
+// SetBucketReplicationArgs - set bucket replication RPC arguments.
+type SetBucketReplicationArgs struct {
+	AuthArgs
+	BucketName string
+	Config     replication.Config
+}
+
+// SetBucketReplication - handles set bucket replication RPC call which adds bucket replication configuration to globalReplicationSys.
+func (receiver *peerRPCReceiver) SetBucketReplication(args *SetBucketReplicationArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	globalReplicationSys.Set(args.BucketName, args.Config)
+	return nil
+}
+
+// SetBucketObjectLockArgs - set bucket object lock RPC arguments.
+type SetBucketObjectLockArgs struct {
+	AuthArgs
+	BucketName string
+	Config     objectlock.Config
+}
+
+// SetBucketObjectLock - handles set bucket object lock RPC call which adds bucket object lock configuration to globalBucketObjectLockSys.
+func (receiver *peerRPCReceiver) SetBucketObjectLock(args *SetBucketObjectLockArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	globalBucketObjectLockSys.Set(args.BucketName, args.Config)
+	return nil
+}
+
+// SetBucketQuotaArgs - set bucket quota RPC arguments.
+type SetBucketQuotaArgs struct {
+	AuthArgs
+	BucketName string
+	Quota      madmin.BucketQuota
+}
+
+// SetBucketQuota - handles set bucket quota RPC call which adds bucket quota configuration to globalBucketQuotaSys.
+func (receiver *peerRPCReceiver) SetBucketQuota(args *SetBucketQuotaArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	globalBucketQuotaSys.Set(args.BucketName, args.Quota)
+	return nil
+}
+
+// UpdateBucketUsageArgs - update bucket usage RPC arguments.
+type UpdateBucketUsageArgs struct {
+	AuthArgs
+	BucketName string
+	Size       int64
+	Time       time.Time
+}
+
+// UpdateBucketUsage - handles update bucket usage RPC call which accounts the size written to a bucket in globalBucketQuotaSys.
+func (receiver *peerRPCReceiver) UpdateBucketUsage(args *UpdateBucketUsageArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	globalBucketQuotaSys.addUsage(args.BucketName, args.Size, args.Time)
+	return nil
+}
+
+// RemoveBucketQuotaArgs - delete bucket quota RPC arguments.
+type RemoveBucketQuotaArgs struct {
+	AuthArgs
+	BucketName string
+}
+
+// RemoveBucketQuota - handles delete bucket quota RPC call which removes bucket quota configuration from globalBucketQuotaSys.
+func (receiver *peerRPCReceiver) RemoveBucketQuota(args *RemoveBucketQuotaArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	globalBucketQuotaSys.Remove(args.BucketName)
+	return nil
+}
+
+// RemoveBucketReplicationArgs - delete bucket replication RPC arguments.
+type RemoveBucketReplicationArgs struct {
+	AuthArgs
+	BucketName string
+}
+
+// RemoveBucketReplication - handles delete bucket replication RPC call which removes bucket replication configuration from globalReplicationSys.
+func (receiver *peerRPCReceiver) RemoveBucketReplication(args *RemoveBucketReplicationArgs, reply *VoidReply) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	globalReplicationSys.Remove(args.BucketName)
+	return nil
+}
+
 // PutBucketNotificationArgs - put bucket notification RPC arguments.
 type PutBucketNotificationArgs struct {
 	AuthArgs
@@ -33,6 +33,7 @@ import (
 	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/handlers"
 	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/policy/condition"
 )
 
 // PolicySys - policy subsystem.
@@ -190,7 +191,7 @@ func getConditionValues(request *http.Request, locationConstraint string, userna
 		return "Anonymous"
 	}()
 	args := map[string][]string{
-		"CurrenTime":      {currTime.Format(event.AMZTimeFormat)},
+		"CurrentTime":     {currTime.Format(event.AMZTimeFormat)},
 		"EpochTime":       {fmt.Sprintf("%d", currTime.Unix())},
 		"principaltype":   {principalType},
 		"SecureTransport": {fmt.Sprintf("%t", request.TLS != nil)},
@@ -210,6 +211,10 @@ func getConditionValues(request *http.Request, locationConstraint string, userna
 	}
 
 	for key, values := range request.URL.Query() {
+		// Claims of temporary credentials must not be supplied by clients.
+		if strings.HasPrefix(strings.ToLower(key), condition.JWTPrefix) {
+			continue
+		}
 		if existingValues, found := args[key]; found {
 			args[key] = append(existingValues, values...)
 		} else {
@@ -30,6 +30,7 @@ import (
 	"time"
 
 	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/pkg/madmin"
 )
 
 const (
@@ -169,6 +170,19 @@ func testPostPolicyBucketHandler(obj ObjectLayer, instanceType string, t TestErr
 		}
 	}
 
+	// Uploads beyond the hard quota of the bucket are denied.
+	globalBucketQuotaSys.Set(bucketName, madmin.BucketQuota{Quota: 1, Type: madmin.HardQuota})
+	rec := httptest.NewRecorder()
+	req, perr := newPostRequestV2("", bucketName, "testobject", credentials.AccessKey, credentials.SecretKey)
+	if perr != nil {
+		t.Fatalf("%s: Failed to create HTTP request for PostPolicyHandler: <ERROR> %v", instanceType, perr)
+	}
+	apiRouter.ServeHTTP(rec, req)
+	globalBucketQuotaSys.Remove(bucketName)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusBadRequest, rec.Code)
+	}
+
 	// Test cases for signature-V4.
 	testCasesV4 := []struct {
 		objectName         string
@@ -499,7 +513,7 @@ func testPostPolicyBucketHandlerRedirect(obj ObjectLayer, instanceType string, t
 // postPresignSignatureV4 - presigned signature for PostPolicy requests.
 func postPresignSignatureV4(policyBase64 string, t time.Time, secretAccessKey, location string) string {
 	// Get signining key.
-	signingkey := getSigningKey(secretAccessKey, t, location)
+	signingkey := getSigningKey(secretAccessKey, t, location, serviceS3)
 	// Calculate signature.
 	signature := getSignature(signingkey, policyBase64)
 	return signature
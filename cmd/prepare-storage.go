@@ -158,8 +158,10 @@ var errXLV3ThisEmpty = fmt.Errorf("XL format version 3 has This field empty")
 
 // connect to list of endpoints and load all XL disk formats, validate the formats are correct
 // and are in quorum, if no formats are found attempt to initialize all of them for the first
-// time. additionally make sure to close all the disks used in this attempt.
-func connectLoadInitFormats(retryCount int, firstDisk bool, endpoints EndpointList, setCount, drivesPerSet int) (*formatXLV3, error) {
+// time. additionally make sure to close all the disks used in this attempt. A non-empty
+// deploymentID is used for freshly formatted disks and must match the ID of already
+// formatted disks, this keeps all server pools of a deployment under the same ID.
+func connectLoadInitFormats(retryCount int, firstDisk bool, endpoints EndpointList, setCount, drivesPerSet int, deploymentID string) (*formatXLV3, error) {
 	// Initialize all storage disks
 	storageDisks, err := initStorageDisks(endpoints)
 	if err != nil {
@@ -198,7 +200,7 @@ func connectLoadInitFormats(retryCount int, firstDisk bool, endpoints EndpointLi
 
 	// All disks report unformatted we should initialized everyone.
 	if shouldInitXLDisks(sErrs) && firstDisk {
-		return initFormatXL(context.Background(), storageDisks, setCount, drivesPerSet)
+		return initFormatXL(context.Background(), storageDisks, setCount, drivesPerSet, deploymentID)
 	}
 
 	// Return error when quorum unformatted disks - indicating we are
@@ -244,21 +246,26 @@ func connectLoadInitFormats(retryCount int, firstDisk bool, endpoints EndpointLi
 	}
 
 	if format.ID == "" {
-		if err = formatXLFixDeploymentID(context.Background(), storageDisks, format); err != nil {
+		if err = formatXLFixDeploymentID(context.Background(), endpoints, storageDisks, format, deploymentID); err != nil {
 			return nil, err
 		}
 	}
 
+	if deploymentID != "" && format.ID != deploymentID {
+		return nil, fmt.Errorf("Disks %s belong to a different deployment %s, expected %s", endpoints, format.ID, deploymentID)
+	}
+
 	globalDeploymentID = format.ID
 
-	if err = formatXLFixLocalDeploymentID(context.Background(), storageDisks, format); err != nil {
+	if err = formatXLFixLocalDeploymentID(context.Background(), endpoints, storageDisks, format); err != nil {
 		return nil, err
 	}
 	return format, nil
 }
 
-// Format disks before initialization of object layer.
-func waitForFormatXL(ctx context.Context, firstDisk bool, endpoints EndpointList, setCount, disksPerSet int) (format *formatXLV3, err error) {
+// Format disks before initialization of object layer, deploymentID
+// is empty unless the disks belong to an additional server pool.
+func waitForFormatXL(ctx context.Context, firstDisk bool, endpoints EndpointList, setCount, disksPerSet int, deploymentID string) (format *formatXLV3, err error) {
 	if len(endpoints) == 0 || setCount == 0 || disksPerSet == 0 {
 		return nil, errInvalidArgument
 	}
@@ -290,7 +297,7 @@ func waitForFormatXL(ctx context.Context, firstDisk bool, endpoints EndpointList
 	for {
 		select {
 		case retryCount := <-retryTimerCh:
-			format, err := connectLoadInitFormats(retryCount, firstDisk, endpoints, setCount, disksPerSet)
+			format, err := connectLoadInitFormats(retryCount, firstDisk, endpoints, setCount, disksPerSet, deploymentID)
 			if err != nil {
 				switch err {
 				case errNotFirstDisk:
@@ -49,6 +49,8 @@ func registerDistXLRouters(router *mux.Router, endpoints EndpointList) {
 var globalHandlers = []HandlerFunc{
 	// set x-amz-request-id, x-minio-deployment-id header.
 	addCustomHeaders,
+	// Record access logs of buckets with access logging enabled.
+	setBucketLoggingHandler,
 	// set HTTP security headers such as Content-Security-Policy.
 	addSecurityHeaders,
 	// Forward path style requests to actual host in a bucket federated setup.
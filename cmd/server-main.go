@@ -161,12 +161,15 @@ func serverHandleCmdArgs(ctx *cli.Context) {
 
 	endpoints := strings.Fields(os.Getenv("MINIO_ENDPOINTS"))
 	if len(endpoints) > 0 {
-		globalMinioAddr, globalEndpoints, setupType, globalXLSetCount, globalXLSetDriveCount, err = createServerEndpoints(globalCLIContext.Addr, endpoints...)
+		globalMinioAddr, globalEndpointPools, setupType, err = createServerEndpoints(globalCLIContext.Addr, endpoints...)
 	} else {
-		globalMinioAddr, globalEndpoints, setupType, globalXLSetCount, globalXLSetDriveCount, err = createServerEndpoints(globalCLIContext.Addr, ctx.Args()...)
+		globalMinioAddr, globalEndpointPools, setupType, err = createServerEndpoints(globalCLIContext.Addr, ctx.Args()...)
 	}
 	logger.FatalIf(err, "Invalid command line arguments")
 
+	globalEndpoints = globalEndpointPools.Endpoints()
+	globalXLSetDriveCount = globalEndpointPools[0].DrivesPerSet
+
 	globalMinioHost, globalMinioPort = mustSplitHostPort(globalMinioAddr)
 
 	// On macOS, if a process already listens on LOCALIPADDR:PORT, net.Listen() falls back
@@ -307,7 +310,7 @@ func serverMain(ctx *cli.Context) {
 
 	signal.Notify(globalOSSignalCh, os.Interrupt, syscall.SIGTERM)
 
-	newObject, err := newObjectLayer(globalEndpoints)
+	newObject, err := newObjectLayer(globalEndpointPools)
 	if err != nil {
 		// Stop watching for any certificate changes.
 		globalTLSCerts.Stop()
@@ -356,6 +359,70 @@ func serverMain(ctx *cli.Context) {
 		logger.Fatal(err, "Unable to initialize policy system")
 	}
 
+	// Create new versioning system.
+	globalBucketVersioningSys = NewBucketVersioningSys()
+
+	// Initialize versioning system.
+	if err = globalBucketVersioningSys.Init(newObject); err != nil {
+		logger.Fatal(err, "Unable to initialize versioning system")
+	}
+
+	// Create new lifecycle system.
+	globalLifecycleSys = NewLifecycleSys()
+
+	// Initialize lifecycle system.
+	if err = globalLifecycleSys.Init(newObject); err != nil {
+		logger.Fatal(err, "Unable to initialize lifecycle system")
+	}
+
+	// Create new CORS system.
+	globalBucketCorsSys = NewBucketCorsSys()
+
+	// Initialize CORS system.
+	if err = globalBucketCorsSys.Init(newObject); err != nil {
+		logger.Fatal(err, "Unable to initialize CORS system")
+	}
+
+	// Create new website system.
+	globalBucketWebsiteSys = NewBucketWebsiteSys()
+
+	// Initialize website system.
+	if err = globalBucketWebsiteSys.Init(newObject); err != nil {
+		logger.Fatal(err, "Unable to initialize website system")
+	}
+
+	// Create new logging system.
+	globalBucketLoggingSys = NewBucketLoggingSys()
+
+	// Initialize logging system.
+	if err = globalBucketLoggingSys.Init(newObject); err != nil {
+		logger.Fatal(err, "Unable to initialize logging system")
+	}
+
+	// Create new replication system.
+	globalReplicationSys = NewReplicationSys()
+
+	// Initialize replication system.
+	if err = globalReplicationSys.Init(newObject); err != nil {
+		logger.Fatal(err, "Unable to initialize replication system")
+	}
+
+	// Create new object lock system.
+	globalBucketObjectLockSys = NewBucketObjectLockSys()
+
+	// Initialize object lock system.
+	if err = globalBucketObjectLockSys.Init(newObject); err != nil {
+		logger.Fatal(err, "Unable to initialize object lock system")
+	}
+
+	// Create new bucket quota system.
+	globalBucketQuotaSys = NewBucketQuotaSys()
+
+	// Initialize bucket quota system.
+	if err = globalBucketQuotaSys.Init(newObject); err != nil {
+		logger.Fatal(err, "Unable to initialize bucket quota system")
+	}
+
 	// Create new notification system.
 	globalNotificationSys = NewNotificationSys(globalServerConfig, globalEndpoints)
 
@@ -377,23 +444,52 @@ func serverMain(ctx *cli.Context) {
 	// Set uptime time after object layer has initialized.
 	globalBootTime = UTCNow()
 
+	// Expire objects as per bucket lifecycle rules in background.
+	go startDailyLifecycle(newObject)
+
+	// Crawl data usage of all buckets in background.
+	go startDataUsageCrawler(newObject)
+
+	// Move objects out of draining server pools in background.
+	go startDecommissionRoutine(newObject)
+
+	// Heal replaced drives in background.
+	go startBackgroundHealing(newObject)
+
+	// Verify checksums of all objects and heal bitrot in background.
+	go startBitrotScrubber(newObject)
+
 	handleSignals()
 }
 
 // Initialize object layer with the supplied disks, objectLayer is nil upon any error.
-func newObjectLayer(endpoints EndpointList) (newObject ObjectLayer, err error) {
+func newObjectLayer(pools EndpointPools) (newObject ObjectLayer, err error) {
 	// For FS only, directly use the disk.
 
-	isFS := len(endpoints) == 1
+	isFS := len(pools) == 1 && len(pools[0].Endpoints) == 1
 	if isFS {
 		// Initialize new FS object layer.
-		return NewFSObjectLayer(endpoints[0].Path)
+		return NewFSObjectLayer(pools[0].Endpoints[0].Path)
 	}
 
-	format, err := waitForFormatXL(context.Background(), endpoints[0].IsLocal, endpoints, globalXLSetCount, globalXLSetDriveCount)
-	if err != nil {
-		return nil, err
+	// Additional server pools join the deployment of the first pool.
+	var deploymentID string
+	sets := make([]*xlSets, len(pools))
+	for i, pool := range pools {
+		endpoints := pool.Endpoints
+		format, err := waitForFormatXL(context.Background(), endpoints[0].IsLocal, endpoints, pool.SetCount, pool.DrivesPerSet, deploymentID)
+		if err != nil {
+			return nil, err
+		}
+		deploymentID = format.ID
+
+		newObject, err := newXLSets(endpoints, format, len(format.XL.Sets), len(format.XL.Sets[0]))
+		if err != nil {
+			return nil, err
+		}
+		sets[i] = newObject.(*xlSets)
 	}
 
-	return newXLSets(endpoints, format, len(format.XL.Sets), len(format.XL.Sets[0]))
+	// A single pool is combined as well, its erasure sets can be drained.
+	return newXLPools(sets), nil
 }
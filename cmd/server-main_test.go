@@ -32,7 +32,7 @@ func TestNewObjectLayer(t *testing.T) {
 	defer removeRoots(disks)
 
 	endpoints := mustGetNewEndpointList(disks...)
-	obj, err := newObjectLayer(endpoints)
+	obj, err := newObjectLayer(EndpointPools{{SetCount: 1, DrivesPerSet: 1, Endpoints: endpoints}})
 	if err != nil {
 		t.Fatal("Unexpected object layer initialization error", err)
 	}
@@ -51,17 +51,40 @@ func TestNewObjectLayer(t *testing.T) {
 	}
 	defer removeRoots(disks)
 
-	globalXLSetCount = 1
-	globalXLSetDriveCount = 16
-
 	endpoints = mustGetNewEndpointList(disks...)
-	obj, err = newObjectLayer(endpoints)
+	obj, err = newObjectLayer(EndpointPools{{SetCount: 1, DrivesPerSet: 16, Endpoints: endpoints}})
 	if err != nil {
 		t.Fatal("Unexpected object layer initialization error", err)
 	}
 
-	_, ok = obj.(*xlSets)
+	// A single pool is combined as well, its erasure sets can be drained.
+	_, ok = obj.(*xlPools)
 	if !ok {
 		t.Fatal("Unexpected object layer detected", reflect.TypeOf(obj))
 	}
+
+	// Tests for XL object layer initialization with server pools.
+
+	// Create temporary backend for the test server.
+	disks, err = getRandomDisks(2 * nDisks)
+	if err != nil {
+		t.Fatal("Failed to create disks for the backend")
+	}
+	defer removeRoots(disks)
+
+	obj, err = newObjectLayer(EndpointPools{
+		{SetCount: 1, DrivesPerSet: 16, Endpoints: mustGetNewEndpointList(disks[:nDisks]...)},
+		{SetCount: 1, DrivesPerSet: 16, Endpoints: mustGetNewEndpointList(disks[nDisks:]...)},
+	})
+	if err != nil {
+		t.Fatal("Unexpected object layer initialization error", err)
+	}
+
+	pools, ok := obj.(*xlPools)
+	if !ok {
+		t.Fatal("Unexpected object layer detected", reflect.TypeOf(obj))
+	}
+	if pools.pools[0].format.ID != pools.pools[1].format.ID {
+		t.Fatal("Server pools have different deployment IDs", pools.pools[0].format.ID, pools.pools[1].format.ID)
+	}
 }
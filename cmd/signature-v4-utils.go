@@ -17,11 +17,17 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
 	"crypto/hmac"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"strconv"
 	"strings"
 
+	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
 	"github.com/minio/sha256-simd"
 )
@@ -52,6 +58,24 @@ func skipContentSha256Cksum(r *http.Request) bool {
 	return !(ok && v[0] != unsignedPayload)
 }
 
+// Returns SHA256 of the form body of STS requests for calculating
+// canonical-request, STS clients sign the hash of the body without
+// sending X-Amz-Content-Sha256. The body is restored for parsing the
+// form, bodies beyond stsRequestBodyLimit are rejected.
+func getSTSContentSha256Cksum(r *http.Request) (string, APIErrorCode) {
+	payload, err := ioutil.ReadAll(io.LimitReader(r.Body, stsRequestBodyLimit+1))
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		return "", ErrIncompleteBody
+	}
+	if len(payload) > stsRequestBodyLimit {
+		return "", ErrEntityTooLarge
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(payload))
+	sum256 := sha256.Sum256(payload)
+	return hex.EncodeToString(sum256[:]), ErrNone
+}
+
 // Returns SHA256 for calculating canonical-request.
 func getContentSha256Cksum(r *http.Request) string {
 	var (
@@ -17,8 +17,13 @@
 package cmd
 
 import (
+	"bytes"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"strings"
 	"testing"
+	"testing/iotest"
 )
 
 // TestSkipContentSha256Cksum - Test validate the logic which decides whether
@@ -254,3 +259,44 @@ func TestGetContentSha256Cksum(t *testing.T) {
 		}
 	}
 }
+
+// Test getSTSContentSha256Cksum
+func TestGetSTSContentSha256Cksum(t *testing.T) {
+	body := "Action=AssumeRole&Version=2011-06-15"
+	testCases := []struct {
+		body        io.Reader
+		expected    string
+		expectedErr APIErrorCode
+	}{
+		{strings.NewReader(body), getSHA256Hash([]byte(body)), ErrNone},
+		{strings.NewReader(""), emptySHA256, ErrNone},
+		{bytes.NewReader(make([]byte, stsRequestBodyLimit)), getSHA256Hash(make([]byte, stsRequestBodyLimit)), ErrNone},
+		{bytes.NewReader(make([]byte, stsRequestBodyLimit+1)), "", ErrEntityTooLarge},
+		{iotest.TimeoutReader(strings.NewReader(body)), "", ErrIncompleteBody},
+	}
+
+	for i, testCase := range testCases {
+		r, err := http.NewRequest("POST", "http://localhost/", testCase.body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, s3Err := getSTSContentSha256Cksum(r)
+		if s3Err != testCase.expectedErr {
+			t.Fatalf("Test %d: got error %v expected %v", i+1, s3Err, testCase.expectedErr)
+		}
+		if got != testCase.expected {
+			t.Errorf("Test %d: got:%s expected:%s", i+1, got, testCase.expected)
+		}
+		if s3Err != ErrNone {
+			continue
+		}
+		// The body is restored for parsing the form.
+		payload, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if getSHA256Hash(payload) != testCase.expected {
+			t.Errorf("Test %d: body not restored", i+1)
+		}
+	}
+}
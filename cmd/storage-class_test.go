@@ -93,7 +93,7 @@ func testValidateParity(obj ObjectLayer, instanceType string, dirs []string, t T
 	defer func() {
 		globalXLSetDriveCount = saveSetDriveCount
 	}()
-	globalXLSetCount = len(dirs)
+	globalXLSetDriveCount = len(dirs)
 
 	tests := []struct {
 		rrsParity int
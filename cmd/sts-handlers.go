@@ -17,12 +17,16 @@
 package cmd
 
 import (
+	"context"
+	"encoding/base64"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/gorilla/mux"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/iam/policy"
 	"github.com/minio/minio/pkg/iam/validator"
 )
 
@@ -30,9 +34,17 @@ const (
 	// STS API version.
 	stsAPIVersion = "2011-06-15"
 
+	// Maximum size of the form body of STS requests.
+	stsRequestBodyLimit = 1 << 20 // 1 MiB
+
+	// Maximum size of an inline session policy of AssumeRole.
+	maxSessionPolicySize = 2048
+
 	// STS API action constants
+	assumeRole   = "AssumeRole"
 	clientGrants = "AssumeRoleWithClientGrants"
 	webIdentity  = "AssumeRoleWithWebIdentity"
+	ldapIdentity = "AssumeRoleWithLDAPIdentity"
 )
 
 // stsAPIHandlers implements and provides http handlers for AWS STS API.
@@ -46,6 +58,15 @@ func registerSTSRouter(router *mux.Router) {
 	// STS Router
 	stsRouter := router.NewRoute().PathPrefix("/").Subrouter()
 
+	// AssumeRole, the form is sent in the body of a request signed
+	// with the credentials of an IAM user.
+	stsRouter.Methods("POST").MatcherFunc(func(r *http.Request, rm *mux.RouteMatch) bool {
+		ctypeOk := strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded")
+		authOk := strings.HasPrefix(r.Header.Get("Authorization"), signV4Algorithm)
+		noQueries := len(r.URL.Query()) == 0
+		return ctypeOk && authOk && noQueries
+	}).HandlerFunc(httpTraceAll(sts.AssumeRole))
+
 	// Assume roles with JWT handler, handles both ClientGrants and WebIdentity.
 	stsRouter.Methods("POST").HeadersRegexp("Content-Type", "application/x-www-form-urlencoded*").
 		HandlerFunc(httpTraceAll(sts.AssumeRoleWithJWT))
@@ -62,6 +83,154 @@ func registerSTSRouter(router *mux.Router) {
 		Queries("Version", stsAPIVersion).
 		Queries("WebIdentityToken", "{Token:.*}")
 
+	// AssumeRoleWithLDAPIdentity
+	stsRouter.Methods("POST").HandlerFunc(httpTraceAll(sts.AssumeRoleWithLDAPIdentity)).
+		Queries("Action", ldapIdentity).
+		Queries("Version", stsAPIVersion).
+		Queries("LDAPUsername", "{LDAPUsername:.*}").
+		Queries("LDAPPassword", "{LDAPPassword:.*}")
+}
+
+// checkAssumeRoleAuth - authenticates AssumeRole requests, which must be
+// signed for the STS service with the credentials of an IAM user.
+func checkAssumeRoleAuth(ctx context.Context, r *http.Request) (user auth.Credentials, s3Err APIErrorCode) {
+	if getRequestAuthType(r) != authTypeSigned {
+		return user, ErrAccessDenied
+	}
+
+	// Temporary credentials cannot assume roles.
+	if getSessionToken(r) != "" {
+		return user, ErrAccessDenied
+	}
+
+	region := globalServerConfig.GetRegion()
+	if s3Err = isReqAuthenticated(ctx, r, region, serviceSTS); s3Err != ErrNone {
+		return user, s3Err
+	}
+
+	var owner bool
+	user, owner, s3Err = getReqAccessKeyV4(r, region, serviceSTS)
+	if s3Err != ErrNone {
+		return user, s3Err
+	}
+
+	// Root credentials have no policies for the temporary
+	// credentials to inherit, service accounts would lose
+	// their embedded policy.
+	if owner || user.IsServiceAccount() {
+		return user, ErrAccessDenied
+	}
+
+	return user, ErrNone
+}
+
+// AssumeRole - implementation of AWS STS API AssumeRole to get temporary
+// credentials for IAM users, the credentials inherit the policies of the
+// user, optionally restricted further by an inline session policy.
+// https://docs.aws.amazon.com/STS/latest/APIReference/API_AssumeRole.html
+func (sts *stsAPIHandlers) AssumeRole(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, assumeRole)
+
+	defer logger.AuditLog(w, r, assumeRole, nil)
+
+	user, s3Err := checkAssumeRoleAuth(ctx, r)
+	if s3Err != ErrNone {
+		apiErr := getAPIError(s3Err)
+		writeSTSErrorResponse(w, STSError{
+			Code:           apiErr.Code,
+			Description:    apiErr.Description,
+			HTTPStatusCode: apiErr.HTTPStatusCode,
+		})
+		return
+	}
+
+	// Parse the incoming form data.
+	if err := r.ParseForm(); err != nil {
+		logger.LogIf(ctx, err)
+		writeSTSErrorResponse(w, stsErrCodes.ToSTSErr(ErrSTSInvalidParameterValue))
+		return
+	}
+
+	if r.Form.Get("Version") != stsAPIVersion {
+		logger.LogIf(ctx, fmt.Errorf("Invalid STS API version %s, expecting %s", r.Form.Get("Version"), stsAPIVersion))
+		writeSTSErrorResponse(w, stsErrCodes.ToSTSErr(ErrSTSMissingParameter))
+		return
+	}
+
+	if action := r.Form.Get("Action"); action != assumeRole {
+		logger.LogIf(ctx, fmt.Errorf("Unsupported action %s", action))
+		writeSTSErrorResponse(w, stsErrCodes.ToSTSErr(ErrSTSInvalidParameterValue))
+		return
+	}
+
+	expiry, err := validator.GetDefaultExpiration(r.Form.Get("DurationSeconds"))
+	if err != nil {
+		logger.LogIf(ctx, err)
+		writeSTSErrorResponse(w, stsErrCodes.ToSTSErr(ErrSTSInvalidParameterValue))
+		return
+	}
+
+	m := map[string]interface{}{
+		"exp": float64(UTCNow().Add(expiry).Unix()),
+	}
+
+	sessionPolicyStr := r.Form.Get("Policy")
+	if sessionPolicyStr != "" {
+		if len(sessionPolicyStr) > maxSessionPolicySize {
+			writeSTSErrorResponse(w, stsErrCodes.ToSTSErr(ErrSTSInvalidParameterValue))
+			return
+		}
+
+		sessionPolicy, err := iampolicy.ParseConfig(strings.NewReader(sessionPolicyStr))
+		if err != nil {
+			logger.LogIf(ctx, err)
+			writeSTSErrorResponse(w, stsErrCodes.ToSTSErr(ErrSTSMalformedPolicyDocument))
+			return
+		}
+
+		// Version is needed to tell a policy apart from any JSON object.
+		if sessionPolicy.Version == "" {
+			writeSTSErrorResponse(w, stsErrCodes.ToSTSErr(ErrSTSMalformedPolicyDocument))
+			return
+		}
+
+		m[iampolicy.SessionPolicyName] = base64.StdEncoding.EncodeToString([]byte(sessionPolicyStr))
+	}
+
+	secret := globalServerConfig.GetCredential().SecretKey
+	cred, err := auth.GetNewCredentialsWithMetadata(m, secret)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		writeSTSErrorResponse(w, stsErrCodes.ToSTSErr(ErrSTSInternalError))
+		return
+	}
+
+	// The temporary credentials inherit the policies of the user
+	// and of its groups at the time they are issued.
+	policyName := globalIAMSys.GetUserPolicies(user.AccessKey)
+
+	// Set the newly generated credentials.
+	if err = globalIAMSys.SetTempUser(cred.AccessKey, cred, policyName); err != nil {
+		logger.LogIf(ctx, err)
+		writeSTSErrorResponse(w, stsErrCodes.ToSTSErr(ErrSTSInternalError))
+		return
+	}
+
+	// Notify all other Minio peers to reload temp users
+	for _, nerr := range globalNotificationSys.LoadUsers() {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+
+	encodedSuccessResponse := encodeResponse(&AssumeRoleResponse{
+		Result: AssumeRoleResult{
+			Credentials: cred,
+		},
+	})
+
+	writeSuccessResponseXML(w, encodedSuccessResponse)
 }
 
 func (sts *stsAPIHandlers) AssumeRoleWithJWT(w http.ResponseWriter, r *http.Request) {
@@ -82,6 +251,9 @@ func (sts *stsAPIHandlers) AssumeRoleWithJWT(w http.ResponseWriter, r *http.Requ
 
 	action := r.Form.Get("Action")
 	switch action {
+	case ldapIdentity:
+		sts.AssumeRoleWithLDAPIdentity(w, r)
+		return
 	case clientGrants, webIdentity:
 	default:
 		logger.LogIf(ctx, fmt.Errorf("Unsupported action %s", action))
@@ -205,3 +377,99 @@ func (sts *stsAPIHandlers) AssumeRoleWithWebIdentity(w http.ResponseWriter, r *h
 func (sts *stsAPIHandlers) AssumeRoleWithClientGrants(w http.ResponseWriter, r *http.Request) {
 	sts.AssumeRoleWithJWT(w, r)
 }
+
+// AssumeRoleWithLDAPIdentity - implements user auth against LDAP server,
+// the policies attached to the groups of the user in the LDAP directory
+// apply to the temporary credentials.
+//
+// Eg:-
+//    $ curl https://minio:9000/?Action=AssumeRoleWithLDAPIdentity&LDAPUsername=<user>&LDAPPassword=<password>
+func (sts *stsAPIHandlers) AssumeRoleWithLDAPIdentity(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, ldapIdentity)
+
+	defer logger.AuditLog(w, r, ldapIdentity, nil)
+
+	// Parse the incoming form data.
+	if err := r.ParseForm(); err != nil {
+		logger.LogIf(ctx, err)
+		writeSTSErrorResponse(w, stsErrCodes.ToSTSErr(ErrSTSInvalidParameterValue))
+		return
+	}
+
+	if r.Form.Get("Version") != stsAPIVersion {
+		logger.LogIf(ctx, fmt.Errorf("Invalid STS API version %s, expecting %s", r.Form.Get("Version"), stsAPIVersion))
+		writeSTSErrorResponse(w, stsErrCodes.ToSTSErr(ErrSTSMissingParameter))
+		return
+	}
+
+	if !globalLDAPConfig.Enabled {
+		writeSTSErrorResponse(w, stsErrCodes.ToSTSErr(ErrSTSNotInitialized))
+		return
+	}
+
+	ldapUsername := r.Form.Get("LDAPUsername")
+	ldapPassword := r.Form.Get("LDAPPassword")
+	if ldapUsername == "" || ldapPassword == "" {
+		writeSTSErrorResponse(w, stsErrCodes.ToSTSErr(ErrSTSMissingParameter))
+		return
+	}
+
+	expiry, err := globalLDAPConfig.GetExpiryDuration(r.Form.Get("DurationSeconds"))
+	if err != nil {
+		logger.LogIf(ctx, err)
+		writeSTSErrorResponse(w, stsErrCodes.ToSTSErr(ErrSTSInvalidParameterValue))
+		return
+	}
+
+	userDN, groups, err := globalLDAPConfig.Authenticate(ldapUsername, ldapPassword)
+	if err != nil {
+		if err == errAuthentication {
+			writeSTSErrorResponse(w, stsErrCodes.ToSTSErr(ErrSTSAccessDenied))
+			return
+		}
+		logger.LogIf(ctx, err)
+		writeSTSErrorResponse(w, stsErrCodes.ToSTSErr(ErrSTSInternalError))
+		return
+	}
+
+	m := map[string]interface{}{
+		"exp":      float64(UTCNow().Add(expiry).Unix()),
+		"ldapUser": userDN,
+	}
+
+	secret := globalServerConfig.GetCredential().SecretKey
+	cred, err := auth.GetNewCredentialsWithMetadata(m, secret)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		writeSTSErrorResponse(w, stsErrCodes.ToSTSErr(ErrSTSInternalError))
+		return
+	}
+
+	// LDAP group DNs are mapped to the canned policies attached
+	// to the IAM groups of the same name.
+	policyName := globalIAMSys.GetGroupsPolicies(groups)
+
+	// Set the newly generated credentials.
+	if err = globalIAMSys.SetTempUser(cred.AccessKey, cred, policyName); err != nil {
+		logger.LogIf(ctx, err)
+		writeSTSErrorResponse(w, stsErrCodes.ToSTSErr(ErrSTSInternalError))
+		return
+	}
+
+	// Notify all other Minio peers to reload temp users
+	for _, nerr := range globalNotificationSys.LoadUsers() {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+
+	encodedSuccessResponse := encodeResponse(&AssumeRoleWithLDAPResponse{
+		Result: LDAPIdentityResult{
+			Credentials:             cred,
+			SubjectFromLDAPIdentity: userDN,
+		},
+	})
+
+	writeSuccessResponseXML(w, encodedSuccessResponse)
+}
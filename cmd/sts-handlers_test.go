@@ -0,0 +1,235 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// newTestSTSRequest - returns an AssumeRole request with the given form
+// signed for the STS service.
+func newTestSTSRequest(form url.Values, accessKey, secretKey string) *http.Request {
+	body := form.Encode()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	t := UTCNow()
+	req.Header.Set("X-Amz-Date", t.Format(iso8601Format))
+
+	region := globalServerConfig.GetRegion()
+	scope := strings.Join([]string{t.Format(yyyymmdd), region, string(serviceSTS), "aws4_request"}, "/")
+
+	signedHeaders := http.Header{}
+	signedHeaders.Set("content-type", req.Header.Get("Content-Type"))
+	signedHeaders.Set("host", req.Host)
+	signedHeaders.Set("x-amz-date", req.Header.Get("X-Amz-Date"))
+
+	canonicalRequest := getCanonicalRequest(signedHeaders, getSHA256Hash([]byte(body)), "", "/", http.MethodPost)
+	stringToSign := getStringToSign(canonicalRequest, t, scope)
+	signature := getSignature(getSigningKey(secretKey, t, region, serviceSTS), stringToSign)
+
+	req.Header.Set("Authorization", signV4Algorithm+" Credential="+accessKey+"/"+scope+
+		", SignedHeaders=content-type;host;x-amz-date, Signature="+signature)
+	return req
+}
+
+// TestAssumeRole - tests issuing temporary credentials to IAM users with
+// their policies, restricted by an optional session policy.
+func TestAssumeRole(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	if err = globalIAMSys.SetUser("user", madmin.UserInfo{SecretKey: "secretkey", Status: madmin.AccountEnabled}); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.SetPolicy("user", "readwrite", false); err != nil {
+		t.Fatal(err)
+	}
+
+	router := mux.NewRouter()
+	registerSTSRouter(router)
+
+	getObjectPolicy := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::bucket/*"]}]}`
+	rootCred := globalServerConfig.GetCredential()
+
+	testCases := []struct {
+		accessKey      string
+		secretKey      string
+		durationSecs   string
+		policy         string
+		expectedCode   int
+		isGetAllowed   bool
+		isPutAllowed   bool
+		expectedExpiry time.Duration
+	}{
+		{"user", "secretkey", "", "", http.StatusOK, true, true, time.Hour},
+		{"user", "secretkey", "900", getObjectPolicy, http.StatusOK, true, false, 15 * time.Minute},
+		{"user", "wrongsecret", "", "", http.StatusForbidden, false, false, 0},
+		{rootCred.AccessKey, rootCred.SecretKey, "", "", http.StatusForbidden, false, false, 0},
+		{"user", "secretkey", "60", "", http.StatusBadRequest, false, false, 0},
+		{"user", "secretkey", "", `{"Statement":[]}`, http.StatusBadRequest, false, false, 0},
+		{"user", "secretkey", "", "policy", http.StatusBadRequest, false, false, 0},
+	}
+
+	for i, testCase := range testCases {
+		form := url.Values{}
+		form.Set("Action", assumeRole)
+		form.Set("Version", stsAPIVersion)
+		if testCase.durationSecs != "" {
+			form.Set("DurationSeconds", testCase.durationSecs)
+		}
+		if testCase.policy != "" {
+			form.Set("Policy", testCase.policy)
+		}
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, newTestSTSRequest(form, testCase.accessKey, testCase.secretKey))
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("test %d: expected %d, got %d: %s", i+1, testCase.expectedCode, rec.Code, rec.Body.String())
+		}
+		if rec.Code != http.StatusOK {
+			continue
+		}
+
+		var response AssumeRoleResponse
+		if err = xml.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("test %d: %v", i+1, err)
+		}
+		cred := response.Result.Credentials
+		if expiry := cred.Expiration.Sub(UTCNow()); expiry > testCase.expectedExpiry || expiry < testCase.expectedExpiry-time.Minute {
+			t.Fatalf("test %d: unexpected expiry %v", i+1, expiry)
+		}
+
+		// Temporary credentials present their session token.
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Amz-Security-Token", cred.SessionToken)
+		claims, err := getClaimsFromToken(req)
+		if err != nil {
+			t.Fatalf("test %d: %v", i+1, err)
+		}
+
+		isAllowed := func(action iampolicy.Action) bool {
+			return globalIAMSys.IsAllowed(iampolicy.Args{
+				AccountName: cred.AccessKey,
+				Action:      action,
+				BucketName:  "bucket",
+				ObjectName:  "object",
+				Claims:      claims,
+			})
+		}
+		if isAllowed(iampolicy.GetObjectAction) != testCase.isGetAllowed || isAllowed(iampolicy.PutObjectAction) != testCase.isPutAllowed {
+			t.Fatalf("test %d: unexpected permissions of temporary credentials", i+1)
+		}
+	}
+}
+
+// TestAssumeRoleWithLDAPIdentity - tests issuing temporary credentials
+// to LDAP users with the policies of the IAM groups named after their
+// LDAP groups.
+func TestAssumeRoleWithLDAPIdentity(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	ldapConfig, server := newTestLDAPConfig(t)
+	defer server.Close()
+
+	if err = globalIAMSys.AddUsersToGroup("cn=admins,ou=groups,dc=example,dc=com", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.SetPolicy("cn=admins,ou=groups,dc=example,dc=com", "readonly", true); err != nil {
+		t.Fatal(err)
+	}
+
+	router := mux.NewRouter()
+	registerSTSRouter(router)
+
+	assumeRole := func(username, password string) *httptest.ResponseRecorder {
+		form := url.Values{}
+		form.Set("Action", ldapIdentity)
+		form.Set("Version", stsAPIVersion)
+		form.Set("LDAPUsername", username)
+		form.Set("LDAPPassword", password)
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// LDAP is not configured.
+	if rec := assumeRole("alice", "alice-password"); rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected %d, got %d: %s", http.StatusServiceUnavailable, rec.Code, rec.Body.String())
+	}
+
+	globalLDAPConfig = ldapConfig
+
+	testCases := []struct {
+		username     string
+		password     string
+		expectedCode int
+		isAllowed    bool
+	}{
+		{"alice", "alice-password", http.StatusOK, true},
+		{"bob", "bob-password", http.StatusOK, false},
+		{"alice", "bob-password", http.StatusForbidden, false},
+		{"alice", "", http.StatusBadRequest, false},
+	}
+
+	for i, testCase := range testCases {
+		rec := assumeRole(testCase.username, testCase.password)
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("test %d: expected %d, got %d: %s", i+1, testCase.expectedCode, rec.Code, rec.Body.String())
+		}
+		if rec.Code != http.StatusOK {
+			continue
+		}
+
+		var response AssumeRoleWithLDAPResponse
+		if err = xml.NewDecoder(rec.Body).Decode(&response); err != nil {
+			t.Fatalf("test %d: %v", i+1, err)
+		}
+		if response.Result.SubjectFromLDAPIdentity != "uid="+testCase.username+",ou=people,dc=example,dc=com" {
+			t.Fatalf("test %d: unexpected subject %s", i+1, response.Result.SubjectFromLDAPIdentity)
+		}
+
+		isAllowed := globalIAMSys.IsAllowed(iampolicy.Args{
+			AccountName: response.Result.Credentials.AccessKey,
+			Action:      iampolicy.GetObjectAction,
+			BucketName:  "bucket",
+			ObjectName:  "object",
+		})
+		if isAllowed != testCase.isAllowed {
+			t.Fatalf("test %d: expected allowed %v, got %v", i+1, testCase.isAllowed, isAllowed)
+		}
+	}
+}
@@ -190,7 +190,7 @@ func prepareXL32() (ObjectLayer, []string, error) {
 
 	endpoints := append(endpoints1, endpoints2...)
 	fsDirs := append(fsDirs1, fsDirs2...)
-	format, err := waitForFormatXL(context.Background(), true, endpoints, 2, 16)
+	format, err := waitForFormatXL(context.Background(), true, endpoints, 2, 16, "")
 	if err != nil {
 		removeRoots(fsDirs)
 		return nil, nil, err
@@ -362,6 +362,30 @@ func UnstartedTestServer(t TestErrHandler, instanceType string) TestServer {
 	globalPolicySys = NewPolicySys()
 	globalPolicySys.Init(objLayer)
 
+	globalBucketVersioningSys = NewBucketVersioningSys()
+	globalBucketVersioningSys.Init(objLayer)
+
+	globalLifecycleSys = NewLifecycleSys()
+	globalLifecycleSys.Init(objLayer)
+
+	globalBucketCorsSys = NewBucketCorsSys()
+	globalBucketCorsSys.Init(objLayer)
+
+	globalBucketWebsiteSys = NewBucketWebsiteSys()
+	globalBucketWebsiteSys.Init(objLayer)
+
+	globalBucketLoggingSys = NewBucketLoggingSys()
+	globalBucketLoggingSys.Init(objLayer)
+
+	globalReplicationSys = NewReplicationSys()
+	globalReplicationSys.Init(objLayer)
+
+	globalBucketObjectLockSys = NewBucketObjectLockSys()
+	globalBucketObjectLockSys.Init(objLayer)
+
+	globalBucketQuotaSys = NewBucketQuotaSys()
+	globalBucketQuotaSys.Init(objLayer)
+
 	globalNotificationSys = NewNotificationSys(globalServerConfig, testServer.Disks)
 	globalNotificationSys.Init(objLayer)
 
@@ -874,7 +898,7 @@ func preSignV4(req *http.Request, accessKeyID, secretAccessKey string, expires i
 	queryStr := strings.Replace(query.Encode(), "+", "%20", -1)
 	canonicalRequest := getCanonicalRequest(extractedSignedHeaders, unsignedPayload, queryStr, req.URL.Path, req.Method)
 	stringToSign := getStringToSign(canonicalRequest, date, scope)
-	signingKey := getSigningKey(secretAccessKey, date, region)
+	signingKey := getSigningKey(secretAccessKey, date, region, serviceS3)
 	signature := getSignature(signingKey, stringToSign)
 
 	req.URL.RawQuery = query.Encode()
@@ -1584,7 +1608,7 @@ func newTestObjectLayer(endpoints EndpointList) (newObject ObjectLayer, err erro
 		return NewFSObjectLayer(endpoints[0].Path)
 	}
 
-	_, err = waitForFormatXL(context.Background(), endpoints[0].IsLocal, endpoints, 1, 16)
+	_, err = waitForFormatXL(context.Background(), endpoints[0].IsLocal, endpoints, 1, 16, "")
 	if err != nil {
 		return nil, err
 	}
@@ -1615,6 +1639,14 @@ func newTestObjectLayer(endpoints EndpointList) (newObject ObjectLayer, err erro
 	globalIAMSys.Init(xl)
 
 	globalPolicySys = NewPolicySys()
+	globalBucketVersioningSys = NewBucketVersioningSys()
+	globalLifecycleSys = NewLifecycleSys()
+	globalBucketCorsSys = NewBucketCorsSys()
+	globalBucketWebsiteSys = NewBucketWebsiteSys()
+	globalBucketLoggingSys = NewBucketLoggingSys()
+	globalReplicationSys = NewReplicationSys()
+	globalBucketObjectLockSys = NewBucketObjectLockSys()
+	globalBucketQuotaSys = NewBucketQuotaSys()
 	globalNotificationSys = NewNotificationSys(globalServerConfig, endpoints)
 
 	return xl, nil
@@ -2041,6 +2073,10 @@ func registerBucketLevelFunc(bucket *mux.Router, api objectAPIHandlers, apiFunct
 		case "HeadObject":
 			// Register HeadObject handler.
 			bucket.Methods("Head").Path("/{object:.+}").HandlerFunc(api.HeadObjectHandler)
+		case "GetWebsiteObject":
+			// Register GetWebsiteObject handler for objects and the bucket index.
+			bucket.Methods("GET", "HEAD").Path("/{object:.+}").MatcherFunc(isWebsiteReq).HandlerFunc(api.GetWebsiteObjectHandler)
+			bucket.Methods("GET", "HEAD").MatcherFunc(isWebsiteReq).HandlerFunc(api.GetWebsiteObjectHandler)
 		case "GetObject":
 			// Register GetObject handler.
 			bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(api.GetObjectHandler)
@@ -2160,6 +2196,12 @@ func initTestWebRPCEndPoint(objLayer ObjectLayer) http.Handler {
 	globalObjectAPI = objLayer
 	globalObjLayerMutex.Unlock()
 
+	// Users are looked up in IAM when logging in.
+	if globalIAMSys == nil {
+		globalIAMSys = NewIAMSys()
+		globalIAMSys.Init(objLayer)
+	}
+
 	// Initialize router.
 	muxRouter := mux.NewRouter().SkipClean(true)
 	registerWebRouter(muxRouter)
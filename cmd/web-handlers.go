@@ -173,6 +173,19 @@ func (web *webAPIHandlers) MakeBucket(r *http.Request, args *MakeBucketArgs, rep
 					return toJSONError(err)
 				}
 
+				// Get host and port from Request.RemoteAddr.
+				host, port, _ := net.SplitHostPort(handlers.GetSourceIP(r))
+
+				// Notify bucket created event.
+				sendEvent(eventArgs{
+					EventName:  event.BucketCreated,
+					BucketName: args.BucketName,
+					ReqParams:  extractReqParams(r),
+					UserAgent:  r.UserAgent(),
+					Host:       host,
+					Port:       port,
+				})
+
 				reply.UIVersion = browser.UIVersion
 				return nil
 			}
@@ -185,6 +198,19 @@ func (web *webAPIHandlers) MakeBucket(r *http.Request, args *MakeBucketArgs, rep
 		return toJSONError(err, args.BucketName)
 	}
 
+	// Get host and port from Request.RemoteAddr.
+	host, port, _ := net.SplitHostPort(handlers.GetSourceIP(r))
+
+	// Notify bucket created event.
+	sendEvent(eventArgs{
+		EventName:  event.BucketCreated,
+		BucketName: args.BucketName,
+		ReqParams:  extractReqParams(r),
+		UserAgent:  r.UserAgent(),
+		Host:       host,
+		Port:       port,
+	})
+
 	reply.UIVersion = browser.UIVersion
 	return nil
 }
@@ -242,8 +268,30 @@ func (web *webAPIHandlers) DeleteBucket(r *http.Request, args *RemoveBucketArgs,
 		return toJSONError(err, args.BucketName)
 	}
 
+	// Get host and port from Request.RemoteAddr.
+	host, port, _ := net.SplitHostPort(handlers.GetSourceIP(r))
+
+	// Notify bucket removed event, before its notification
+	// configuration is removed.
+	sendEvent(eventArgs{
+		EventName:  event.BucketRemoved,
+		BucketName: args.BucketName,
+		ReqParams:  extractReqParams(r),
+		UserAgent:  r.UserAgent(),
+		Host:       host,
+		Port:       port,
+	})
+
 	globalNotificationSys.RemoveNotification(args.BucketName)
 	globalPolicySys.Remove(args.BucketName)
+	globalBucketVersioningSys.Remove(args.BucketName)
+	globalLifecycleSys.Remove(args.BucketName)
+	globalBucketCorsSys.Remove(args.BucketName)
+	globalBucketWebsiteSys.Remove(args.BucketName)
+	globalBucketLoggingSys.Remove(args.BucketName)
+	globalReplicationSys.Remove(args.BucketName)
+	globalBucketObjectLockSys.Remove(args.BucketName)
+	globalBucketQuotaSys.Remove(args.BucketName)
 	globalNotificationSys.DeleteBucket(ctx, args.BucketName)
 
 	if globalDNSConfig != nil {
@@ -596,6 +644,10 @@ func (web *webAPIHandlers) RemoveObject(r *http.Request, args *RemoveObjectArgs,
 	}
 
 	var err error
+	// Objects in buckets with versioning are removed by adding a delete marker.
+	var opts ObjectOptions
+	setVersioningOpts(&opts, args.BucketName)
+
 next:
 	for _, objectName := range args.Objects {
 		// If not a directory, remove the object.
@@ -618,7 +670,7 @@ next:
 				return toJSONError(errAccessDenied)
 			}
 
-			if err = deleteObject(context.Background(), objectAPI, web.CacheAPI(), args.BucketName, objectName, r); err != nil {
+			if _, err = deleteObject(context.Background(), objectAPI, web.CacheAPI(), args.BucketName, objectName, opts, r); err != nil {
 				break next
 			}
 			continue
@@ -645,7 +697,7 @@ next:
 			}
 			marker = lo.NextMarker
 			for _, obj := range lo.Objects {
-				err = deleteObject(context.Background(), objectAPI, web.CacheAPI(), args.BucketName, obj.Name, r)
+				_, err = deleteObject(context.Background(), objectAPI, web.CacheAPI(), args.BucketName, obj.Name, opts, r)
 				if err != nil {
 					break next
 				}
@@ -898,6 +950,9 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Uploads from the browser are never replicas of another bucket.
+	setReplicationMetadata(false, bucket, object, metadata)
+
 	var pReader *PutObjReader
 	var reader io.Reader = r.Body
 	actualSize := size
@@ -946,6 +1001,7 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 		writeErrorResponseHeadersOnly(w, toAPIError(ctx, err))
 		return
 	}
+	setVersioningOpts(&opts, bucket)
 	if objectAPI.IsEncryptionSupported() {
 		if hasServerSideEncryptionHeader(r.Header) && !hasSuffix(object, slashSeparator) { // handle SSE requests
 			rawReader := hashReader
@@ -976,6 +1032,14 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Deny writes beyond the hard quota of the bucket, an overwrite
+	// releases the size of the object it replaces.
+	prevSize := getQuotaObjectSize(ctx, objectAPI, bucket, object, "")
+	if err = globalBucketQuotaSys.enforce(ctx, objectAPI, bucket, actualSize-prevSize); err != nil {
+		writeWebErrorResponse(w, err)
+		return
+	}
+
 	putObject := objectAPI.PutObject
 	if !hasServerSideEncryptionHeader(r.Header) && web.CacheAPI() != nil {
 		putObject = web.CacheAPI().PutObject
@@ -985,6 +1049,11 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 		writeWebErrorResponse(w, err)
 		return
 	}
+	globalBucketQuotaSys.update(objectAPI, bucket, objInfo.Size-prevSize)
+
+	// Replicate the object asynchronously.
+	queueObjectReplication(ctx, objectAPI, objInfo)
+
 	if objectAPI.IsEncryptionSupported() {
 		if crypto.IsEncrypted(objInfo.UserDefined) {
 			switch {
@@ -1753,7 +1822,7 @@ func presignedGet(host, bucket, object string, expiry int64, creds auth.Credenti
 	extractedSignedHeaders.Set("host", host)
 	canonicalRequest := getCanonicalRequest(extractedSignedHeaders, unsignedPayload, queryStr, path, "GET")
 	stringToSign := getStringToSign(canonicalRequest, date, getScope(date, region))
-	signingKey := getSigningKey(secretKey, date, region)
+	signingKey := getSigningKey(secretKey, date, region, serviceS3)
 	signature := getSignature(signingKey, stringToSign)
 
 	// Construct the final presigned URL.
@@ -1893,6 +1962,8 @@ func toWebAPIError(err error) APIError {
 		return getAPIError(ErrReadQuorum)
 	case PolicyNesting:
 		return getAPIError(ErrPolicyNesting)
+	case BucketQuotaExceeded:
+		return getAPIError(ErrAdminBucketQuotaExceeded)
 	case NotImplemented:
 		return APIError{
 			Code:           "NotImplemented",
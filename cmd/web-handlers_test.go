@@ -37,6 +37,7 @@ import (
 	humanize "github.com/dustin/go-humanize"
 	miniogopolicy "github.com/minio/minio-go/pkg/policy"
 	"github.com/minio/minio/pkg/hash"
+	"github.com/minio/minio/pkg/madmin"
 	"github.com/minio/minio/pkg/policy"
 	"github.com/minio/minio/pkg/policy/condition"
 )
@@ -940,6 +941,14 @@ func testUploadWebHandler(obj ObjectLayer, instanceType string, t TestErrHandler
 	if code != http.StatusOK {
 		t.Fatalf("Expected the response status to be 200, but instead found `%d`", code)
 	}
+
+	// Upload beyond the hard quota of the bucket should fail.
+	globalBucketQuotaSys.Set(bucketName, madmin.BucketQuota{Quota: 1, Type: madmin.HardQuota})
+	defer globalBucketQuotaSys.Remove(bucketName)
+	code = test(authorization, true)
+	if code != http.StatusBadRequest {
+		t.Fatalf("Expected the response status to be 400, but instead found `%d`", code)
+	}
 }
 
 // Wrapper for calling Download Handler
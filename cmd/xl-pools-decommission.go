@@ -0,0 +1,663 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/minio/pkg/sync/errgroup"
+)
+
+const (
+	// Interval at which every node checks if a server pool or an erasure
+	// set is draining.
+	decommissionTick = time.Minute
+
+	// Lock held by the node moving objects out of the draining pools and sets.
+	decommissionLeaderLock = "leader-decommission.lock"
+
+	// Holds the progress of the server pool and erasure set decommissions.
+	decommissionFile = "decommission.json"
+
+	// Locks serializing the updates of objects and multipart uploads
+	// with their move out of a draining pool or set are taken under
+	// decommission/objects/<bucket>/<object> and decommission/uploads/<uploadID>,
+	// writes which may place new objects read lock decommission/writes.
+	drainLockPrefix    = "decommission"
+	drainObjectsPrefix = "objects"
+	drainUploadsPrefix = "uploads"
+	drainWritesLock    = "writes"
+)
+
+var (
+	errInvalidPool    = errors.New("Server pool does not exist")
+	errPoolNotActive  = errors.New("Server pool is already draining or decommissioned")
+	errLastActivePool = errors.New("The last active server pool cannot be decommissioned")
+	errInvalidSet     = errors.New("Erasure set does not exist")
+	errSetNotActive   = errors.New("Erasure set is already draining or decommissioned")
+	errLastActiveSet  = errors.New("The last active erasure set of a server pool cannot be decommissioned")
+)
+
+// poolID - returns the identifier of the pool, the UUID of its first disk,
+// which unlike its position on the command line does not change when
+// other pools are removed.
+func (s *xlSets) poolID() string {
+	return s.getFormat().XL.Sets[0][0]
+}
+
+// setID - returns the identifier of an erasure set of the pool in the
+// decommission progress.
+func (s *xlSets) setID(setIdx int) string {
+	return path.Join(s.poolID(), strconv.Itoa(setIdx))
+}
+
+// saveDecommissionState - persists the decommission state of the pool in
+// `format.json` of all its disks.
+func (s *xlSets) saveDecommissionState(ctx context.Context, state string) error {
+	return s.updateFormat(ctx, func(format *formatXLV3) {
+		format.XL.Decommission = state
+	})
+}
+
+// saveSetDecommissionState - persists the decommission state of an erasure
+// set of the pool in `format.json` of all disks of the pool.
+func (s *xlSets) saveSetDecommissionState(ctx context.Context, setIdx int, state string) error {
+	return s.updateFormat(ctx, func(format *formatXLV3) {
+		// The states are copied, the reference format is read concurrently.
+		states := make([]string, len(format.XL.Sets))
+		copy(states, format.XL.SetsDecommission)
+		states[setIdx] = state
+		format.XL.SetsDecommission = states
+	})
+}
+
+// updateFormat - applies an update to `format.json` of all disks of the
+// pool and to the reference format.
+func (s *xlSets) updateFormat(ctx context.Context, update func(format *formatXLV3)) (err error) {
+	// Acquire lock on format.json
+	formatLock := s.getHashedSet(formatConfigFile).nsMutex.NewNSLock(minioMetaBucket, formatConfigFile)
+	if err = formatLock.GetLock(globalHealingTimeout); err != nil {
+		return err
+	}
+	defer formatLock.Unlock()
+
+	storageDisks, err := initStorageDisks(s.endpoints)
+	if err != nil {
+		return err
+	}
+	defer closeStorageDisks(storageDisks)
+
+	formats, _ := loadFormatXLAll(storageDisks)
+	for _, format := range formats {
+		if format != nil {
+			update(format)
+		}
+	}
+	if err = saveFormatXLAll(ctx, storageDisks, formats); err != nil {
+		return err
+	}
+
+	// Replace the reference format, it is copied as requests read it concurrently.
+	s.formatMu.Lock()
+	format := *s.format
+	update(&format)
+	s.format = &format
+	s.formatMu.Unlock()
+	return nil
+}
+
+// StartDecommission - marks a server pool as draining, its objects and
+// multipart uploads are moved to the active pools in background.
+func (z *xlPools) StartDecommission(ctx context.Context, poolIdx int) error {
+	if poolIdx < 0 || poolIdx >= len(z.pools) {
+		return errInvalidPool
+	}
+	if z.pools[poolIdx].decommissionState() != madmin.PoolActive {
+		return errPoolNotActive
+	}
+
+	activePools := 0
+	for _, pool := range z.pools {
+		if pool.decommissionState() == madmin.PoolActive {
+			activePools++
+		}
+	}
+	if activePools < 2 {
+		return errLastActivePool
+	}
+
+	if err := z.pools[poolIdx].saveDecommissionState(ctx, madmin.PoolDraining); err != nil {
+		return err
+	}
+
+	progress, err := loadDecommissionProgress(ctx, z)
+	if err != nil {
+		return err
+	}
+	progress[z.pools[poolIdx].poolID()] = madmin.PoolDecommissionInfo{
+		StartTime:  UTCNow(),
+		LastUpdate: UTCNow(),
+	}
+	return saveDecommissionProgress(ctx, z, progress)
+}
+
+// StartSetDecommission - marks an erasure set of an active server pool as
+// draining, its objects and multipart uploads are moved to the active sets
+// of the pool in background.
+func (z *xlPools) StartSetDecommission(ctx context.Context, poolIdx, setIdx int) error {
+	if poolIdx < 0 || poolIdx >= len(z.pools) {
+		return errInvalidPool
+	}
+	pool := z.pools[poolIdx]
+	if pool.decommissionState() != madmin.PoolActive {
+		return errPoolNotActive
+	}
+	if setIdx < 0 || setIdx >= len(pool.sets) {
+		return errInvalidSet
+	}
+	if pool.setDecommissionState(setIdx) != madmin.PoolActive {
+		return errSetNotActive
+	}
+
+	activeSets := 0
+	for index := range pool.sets {
+		if pool.setDecommissionState(index) == madmin.PoolActive {
+			activeSets++
+		}
+	}
+	if activeSets < 2 {
+		return errLastActiveSet
+	}
+
+	if err := pool.saveSetDecommissionState(ctx, setIdx, madmin.PoolDraining); err != nil {
+		return err
+	}
+
+	progress, err := loadDecommissionProgress(ctx, z)
+	if err != nil {
+		return err
+	}
+	progress[pool.setID(setIdx)] = madmin.PoolDecommissionInfo{
+		StartTime:  UTCNow(),
+		LastUpdate: UTCNow(),
+	}
+	return saveDecommissionProgress(ctx, z, progress)
+}
+
+// DecommissionStatus - returns the decommission state and progress of all
+// pools and of the erasure sets draining or decommissioned.
+func (z *xlPools) DecommissionStatus(ctx context.Context) (status madmin.DecommissionStatus, err error) {
+	progress, err := loadDecommissionProgress(ctx, z)
+	if err != nil {
+		return status, err
+	}
+
+	for index, pool := range z.pools {
+		info := progress[pool.poolID()]
+		info.Pool = index
+		info.State = pool.decommissionState()
+		status.Pools = append(status.Pools, info)
+
+		for setIdx := range pool.sets {
+			state := pool.setDecommissionState(setIdx)
+			if state == madmin.PoolActive {
+				continue
+			}
+			info := progress[pool.setID(setIdx)]
+			info.Pool = index
+			info.State = state
+			status.Sets = append(status.Sets, madmin.SetDecommissionInfo{PoolDecommissionInfo: info, Set: setIdx})
+		}
+	}
+	return status, nil
+}
+
+// loadDecommissionProgress - returns the progress of the pool and set
+// decommissions keyed by pool or set ID.
+func loadDecommissionProgress(ctx context.Context, objAPI ObjectLayer) (map[string]madmin.PoolDecommissionInfo, error) {
+	progress := make(map[string]madmin.PoolDecommissionInfo)
+
+	data, err := readConfig(ctx, objAPI, path.Join(minioConfigPrefix, decommissionFile))
+	if err != nil {
+		if err == errConfigNotFound {
+			return progress, nil
+		}
+		return nil, err
+	}
+
+	if err = json.Unmarshal(data, &progress); err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
+
+// saveDecommissionProgress - persists the progress of the pool and set
+// decommissions under the minio meta bucket.
+func saveDecommissionProgress(ctx context.Context, objAPI ObjectLayer, progress map[string]madmin.PoolDecommissionInfo) error {
+	data, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+
+	return saveConfig(ctx, objAPI, path.Join(minioConfigPrefix, decommissionFile), data)
+}
+
+// startDecommissionRoutine - moves the objects out of draining pools and
+// sets in background until the server stops, should be run in a go-routine.
+func startDecommissionRoutine(objAPI ObjectLayer) {
+	z, ok := objAPI.(*xlPools)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+
+	ticker := time.NewTicker(decommissionTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-GlobalServiceDoneCh:
+			return
+		case <-ticker.C:
+			if err := decommissionRound(ctx, z); err != nil {
+				// Another node holds the leader lock and moves the objects.
+				if _, ok := err.(OperationTimedOut); ok {
+					continue
+				}
+				logger.LogIf(ctx, err)
+			}
+		}
+	}
+}
+
+// decommissionRound - drains all draining pools and sets and marks them
+// as decommissioned once empty, only one node of the cluster moves
+// objects at a time.
+func decommissionRound(ctx context.Context, z *xlPools) error {
+	if !z.isDraining() {
+		return nil
+	}
+
+	// Do not wait for the leader lock, a failure means another node is the leader.
+	zeroDuration := time.Millisecond
+	leaderLock := globalNSMutex.NewNSLock(minioMetaBucket, decommissionLeaderLock)
+	if err := leaderLock.GetLock(newDynamicTimeout(zeroDuration, zeroDuration)); err != nil {
+		return err
+	}
+	defer leaderLock.Unlock()
+
+	for _, pool := range z.pools {
+		switch pool.decommissionState() {
+		case madmin.PoolDraining:
+			if err := z.drainPool(ctx, pool); err != nil {
+				return err
+			}
+			if err := pool.saveDecommissionState(ctx, madmin.PoolDecommissioned); err != nil {
+				return err
+			}
+		case madmin.PoolActive:
+			if !pool.isDrainingSets() {
+				continue
+			}
+			for setIdx := range pool.sets {
+				if pool.setDecommissionState(setIdx) != madmin.PoolDraining {
+					continue
+				}
+				if err := z.drainSet(ctx, pool, setIdx); err != nil {
+					return err
+				}
+				if err := pool.saveSetDecommissionState(ctx, setIdx, madmin.PoolDecommissioned); err != nil {
+					return err
+				}
+			}
+		default:
+			continue
+		}
+
+		// Notify the peers to reload the format of the decommissioned pool or sets.
+		for _, nerr := range globalNotificationSys.ReloadFormat(false) {
+			if nerr.Err != nil {
+				logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+				logger.LogIf(ctx, nerr.Err)
+			}
+		}
+	}
+	return nil
+}
+
+// drainPool - moves all objects and multipart uploads of a draining pool
+// to the active pool with the most free space.
+func (z *xlPools) drainPool(ctx context.Context, pool *xlSets) error {
+	getTarget := func() *xlSets {
+		return z.pools[z.getAvailablePoolIdx()]
+	}
+	return z.drain(ctx, pool, pool.poolID(), pool.sets, getTarget)
+}
+
+// drainSet - moves all objects and multipart uploads of a draining erasure
+// set to the active sets of its pool.
+func (z *xlPools) drainSet(ctx context.Context, pool *xlSets, setIdx int) error {
+	getTarget := func() *xlSets {
+		return pool
+	}
+	return z.drain(ctx, pool, pool.setID(setIdx), pool.sets[setIdx:setIdx+1], getTarget)
+}
+
+// drain - moves all objects and multipart uploads of the erasure sets of
+// a pool to the target pool, whose placement leaves out draining sets.
+// The sets are walked again until a walk finds nothing to move, the
+// progress kept under id is persisted after every erasure set.
+func (z *xlPools) drain(ctx context.Context, pool *xlSets, id string, sets []*xlObjects, getTarget func() *xlSets) error {
+	progress, err := loadDecommissionProgress(ctx, z)
+	if err != nil {
+		return err
+	}
+	info := progress[id]
+
+	// Wait for the writes which picked the draining pool or set before
+	// the drain started, their objects are found by the walks below.
+	writesLock := z.newDrainLock(drainWritesLock)
+	if err = writesLock.GetLock(globalObjectTimeout); err != nil {
+		return err
+	}
+	writesLock.Unlock()
+
+	for {
+		target := getTarget()
+
+		buckets, err := pool.ListBuckets(ctx)
+		if err != nil {
+			return err
+		}
+
+		var moved uint64
+		info.ObjectsFailed = 0
+		for _, set := range sets {
+			objects, failed := z.drainObjects(ctx, set, target, buckets)
+			uploads, failedUploads := z.drainUploads(ctx, set, target)
+
+			moved += objects + uploads
+			info.ObjectsMoved += objects
+			info.UploadsMoved += uploads
+			info.ObjectsFailed += failed + failedUploads
+			info.LastUpdate = UTCNow()
+			progress[id] = info
+			if err = saveDecommissionProgress(ctx, z, progress); err != nil {
+				return err
+			}
+		}
+
+		if moved == 0 {
+			if info.ObjectsFailed > 0 {
+				return fmt.Errorf("%d objects could not be moved out of %s", info.ObjectsFailed, id)
+			}
+			return nil
+		}
+	}
+}
+
+// drainObjects - moves the objects of a draining erasure set or of an erasure
+// set of a draining pool to the target pool, including the configuration and
+// bucket metadata kept in the minio meta bucket.
+func (z *xlPools) drainObjects(ctx context.Context, xl *xlObjects, target *xlSets, buckets []BucketInfo) (moved, failed uint64) {
+	volumes := [][2]string{
+		{minioMetaBucket, minioConfigPrefix + slashSeparator},
+		{minioMetaBucket, bucketConfigPrefix + slashSeparator},
+	}
+	for _, bucket := range buckets {
+		volumes = append(volumes, [2]string{bucket.Name, ""})
+	}
+
+	for _, volume := range volumes {
+		bucket, prefix := volume[0], volume[1]
+
+		endWalkCh := make(chan struct{})
+		listDir := listDirFactory(ctx, xl.isObject, xl.getLoadBalancedDisks()...)
+		walkResultCh := startTreeWalk(ctx, bucket, prefix, "", true, listDir, xl.isObject, xl.isObjectDir, endWalkCh)
+		for walkResult := range walkResultCh {
+			if walkResult.err != nil {
+				// Bucket removed while draining.
+				if walkResult.err != errVolumeNotFound {
+					logger.LogIf(ctx, walkResult.err)
+				}
+				break
+			}
+
+			object := walkResult.entry
+			err := z.moveDrainedObject(ctx, xl, target.getHashedSet(object), bucket, object)
+			switch err {
+			case nil:
+				moved++
+			case errFileNotFound:
+				// Object removed while draining.
+			default:
+				logger.GetReqInfo(ctx).AppendTags("object", pathJoin(bucket, object))
+				logger.LogIf(ctx, err)
+				failed++
+			}
+		}
+		close(endWalkCh)
+	}
+	return moved, failed
+}
+
+// moveDrainedObject - moves an object out of a draining pool or set, holding
+// the exclusive drain lock of the object.
+func (z *xlPools) moveDrainedObject(ctx context.Context, src, dst *xlObjects, bucket, object string) error {
+	drainLock := z.newDrainLock(drainObjectsPrefix, bucket, object)
+	if err := drainLock.GetLock(globalObjectTimeout); err != nil {
+		return err
+	}
+	defer drainLock.Unlock()
+
+	return moveObject(ctx, src, dst, bucket, object)
+}
+
+// drainUploads - moves the multipart uploads of a draining erasure set or of an
+// erasure set of a draining pool to the target pool. Uploads are stored under
+// sha256(bucket/object)/uploadID, the bucket and object are read from the upload
+// metadata.
+func (z *xlPools) drainUploads(ctx context.Context, xl *xlObjects, target *xlSets) (moved, failed uint64) {
+	disks := xl.getLoadBalancedDisks()
+
+	uploadIDPaths := set.NewStringSet()
+	for _, disk := range disks {
+		if disk == nil {
+			continue
+		}
+		shaDirs, err := disk.ListDir(minioMetaMultipartBucket, "", -1)
+		if err != nil {
+			continue
+		}
+		for _, shaDir := range shaDirs {
+			uploadIDDirs, err := disk.ListDir(minioMetaMultipartBucket, shaDir, -1)
+			if err != nil {
+				continue
+			}
+			for _, uploadIDDir := range uploadIDDirs {
+				uploadIDPaths.Add(pathJoin(shaDir, uploadIDDir))
+			}
+		}
+	}
+
+	for _, uploadIDPath := range uploadIDPaths.ToSlice() {
+		var meta map[string]string
+		err := errDiskNotFound
+		for _, disk := range disks {
+			if disk == nil {
+				continue
+			}
+			if _, meta, err = readXLMetaStat(ctx, disk, minioMetaMultipartBucket, uploadIDPath); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			// Upload completed or aborted while draining.
+			continue
+		}
+
+		bucketObject, ok := meta[multipartUploadObjectKey]
+		if !ok {
+			logger.LogIf(ctx, fmt.Errorf("Multipart upload %s has no object name and cannot be moved", uploadIDPath))
+			failed++
+			continue
+		}
+
+		bucket, object := path2BucketAndObject(bucketObject)
+		uploadID := path.Base(strings.TrimSuffix(uploadIDPath, slashSeparator))
+		err = z.moveDrainedUpload(ctx, xl, target.getHashedSet(object), bucket, object, uploadID)
+		switch err {
+		case nil:
+			moved++
+		case errFileNotFound:
+			// Upload completed or aborted while draining.
+		default:
+			logger.GetReqInfo(ctx).AppendTags("uploadID", uploadID)
+			logger.LogIf(ctx, err)
+			failed++
+		}
+	}
+	return moved, failed
+}
+
+// moveDrainedUpload - moves a multipart upload out of a draining pool or set,
+// holding the exclusive drain lock of the upload.
+func (z *xlPools) moveDrainedUpload(ctx context.Context, src, dst *xlObjects, bucket, object, uploadID string) error {
+	drainLock := z.newDrainLock(drainUploadsPrefix, uploadID)
+	if err := drainLock.GetLock(globalObjectTimeout); err != nil {
+		return err
+	}
+	defer drainLock.Unlock()
+
+	return moveUpload(ctx, src, dst, bucket, object, uploadID)
+}
+
+// moveUpload - moves a multipart upload between erasure sets of the same size.
+func moveUpload(ctx context.Context, src, dst *xlObjects, bucket, object, uploadID string) error {
+	uploadIDLock := src.nsMutex.NewNSLock(minioMetaMultipartBucket, src.getUploadIDLockPath(bucket, object, uploadID))
+	if err := uploadIDLock.GetLock(globalOperationTimeout); err != nil {
+		return err
+	}
+	defer uploadIDLock.Unlock()
+
+	return moveXLDir(ctx, src, dst, minioMetaMultipartBucket, src.getUploadIDDir(bucket, object, uploadID))
+}
+
+// moveObject - moves an object between erasure sets of the same size.
+func moveObject(ctx context.Context, src, dst *xlObjects, bucket, object string) error {
+	objectLock := src.nsMutex.NewNSLock(bucket, object)
+	if err := objectLock.GetLock(globalObjectTimeout); err != nil {
+		return err
+	}
+	defer objectLock.Unlock()
+
+	// Directory objects have no files.
+	if hasSuffix(object, slashSeparator) {
+		writeQuorum := len(dst.getDisks())/2 + 1
+		if err := dst.putObjectDir(ctx, bucket, object, writeQuorum); err != nil {
+			return err
+		}
+		return src.deleteObject(ctx, bucket, object, writeQuorum, true)
+	}
+
+	return moveXLDir(ctx, src, dst, bucket, object)
+}
+
+// moveXLDir - moves the directory of an object or of a multipart upload
+// between erasure sets of the same size. Every disk copies its files to
+// the disk at the same position of the target set, which keeps the erasure
+// distribution and the bitrot checksums valid. The directory is written to
+// the target set before being removed from the source set.
+func moveXLDir(ctx context.Context, src, dst *xlObjects, volume, dirPath string) error {
+	srcDisks := src.getDisks()
+	dstDisks := dst.getDisks()
+	writeQuorum := len(dstDisks)/2 + 1
+
+	tmpID := mustGetUUID()
+
+	// Copy the files in parallel across all disks.
+	g := errgroup.WithNErrs(len(dstDisks))
+	for index := range dstDisks {
+		index := index
+		g.Go(func() error {
+			return copyDiskDir(srcDisks[index], dstDisks[index], volume, dirPath, minioMetaTmpBucket, tmpID)
+		}, index)
+	}
+
+	if err := reduceWriteQuorumErrs(ctx, g.Wait(), objectOpIgnoredErrs, writeQuorum); err != nil {
+		dst.deleteObject(ctx, minioMetaTmpBucket, tmpID, writeQuorum, false)
+		return err
+	}
+
+	if _, err := rename(ctx, dstDisks, minioMetaTmpBucket, tmpID, volume, dirPath, true, writeQuorum, nil); err != nil {
+		dst.deleteObject(ctx, minioMetaTmpBucket, tmpID, writeQuorum, false)
+		return err
+	}
+
+	return src.deleteObject(ctx, volume, dirPath, len(srcDisks)/2+1, false)
+}
+
+// copyDiskDir - copies the files of a directory from a disk to another.
+func copyDiskDir(srcDisk, dstDisk StorageAPI, srcVolume, srcDir, dstVolume, dstDir string) error {
+	if srcDisk == nil || dstDisk == nil {
+		return errDiskNotFound
+	}
+
+	entries, err := srcDisk.ListDir(srcVolume, srcDir, -1)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if hasSuffix(entry, slashSeparator) {
+			err = copyDiskDir(srcDisk, dstDisk, srcVolume, pathJoin(srcDir, entry), dstVolume, pathJoin(dstDir, entry))
+		} else {
+			err = copyDiskFile(srcDisk, dstDisk, srcVolume, pathJoin(srcDir, entry), dstVolume, pathJoin(dstDir, entry))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyDiskFile - copies a file from a disk to another.
+func copyDiskFile(srcDisk, dstDisk StorageAPI, srcVolume, srcPath, dstVolume, dstPath string) error {
+	fi, err := srcDisk.StatFile(srcVolume, srcPath)
+	if err != nil {
+		return err
+	}
+
+	reader, err := srcDisk.ReadFileStream(srcVolume, srcPath, 0, fi.Size)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	return dstDisk.CreateFile(dstVolume, dstPath, fi.Size, reader)
+}
@@ -0,0 +1,1017 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/minio/minio-go/pkg/set"
+	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/sync/errgroup"
+)
+
+// xlPools implements ObjectLayer combining server pools, each pool is an
+// independent set of erasure coded sets. New objects are written to the
+// active pool with the most free space, existing objects are looked up
+// across all pools and always updated in the pool holding them.
+type xlPools struct {
+	pools []*xlSets
+
+	// Namespace lock serializing the updates of objects and multipart
+	// uploads with their move out of a draining pool or set.
+	nsMutex *nsLockMap
+}
+
+// Initialize new server pools of erasure coded sets.
+func newXLPools(pools []*xlSets) *xlPools {
+	return &xlPools{
+		pools:   pools,
+		nsMutex: pools[0].sets[0].nsMutex,
+	}
+}
+
+// allSets - returns the erasure coded sets of all pools, decommissioned
+// sets hold no objects and are left out so their drives can be removed.
+func (z *xlPools) allSets() (sets []*xlObjects) {
+	for _, pool := range z.pools {
+		for index, set := range pool.sets {
+			if pool.setDecommissionState(index) != madmin.PoolDecommissioned {
+				sets = append(sets, set)
+			}
+		}
+	}
+	return sets
+}
+
+// availableSpace - returns the free space of all online disks in the pool.
+func (s *xlSets) availableSpace() (available uint64) {
+	for _, set := range s.sets {
+		for _, disk := range set.getDisks() {
+			if disk == nil {
+				continue
+			}
+			info, err := disk.DiskInfo()
+			if err != nil {
+				continue
+			}
+			available += info.Free
+		}
+	}
+	return available
+}
+
+// decommissionState - returns the decommission state of the pool.
+func (s *xlSets) decommissionState() string {
+	state := s.getFormat().XL.Decommission
+	if state == "" {
+		return madmin.PoolActive
+	}
+	return state
+}
+
+// setDecommissionState - returns the decommission state of an erasure set of the pool.
+func (s *xlSets) setDecommissionState(setIdx int) string {
+	states := s.getFormat().XL.SetsDecommission
+	if setIdx >= len(states) || states[setIdx] == "" {
+		return madmin.PoolActive
+	}
+	return states[setIdx]
+}
+
+// isDrainingSets - returns true if any erasure set of the pool is draining.
+func (s *xlSets) isDrainingSets() bool {
+	for index := range s.sets {
+		if s.setDecommissionState(index) == madmin.PoolDraining {
+			return true
+		}
+	}
+	return false
+}
+
+// getAvailablePoolIdx - returns the index of the active pool with the
+// most free space, draining and decommissioned pools take no new objects.
+func (z *xlPools) getAvailablePoolIdx() int {
+	var maxAvailable uint64
+	poolIdx := -1
+	for index, pool := range z.pools {
+		if pool.decommissionState() != madmin.PoolActive {
+			continue
+		}
+		if available := pool.availableSpace(); poolIdx < 0 || available > maxAvailable {
+			maxAvailable = available
+			poolIdx = index
+		}
+	}
+	if poolIdx < 0 {
+		// No pool is in service, keep using the first pool.
+		return 0
+	}
+	return poolIdx
+}
+
+// readOrder - returns the pools in the order objects are looked up. Draining
+// pools come first, a moved object is removed from the draining pool only
+// after it has been written to an active pool.
+func (z *xlPools) readOrder() []*xlSets {
+	pools := make([]*xlSets, 0, len(z.pools))
+	for _, pool := range z.pools {
+		if pool.decommissionState() == madmin.PoolDraining {
+			pools = append(pools, pool)
+		}
+	}
+	for _, pool := range z.pools {
+		if pool.decommissionState() != madmin.PoolDraining {
+			pools = append(pools, pool)
+		}
+	}
+	return pools
+}
+
+// isDraining - returns true if any pool or erasure set is draining.
+func (z *xlPools) isDraining() bool {
+	for _, pool := range z.pools {
+		if pool.decommissionState() == madmin.PoolDraining || pool.isDrainingSets() {
+			return true
+		}
+	}
+	return false
+}
+
+// newDrainLock - returns the lock serializing the updates of an object or
+// of a multipart upload with its move out of a draining pool or set.
+func (z *xlPools) newDrainLock(path ...string) RWLocker {
+	return z.nsMutex.NewNSLock(minioMetaBucket, pathJoin(append([]string{drainLockPrefix}, path...)...))
+}
+
+// drainRLock - read locks an object or a multipart upload against its move
+// out of a draining pool or set, the returned function releases the lock.
+// The lock is taken even when nothing drains, a drain may start while the
+// update is in progress.
+func (z *xlPools) drainRLock(path ...string) (func(), error) {
+	drainLock := z.newDrainLock(path...)
+	if err := drainLock.GetRLock(globalObjectTimeout); err != nil {
+		return nil, err
+	}
+	return drainLock.RUnlock, nil
+}
+
+// placementLock - locks an object which the write may create. With several
+// pools the object is locked exclusively across the lookup of its pool and
+// the write, so concurrent writes of a new object are not placed on different
+// pools. The writes are also read locked, a drain starting meanwhile waits
+// for the write instead of missing its object. The returned function releases
+// the locks.
+func (z *xlPools) placementLock(bucket, object string) (func(), error) {
+	writesUnlock, err := z.drainRLock(drainWritesLock)
+	if err != nil {
+		return nil, err
+	}
+
+	var objectUnlock func()
+	if len(z.pools) == 1 {
+		objectUnlock, err = z.drainRLock(drainObjectsPrefix, bucket, object)
+	} else {
+		drainLock := z.newDrainLock(drainObjectsPrefix, bucket, object)
+		if err = drainLock.GetLock(globalObjectTimeout); err == nil {
+			objectUnlock = drainLock.Unlock
+		}
+	}
+	if err != nil {
+		writesUnlock()
+		return nil, err
+	}
+
+	return func() {
+		objectUnlock()
+		writesUnlock()
+	}, nil
+}
+
+// findPoolIdx - returns the index of the pool holding any version of
+// the object, -1 is returned when no pool holds the object. Pools are
+// only checked for the presence of `xl.json`, a pool which can not tell
+// is treated as not holding the object instead of failing the write.
+func (z *xlPools) findPoolIdx(bucket, object string) int {
+	for index, pool := range z.pools {
+		if pool.isObject(bucket, object) {
+			return index
+		}
+	}
+	return -1
+}
+
+// getPoolIdx - returns the index of the pool holding the object, or
+// the pool with the most free space when the object does not exist.
+func (z *xlPools) getPoolIdx(ctx context.Context, bucket, object string) (int, error) {
+	// A single pool holds all objects.
+	if len(z.pools) == 1 {
+		return 0, nil
+	}
+
+	index := z.findPoolIdx(bucket, object)
+	if index < 0 {
+		index = z.getAvailablePoolIdx()
+	}
+	return index, nil
+}
+
+// getUploadPool - returns the pool holding the multipart upload.
+func (z *xlPools) getUploadPool(ctx context.Context, bucket, object, uploadID string) (*xlSets, error) {
+	// A single pool holds all multipart uploads.
+	if len(z.pools) == 1 {
+		return z.pools[0], nil
+	}
+
+	for _, pool := range z.pools {
+		if pool.isUploadIDExists(ctx, bucket, object, uploadID) {
+			return pool, nil
+		}
+	}
+	return nil, InvalidUploadID{UploadID: uploadID}
+}
+
+// isErrObjectMissing - checks if the object or its version is not in the pool.
+func isErrObjectMissing(err error) bool {
+	switch err.(type) {
+	case ObjectNotFound, VersionNotFound:
+		return true
+	}
+	return false
+}
+
+// StorageInfo - combines output of StorageInfo across all server pools.
+func (z *xlPools) StorageInfo(ctx context.Context) StorageInfo {
+	storageInfo := z.pools[0].StorageInfo(ctx)
+	for _, pool := range z.pools[1:] {
+		lstorageInfo := pool.StorageInfo(ctx)
+		storageInfo.Used = storageInfo.Used + lstorageInfo.Used
+		storageInfo.Backend.OnlineDisks = storageInfo.Backend.OnlineDisks + lstorageInfo.Backend.OnlineDisks
+		storageInfo.Backend.OfflineDisks = storageInfo.Backend.OfflineDisks + lstorageInfo.Backend.OfflineDisks
+		storageInfo.Backend.Sets = append(storageInfo.Backend.Sets, lstorageInfo.Backend.Sets...)
+	}
+	return storageInfo
+}
+
+// Shutdown shutsdown all server pools in parallel
+// returns error upon first error.
+func (z *xlPools) Shutdown(ctx context.Context) error {
+	g := errgroup.WithNErrs(len(z.pools))
+
+	for index := range z.pools {
+		index := index
+		g.Go(func() error {
+			return z.pools[index].Shutdown(ctx)
+		}, index)
+	}
+
+	for _, err := range g.Wait() {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MakeBucketWithLocation - creates a new bucket across all sets of all
+// pools simultaneously, a write quorum failure in any of the sets undoes
+// the successful operations.
+func (z *xlPools) MakeBucketWithLocation(ctx context.Context, bucket, location string) error {
+	sets := z.allSets()
+	g := errgroup.WithNErrs(len(sets))
+
+	// Create buckets in parallel across all sets.
+	for index := range sets {
+		index := index
+		g.Go(func() error {
+			return sets[index].MakeBucketWithLocation(ctx, bucket, location)
+		}, index)
+	}
+
+	errs := g.Wait()
+	// Upon even a single write quorum error we undo all previously created buckets.
+	for _, err := range errs {
+		if err != nil {
+			if _, ok := err.(InsufficientWriteQuorum); ok {
+				undoMakeBucketSets(bucket, sets, errs)
+			}
+			return err
+		}
+	}
+
+	// Success.
+	return nil
+}
+
+// getBucketPool - returns the first active pool, bucket metadata is read
+// from it. Buckets are created on all sets which are not decommissioned,
+// each pool reads them from an active set.
+func (z *xlPools) getBucketPool() *xlSets {
+	for _, pool := range z.pools {
+		if pool.decommissionState() == madmin.PoolActive {
+			return pool
+		}
+	}
+	return z.pools[0]
+}
+
+// GetBucketInfo - returns bucket info from the first active pool, buckets
+// are present on all sets which are not decommissioned.
+func (z *xlPools) GetBucketInfo(ctx context.Context, bucket string) (bucketInfo BucketInfo, err error) {
+	return z.getBucketPool().GetBucketInfo(ctx, bucket)
+}
+
+// ListBuckets - lists all buckets from the first active pool, buckets
+// are present on all sets which are not decommissioned.
+func (z *xlPools) ListBuckets(ctx context.Context) (buckets []BucketInfo, err error) {
+	return z.getBucketPool().ListBuckets(ctx)
+}
+
+// DeleteBucket - deletes a bucket across all sets of all pools
+// simultaneously, a write quorum failure in any of the sets undoes
+// the successful operations.
+func (z *xlPools) DeleteBucket(ctx context.Context, bucket string) error {
+	sets := z.allSets()
+	g := errgroup.WithNErrs(len(sets))
+
+	// Delete buckets in parallel across all sets.
+	for index := range sets {
+		index := index
+		g.Go(func() error {
+			return sets[index].DeleteBucket(ctx, bucket)
+		}, index)
+	}
+
+	errs := g.Wait()
+	// For any write quorum failure, we undo all the delete buckets operation
+	// by creating all the buckets again.
+	for _, err := range errs {
+		if err != nil {
+			if _, ok := err.(InsufficientWriteQuorum); ok {
+				undoDeleteBucketSets(bucket, sets, errs)
+			}
+			return err
+		}
+	}
+
+	// Delete all bucket metadata.
+	deleteBucketMetadata(ctx, bucket, z)
+
+	// Success.
+	return nil
+}
+
+// listEntry - an object or a prefix of a pool listing.
+type listEntry struct {
+	name   string
+	object *ObjectInfo
+}
+
+// mergeListEntries - merges the lexically sorted listings of all pools
+// into the first maxKeys entries, prefixes found on several pools are
+// listed only once, as are object versions found on a draining pool and
+// on the pool they are being moved to. truncated is set when entries are
+// left over.
+func mergeListEntries(listings [][]listEntry, maxKeys int) (entries []listEntry, truncated bool) {
+	prefixes := set.NewStringSet()
+	versions := set.NewStringSet()
+	for _, listing := range listings {
+		for _, entry := range listing {
+			if entry.object == nil {
+				if prefixes.Contains(entry.name) {
+					continue
+				}
+				prefixes.Add(entry.name)
+			} else {
+				version := entry.name + slashSeparator + entry.object.VersionID
+				if versions.Contains(version) {
+					continue
+				}
+				versions.Add(version)
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	// Versions of an object are found on a single pool once moved, the
+	// stable sort keeps them in their original order.
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].name < entries[j].name
+	})
+
+	if maxKeys >= 0 && len(entries) > maxKeys {
+		return entries[:maxKeys], true
+	}
+	return entries, false
+}
+
+// ListObjects - lists objects of all pools, the lexically sorted
+// listing of each pool is merged into a single listing.
+func (z *xlPools) ListObjects(ctx context.Context, bucket, prefix, marker, delimiter string, maxKeys int) (ListObjectsInfo, error) {
+	var result ListObjectsInfo
+
+	// Over flowing count - reset to maxObjectList, every pool
+	// returns at most maxObjectList entries.
+	if maxKeys < 0 || maxKeys > maxObjectList {
+		maxKeys = maxObjectList
+	}
+
+	listings := make([][]listEntry, len(z.pools))
+	for index, pool := range z.pools {
+		loi, err := pool.ListObjects(ctx, bucket, prefix, marker, delimiter, maxKeys)
+		if err != nil {
+			return result, err
+		}
+		result.IsTruncated = result.IsTruncated || loi.IsTruncated
+		for i := range loi.Objects {
+			listings[index] = append(listings[index], listEntry{name: loi.Objects[i].Name, object: &loi.Objects[i]})
+		}
+		for _, prefix := range loi.Prefixes {
+			listings[index] = append(listings[index], listEntry{name: prefix})
+		}
+	}
+
+	entries, truncated := mergeListEntries(listings, maxKeys)
+	result.IsTruncated = result.IsTruncated || truncated
+	for _, entry := range entries {
+		result.NextMarker = entry.name
+		if entry.object == nil {
+			result.Prefixes = append(result.Prefixes, entry.name)
+			continue
+		}
+		result.Objects = append(result.Objects, *entry.object)
+	}
+	return result, nil
+}
+
+// ListObjectsV2 lists all objects in bucket filtered by prefix
+func (z *xlPools) ListObjectsV2(ctx context.Context, bucket, prefix, continuationToken, delimiter string, maxKeys int, fetchOwner bool, startAfter string) (result ListObjectsV2Info, err error) {
+	marker := continuationToken
+	if marker == "" {
+		marker = startAfter
+	}
+
+	loi, err := z.ListObjects(ctx, bucket, prefix, marker, delimiter, maxKeys)
+	if err != nil {
+		return result, err
+	}
+
+	listObjectsV2Info := ListObjectsV2Info{
+		IsTruncated:           loi.IsTruncated,
+		ContinuationToken:     continuationToken,
+		NextContinuationToken: loi.NextMarker,
+		Objects:               loi.Objects,
+		Prefixes:              loi.Prefixes,
+	}
+	return listObjectsV2Info, err
+}
+
+// ListObjectVersions - lists all versions of the objects of all pools,
+// the versions of an object are always kept on a single pool.
+func (z *xlPools) ListObjectVersions(ctx context.Context, bucket, prefix, keyMarker, versionIDMarker, delimiter string, maxKeys int) (ListObjectVersionsInfo, error) {
+	var result ListObjectVersionsInfo
+
+	// Over flowing count - reset to maxObjectList.
+	if maxKeys < 0 || maxKeys > maxObjectList {
+		maxKeys = maxObjectList
+	}
+
+	listings := make([][]listEntry, len(z.pools))
+	for index, pool := range z.pools {
+		lovi, err := pool.ListObjectVersions(ctx, bucket, prefix, keyMarker, versionIDMarker, delimiter, maxKeys)
+		if err != nil {
+			return result, err
+		}
+		result.IsTruncated = result.IsTruncated || lovi.IsTruncated
+		for i := range lovi.Objects {
+			listings[index] = append(listings[index], listEntry{name: lovi.Objects[i].Name, object: &lovi.Objects[i]})
+		}
+		for _, prefix := range lovi.Prefixes {
+			listings[index] = append(listings[index], listEntry{name: prefix})
+		}
+	}
+
+	entries, truncated := mergeListEntries(listings, maxKeys)
+	result.IsTruncated = result.IsTruncated || truncated
+	for _, entry := range entries {
+		result.NextKeyMarker = entry.name
+		if entry.object == nil {
+			result.Prefixes = append(result.Prefixes, entry.name)
+			result.NextVersionIDMarker = ""
+			continue
+		}
+		result.Objects = append(result.Objects, *entry.object)
+		result.NextVersionIDMarker = getVersionID(entry.object.VersionID)
+	}
+	if !result.IsTruncated {
+		result.NextKeyMarker = ""
+		result.NextVersionIDMarker = ""
+	}
+	return result, nil
+}
+
+// SetBucketPolicy persist the new policy on the bucket.
+func (z *xlPools) SetBucketPolicy(ctx context.Context, bucket string, policy *policy.Policy) error {
+	return savePolicyConfig(ctx, z, bucket, policy)
+}
+
+// GetBucketPolicy will return a policy on a bucket
+func (z *xlPools) GetBucketPolicy(ctx context.Context, bucket string) (*policy.Policy, error) {
+	return getPolicyConfig(z, bucket)
+}
+
+// DeleteBucketPolicy deletes all policies on bucket
+func (z *xlPools) DeleteBucketPolicy(ctx context.Context, bucket string) error {
+	return removePolicyConfig(ctx, z, bucket)
+}
+
+// IsNotificationSupported returns whether bucket notification is applicable for this layer.
+func (z *xlPools) IsNotificationSupported() bool {
+	return z.pools[0].IsNotificationSupported()
+}
+
+// IsListenBucketSupported returns whether listen bucket notification is applicable for this layer.
+func (z *xlPools) IsListenBucketSupported() bool {
+	return z.pools[0].IsListenBucketSupported()
+}
+
+// IsEncryptionSupported returns whether server side encryption is implemented for this layer.
+func (z *xlPools) IsEncryptionSupported() bool {
+	return z.pools[0].IsEncryptionSupported()
+}
+
+// IsCompressionSupported returns whether compression is applicable for this layer.
+func (z *xlPools) IsCompressionSupported() bool {
+	return z.pools[0].IsCompressionSupported()
+}
+
+// IsVersioningSupported returns whether bucket versioning is applicable for this layer.
+func (z *xlPools) IsVersioningSupported() bool {
+	return z.pools[0].IsVersioningSupported()
+}
+
+// --- Object Operations ---
+
+// GetObjectNInfo - returns object info and locked object ReadCloser
+// from the first pool holding the object.
+func (z *xlPools) GetObjectNInfo(ctx context.Context, bucket, object string, rs *HTTPRangeSpec, h http.Header, lockType LockType, opts ObjectOptions) (gr *GetObjectReader, err error) {
+	for _, pool := range z.readOrder() {
+		gr, err = pool.GetObjectNInfo(ctx, bucket, object, rs, h, lockType, opts)
+		if err == nil || !isErrObjectMissing(err) {
+			return gr, err
+		}
+	}
+	return gr, err
+}
+
+// GetObject - reads an object from the first pool holding the object.
+func (z *xlPools) GetObject(ctx context.Context, bucket, object string, startOffset int64, length int64, writer io.Writer, etag string, opts ObjectOptions) (err error) {
+	for _, pool := range z.readOrder() {
+		err = pool.GetObject(ctx, bucket, object, startOffset, length, writer, etag, opts)
+		if err == nil || !isErrObjectMissing(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// GetObjectInfo - reads object metadata from the first pool holding the object.
+func (z *xlPools) GetObjectInfo(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	for _, pool := range z.readOrder() {
+		objInfo, err = pool.GetObjectInfo(ctx, bucket, object, opts)
+		if err == nil || !isErrObjectMissing(err) {
+			return objInfo, err
+		}
+	}
+	return objInfo, err
+}
+
+// PutObject - writes an object to the pool holding the object, new
+// objects are written to the pool with the most free space.
+func (z *xlPools) PutObject(ctx context.Context, bucket string, object string, data *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	unlock, err := z.placementLock(bucket, object)
+	if err != nil {
+		return objInfo, err
+	}
+	defer unlock()
+
+	index, err := z.getPoolIdx(ctx, bucket, object)
+	if err != nil {
+		return objInfo, err
+	}
+	return z.pools[index].PutObject(ctx, bucket, object, data, opts)
+}
+
+// CopyObject - copies objects to the pool of the destination object, on server side.
+func (z *xlPools) CopyObject(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (objInfo ObjectInfo, err error) {
+	unlock, err := z.placementLock(destBucket, destObject)
+	if err != nil {
+		return objInfo, err
+	}
+	defer unlock()
+
+	index, err := z.getPoolIdx(ctx, destBucket, destObject)
+	if err != nil {
+		return objInfo, err
+	}
+	return z.pools[index].CopyObject(ctx, srcBucket, srcObject, destBucket, destObject, srcInfo, srcOpts, dstOpts)
+}
+
+// DeleteObject - deletes an object from the pool holding the object.
+func (z *xlPools) DeleteObject(ctx context.Context, bucket string, object string) (err error) {
+	unlock, err := z.drainRLock(drainObjectsPrefix, bucket, object)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	index, err := z.getPoolIdx(ctx, bucket, object)
+	if err != nil {
+		return err
+	}
+	return z.pools[index].DeleteObject(ctx, bucket, object)
+}
+
+// DeleteObjectVersion - deletes an object version from the pool holding the
+// object, delete markers of new objects go to the pool with the most free space.
+func (z *xlPools) DeleteObjectVersion(ctx context.Context, bucket string, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	unlock, err := z.placementLock(bucket, object)
+	if err != nil {
+		return objInfo, err
+	}
+	defer unlock()
+
+	index, err := z.getPoolIdx(ctx, bucket, object)
+	if err != nil {
+		return objInfo, err
+	}
+	return z.pools[index].DeleteObjectVersion(ctx, bucket, object, opts)
+}
+
+// PutObjectTags - replaces the tags of an object in the pool holding the object.
+func (z *xlPools) PutObjectTags(ctx context.Context, bucket, object, tags string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	unlock, err := z.drainRLock(drainObjectsPrefix, bucket, object)
+	if err != nil {
+		return objInfo, err
+	}
+	defer unlock()
+
+	index, err := z.getPoolIdx(ctx, bucket, object)
+	if err != nil {
+		return objInfo, err
+	}
+	return z.pools[index].PutObjectTags(ctx, bucket, object, tags, opts)
+}
+
+// PutObjectMetadata - updates the metadata of an object in the pool holding the object.
+func (z *xlPools) PutObjectMetadata(ctx context.Context, bucket, object string, meta map[string]string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	unlock, err := z.drainRLock(drainObjectsPrefix, bucket, object)
+	if err != nil {
+		return objInfo, err
+	}
+	defer unlock()
+
+	index, err := z.getPoolIdx(ctx, bucket, object)
+	if err != nil {
+		return objInfo, err
+	}
+	return z.pools[index].PutObjectMetadata(ctx, bucket, object, meta, opts)
+}
+
+// ListMultipartUploads - lists the multipart uploads of the object across all pools.
+func (z *xlPools) ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (result ListMultipartsInfo, err error) {
+	if err = checkListMultipartArgs(ctx, bucket, prefix, keyMarker, uploadIDMarker, delimiter, z); err != nil {
+		return result, err
+	}
+
+	result.MaxUploads = maxUploads
+	result.KeyMarker = keyMarker
+	result.UploadIDMarker = uploadIDMarker
+	result.Prefix = prefix
+	result.Delimiter = delimiter
+
+	// In list multipart uploads we are going to treat input prefix as the
+	// object, the sorted uploads of all pools are merged before paging.
+	listings := make([][]MultipartInfo, len(z.pools))
+	for index, pool := range z.pools {
+		if listings[index], err = pool.listMultipartUploads(ctx, bucket, prefix); err != nil {
+			return result, err
+		}
+	}
+
+	// Uploads found on a draining pool and on the pool they are being
+	// moved to are listed only once.
+	uploadIDs := set.NewStringSet()
+	var uploads []MultipartInfo
+	for {
+		next := -1
+		for index, listing := range listings {
+			if len(listing) == 0 {
+				continue
+			}
+			if next < 0 || isMultipartInfoLess(listing[0], listings[next][0]) {
+				next = index
+			}
+		}
+		if next < 0 {
+			break
+		}
+		upload := listings[next][0]
+		listings[next] = listings[next][1:]
+		if uploadIDs.Contains(upload.UploadID) {
+			continue
+		}
+		uploadIDs.Add(upload.UploadID)
+		uploads = append(uploads, upload)
+	}
+
+	setMultipartUploadsPage(&result, uploads)
+	return result, nil
+}
+
+// NewMultipartUpload - initiates a new multipart upload on the pool holding
+// the object or its pending uploads, otherwise on the active pool with the
+// most free space.
+func (z *xlPools) NewMultipartUpload(ctx context.Context, bucket, object string, opts ObjectOptions) (uploadID string, err error) {
+	unlock, err := z.placementLock(bucket, object)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	// A single pool holds all objects and multipart uploads.
+	if len(z.pools) == 1 {
+		return z.pools[0].NewMultipartUpload(ctx, bucket, object, opts)
+	}
+
+	index := z.findPoolIdx(bucket, object)
+	for i, pool := range z.pools {
+		if index >= 0 {
+			break
+		}
+		if pool.decommissionState() != madmin.PoolActive {
+			continue
+		}
+		result, err := pool.ListMultipartUploads(ctx, bucket, object, "", "", "", 1)
+		if err != nil {
+			return "", err
+		}
+		if len(result.Uploads) > 0 {
+			index = i
+		}
+	}
+	if index < 0 {
+		index = z.getAvailablePoolIdx()
+	}
+	return z.pools[index].NewMultipartUpload(ctx, bucket, object, opts)
+}
+
+// CopyObjectPart - copies a part of an object to the pool holding the multipart upload.
+func (z *xlPools) CopyObjectPart(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, uploadID string, partID int,
+	startOffset int64, length int64, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (partInfo PartInfo, err error) {
+	unlock, err := z.drainRLock(drainUploadsPrefix, uploadID)
+	if err != nil {
+		return partInfo, err
+	}
+	defer unlock()
+
+	pool, err := z.getUploadPool(ctx, destBucket, destObject, uploadID)
+	if err != nil {
+		return partInfo, err
+	}
+	return pool.CopyObjectPart(ctx, srcBucket, srcObject, destBucket, destObject, uploadID, partID, startOffset, length, srcInfo, srcOpts, dstOpts)
+}
+
+// PutObjectPart - writes part of an object to the pool holding the multipart upload.
+func (z *xlPools) PutObjectPart(ctx context.Context, bucket, object, uploadID string, partID int, data *PutObjReader, opts ObjectOptions) (info PartInfo, err error) {
+	unlock, err := z.drainRLock(drainUploadsPrefix, uploadID)
+	if err != nil {
+		return info, err
+	}
+	defer unlock()
+
+	pool, err := z.getUploadPool(ctx, bucket, object, uploadID)
+	if err != nil {
+		return info, err
+	}
+	return pool.PutObjectPart(ctx, bucket, object, uploadID, partID, data, opts)
+}
+
+// ListObjectParts - lists all uploaded parts of the multipart upload.
+func (z *xlPools) ListObjectParts(ctx context.Context, bucket, object, uploadID string, partNumberMarker int, maxParts int, opts ObjectOptions) (result ListPartsInfo, err error) {
+	pool, err := z.getUploadPool(ctx, bucket, object, uploadID)
+	if err != nil {
+		return result, err
+	}
+	return pool.ListObjectParts(ctx, bucket, object, uploadID, partNumberMarker, maxParts, opts)
+}
+
+// AbortMultipartUpload - aborts an in-progress multipart upload.
+func (z *xlPools) AbortMultipartUpload(ctx context.Context, bucket, object, uploadID string) error {
+	unlock, err := z.drainRLock(drainUploadsPrefix, uploadID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	pool, err := z.getUploadPool(ctx, bucket, object, uploadID)
+	if err != nil {
+		return err
+	}
+	return pool.AbortMultipartUpload(ctx, bucket, object, uploadID)
+}
+
+// CompleteMultipartUpload - completes a pending multipart upload on the pool holding it.
+func (z *xlPools) CompleteMultipartUpload(ctx context.Context, bucket, object, uploadID string, uploadedParts []CompletePart, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	writesUnlock, err := z.drainRLock(drainWritesLock)
+	if err != nil {
+		return objInfo, err
+	}
+	defer writesUnlock()
+
+	// Objects and uploads held by a draining pool or set are moved out
+	// first, which requires the exclusive drain lock of the object.
+	drainLock := z.newDrainLock(drainObjectsPrefix, bucket, object)
+	if err = drainLock.GetLock(globalObjectTimeout); err != nil {
+		return objInfo, err
+	}
+	defer drainLock.Unlock()
+
+	unlock, err := z.drainRLock(drainUploadsPrefix, uploadID)
+	if err != nil {
+		return objInfo, err
+	}
+	defer unlock()
+
+	pool, err := z.getUploadPool(ctx, bucket, object, uploadID)
+	if err != nil {
+		return objInfo, err
+	}
+
+	// A single pool moves its objects and uploads between its sets.
+	if len(z.pools) == 1 {
+		return pool.CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts, opts)
+	}
+
+	index := z.findPoolIdx(bucket, object)
+	if pool.decommissionState() == madmin.PoolDraining {
+		// The upload is completed on an active pool, the object would
+		// be left behind on the draining pool otherwise.
+		target := index
+		if target < 0 || z.pools[target].decommissionState() != madmin.PoolActive {
+			target = z.getAvailablePoolIdx()
+		}
+		if err = moveUpload(ctx, pool.getUploadSet(ctx, bucket, object, uploadID), z.pools[target].getHashedSet(object), bucket, object, uploadID); err != nil {
+			return objInfo, toObjectErr(err, bucket, object)
+		}
+		pool = z.pools[target]
+	}
+	if index >= 0 && z.pools[index] != pool && z.pools[index].decommissionState() == madmin.PoolDraining {
+		objectSet, err := z.pools[index].getObjectSet(ctx, bucket, object)
+		if err != nil {
+			return objInfo, err
+		}
+		if err = moveObject(ctx, objectSet, pool.getUploadSet(ctx, bucket, object, uploadID), bucket, object); err != nil {
+			return objInfo, toObjectErr(err, bucket, object)
+		}
+	}
+
+	return pool.CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts, opts)
+}
+
+// ReloadFormat - reloads the format of all pools from the disks.
+func (z *xlPools) ReloadFormat(ctx context.Context, dryRun bool) error {
+	for _, pool := range z.pools {
+		if err := pool.ReloadFormat(ctx, dryRun); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeHealResult - adds the drives and sets of a pool to the heal result.
+func mergeHealResult(result *madmin.HealResultItem, poolResult madmin.HealResultItem) {
+	result.DiskCount += poolResult.DiskCount
+	result.SetCount += poolResult.SetCount
+	result.Before.Drives = append(result.Before.Drives, poolResult.Before.Drives...)
+	result.After.Drives = append(result.After.Drives, poolResult.After.Drives...)
+}
+
+// HealFormat - heals missing `format.json` on fresh unformatted disks of all pools.
+func (z *xlPools) HealFormat(ctx context.Context, dryRun bool) (madmin.HealResultItem, error) {
+	result := madmin.HealResultItem{
+		Type:   madmin.HealItemMetadata,
+		Detail: "disk-format",
+	}
+
+	var healed bool
+	for _, pool := range z.pools {
+		poolResult, err := pool.HealFormat(ctx, dryRun)
+		if err != nil && err != errNoHealRequired {
+			return result, err
+		}
+		healed = healed || err == nil
+		mergeHealResult(&result, poolResult)
+	}
+
+	if !healed {
+		return result, errNoHealRequired
+	}
+	return result, nil
+}
+
+// HealBucket - heals inconsistent buckets and bucket metadata on all pools.
+func (z *xlPools) HealBucket(ctx context.Context, bucket string, dryRun, remove bool) (madmin.HealResultItem, error) {
+	result := madmin.HealResultItem{
+		Type:   madmin.HealItemBucket,
+		Bucket: bucket,
+	}
+
+	for _, pool := range z.pools {
+		poolResult, err := pool.HealBucket(ctx, bucket, dryRun, remove)
+		if err != nil {
+			return result, err
+		}
+		mergeHealResult(&result, poolResult)
+	}
+	return result, nil
+}
+
+// HealObject - heals inconsistent object on the first pool holding the object.
+func (z *xlPools) HealObject(ctx context.Context, bucket, object string, dryRun, remove bool) (result madmin.HealResultItem, err error) {
+	for _, pool := range z.readOrder() {
+		result, err = pool.HealObject(ctx, bucket, object, dryRun, remove)
+		if err == nil || !isErrObjectMissing(err) {
+			return result, err
+		}
+	}
+	return result, err
+}
+
+// ListBucketsHeal - lists all buckets which need healing on any of the pools.
+func (z *xlPools) ListBucketsHeal(ctx context.Context) ([]BucketInfo, error) {
+	listBuckets := []BucketInfo{}
+	var healBuckets = map[string]BucketInfo{}
+	for _, pool := range z.pools {
+		buckets, err := pool.ListBucketsHeal(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, bucketInfo := range buckets {
+			healBuckets[bucketInfo.Name] = bucketInfo
+		}
+	}
+	for _, bucketInfo := range healBuckets {
+		listBuckets = append(listBuckets, bucketInfo)
+	}
+	return listBuckets, nil
+}
+
+// ListObjectsHeal - lists the objects of all pools which need healing.
+func (z *xlPools) ListObjectsHeal(ctx context.Context, bucket, prefix, marker, delimiter string, maxKeys int) (ListObjectsInfo, error) {
+	var result ListObjectsInfo
+
+	// Over flowing count - reset to maxObjectList, every pool
+	// returns at most maxObjectList entries.
+	if maxKeys < 0 || maxKeys > maxObjectList {
+		maxKeys = maxObjectList
+	}
+
+	listings := make([][]listEntry, len(z.pools))
+	for index, pool := range z.pools {
+		loi, err := pool.ListObjectsHeal(ctx, bucket, prefix, marker, delimiter, maxKeys)
+		if err != nil {
+			return result, err
+		}
+		result.IsTruncated = result.IsTruncated || loi.IsTruncated
+		for i := range loi.Objects {
+			listings[index] = append(listings[index], listEntry{name: loi.Objects[i].Name, object: &loi.Objects[i]})
+		}
+	}
+
+	entries, truncated := mergeListEntries(listings, maxKeys)
+	result.IsTruncated = result.IsTruncated || truncated
+	for _, entry := range entries {
+		result.NextMarker = entry.name
+		result.Objects = append(result.Objects, *entry.object)
+	}
+	return result, nil
+}
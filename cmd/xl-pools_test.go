@@ -0,0 +1,478 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// Tests merging the listings of server pools.
+func TestMergeListEntries(t *testing.T) {
+	object := func(name string) listEntry {
+		return listEntry{name: name, object: &ObjectInfo{Name: name}}
+	}
+	prefix := func(name string) listEntry {
+		return listEntry{name: name}
+	}
+
+	testCases := []struct {
+		listings          [][]listEntry
+		maxKeys           int
+		expectedEntries   []string
+		expectedTruncated bool
+	}{
+		{[][]listEntry{{object("a"), object("c")}, {object("b")}}, 10, []string{"a", "b", "c"}, false},
+		{[][]listEntry{{object("a"), object("c")}, {object("b")}}, 2, []string{"a", "b"}, true},
+		{[][]listEntry{{object("a"), prefix("d/")}, {prefix("d/"), object("e")}}, 10, []string{"a", "d/", "e"}, false},
+		{[][]listEntry{{}, {object("b")}}, 1, []string{"b"}, false},
+		// Object being moved out of a draining pool.
+		{[][]listEntry{{object("a")}, {object("a"), object("b")}}, 10, []string{"a", "b"}, false},
+		{[][]listEntry{{}, {}}, 10, nil, false},
+	}
+
+	for i, testCase := range testCases {
+		entries, truncated := mergeListEntries(testCase.listings, testCase.maxKeys)
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.name)
+		}
+		if !reflect.DeepEqual(names, testCase.expectedEntries) {
+			t.Fatalf("test %v: entries: expected: %v, got: %v", i+1, testCase.expectedEntries, names)
+		}
+		if truncated != testCase.expectedTruncated {
+			t.Fatalf("test %v: truncated: expected: %v, got: %v", i+1, testCase.expectedTruncated, truncated)
+		}
+	}
+}
+
+// Tests that objects are looked up and updated across server pools.
+func TestXLPoolsObjects(t *testing.T) {
+	nDisks := 16
+	disks, err := getRandomDisks(2 * nDisks)
+	if err != nil {
+		t.Fatal("Failed to create disks for the backend")
+	}
+	defer removeRoots(disks)
+
+	obj, err := newObjectLayer(EndpointPools{
+		{SetCount: 1, DrivesPerSet: 16, Endpoints: mustGetNewEndpointList(disks[:nDisks]...)},
+		{SetCount: 1, DrivesPerSet: 16, Endpoints: mustGetNewEndpointList(disks[nDisks:]...)},
+	})
+	if err != nil {
+		t.Fatal("Unexpected object layer initialization error", err)
+	}
+	z := obj.(*xlPools)
+	defer z.Shutdown(context.Background())
+
+	ctx := context.Background()
+	bucket := "bucket"
+	if err = z.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+	for _, pool := range z.pools {
+		if _, err = pool.GetBucketInfo(ctx, bucket); err != nil {
+			t.Fatal("Bucket not created on all pools", err)
+		}
+	}
+
+	data := []byte("hello")
+	putObject := func(objAPI ObjectLayer, object string) {
+		if _, err := objAPI.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Object already on the second pool is updated in place.
+	putObject(z.pools[1], "object1")
+	putObject(z, "object1")
+	if _, err = z.pools[0].GetObjectInfo(ctx, bucket, "object1", ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatal("Object written to the wrong pool", err)
+	}
+	if _, err = z.GetObjectInfo(ctx, bucket, "object1", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	putObject(z.pools[0], "object0")
+	putObject(z, "object2")
+
+	result, err := z.ListObjects(ctx, bucket, "", "", "", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Objects) != 2 || result.Objects[0].Name != "object0" || result.Objects[1].Name != "object1" || !result.IsTruncated {
+		t.Fatalf("Unexpected listing %v", result)
+	}
+	result, err = z.ListObjects(ctx, bucket, "", result.NextMarker, "", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Objects) != 1 || result.Objects[0].Name != "object2" || result.IsTruncated {
+		t.Fatalf("Unexpected listing %v", result)
+	}
+
+	if err = z.DeleteObject(ctx, bucket, "object1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = z.GetObjectInfo(ctx, bucket, "object1", ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatal("Expected object to be deleted", err)
+	}
+}
+
+// Tests paging through the multipart uploads of server pools.
+func TestXLPoolsListMultipartUploads(t *testing.T) {
+	nDisks := 16
+	disks, err := getRandomDisks(2 * nDisks)
+	if err != nil {
+		t.Fatal("Failed to create disks for the backend")
+	}
+	defer removeRoots(disks)
+
+	obj, err := newObjectLayer(EndpointPools{
+		{SetCount: 1, DrivesPerSet: 16, Endpoints: mustGetNewEndpointList(disks[:nDisks]...)},
+		{SetCount: 1, DrivesPerSet: 16, Endpoints: mustGetNewEndpointList(disks[nDisks:]...)},
+	})
+	if err != nil {
+		t.Fatal("Unexpected object layer initialization error", err)
+	}
+	z := obj.(*xlPools)
+	defer z.Shutdown(context.Background())
+
+	ctx := context.Background()
+	bucket, object := "bucket", "object"
+	if err = z.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// Uploads initiated alternately on both pools.
+	var uploadIDs []string
+	for i := 0; i < 5; i++ {
+		uploadID, err := z.pools[i%2].NewMultipartUpload(ctx, bucket, object, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		uploadIDs = append(uploadIDs, uploadID)
+	}
+
+	testCases := []struct {
+		expectedUploads   []string
+		expectedTruncated bool
+	}{
+		{uploadIDs[0:2], true},
+		{uploadIDs[2:4], true},
+		{uploadIDs[4:5], false},
+	}
+
+	var keyMarker, uploadIDMarker string
+	for i, testCase := range testCases {
+		result, err := z.ListMultipartUploads(ctx, bucket, object, keyMarker, uploadIDMarker, "", 2)
+		if err != nil {
+			t.Fatalf("test %v: %s", i+1, err)
+		}
+		var uploads []string
+		for _, upload := range result.Uploads {
+			uploads = append(uploads, upload.UploadID)
+		}
+		if !reflect.DeepEqual(uploads, testCase.expectedUploads) {
+			t.Fatalf("test %v: uploads: expected: %v, got: %v", i+1, testCase.expectedUploads, uploads)
+		}
+		if result.IsTruncated != testCase.expectedTruncated {
+			t.Fatalf("test %v: truncated: expected: %v, got: %v", i+1, testCase.expectedTruncated, result.IsTruncated)
+		}
+		expectedMarker := ""
+		if testCase.expectedTruncated {
+			expectedMarker = uploads[len(uploads)-1]
+		}
+		if result.NextUploadIDMarker != expectedMarker {
+			t.Fatalf("test %v: next upload ID marker: expected: %v, got: %v", i+1, expectedMarker, result.NextUploadIDMarker)
+		}
+		keyMarker, uploadIDMarker = result.NextKeyMarker, result.NextUploadIDMarker
+	}
+}
+
+// Tests draining a server pool.
+func TestXLPoolsDecommission(t *testing.T) {
+	nDisks := 16
+	disks, err := getRandomDisks(2 * nDisks)
+	if err != nil {
+		t.Fatal("Failed to create disks for the backend")
+	}
+	defer removeRoots(disks)
+
+	obj, err := newObjectLayer(EndpointPools{
+		{SetCount: 1, DrivesPerSet: 16, Endpoints: mustGetNewEndpointList(disks[:nDisks]...)},
+		{SetCount: 1, DrivesPerSet: 16, Endpoints: mustGetNewEndpointList(disks[nDisks:]...)},
+	})
+	if err != nil {
+		t.Fatal("Unexpected object layer initialization error", err)
+	}
+	z := obj.(*xlPools)
+	defer z.Shutdown(context.Background())
+
+	ctx := context.Background()
+	bucket := "bucket"
+	if err = z.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte("hello")
+	objects := []string{"object", "prefix/object"}
+	for _, object := range objects {
+		if _, err = z.pools[0].PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	uploadID, err := z.pools[0].NewMultipartUpload(ctx, bucket, "upload", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	part, err := z.PutObjectPart(ctx, bucket, "upload", uploadID, 1, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		pool        int
+		expectedErr error
+	}{
+		{2, errInvalidPool},
+		{0, nil},
+		{0, errPoolNotActive},
+		{1, errLastActivePool},
+	}
+	for i, testCase := range testCases {
+		if err = z.StartDecommission(ctx, testCase.pool); err != testCase.expectedErr {
+			t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+	}
+
+	// New objects go to the active pool.
+	if _, err = z.PutObject(ctx, bucket, "new-object", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = z.pools[1].GetObjectInfo(ctx, bucket, "new-object", ObjectOptions{}); err != nil {
+		t.Fatal("Object written to the draining pool", err)
+	}
+
+	if err = z.drainPool(ctx, z.pools[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, object := range objects {
+		if _, err = z.pools[0].GetObjectInfo(ctx, bucket, object, ObjectOptions{}); !isErrObjectNotFound(err) {
+			t.Fatal("Object left on the draining pool", err)
+		}
+		var buf bytes.Buffer
+		if err = z.GetObject(ctx, bucket, object, 0, int64(len(data)), &buf, "", ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.Bytes(), data) {
+			t.Fatalf("Object %s: expected: %s, got: %s", object, data, buf.Bytes())
+		}
+	}
+
+	if z.pools[0].getHashedSet("upload").isUploadIDExists(ctx, bucket, "upload", uploadID) {
+		t.Fatal("Multipart upload left on the draining pool")
+	}
+	if _, err = z.CompleteMultipartUpload(ctx, bucket, "upload", uploadID, []CompletePart{{PartNumber: 1, ETag: part.ETag}}, ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = z.pools[1].GetObjectInfo(ctx, bucket, "upload", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := z.DecommissionStatus(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := status.Pools[0]
+	if info.State != madmin.PoolDraining || info.ObjectsMoved != uint64(len(objects)) || info.UploadsMoved != 1 || info.ObjectsFailed != 0 {
+		t.Fatalf("Unexpected decommission status %v", info)
+	}
+	if status.Pools[1].State != madmin.PoolActive {
+		t.Fatalf("Unexpected decommission status %v", status.Pools[1])
+	}
+
+	// The decommission state is kept in the format of the pool.
+	if err = z.pools[0].saveDecommissionState(ctx, madmin.PoolDecommissioned); err != nil {
+		t.Fatal(err)
+	}
+	if err = z.pools[0].ReloadFormat(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+	if state := z.pools[0].decommissionState(); state != madmin.PoolDecommissioned {
+		t.Fatalf("Expected pool to be decommissioned, got %s", state)
+	}
+}
+
+// Tests draining an erasure set of a server pool.
+func TestXLPoolsSetDecommission(t *testing.T) {
+	nDisks := 32
+	disks, err := getRandomDisks(nDisks)
+	if err != nil {
+		t.Fatal("Failed to create disks for the backend")
+	}
+	defer removeRoots(disks)
+
+	obj, err := newObjectLayer(EndpointPools{
+		{SetCount: 2, DrivesPerSet: 16, Endpoints: mustGetNewEndpointList(disks...)},
+	})
+	if err != nil {
+		t.Fatal("Unexpected object layer initialization error", err)
+	}
+	z := obj.(*xlPools)
+	defer z.Shutdown(context.Background())
+
+	ctx := context.Background()
+	bucket := "bucket"
+	if err = z.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// Names hashed to the first set.
+	pool := z.pools[0]
+	var names []string
+	for i := 0; len(names) < 4; i++ {
+		name := fmt.Sprintf("object%d", i)
+		if hashKey(pool.distributionAlgo, name, len(pool.sets)) == 0 {
+			names = append(names, name)
+		}
+	}
+	objects, upload, newObject := names[:2], names[2], names[3]
+
+	data := []byte("hello")
+	for _, object := range objects {
+		if _, err = z.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	uploadID, err := z.NewMultipartUpload(ctx, bucket, upload, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	part, err := z.PutObjectPart(ctx, bucket, upload, uploadID, 1, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pool.sets[0].isUploadIDExists(ctx, bucket, upload, uploadID) {
+		t.Fatal("Multipart upload not on the hashed set")
+	}
+
+	testCases := []struct {
+		pool        int
+		set         int
+		expectedErr error
+	}{
+		{1, 0, errInvalidPool},
+		{0, 2, errInvalidSet},
+		{0, 0, nil},
+		{0, 0, errSetNotActive},
+		{0, 1, errLastActiveSet},
+	}
+	for i, testCase := range testCases {
+		if err = z.StartSetDecommission(ctx, testCase.pool, testCase.set); err != testCase.expectedErr {
+			t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+	}
+	if !z.isDraining() {
+		t.Fatal("Expected the draining set to be reported")
+	}
+
+	// Objects of the draining set stay readable, new objects go to the active set.
+	for _, object := range objects {
+		if _, err = z.GetObjectInfo(ctx, bucket, object, ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err = z.PutObject(ctx, bucket, newObject, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = pool.sets[1].GetObjectInfo(ctx, bucket, newObject, ObjectOptions{}); err != nil {
+		t.Fatal("Object written to the draining set", err)
+	}
+
+	if err = z.drainSet(ctx, pool, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, object := range objects {
+		if _, err = pool.sets[0].GetObjectInfo(ctx, bucket, object, ObjectOptions{}); !isErrObjectNotFound(err) {
+			t.Fatal("Object left on the draining set", err)
+		}
+		var buf bytes.Buffer
+		if err = z.GetObject(ctx, bucket, object, 0, int64(len(data)), &buf, "", ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.Bytes(), data) {
+			t.Fatalf("Object %s: expected: %s, got: %s", object, data, buf.Bytes())
+		}
+	}
+	result, err := z.ListObjects(ctx, bucket, "", "", "", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Objects) != 3 {
+		t.Fatalf("Unexpected listing %v", result)
+	}
+
+	if pool.sets[0].isUploadIDExists(ctx, bucket, upload, uploadID) {
+		t.Fatal("Multipart upload left on the draining set")
+	}
+	if _, err = z.CompleteMultipartUpload(ctx, bucket, upload, uploadID, []CompletePart{{PartNumber: 1, ETag: part.ETag}}, ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = pool.sets[1].GetObjectInfo(ctx, bucket, upload, ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := z.DecommissionStatus(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(status.Sets) != 1 {
+		t.Fatalf("Unexpected decommission status %v", status)
+	}
+	info := status.Sets[0]
+	if info.Pool != 0 || info.Set != 0 || info.State != madmin.PoolDraining || info.ObjectsMoved != uint64(len(objects)) || info.UploadsMoved != 1 || info.ObjectsFailed != 0 {
+		t.Fatalf("Unexpected decommission status %v", info)
+	}
+	if status.Pools[0].State != madmin.PoolActive {
+		t.Fatalf("Unexpected decommission status %v", status.Pools[0])
+	}
+
+	// The decommission state is kept in the format of the pool.
+	if err = pool.saveSetDecommissionState(ctx, 0, madmin.PoolDecommissioned); err != nil {
+		t.Fatal(err)
+	}
+	if err = pool.ReloadFormat(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+	if state := pool.setDecommissionState(0); state != madmin.PoolDecommissioned {
+		t.Fatalf("Expected set to be decommissioned, got %s", state)
+	}
+	if z.isDraining() {
+		t.Fatal("Expected no draining set")
+	}
+	if pool.getHashedSet(newObject) != pool.sets[1] {
+		t.Fatal("Object placed on the decommissioned set")
+	}
+}
@@ -18,6 +18,8 @@ package cmd
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/binary"
 	"fmt"
 	"hash/crc32"
 	"io"
@@ -55,8 +57,10 @@ func (s setsStorageAPI) Close() error {
 type xlSets struct {
 	sets []*xlObjects
 
-	// Reference format.
-	format *formatXLV3
+	// Reference format, replaced under formatMu when the pool or one
+	// of its sets is decommissioned or the disks are reformatted.
+	format   *formatXLV3
+	formatMu sync.RWMutex
 
 	// xlDisks mutex to lock xlDisks.
 	xlDisksMu sync.RWMutex
@@ -115,6 +119,10 @@ func connectEndpoint(endpoint Endpoint) (StorageAPI, *formatXLV3, error) {
 
 	format, err := loadFormatXL(disk)
 	if err != nil {
+		if err == errUnformattedDisk {
+			// A fresh disk replaced a failed one, format and heal it.
+			signalUnformattedDisk()
+		}
 		// Close the internal connection to avoid connection leaks.
 		disk.Close()
 		return nil, nil, err
@@ -168,6 +176,21 @@ func (s *xlSets) reInitDisks(refFormat *formatXLV3, storageDisks []StorageAPI, f
 	return xlDisks
 }
 
+// getFormat - returns the reference format, the returned format is
+// never modified and may be read without holding the lock.
+func (s *xlSets) getFormat() *formatXLV3 {
+	s.formatMu.RLock()
+	defer s.formatMu.RUnlock()
+	return s.format
+}
+
+// setFormat - replaces the reference format.
+func (s *xlSets) setFormat(format *formatXLV3) {
+	s.formatMu.Lock()
+	s.format = format
+	s.formatMu.Unlock()
+}
+
 // connectDisksWithQuorum is same as connectDisks but waits
 // for quorum number of formatted disks to be online in
 // any given sets.
@@ -183,7 +206,7 @@ func (s *xlSets) connectDisksWithQuorum() {
 				printEndpointError(endpoint, err)
 				continue
 			}
-			i, j, err := findDiskIndex(s.format, format)
+			i, j, err := findDiskIndex(s.getFormat(), format)
 			if err != nil {
 				// Close the internal connection to avoid connection leaks.
 				disk.Close()
@@ -211,7 +234,7 @@ func (s *xlSets) connectDisks() {
 			printEndpointError(endpoint, err)
 			continue
 		}
-		i, j, err := findDiskIndex(s.format, format)
+		i, j, err := findDiskIndex(s.getFormat(), format)
 		if err != nil {
 			// Close the internal connection to avoid connection leaks.
 			disk.Close()
@@ -464,9 +487,129 @@ func hashKey(algo string, key string, cardinality int) int {
 	}
 }
 
-// Returns always a same erasure coded set for a given input.
+// Returns always a same erasure coded set for a given input, inputs hashed
+// to a draining or decommissioned set are placed on an active set instead.
 func (s *xlSets) getHashedSet(input string) (set *xlObjects) {
-	return s.sets[hashKey(s.distributionAlgo, input, len(s.sets))]
+	index := hashKey(s.distributionAlgo, input, len(s.sets))
+	if s.setDecommissionState(index) == madmin.PoolActive {
+		return s.sets[index]
+	}
+
+	// The active set of the highest weight is picked, the placement of the
+	// input does not change when other sets are decommissioned later.
+	format := s.getFormat()
+	var maxWeight uint32
+	fallback := -1
+	for i := range s.sets {
+		if s.setDecommissionState(i) != madmin.PoolActive {
+			continue
+		}
+		sum := sha256.Sum256([]byte(format.XL.Sets[i][0] + input))
+		if weight := binary.BigEndian.Uint32(sum[:4]); fallback < 0 || weight > maxWeight {
+			maxWeight = weight
+			fallback = i
+		}
+	}
+	if fallback < 0 {
+		// No set is in service, keep using the hashed set.
+		return s.sets[index]
+	}
+	return s.sets[fallback]
+}
+
+// getReadSets - returns the erasure sets an input is looked up in. The
+// draining set the input is hashed to comes first, a moved object is
+// removed from the draining set only after it has been written to the
+// active set.
+func (s *xlSets) getReadSets(input string) []*xlObjects {
+	index := hashKey(s.distributionAlgo, input, len(s.sets))
+	if s.setDecommissionState(index) != madmin.PoolDraining {
+		return []*xlObjects{s.getHashedSet(input)}
+	}
+	return []*xlObjects{s.sets[index], s.getHashedSet(input)}
+}
+
+// isObject - checks if the entry is an object on any set it is looked up in.
+func (s *xlSets) isObject(bucket, entry string) bool {
+	for _, set := range s.getReadSets(entry) {
+		if set.isObject(bucket, entry) {
+			return true
+		}
+	}
+	return false
+}
+
+// getObjectVersions - returns the versions of the object from the first
+// set holding the object.
+func (s *xlSets) getObjectVersions(ctx context.Context, bucket, object string) (objInfos []ObjectInfo, err error) {
+	for _, set := range s.getReadSets(object) {
+		objInfos, err = set.getObjectVersions(ctx, bucket, object)
+		if err != errFileNotFound {
+			return objInfos, err
+		}
+	}
+	return objInfos, err
+}
+
+// getObjectSet - returns the set holding any version of the object, or
+// the set new objects of that name are placed on. Objects are updated
+// on a draining set until they are moved.
+func (s *xlSets) getObjectSet(ctx context.Context, bucket, object string) (*xlObjects, error) {
+	readSets := s.getReadSets(object)
+	for _, set := range readSets[:len(readSets)-1] {
+		_, err := set.getObjectVersions(ctx, bucket, object)
+		if err == nil {
+			return set, nil
+		}
+		if err != errFileNotFound {
+			return nil, toObjectErr(err, bucket, object)
+		}
+	}
+	return readSets[len(readSets)-1], nil
+}
+
+// getUploadSet - returns the set holding the multipart upload, or the set
+// new objects of that name are placed on.
+func (s *xlSets) getUploadSet(ctx context.Context, bucket, object, uploadID string) *xlObjects {
+	readSets := s.getReadSets(object)
+	for _, set := range readSets[:len(readSets)-1] {
+		if set.isUploadIDExists(ctx, bucket, object, uploadID) {
+			return set
+		}
+	}
+	return readSets[len(readSets)-1]
+}
+
+// isUploadIDExists - checks if the multipart upload exists on any set it
+// is looked up in.
+func (s *xlSets) isUploadIDExists(ctx context.Context, bucket, object, uploadID string) bool {
+	return s.getUploadSet(ctx, bucket, object, uploadID).isUploadIDExists(ctx, bucket, object, uploadID)
+}
+
+// listMultipartUploads - returns the sorted multipart uploads of the object
+// from all sets it is looked up in.
+func (s *xlSets) listMultipartUploads(ctx context.Context, bucket, object string) ([]MultipartInfo, error) {
+	var uploads []MultipartInfo
+	for _, set := range s.getReadSets(object) {
+		setUploads, err := set.listMultipartUploads(ctx, bucket, object)
+		if err != nil {
+			return nil, err
+		}
+		uploads = append(uploads, setUploads...)
+	}
+	sort.Slice(uploads, func(i, j int) bool {
+		return isMultipartInfoLess(uploads[i], uploads[j])
+	})
+
+	// Uploads being moved out of a draining set are listed only once.
+	var merged []MultipartInfo
+	for _, upload := range uploads {
+		if len(merged) > 0 && merged[len(merged)-1].UploadID == upload.UploadID {
+			continue
+		}
+		merged = append(merged, upload)
+	}
+	return merged, nil
 }
 
 // GetBucketInfo - returns bucket info from one of the erasure coded set.
@@ -531,6 +674,11 @@ func (s *xlSets) IsCompressionSupported() bool {
 	return s.getHashedSet("").IsCompressionSupported()
 }
 
+// IsVersioningSupported returns whether bucket versioning is applicable for this layer.
+func (s *xlSets) IsVersioningSupported() bool {
+	return s.getHashedSet("").IsVersioningSupported()
+}
+
 // DeleteBucket - deletes a bucket on all sets simultaneously,
 // even if one of the sets fail to delete buckets, we proceed to
 // undo a successful operation.
@@ -593,33 +741,92 @@ func (s *xlSets) ListBuckets(ctx context.Context) (buckets []BucketInfo, err err
 
 // GetObjectNInfo - returns object info and locked object ReadCloser
 func (s *xlSets) GetObjectNInfo(ctx context.Context, bucket, object string, rs *HTTPRangeSpec, h http.Header, lockType LockType, opts ObjectOptions) (gr *GetObjectReader, err error) {
-	return s.getHashedSet(object).GetObjectNInfo(ctx, bucket, object, rs, h, lockType, opts)
+	for _, set := range s.getReadSets(object) {
+		gr, err = set.GetObjectNInfo(ctx, bucket, object, rs, h, lockType, opts)
+		if err == nil || !isErrObjectMissing(err) {
+			return gr, err
+		}
+	}
+	return gr, err
 }
 
 // GetObject - reads an object from the hashedSet based on the object name.
-func (s *xlSets) GetObject(ctx context.Context, bucket, object string, startOffset int64, length int64, writer io.Writer, etag string, opts ObjectOptions) error {
-	return s.getHashedSet(object).GetObject(ctx, bucket, object, startOffset, length, writer, etag, opts)
+func (s *xlSets) GetObject(ctx context.Context, bucket, object string, startOffset int64, length int64, writer io.Writer, etag string, opts ObjectOptions) (err error) {
+	for _, set := range s.getReadSets(object) {
+		err = set.GetObject(ctx, bucket, object, startOffset, length, writer, etag, opts)
+		if err == nil || !isErrObjectMissing(err) {
+			return err
+		}
+	}
+	return err
 }
 
 // PutObject - writes an object to hashedSet based on the object name.
 func (s *xlSets) PutObject(ctx context.Context, bucket string, object string, data *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error) {
-	return s.getHashedSet(object).PutObject(ctx, bucket, object, data, opts)
+	set, err := s.getObjectSet(ctx, bucket, object)
+	if err != nil {
+		return objInfo, err
+	}
+	return set.PutObject(ctx, bucket, object, data, opts)
 }
 
 // GetObjectInfo - reads object metadata from the hashedSet based on the object name.
 func (s *xlSets) GetObjectInfo(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
-	return s.getHashedSet(object).GetObjectInfo(ctx, bucket, object, opts)
+	for _, set := range s.getReadSets(object) {
+		objInfo, err = set.GetObjectInfo(ctx, bucket, object, opts)
+		if err == nil || !isErrObjectMissing(err) {
+			return objInfo, err
+		}
+	}
+	return objInfo, err
 }
 
 // DeleteObject - deletes an object from the hashedSet based on the object name.
 func (s *xlSets) DeleteObject(ctx context.Context, bucket string, object string) (err error) {
-	return s.getHashedSet(object).DeleteObject(ctx, bucket, object)
+	set, err := s.getObjectSet(ctx, bucket, object)
+	if err != nil {
+		return err
+	}
+	return set.DeleteObject(ctx, bucket, object)
+}
+
+// DeleteObjectVersion - deletes an object version from the hashedSet based on the object name.
+func (s *xlSets) DeleteObjectVersion(ctx context.Context, bucket string, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	set, err := s.getObjectSet(ctx, bucket, object)
+	if err != nil {
+		return objInfo, err
+	}
+	return set.DeleteObjectVersion(ctx, bucket, object, opts)
+}
+
+// PutObjectTags - replaces the tags of an object in the hashedSet based on the object name.
+func (s *xlSets) PutObjectTags(ctx context.Context, bucket, object, tags string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	set, err := s.getObjectSet(ctx, bucket, object)
+	if err != nil {
+		return objInfo, err
+	}
+	return set.PutObjectTags(ctx, bucket, object, tags, opts)
+}
+
+// PutObjectMetadata - updates the metadata of an object in the hashedSet based on the object name.
+func (s *xlSets) PutObjectMetadata(ctx context.Context, bucket, object string, meta map[string]string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	set, err := s.getObjectSet(ctx, bucket, object)
+	if err != nil {
+		return objInfo, err
+	}
+	return set.PutObjectMetadata(ctx, bucket, object, meta, opts)
 }
 
 // CopyObject - copies objects from one hashedSet to another hashedSet, on server side.
 func (s *xlSets) CopyObject(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (objInfo ObjectInfo, err error) {
-	srcSet := s.getHashedSet(srcObject)
-	destSet := s.getHashedSet(destObject)
+	srcSet, err := s.getObjectSet(ctx, srcBucket, srcObject)
+	if err != nil {
+		return objInfo, err
+	}
+	destSet, err := s.getObjectSet(ctx, destBucket, destObject)
+	if err != nil {
+		return objInfo, err
+	}
 
 	// Check if this request is only metadata update.
 	cpSrcDstSame := isStringEqual(pathJoin(srcBucket, srcObject), pathJoin(destBucket, destObject))
@@ -728,7 +935,7 @@ func (s *xlSets) ListObjects(ctx context.Context, bucket, prefix, marker, delimi
 			entry = strings.TrimSuffix(entry, slashSeparator)
 			// Verify if we are at the leaf, a leaf is where we
 			// see `xl.json` inside a directory.
-			return s.getHashedSet(entry).isObject(bucket, entry)
+			return s.isObject(bucket, entry)
 		}
 
 		isLeafDir := func(bucket, entry string) bool {
@@ -776,7 +983,12 @@ func (s *xlSets) ListObjects(ctx context.Context, bucket, prefix, marker, delimi
 				}
 			}
 		} else {
-			objInfo, err = s.getHashedSet(walkResult.entry).getObjectInfo(ctx, bucket, walkResult.entry)
+			for _, set := range s.getReadSets(walkResult.entry) {
+				objInfo, err = set.getObjectInfo(ctx, bucket, walkResult.entry, ObjectOptions{})
+				if err != errFileNotFound {
+					break
+				}
+			}
 		}
 		if err != nil {
 			// Ignore errFileNotFound as the object might have got
@@ -816,43 +1028,137 @@ func (s *xlSets) ListObjects(ctx context.Context, bucket, prefix, marker, delimi
 	return result, nil
 }
 
+// ListObjectVersions - lists all versions of the objects across sets, objects are
+// walked the same way as ListObjects() and their versions read from the hashedSet.
+func (s *xlSets) ListObjectVersions(ctx context.Context, bucket, prefix, keyMarker, versionIDMarker, delimiter string, maxKeys int) (ListObjectVersionsInfo, error) {
+	var result ListObjectVersionsInfo
+	// validate all the inputs for listObjectVersions
+	if err := checkListObjsArgs(ctx, bucket, prefix, keyMarker, delimiter, s); err != nil {
+		return result, err
+	}
+
+	// With max keys of zero we have reached eof, return right here.
+	if maxKeys == 0 {
+		return result, nil
+	}
+
+	// For delimiter and prefix as '/' we do not list anything at all.
+	if delimiter == slashSeparator && prefix == slashSeparator {
+		return result, nil
+	}
+
+	// Over flowing count - reset to maxObjectList.
+	if maxKeys < 0 || maxKeys > maxObjectList {
+		maxKeys = maxObjectList
+	}
+
+	recursive := delimiter != slashSeparator
+
+	endWalkCh := make(chan struct{})
+	defer close(endWalkCh)
+
+	isLeaf := func(bucket, entry string) bool {
+		entry = strings.TrimSuffix(entry, slashSeparator)
+		return s.isObject(bucket, entry)
+	}
+
+	isLeafDir := func(bucket, entry string) bool {
+		for _, set := range s.sets {
+			if set.isObjectDir(bucket, entry) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var setDisks = make([][]StorageAPI, len(s.sets))
+	for _, set := range s.sets {
+		setDisks = append(setDisks, set.getLoadBalancedDisks())
+	}
+
+	listDir := listDirSetsFactory(ctx, isLeaf, isLeafDir, setDisks...)
+	walkResultCh := startTreeWalk(ctx, bucket, prefix, keyMarker, recursive, listDir, isLeaf, isLeafDir, endWalkCh)
+
+	return listObjectVersions(ctx, bucket, keyMarker, versionIDMarker, delimiter, maxKeys, walkResultCh, s.getObjectVersions)
+}
+
 func (s *xlSets) ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (result ListMultipartsInfo, err error) {
+	if err = checkListMultipartArgs(ctx, bucket, prefix, keyMarker, uploadIDMarker, delimiter, s); err != nil {
+		return result, err
+	}
+
+	result.MaxUploads = maxUploads
+	result.KeyMarker = keyMarker
+	result.UploadIDMarker = uploadIDMarker
+	result.Prefix = prefix
+	result.Delimiter = delimiter
+
 	// In list multipart uploads we are going to treat input prefix as the object,
 	// this means that we are not supporting directory navigation.
-	return s.getHashedSet(prefix).ListMultipartUploads(ctx, bucket, prefix, keyMarker, uploadIDMarker, delimiter, maxUploads)
+	uploads, err := s.listMultipartUploads(ctx, bucket, prefix)
+	if err != nil {
+		return result, err
+	}
+	setMultipartUploadsPage(&result, uploads)
+	return result, nil
 }
 
 // Initiate a new multipart upload on a hashedSet based on object name.
 func (s *xlSets) NewMultipartUpload(ctx context.Context, bucket, object string, opts ObjectOptions) (uploadID string, err error) {
-	return s.getHashedSet(object).NewMultipartUpload(ctx, bucket, object, opts)
+	set, err := s.getObjectSet(ctx, bucket, object)
+	if err != nil {
+		return "", err
+	}
+	return set.NewMultipartUpload(ctx, bucket, object, opts)
 }
 
 // Copies a part of an object from source hashedSet to destination hashedSet.
 func (s *xlSets) CopyObjectPart(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, uploadID string, partID int,
 	startOffset int64, length int64, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (partInfo PartInfo, err error) {
-	destSet := s.getHashedSet(destObject)
+	destSet := s.getUploadSet(ctx, destBucket, destObject, uploadID)
 
 	return destSet.PutObjectPart(ctx, destBucket, destObject, uploadID, partID, NewPutObjReader(srcInfo.Reader, nil, nil), dstOpts)
 }
 
 // PutObjectPart - writes part of an object to hashedSet based on the object name.
 func (s *xlSets) PutObjectPart(ctx context.Context, bucket, object, uploadID string, partID int, data *PutObjReader, opts ObjectOptions) (info PartInfo, err error) {
-	return s.getHashedSet(object).PutObjectPart(ctx, bucket, object, uploadID, partID, data, opts)
+	return s.getUploadSet(ctx, bucket, object, uploadID).PutObjectPart(ctx, bucket, object, uploadID, partID, data, opts)
 }
 
 // ListObjectParts - lists all uploaded parts to an object in hashedSet.
 func (s *xlSets) ListObjectParts(ctx context.Context, bucket, object, uploadID string, partNumberMarker int, maxParts int, opts ObjectOptions) (result ListPartsInfo, err error) {
-	return s.getHashedSet(object).ListObjectParts(ctx, bucket, object, uploadID, partNumberMarker, maxParts, opts)
+	return s.getUploadSet(ctx, bucket, object, uploadID).ListObjectParts(ctx, bucket, object, uploadID, partNumberMarker, maxParts, opts)
 }
 
 // Aborts an in-progress multipart operation on hashedSet based on the object name.
 func (s *xlSets) AbortMultipartUpload(ctx context.Context, bucket, object, uploadID string) error {
-	return s.getHashedSet(object).AbortMultipartUpload(ctx, bucket, object, uploadID)
+	return s.getUploadSet(ctx, bucket, object, uploadID).AbortMultipartUpload(ctx, bucket, object, uploadID)
 }
 
 // CompleteMultipartUpload - completes a pending multipart transaction, on hashedSet based on object name.
+// The object and the upload are completed on the same set, whichever of them is held by a draining
+// set is moved out of it first.
 func (s *xlSets) CompleteMultipartUpload(ctx context.Context, bucket, object, uploadID string, uploadedParts []CompletePart, opts ObjectOptions) (objInfo ObjectInfo, err error) {
-	return s.getHashedSet(object).CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts, opts)
+	uploadSet := s.getUploadSet(ctx, bucket, object, uploadID)
+	objectSet, err := s.getObjectSet(ctx, bucket, object)
+	if err != nil {
+		return objInfo, err
+	}
+
+	if readSets := s.getReadSets(object); len(readSets) > 1 && uploadSet != objectSet {
+		drainingSet := readSets[0]
+		if objectSet == drainingSet {
+			err = moveObject(ctx, drainingSet, uploadSet, bucket, object)
+		} else {
+			err = moveUpload(ctx, drainingSet, objectSet, bucket, object, uploadID)
+			uploadSet = objectSet
+		}
+		if err != nil {
+			return objInfo, toObjectErr(err, bucket, object)
+		}
+	}
+
+	return uploadSet.CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts, opts)
 }
 
 /*
@@ -990,7 +1296,7 @@ func (s *xlSets) ReloadFormat(ctx context.Context, dryRun bool) (err error) {
 	s.disksConnectDoneCh <- struct{}{}
 
 	// Replace the new format.
-	s.format = refFormat
+	s.setFormat(refFormat)
 
 	s.xlDisksMu.Lock()
 	{
@@ -1204,7 +1510,7 @@ func (s *xlSets) HealFormat(ctx context.Context, dryRun bool) (res madmin.HealRe
 		s.disksConnectDoneCh <- struct{}{}
 
 		// Replace with new reference format.
-		s.format = refFormat
+		s.setFormat(refFormat)
 
 		s.xlDisksMu.Lock()
 		{
@@ -1300,8 +1606,14 @@ func (s *xlSets) HealBucket(ctx context.Context, bucket string, dryRun, remove b
 }
 
 // HealObject - heals inconsistent object on a hashedSet based on object name.
-func (s *xlSets) HealObject(ctx context.Context, bucket, object string, dryRun, remove bool) (madmin.HealResultItem, error) {
-	return s.getHashedSet(object).HealObject(ctx, bucket, object, dryRun, remove)
+func (s *xlSets) HealObject(ctx context.Context, bucket, object string, dryRun, remove bool) (result madmin.HealResultItem, err error) {
+	for _, set := range s.getReadSets(object) {
+		result, err = set.HealObject(ctx, bucket, object, dryRun, remove)
+		if err == nil || !isErrObjectMissing(err) {
+			return result, err
+		}
+	}
+	return result, err
 }
 
 // Lists all buckets which need healing.
@@ -1339,7 +1651,7 @@ func (s *xlSets) listObjectsHeal(ctx context.Context, bucket, prefix, marker, de
 			entry = strings.TrimSuffix(entry, slashSeparator)
 			// Verify if we are at the leaf, a leaf is where we
 			// see `xl.json` inside a directory.
-			return s.getHashedSet(entry).isObject(bucket, entry)
+			return s.isObject(bucket, entry)
 		}
 
 		isLeafDir := func(bucket, entry string) bool {
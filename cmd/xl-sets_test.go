@@ -18,9 +18,12 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
 )
 
 // TestCrcHashMod - test crc hash.
@@ -77,18 +80,18 @@ func TestNewXLSets(t *testing.T) {
 	}
 
 	endpoints := mustGetNewEndpointList(erasureDisks...)
-	_, err := waitForFormatXL(context.Background(), true, endpoints, 0, 16)
+	_, err := waitForFormatXL(context.Background(), true, endpoints, 0, 16, "")
 	if err != errInvalidArgument {
 		t.Fatalf("Expecting error, got %s", err)
 	}
 
-	_, err = waitForFormatXL(context.Background(), true, nil, 1, 16)
+	_, err = waitForFormatXL(context.Background(), true, nil, 1, 16, "")
 	if err != errInvalidArgument {
 		t.Fatalf("Expecting error, got %s", err)
 	}
 
 	// Initializes all erasure disks
-	format, err := waitForFormatXL(context.Background(), true, endpoints, 1, 16)
+	format, err := waitForFormatXL(context.Background(), true, endpoints, 1, 16, "")
 	if err != nil {
 		t.Fatalf("Unable to format disks for erasure, %s", err)
 	}
@@ -117,7 +120,7 @@ func TestHashedLayer(t *testing.T) {
 		objs = append(objs, obj.(*xlObjects))
 	}
 
-	sets := &xlSets{sets: objs, distributionAlgo: "CRCMOD"}
+	sets := &xlSets{sets: objs, format: newFormatXLV3(16, 1), distributionAlgo: "CRCMOD"}
 
 	testCases := []struct {
 		objectName  string
@@ -144,3 +147,55 @@ func TestHashedLayer(t *testing.T) {
 		}
 	}
 }
+
+// TestHashedSetDecommission - tests that objects hashed to draining or
+// decommissioned sets are placed on active sets, without moving the
+// objects placed on active sets.
+func TestHashedSetDecommission(t *testing.T) {
+	var objs []*xlObjects
+	for i := 0; i < 4; i++ {
+		objs = append(objs, &xlObjects{})
+	}
+	sets := &xlSets{sets: objs, format: newFormatXLV3(4, 1), distributionAlgo: "CRCMOD"}
+
+	var objectNames []string
+	for i := 0; i < 100; i++ {
+		objectNames = append(objectNames, fmt.Sprintf("object%d", i))
+	}
+	placement := func() map[string]*xlObjects {
+		placed := make(map[string]*xlObjects)
+		for _, objectName := range objectNames {
+			placed[objectName] = sets.getHashedSet(objectName)
+		}
+		return placed
+	}
+
+	before := placement()
+	sets.format.XL.SetsDecommission = []string{madmin.PoolDraining, "", "", ""}
+	draining := placement()
+	sets.format.XL.SetsDecommission = []string{madmin.PoolDecommissioned, "", madmin.PoolDraining, ""}
+	after := placement()
+
+	for _, objectName := range objectNames {
+		hashed := objs[hashKey(sets.distributionAlgo, objectName, len(objs))]
+		if hashed != objs[0] && draining[objectName] != before[objectName] {
+			t.Fatalf("%s: placement on an active set changed", objectName)
+		}
+		if draining[objectName] == objs[0] {
+			t.Fatalf("%s: placed on a draining set", objectName)
+		}
+		if after[objectName] == objs[0] || after[objectName] == objs[2] {
+			t.Fatalf("%s: placed on a draining or decommissioned set", objectName)
+		}
+		if draining[objectName] != objs[2] && after[objectName] != draining[objectName] {
+			t.Fatalf("%s: placement on an active set changed", objectName)
+		}
+		readSets := sets.getReadSets(objectName)
+		if hashed == objs[2] && (len(readSets) != 2 || readSets[0] != objs[2]) {
+			t.Fatalf("%s: draining set not looked up first", objectName)
+		}
+		if hashed != objs[2] && len(readSets) != 1 {
+			t.Fatalf("%s: unexpected lookup in %d sets", objectName, len(readSets))
+		}
+	}
+}
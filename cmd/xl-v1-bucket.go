@@ -327,3 +327,8 @@ func (xl xlObjects) IsEncryptionSupported() bool {
 func (xl xlObjects) IsCompressionSupported() bool {
 	return true
 }
+
+// IsVersioningSupported returns whether bucket versioning is applicable for this layer.
+func (xl xlObjects) IsVersioningSupported() bool {
+	return true
+}
@@ -181,7 +181,11 @@ func disksWithAllParts(ctx context.Context, onlineDisks []StorageAPI, partsMetad
 		for _, part := range partsMetadata[i].Parts {
 			checksumInfo := erasureInfo.GetChecksumInfo(part.Name)
 			tillOffset := erasure.ShardFileTillOffset(0, part.Size, part.Size)
-			err = bitrotCheckFile(onlineDisk, bucket, pathJoin(object, part.Name), tillOffset, checksumInfo.Algorithm, checksumInfo.Hash, erasure.ShardSize())
+			if partsMetadata[i].isInline() {
+				err = bitrotCheckInline(partsMetadata[i].Data, tillOffset, checksumInfo.Algorithm, erasure.ShardSize())
+			} else {
+				err = bitrotCheckFile(onlineDisk, bucket, pathJoin(object, partsMetadata[i].DataDir, part.Name), tillOffset, checksumInfo.Algorithm, checksumInfo.Hash, erasure.ShardSize())
+			}
 			if err != nil {
 				isCorrupt := strings.HasPrefix(err.Error(), "Bitrot verification mismatch - expected ")
 				if !isCorrupt && err != errFileNotFound && err != errVolumeNotFound {
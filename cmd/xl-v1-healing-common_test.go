@@ -89,6 +89,10 @@ func TestCommonTime(t *testing.T) {
 // TestListOnlineDisks - checks if listOnlineDisks and outDatedDisks
 // are consistent with each other.
 func TestListOnlineDisks(t *testing.T) {
+	// Store the object in part files to tamper with them.
+	defer func(threshold int64) { globalXLInlineThreshold = threshold }(globalXLInlineThreshold)
+	globalXLInlineThreshold = 0
+
 	obj, disks, err := prepareXL16()
 	if err != nil {
 		t.Fatalf("Prepare XL backend failed - %v", err)
@@ -234,6 +234,45 @@ func healObject(ctx context.Context, storageDisks []StorageAPI, bucket string, o
 	// List of disks having all parts as per latest xl.json.
 	availableDisks, dataErrs := disksWithAllParts(ctx, latestDisks, partsMetadata, errs, bucket, object)
 
+	// Latest xlMetaV1 for reference. If a valid metadata is not
+	// present, it is as good as object not found.
+	latestMeta, pErr := pickValidXLMeta(ctx, partsMetadata, modTime, quorum)
+
+	// Metadata and disks having all parts of each version of the
+	// object, the latest version comes first.
+	versionsMetadata := [][]xlMetaV1{make([]xlMetaV1, len(storageDisks))}
+	versionsAvailableDisks := [][]StorageAPI{availableDisks}
+	for i := range partsMetadata {
+		if errs[i] == nil {
+			versionsMetadata[0][i] = partsMetadata[i].noncurrent()
+		}
+	}
+
+	// Noncurrent versions are verified against their own erasure
+	// metadata, a disk missing or corrupting any of them is outdated.
+	for _, version := range latestMeta.Versions {
+		metas, vErrs := xlMetadataVersion(partsMetadata, errs, version)
+		vDisks := make([]StorageAPI, len(storageDisks))
+		for i := range vDisks {
+			if vErrs[i] == nil {
+				vDisks[i] = storageDisks[i]
+			}
+		}
+		vAvailableDisks, vDataErrs := disksWithAllParts(ctx, vDisks, metas, vErrs, bucket, object)
+		for i := range dataErrs {
+			if errs[i] != nil || dataErrs[i] != nil {
+				continue
+			}
+			if vErrs[i] != nil {
+				dataErrs[i] = vErrs[i]
+			} else {
+				dataErrs[i] = vDataErrs[i]
+			}
+		}
+		versionsMetadata = append(versionsMetadata, metas)
+		versionsAvailableDisks = append(versionsAvailableDisks, vAvailableDisks)
+	}
+
 	// Initialize heal result object
 	result = madmin.HealResultItem{
 		Type:      madmin.HealItemObject,
@@ -253,16 +292,19 @@ func healObject(ctx context.Context, storageDisks []StorageAPI, bucket string, o
 	numAvailableDisks := 0
 	disksToHealCount := 0
 	for i, v := range availableDisks {
-		driveState := ""
-		switch {
-		case v != nil:
-			driveState = madmin.DriveStateOk
+		if v != nil {
 			numAvailableDisks++
 			// If data is sane on any one disk, we can
 			// extract the correct object size.
 			result.ObjectSize = partsMetadata[i].Stat.Size
 			result.ParityBlocks = partsMetadata[i].Erasure.ParityBlocks
 			result.DataBlocks = partsMetadata[i].Erasure.DataBlocks
+		}
+
+		driveState := ""
+		switch {
+		case v != nil && dataErrs[i] == nil:
+			driveState = madmin.DriveStateOk
 		case errs[i] == errDiskNotFound, dataErrs[i] == errDiskNotFound:
 			driveState = madmin.DriveStateOffline
 		case errs[i] == errFileNotFound, errs[i] == errVolumeNotFound:
@@ -326,15 +368,86 @@ func healObject(ctx context.Context, storageDisks []StorageAPI, bucket string, o
 		return result, nil
 	}
 
-	// Latest xlMetaV1 for reference. If a valid metadata is not
-	// present, it is as good as object not found.
-	latestMeta, pErr := pickValidXLMeta(ctx, partsMetadata, modTime, quorum)
 	if pErr != nil {
 		return result, toObjectErr(pErr, bucket, object)
 	}
 
+	// Objects with versions keep the data of each version in its own
+	// directory, which is healed separately.
+	versioned := latestMeta.DataDir != "" || latestMeta.DeleteMarker || len(latestMeta.Versions) > 0
+
+	// We write at temporary location and then rename to final location.
+	tmpID := mustGetUUID()
+
+	// Versions of the object in `xl.json` of each outdated disk, and
+	// the versions whose data is healed on it.
+	diskVersions := make([][]xlMetaV1, len(storageDisks))
+	healedVersions := make([][]xlMetaV1, len(storageDisks))
+	for v, version := range latestMeta.allVersions() {
+		metas := versionsMetadata[v]
+		vAvailableDisks := versionsAvailableDisks[v]
+
+		// Outdated disks not having all parts of this version.
+		vOutDatedDisks := make([]StorageAPI, len(storageDisks))
+		vNumAvailableDisks := 0
+		for i, disk := range outDatedDisks {
+			if vAvailableDisks[i] != nil {
+				vNumAvailableDisks++
+			}
+			if disk == nil {
+				continue
+			}
+			if vAvailableDisks[i] != nil {
+				diskVersions[i] = append(diskVersions[i], metas[i])
+				continue
+			}
+			vOutDatedDisks[i] = disk
+		}
+		if diskCount(vOutDatedDisks) == 0 {
+			continue
+		}
+
+		// A noncurrent version which cannot be reconstructed is left
+		// as it is on the disks still having its metadata.
+		if vNumAvailableDisks < version.Erasure.DataBlocks {
+			logger.GetReqInfo(ctx).AppendTags("versionId", version.VersionID)
+			logger.LogIf(ctx, errXLReadQuorum)
+			for i, disk := range vOutDatedDisks {
+				if disk != nil && metas[i].IsValid() {
+					diskVersions[i] = append(diskVersions[i], metas[i])
+				}
+			}
+			continue
+		}
+
+		healedMetas, vOutDatedDisks, hErr := healXLVersion(ctx, bucket, object, tmpID, version,
+			metas, vAvailableDisks, vOutDatedDisks)
+		if hErr != nil {
+			return result, toObjectErr(hErr, bucket, object)
+		}
+		for i, disk := range outDatedDisks {
+			if disk == nil || vAvailableDisks[i] != nil {
+				continue
+			}
+			// Outdated disks that had write errors should not be
+			// written to for remaining versions, so we nil it out.
+			if vOutDatedDisks[i] == nil {
+				outDatedDisks[i] = nil
+				disksToHealCount--
+				continue
+			}
+			diskVersions[i] = append(diskVersions[i], healedMetas[i])
+			healedVersions[i] = append(healedVersions[i], version)
+		}
+
+		// If all disks are having errors, we give up.
+		if disksToHealCount == 0 {
+			return result, fmt.Errorf("all disks without up-to-date data had write errors")
+		}
+	}
+
 	// Clear data files of the object on outdated disks
-	for _, disk := range outDatedDisks {
+	for i, disk := range outDatedDisks {
 		// Before healing outdated disks, we need to remove
 		// xl.json and part files from "bucket/object/" so
 		// that rename(minioMetaBucket, "tmp/tmpuuid/",
@@ -344,6 +457,23 @@ func healObject(ctx context.Context, storageDisks []StorageAPI, bucket string, o
 			continue
 		}
 
+		if versioned {
+			_ = disk.DeleteFile(bucket, pathJoin(object, xlMetaJSONFile))
+			// Remove versions no longer part of the object along
+			// with the stale data of the healed versions.
+			if errs[i] == nil {
+				for _, stale := range partsMetadata[i].allVersions() {
+					if !hasXLVersion(latestMeta.allVersions(), stale) {
+						_ = deleteXLVersionData(ctx, disk, bucket, object, stale)
+					}
+				}
+			}
+			for _, version := range healedVersions[i] {
+				_ = deleteXLVersionData(ctx, disk, bucket, object, version)
+			}
+			continue
+		}
+
 		// List and delete the object directory,
 		files, derr := disk.ListDir(bucket, object, -1)
 		if derr == nil {
@@ -354,60 +484,133 @@ func healObject(ctx context.Context, storageDisks []StorageAPI, bucket string, o
 		}
 	}
 
+	// Generate `xl.json` of each outdated disk from its versions.
+	for i, versions := range diskVersions {
+		if outDatedDisks[i] == nil {
+			continue
+		}
+		partsMetadata[i] = versions[0]
+		partsMetadata[i].Versions = versions[1:]
+	}
+
 	// Reorder so that we have data disks first and parity disks next.
-	latestDisks = shuffleDisks(latestDisks, latestMeta.Erasure.Distribution)
-	outDatedDisks = shuffleDisks(outDatedDisks, latestMeta.Erasure.Distribution)
-	partsMetadata = shufflePartsMetadata(partsMetadata, latestMeta.Erasure.Distribution)
+	distribution := latestMeta.Erasure.Distribution
+	shuffledDisks := shuffleDisks(outDatedDisks, distribution)
+	partsMetadata = shufflePartsMetadata(partsMetadata, distribution)
+
+	// Generate and write `xl.json` generated from other disks.
+	shuffledDisks, aErr := writeUniqueXLMetadata(ctx, shuffledDisks, minioMetaTmpBucket, tmpID,
+		partsMetadata, diskCount(shuffledDisks))
+	if aErr != nil {
+		return result, toObjectErr(aErr, bucket, object)
+	}
+
+	// Rename from tmp location to the actual location.
 	for i := range outDatedDisks {
-		if outDatedDisks[i] == nil {
+		disk := shuffledDisks[i]
+		if distribution != nil {
+			disk = shuffledDisks[distribution[i]-1]
+		}
+		if disk == nil {
 			continue
 		}
-		partsMetadata[i] = newXLMetaFromXLMeta(latestMeta)
+
+		// Attempt a rename now from healed data to final location.
+		if versioned {
+			aErr = renameXLVersions(disk, tmpID, bucket, object, healedVersions[i])
+		} else {
+			aErr = disk.RenameFile(minioMetaTmpBucket, retainSlash(tmpID), bucket,
+				retainSlash(object))
+		}
+		if aErr != nil {
+			logger.LogIf(ctx, aErr)
+			return result, toObjectErr(aErr, bucket, object)
+		}
+
+		for i, v := range result.Before.Drives {
+			if v.Endpoint == disk.String() {
+				result.After.Drives[i].State = madmin.DriveStateOk
+			}
+		}
 	}
 
-	// We write at temporary location and then rename to final location.
-	tmpID := mustGetUUID()
+	// Set the size of the object in the heal result
+	result.ObjectSize = latestMeta.Stat.Size
+
+	return result, nil
+}
+
+// healXLVersion - reconstructs the parts of a single version of the object
+// read from availableDisks onto outDatedDisks, the healed parts are written
+// under tmpID. Returns the healed metadata of each outdated disk, disks
+// which had write errors are set to nil. All slices are in disk order.
+func healXLVersion(ctx context.Context, bucket, object, tmpID string, version xlMetaV1, metas []xlMetaV1,
+	availableDisks, outDatedDisks []StorageAPI) ([]xlMetaV1, []StorageAPI, error) {
+	distribution := version.Erasure.Distribution
+
+	// Reorder so that we have data disks first and parity disks next.
+	availableDisks = shuffleDisks(availableDisks, distribution)
+	outDatedDisks = shuffleDisks(outDatedDisks, distribution)
+	metas = shufflePartsMetadata(metas, distribution)
+
+	// Objects stored inline keep a shard in `xl.json` of each disk.
+	var inline bool
+	for i, disk := range availableDisks {
+		if disk != nil && metas[i].isInline() {
+			inline = true
+		}
+	}
+
+	healedMetas := make([]xlMetaV1, len(outDatedDisks))
+	for i, disk := range outDatedDisks {
+		if disk == nil {
+			continue
+		}
+		healedMetas[i] = newXLMetaFromXLMeta(version)
+		healedMetas[i].Erasure.Index = i + 1
+	}
+
+	erasure, err := NewErasure(ctx, version.Erasure.DataBlocks,
+		version.Erasure.ParityBlocks, version.Erasure.BlockSize)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	// Heal each part. erasureHealFile() will write the healed
+	// Heal each part. erasure.Heal() will write the healed
 	// part to .minio/tmp/uuid/ which needs to be renamed later to
 	// the final location.
-	erasure, err := NewErasure(ctx, latestMeta.Erasure.DataBlocks,
-		latestMeta.Erasure.ParityBlocks, latestMeta.Erasure.BlockSize)
-	if err != nil {
-		return result, toObjectErr(err, bucket, object)
-	}
-
-	erasureInfo := latestMeta.Erasure
-	for partIndex := 0; partIndex < len(latestMeta.Parts); partIndex++ {
-		partName := latestMeta.Parts[partIndex].Name
-		partSize := latestMeta.Parts[partIndex].Size
-		partActualSize := latestMeta.Parts[partIndex].ActualSize
-		partNumber := latestMeta.Parts[partIndex].Number
-		tillOffset := erasure.ShardFileTillOffset(0, partSize, partSize)
-		readers := make([]io.ReaderAt, len(latestDisks))
-		checksumAlgo := erasureInfo.GetChecksumInfo(partName).Algorithm
-		for i, disk := range latestDisks {
+	for _, part := range version.Parts {
+		tillOffset := erasure.ShardFileTillOffset(0, part.Size, part.Size)
+		checksumAlgo := version.Erasure.GetChecksumInfo(part.Name).Algorithm
+		readers := make([]io.ReaderAt, len(availableDisks))
+		for i, disk := range availableDisks {
 			if disk == OfflineDisk {
 				continue
 			}
-			checksumInfo := partsMetadata[i].Erasure.GetChecksumInfo(partName)
-			readers[i] = newBitrotReader(disk, bucket, pathJoin(object, partName), tillOffset, checksumAlgo, checksumInfo.Hash, erasure.ShardSize())
+			checksumInfo := metas[i].Erasure.GetChecksumInfo(part.Name)
+			if inline {
+				readers[i] = newInlineBitrotReader(metas[i].Data, tillOffset, checksumAlgo, erasure.ShardSize())
+				continue
+			}
+			readers[i] = newBitrotReader(disk, bucket, pathJoin(object, version.DataDir, part.Name), tillOffset, checksumAlgo, checksumInfo.Hash, erasure.ShardSize())
 		}
 		writers := make([]io.Writer, len(outDatedDisks))
 		for i, disk := range outDatedDisks {
 			if disk == OfflineDisk {
 				continue
 			}
-			writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, pathJoin(tmpID, partName), tillOffset, checksumAlgo, erasure.ShardSize())
+			if inline {
+				writers[i] = newInlineBitrotWriter(tillOffset, checksumAlgo, erasure.ShardSize())
+				continue
+			}
+			writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, pathJoin(tmpID, version.DataDir, part.Name), tillOffset, checksumAlgo, erasure.ShardSize())
 		}
-		hErr := erasure.Heal(ctx, readers, writers, partSize)
+		hErr := erasure.Heal(ctx, readers, writers, part.Size)
 		closeBitrotReaders(readers)
 		closeBitrotWriters(writers)
 		if hErr != nil {
-			return result, toObjectErr(hErr, bucket, object)
+			return nil, nil, hErr
 		}
-		// outDatedDisks that had write errors should not be
-		// written to for remaining parts, so we nil it out.
 		for i, disk := range outDatedDisks {
 			if disk == nil {
 				continue
@@ -416,51 +619,28 @@ func healObject(ctx context.Context, storageDisks []StorageAPI, bucket string, o
 			// a healed part checksum had a write error.
 			if writers[i] == nil {
 				outDatedDisks[i] = nil
-				disksToHealCount--
 				continue
 			}
-			partsMetadata[i].AddObjectPart(partNumber, partName, "", partSize, partActualSize)
-			partsMetadata[i].Erasure.AddChecksumInfo(ChecksumInfo{partName, checksumAlgo, bitrotWriterSum(writers[i])})
-		}
-
-		// If all disks are having errors, we give up.
-		if disksToHealCount == 0 {
-			return result, fmt.Errorf("all disks without up-to-date data had write errors")
+			healedMetas[i].AddObjectPart(part.Number, part.Name, "", part.Size, part.ActualSize)
+			healedMetas[i].Erasure.AddChecksumInfo(ChecksumInfo{part.Name, checksumAlgo, bitrotWriterSum(writers[i])})
+			if inline {
+				healedMetas[i].Data = inlineBitrotWriterData(writers[i])
+			}
 		}
 	}
 
-	// Generate and write `xl.json` generated from other disks.
-	outDatedDisks, aErr := writeUniqueXLMetadata(ctx, outDatedDisks, minioMetaTmpBucket, tmpID,
-		partsMetadata, diskCount(outDatedDisks))
-	if aErr != nil {
-		return result, toObjectErr(aErr, bucket, object)
+	if distribution == nil {
+		return healedMetas, outDatedDisks, nil
 	}
 
-	// Rename from tmp location to the actual location.
-	for _, disk := range outDatedDisks {
-		if disk == nil {
-			continue
-		}
-
-		// Attempt a rename now from healed data to final location.
-		aErr = disk.RenameFile(minioMetaTmpBucket, retainSlash(tmpID), bucket,
-			retainSlash(object))
-		if aErr != nil {
-			logger.LogIf(ctx, aErr)
-			return result, toObjectErr(aErr, bucket, object)
-		}
-
-		for i, v := range result.Before.Drives {
-			if v.Endpoint == disk.String() {
-				result.After.Drives[i].State = madmin.DriveStateOk
-			}
-		}
+	// Restore the disk order of the results.
+	disks := make([]StorageAPI, len(outDatedDisks))
+	unshuffledMetas := make([]xlMetaV1, len(healedMetas))
+	for index := range disks {
+		disks[index] = outDatedDisks[distribution[index]-1]
+		unshuffledMetas[index] = healedMetas[distribution[index]-1]
 	}
-
-	// Set the size of the object in the heal result
-	result.ObjectSize = latestMeta.Stat.Size
-
-	return result, nil
+	return unshuffledMetas, disks, nil
 }
 
 // healObjectDir - heals object directory specifically, this special call
@@ -21,6 +21,8 @@ import (
 	"context"
 	"path/filepath"
 	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
 )
 
 // Tests undoes and validates if the undoing completes successfully.
@@ -136,3 +138,155 @@ func TestHealObjectXL(t *testing.T) {
 		t.Errorf("Expected %v but received %v", InsufficientReadQuorum{}, err)
 	}
 }
+
+// Tests healing of the latest version of an object with noncurrent versions.
+func TestHealObjectVersionXL(t *testing.T) {
+	// Store the versions in data directories.
+	defer func(threshold int64) { globalXLInlineThreshold = threshold }(globalXLInlineThreshold)
+	globalXLInlineThreshold = 0
+
+	nDisks := 16
+	fsDirs, err := getRandomDisks(nDisks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer removeRoots(fsDirs)
+
+	obj, _, err := initObjectLayer(mustGetNewEndpointList(fsDirs...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bucket := "bucket"
+	object := "object"
+	opts := ObjectOptions{Versioned: true}
+
+	err = obj.MakeBucketWithLocation(context.Background(), bucket, "")
+	if err != nil {
+		t.Fatalf("Failed to make a bucket - %v", err)
+	}
+
+	var objInfo ObjectInfo
+	for _, data := range [][]byte{bytes.Repeat([]byte("a"), 1024), bytes.Repeat([]byte("b"), 1024)} {
+		objInfo, err = obj.PutObject(context.Background(), bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), opts)
+		if err != nil {
+			t.Fatalf("Failed to put object - %v", err)
+		}
+	}
+
+	// Remove the latest version from the first disk.
+	xl := obj.(*xlObjects)
+	firstDisk := xl.storageDisks[0]
+	metaArr, _ := readAllXLMetadata(context.Background(), xl.storageDisks, bucket, object)
+	dataDir := metaArr[0].DataDir
+	if err = firstDisk.DeleteFile(bucket, filepath.Join(object, xlMetaJSONFile)); err != nil {
+		t.Fatalf("Failed to delete a file - %v", err)
+	}
+	if err = cleanupDir(context.Background(), firstDisk, bucket, retainSlash(pathJoin(object, dataDir))); err != nil {
+		t.Fatalf("Failed to delete the data directory - %v", err)
+	}
+
+	_, err = obj.HealObject(context.Background(), bucket, object, false, false)
+	if err != nil {
+		t.Fatalf("Failed to heal object - %v", err)
+	}
+
+	xlMeta, err := readXLMeta(context.Background(), firstDisk, bucket, object)
+	if err != nil {
+		t.Fatalf("Expected xl.json file to be present but read failed - %v", err)
+	}
+	if xlMeta.VersionID != objInfo.VersionID || len(xlMeta.Versions) != 1 {
+		t.Errorf("Expected version %s with one noncurrent version, got %s with %d", objInfo.VersionID, xlMeta.VersionID, len(xlMeta.Versions))
+	}
+	for _, part := range xlMeta.Parts {
+		if _, err = firstDisk.StatFile(bucket, pathJoin(object, dataDir, part.Name)); err != nil {
+			t.Errorf("Expected %s to be healed but stat failed - %v", part.Name, err)
+		}
+	}
+}
+
+// Tests healing of the noncurrent versions of an object on a replaced disk.
+func TestHealObjectNoncurrentVersionXL(t *testing.T) {
+	nDisks := 16
+	fsDirs, err := getRandomDisks(nDisks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer removeRoots(fsDirs)
+
+	obj, _, err := initObjectLayer(mustGetNewEndpointList(fsDirs...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bucket := "bucket"
+	object := "object"
+	opts := ObjectOptions{Versioned: true}
+
+	err = obj.MakeBucketWithLocation(context.Background(), bucket, "")
+	if err != nil {
+		t.Fatalf("Failed to make a bucket - %v", err)
+	}
+
+	var objInfos []ObjectInfo
+	contents := [][]byte{bytes.Repeat([]byte("a"), 1024*1024), bytes.Repeat([]byte("b"), 1024*1024)}
+	for _, data := range contents {
+		objInfo, err := obj.PutObject(context.Background(), bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), opts)
+		if err != nil {
+			t.Fatalf("Failed to put object - %v", err)
+		}
+		objInfos = append(objInfos, objInfo)
+	}
+
+	// Remove all versions from the first disk, as if it was replaced.
+	xl := obj.(*xlObjects)
+	firstDisk := xl.storageDisks[0]
+	if err = cleanupDir(context.Background(), firstDisk, bucket, retainSlash(object)); err != nil {
+		t.Fatalf("Failed to delete the object - %v", err)
+	}
+
+	_, err = obj.HealObject(context.Background(), bucket, object, false, false)
+	if err != nil {
+		t.Fatalf("Failed to heal object - %v", err)
+	}
+
+	xlMeta, err := readXLMeta(context.Background(), firstDisk, bucket, object)
+	if err != nil {
+		t.Fatalf("Expected xl.json file to be present but read failed - %v", err)
+	}
+	if len(xlMeta.Versions) != 1 || xlMeta.Versions[0].VersionID != objInfos[0].VersionID {
+		t.Fatalf("Expected noncurrent version %s, got %v", objInfos[0].VersionID, xlMeta.Versions)
+	}
+	noncurrent := xlMeta.Versions[0]
+	for _, part := range noncurrent.Parts {
+		if _, err = firstDisk.StatFile(bucket, pathJoin(object, noncurrent.DataDir, part.Name)); err != nil {
+			t.Errorf("Expected %s to be healed but stat failed - %v", part.Name, err)
+		}
+	}
+
+	// Nothing is left to heal.
+	res, err := obj.HealObject(context.Background(), bucket, object, true, false)
+	if err != nil {
+		t.Fatalf("Failed to heal object - %v", err)
+	}
+	for _, drive := range res.Before.Drives {
+		if drive.State != madmin.DriveStateOk {
+			t.Fatalf("Expected all drives to be healed, got %v", res.Before.Drives)
+		}
+	}
+
+	// Take parity disks offline, the noncurrent version is read
+	// back with the shards of the healed disk.
+	for i := 1; i <= nDisks/2; i++ {
+		xl.storageDisks[i] = nil
+	}
+	var buf bytes.Buffer
+	if err = obj.GetObject(context.Background(), bucket, object, 0, -1, &buf, "", ObjectOptions{VersionID: objInfos[0].VersionID}); err != nil {
+		t.Fatalf("Failed to read the noncurrent version - %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), contents[0]) {
+		t.Fatal("Expected the noncurrent version to be read back")
+	}
+}
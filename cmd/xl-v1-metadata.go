@@ -154,6 +154,18 @@ type xlMetaV1 struct {
 	Meta map[string]string `json:"meta,omitempty"`
 	// Captures all the individual object `xl.json`.
 	Parts []ObjectPartInfo `json:"parts,omitempty"`
+	// Version ID of this object version, empty for the "null" version.
+	VersionID string `json:"versionId,omitempty"`
+	// Directory holding the parts of this version, parts of versions
+	// written before versioning was enabled live in the object directory.
+	DataDir string `json:"dataDir,omitempty"`
+	// DeleteMarker is set if this version is a delete marker.
+	DeleteMarker bool `json:"deleteMarker,omitempty"`
+	// Erasure shard of this disk for small objects stored inline,
+	// such objects have no part files.
+	Data []byte `json:"data,omitempty"`
+	// Versions holds all noncurrent versions, newest first.
+	Versions []xlMetaV1 `json:"versions,omitempty"`
 }
 
 // XL metadata constants.
@@ -192,9 +204,15 @@ func newXLMetaFromXLMeta(meta xlMetaV1) xlMetaV1 {
 	xlMeta := meta
 	xlMeta.Erasure.Checksums = nil
 	xlMeta.Parts = nil
+	xlMeta.Data = nil
 	return xlMeta
 }
 
+// isInline - tells if the object data is stored inline in `xl.json`.
+func (m xlMetaV1) isInline() bool {
+	return len(m.Data) > 0
+}
+
 // IsValid - tells if the format is sane by validating the version
 // string, format and erasure info fields.
 func (m xlMetaV1) IsValid() bool {
@@ -225,6 +243,8 @@ func (m xlMetaV1) ToObjectInfo(bucket, object string) ObjectInfo {
 		ModTime:         m.Stat.ModTime,
 		ContentType:     m.Meta["content-type"],
 		ContentEncoding: m.Meta["content-encoding"],
+		VersionID:       m.VersionID,
+		DeleteMarker:    m.DeleteMarker,
 	}
 
 	objInfo.backendType = BackendErasure
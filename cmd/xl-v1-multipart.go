@@ -142,6 +142,79 @@ func commitXLMetadata(ctx context.Context, disks []StorageAPI, srcBucket, srcPre
 	return evalDisks(disks, mErrs), err
 }
 
+// listMultipartUploads - returns all the pending multipart uploads of an
+// object, sorted by their initiation time.
+func (xl xlObjects) listMultipartUploads(ctx context.Context, bucket, object string) ([]MultipartInfo, error) {
+	var uploads []MultipartInfo
+	for _, disk := range xl.getLoadBalancedDisks() {
+		if disk == nil {
+			continue
+		}
+		uploadIDs, err := disk.ListDir(minioMetaMultipartBucket, xl.getMultipartSHADir(bucket, object), -1)
+		if err != nil {
+			if err == errFileNotFound {
+				return nil, nil
+			}
+			logger.LogIf(ctx, err)
+			return nil, err
+		}
+		for _, uploadID := range uploadIDs {
+			uploadID = strings.TrimSuffix(uploadID, slashSeparator)
+			stat, _, err := readXLMetaStat(ctx, disk, minioMetaMultipartBucket, xl.getUploadIDDir(bucket, object, uploadID))
+			if err != nil {
+				// The upload was completed or aborted meanwhile.
+				continue
+			}
+			uploads = append(uploads, MultipartInfo{Object: object, UploadID: uploadID, Initiated: stat.ModTime})
+		}
+		break
+	}
+
+	sort.Slice(uploads, func(i, j int) bool {
+		return isMultipartInfoLess(uploads[i], uploads[j])
+	})
+	return uploads, nil
+}
+
+// isMultipartInfoLess - orders multipart uploads by object name, then by
+// initiation time, the upload ID breaks the ties.
+func isMultipartInfoLess(a, b MultipartInfo) bool {
+	if a.Object != b.Object {
+		return a.Object < b.Object
+	}
+	if !a.Initiated.Equal(b.Initiated) {
+		return a.Initiated.Before(b.Initiated)
+	}
+	return a.UploadID < b.UploadID
+}
+
+// setMultipartUploadsPage - sets the uploads of result to the page of at
+// most result.MaxUploads sorted uploads following the upload ID marker,
+// the next markers are set when uploads are left over.
+func setMultipartUploadsPage(result *ListMultipartsInfo, uploads []MultipartInfo) {
+	if result.UploadIDMarker != "" {
+		index := 0
+		for index < len(uploads) && uploads[index].UploadID != result.UploadIDMarker {
+			index++
+		}
+		// Uploads up to the marker were listed by the previous pages.
+		if index < len(uploads) {
+			index++
+		}
+		uploads = uploads[index:]
+	}
+
+	if len(uploads) > result.MaxUploads {
+		uploads = uploads[:result.MaxUploads]
+		result.IsTruncated = true
+	}
+	result.Uploads = uploads
+	if result.IsTruncated && len(uploads) > 0 {
+		result.NextKeyMarker = uploads[len(uploads)-1].Object
+		result.NextUploadIDMarker = uploads[len(uploads)-1].UploadID
+	}
+}
+
 // ListMultipartUploads - lists all the pending multipart
 // uploads for a particular object in a bucket.
 //
@@ -156,34 +229,15 @@ func (xl xlObjects) ListMultipartUploads(ctx context.Context, bucket, object, ke
 
 	result.MaxUploads = maxUploads
 	result.KeyMarker = keyMarker
+	result.UploadIDMarker = uploadIDMarker
 	result.Prefix = object
 	result.Delimiter = delimiter
 
-	for _, disk := range xl.getLoadBalancedDisks() {
-		if disk == nil {
-			continue
-		}
-		uploadIDs, err := disk.ListDir(minioMetaMultipartBucket, xl.getMultipartSHADir(bucket, object), -1)
-		if err != nil {
-			if err == errFileNotFound {
-				return result, nil
-			}
-			logger.LogIf(ctx, err)
-			return result, err
-		}
-		for i := range uploadIDs {
-			uploadIDs[i] = strings.TrimSuffix(uploadIDs[i], slashSeparator)
-		}
-		sort.Strings(uploadIDs)
-		for _, uploadID := range uploadIDs {
-			if len(result.Uploads) == maxUploads {
-				break
-			}
-			result.Uploads = append(result.Uploads, MultipartInfo{Object: object, UploadID: uploadID})
-		}
-		break
+	uploads, err := xl.listMultipartUploads(ctx, bucket, object)
+	if err != nil {
+		return result, err
 	}
-
+	setMultipartUploadsPage(&result, uploads)
 	return result, nil
 }
 
@@ -210,6 +264,8 @@ func (xl xlObjects) newMultipartUpload(ctx context.Context, bucket string, objec
 		meta["content-type"] = contentType
 	}
 	xlMeta.Stat.ModTime = UTCNow()
+	// Record the object name for lifecycle rules, hashed upload paths do not carry it.
+	meta[multipartUploadObjectKey] = pathJoin(bucket, object)
 	xlMeta.Meta = meta
 
 	uploadID := mustGetUUID()
@@ -627,6 +683,13 @@ func (xl xlObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 		return oi, toObjectErr(errFileAccessDenied, bucket, object)
 	}
 
+	// Deny replacing a version protected by object lock.
+	if !opts.Versioned {
+		if err := xl.checkObjectLock(ctx, bucket, object, nullVersionID, opts.BypassGovernance); err != nil {
+			return oi, err
+		}
+	}
+
 	// Calculate s3 compatible md5sum for complete multipart.
 	s3MD5, err := getCompleteMultipartMD5(ctx, parts)
 	if err != nil {
@@ -729,6 +792,9 @@ func (xl xlObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 	// Save the consolidated actual size.
 	xlMeta.Meta[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(objectActualSize, 10)
 
+	// The object name is only needed while the upload is incomplete.
+	delete(xlMeta.Meta, multipartUploadObjectKey)
+
 	tempUploadIDPath := uploadID
 
 	// Update all xl metadata, make sure to not modify fields like
@@ -750,7 +816,9 @@ func (xl xlObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 		return oi, toObjectErr(rErr, minioMetaMultipartBucket, uploadIDPath)
 	}
 
-	if xl.isObject(bucket, object) {
+	versioned := opts.Versioned || opts.VersionSuspended
+
+	if !versioned && xl.isObject(bucket, object) {
 		// Deny if WORM is enabled
 		if globalWORMEnabled {
 			return ObjectInfo{}, ObjectAlreadyExists{Bucket: bucket, Object: object}
@@ -784,6 +852,19 @@ func (xl xlObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 		}
 	}
 
+	if versioned {
+		// Commit as a new version of the object, existing versions are kept.
+		if xlMeta, err = xl.putObjectVersion(ctx, onlineDisks, minioMetaMultipartBucket, uploadIDPath, bucket, object, partsMetadata, writeQuorum, opts); err != nil {
+			return oi, toObjectErr(err, bucket, object)
+		}
+
+		// The `xl.json` of the upload was moved along with the parts.
+		deleteAllXLMetadata(ctx, onlineDisks, bucket, pathJoin(object, xlMeta.DataDir), make([]error, len(onlineDisks)))
+
+		// Success, return object info.
+		return xlMeta.ToObjectInfo(bucket, object), nil
+	}
+
 	// Rename the multipart object to final location.
 	if _, err = rename(ctx, onlineDisks, minioMetaMultipartBucket, uploadIDPath, bucket, object, true, writeQuorum, nil); err != nil {
 		return oi, toObjectErr(err, bucket, object)
@@ -884,9 +965,24 @@ func (xl xlObjects) cleanupStaleMultipartUploadsOnDisk(ctx context.Context, disk
 			if err != nil {
 				continue
 			}
-			if now.Sub(fi.ModTime) > expiry {
+			if now.Sub(fi.ModTime) > expiry || xl.isUploadAbortedByLifecycle(ctx, disk, uploadIDPath, fi.ModTime) {
 				xl.deleteObject(ctx, minioMetaMultipartBucket, uploadIDPath, len(xl.getDisks())/2+1, false)
 			}
 		}
 	}
 }
+
+// Returns true if a lifecycle rule of the bucket aborts the given incomplete multipart upload.
+func (xl xlObjects) isUploadAbortedByLifecycle(ctx context.Context, disk StorageAPI, uploadIDPath string, initiated time.Time) bool {
+	// Avoid reading upload metadata when no bucket has lifecycle rules.
+	if globalLifecycleSys.Empty() {
+		return false
+	}
+
+	_, meta, err := readXLMetaStat(ctx, disk, minioMetaMultipartBucket, uploadIDPath)
+	if err != nil {
+		return false
+	}
+
+	return globalLifecycleSys.abortUpload(meta, initiated)
+}
@@ -67,7 +67,7 @@ func (xl xlObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBuc
 	// Read metadata associated with the object from all disks.
 	storageDisks := xl.getDisks()
 
-	metaArr, errs := readAllXLMetadata(ctx, storageDisks, srcBucket, srcObject)
+	metaArr, errs := readAllXLMetadataVersion(ctx, storageDisks, srcBucket, srcObject, srcOpts.VersionID)
 
 	// get Quorum for this object
 	readQuorum, writeQuorum, err := objectQuorumFromMeta(ctx, xl, metaArr, errs)
@@ -91,8 +91,14 @@ func (xl xlObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBuc
 	// Length of the file to read.
 	length := xlMeta.Stat.Size
 
-	// Check if this request is only metadata update.
-	if cpSrcDstSame {
+	// Check if this request is only metadata update, on versioned
+	// buckets copying an object onto itself adds a new version.
+	if cpSrcDstSame && srcOpts.VersionID == "" && !dstOpts.Versioned && !dstOpts.VersionSuspended {
+		// Deny replacing a version protected by object lock.
+		if err = enforceObjectLock(dstBucket, dstObject, xlMeta.Meta, dstOpts.BypassGovernance); err != nil {
+			return oi, err
+		}
+
 		// Update `xl.json` content on each disks.
 		for index := range metaArr {
 			metaArr[index].Meta = srcInfo.UserDefined
@@ -131,7 +137,12 @@ func (xl xlObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBuc
 		logger.LogIf(ctx, err)
 		return oi, toObjectErr(err, dstBucket, dstObject)
 	}
-	putOpts := ObjectOptions{UserDefined: srcInfo.UserDefined, ServerSideEncryption: dstOpts.ServerSideEncryption}
+	putOpts := ObjectOptions{
+		UserDefined:          srcInfo.UserDefined,
+		ServerSideEncryption: dstOpts.ServerSideEncryption,
+		Versioned:            dstOpts.Versioned,
+		VersionSuspended:     dstOpts.VersionSuspended,
+	}
 	objInfo, err := xl.putObject(ctx, dstBucket, dstObject, NewPutObjReader(hashReader, nil, nil), putOpts)
 	if err != nil {
 		return oi, toObjectErr(err, dstBucket, dstObject)
@@ -186,7 +197,7 @@ func (xl xlObjects) GetObjectNInfo(ctx context.Context, bucket, object string, r
 	}
 
 	var objInfo ObjectInfo
-	objInfo, err = xl.getObjectInfo(ctx, bucket, object)
+	objInfo, err = xl.getObjectInfo(ctx, bucket, object, opts)
 	if err != nil {
 		nsUnlocker()
 		return nil, toObjectErr(err, bucket, object)
@@ -251,8 +262,8 @@ func (xl xlObjects) getObject(ctx context.Context, bucket, object string, startO
 		return toObjectErr(err, bucket, object)
 	}
 
-	// Read metadata associated with the object from all disks.
-	metaArr, errs := readAllXLMetadata(ctx, xl.getDisks(), bucket, object)
+	// Read metadata associated with the requested object version from all disks.
+	metaArr, errs := readAllXLMetadataVersion(ctx, xl.getDisks(), bucket, object, opts.VersionID)
 
 	// get Quorum for this object
 	readQuorum, _, err := objectQuorumFromMeta(ctx, xl, metaArr, errs)
@@ -336,7 +347,11 @@ func (xl xlObjects) getObject(ctx context.Context, bucket, object string, startO
 				continue
 			}
 			checksumInfo := metaArr[index].Erasure.GetChecksumInfo(partName)
-			readers[index] = newBitrotReader(disk, bucket, pathJoin(object, partName), tillOffset, checksumInfo.Algorithm, checksumInfo.Hash, erasure.ShardSize())
+			if xlMeta.isInline() {
+				readers[index] = newInlineBitrotReader(metaArr[index].Data, tillOffset, checksumInfo.Algorithm, erasure.ShardSize())
+				continue
+			}
+			readers[index] = newBitrotReader(disk, bucket, pathJoin(object, xlMeta.DataDir, partName), tillOffset, checksumInfo.Algorithm, checksumInfo.Hash, erasure.ShardSize())
 		}
 		err := erasure.Decode(ctx, writer, readers, partOffset, partLength, partSize)
 		// Note: we should not be defer'ing the following closeBitrotReaders() call as we are inside a for loop i.e if we use defer, we would accumulate a lot of open files by the time
@@ -421,7 +436,7 @@ func (xl xlObjects) GetObjectInfo(ctx context.Context, bucket, object string, op
 		return oi, nil
 	}
 
-	info, err := xl.getObjectInfo(ctx, bucket, object)
+	info, err := xl.getObjectInfo(ctx, bucket, object, opts)
 	if err != nil {
 		return oi, toObjectErr(err, bucket, object)
 	}
@@ -430,11 +445,11 @@ func (xl xlObjects) GetObjectInfo(ctx context.Context, bucket, object string, op
 }
 
 // getObjectInfo - wrapper for reading object metadata and constructs ObjectInfo.
-func (xl xlObjects) getObjectInfo(ctx context.Context, bucket, object string) (objInfo ObjectInfo, err error) {
+func (xl xlObjects) getObjectInfo(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
 	disks := xl.getDisks()
 
-	// Read metadata associated with the object from all disks.
-	metaArr, errs := readAllXLMetadata(ctx, disks, bucket, object)
+	// Read metadata associated with the requested object version from all disks.
+	metaArr, errs := readAllXLMetadataVersion(ctx, disks, bucket, object, opts.VersionID)
 
 	readQuorum, _, err := objectQuorumFromMeta(ctx, xl, metaArr, errs)
 	if err != nil {
@@ -630,6 +645,10 @@ func (xl xlObjects) putObject(ctx context.Context, bucket string, object string,
 	// Total size of the written object
 	var sizeWritten int64
 
+	// Small objects are stored inline in `xl.json` of each disk
+	// instead of separate part files.
+	inline := data.Size() > 0 && data.Size() <= globalXLInlineThreshold
+
 	erasure, err := NewErasure(ctx, xlMeta.Erasure.DataBlocks, xlMeta.Erasure.ParityBlocks, xlMeta.Erasure.BlockSize)
 	if err != nil {
 		return ObjectInfo{}, toObjectErr(err, bucket, object)
@@ -682,6 +701,10 @@ func (xl xlObjects) putObject(ctx context.Context, bucket string, object string,
 			if disk == nil {
 				continue
 			}
+			if inline {
+				writers[i] = newInlineBitrotWriter(erasure.ShardFileSize(curPartSize), DefaultBitrotAlgorithm, erasure.ShardSize())
+				continue
+			}
 			writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, tempErasureObj, erasure.ShardFileSize(curPartSize), DefaultBitrotAlgorithm, erasure.ShardSize())
 		}
 
@@ -722,6 +745,9 @@ func (xl xlObjects) putObject(ctx context.Context, bucket string, object string,
 			}
 			partsMetadata[i].AddObjectPart(partIdx, partName, "", n, data.ActualSize())
 			partsMetadata[i].Erasure.AddChecksumInfo(ChecksumInfo{partName, DefaultBitrotAlgorithm, bitrotWriterSum(w)})
+			if inline {
+				partsMetadata[i].Data = inlineBitrotWriterData(w)
+			}
 		}
 
 		// We wrote everything, break out.
@@ -740,27 +766,6 @@ func (xl xlObjects) putObject(ctx context.Context, bucket string, object string,
 		opts.UserDefined["content-type"] = mimedb.TypeByExtension(path.Ext(object))
 	}
 
-	if xl.isObject(bucket, object) {
-		// Deny if WORM is enabled
-		if globalWORMEnabled {
-			return ObjectInfo{}, ObjectAlreadyExists{Bucket: bucket, Object: object}
-		}
-
-		// Rename if an object already exists to temporary location.
-		newUniqueID := mustGetUUID()
-
-		// Delete successfully renamed object.
-		defer xl.deleteObject(ctx, minioMetaTmpBucket, newUniqueID, writeQuorum, false)
-
-		// NOTE: Do not use online disks slice here: the reason is that existing object should be purged
-		// regardless of `xl.json` status and rolled back in case of errors. Also allow renaming the
-		// existing object if it is not present in quorum disks so users can overwrite stale objects.
-		_, err = rename(ctx, xl.getDisks(), bucket, object, minioMetaTmpBucket, newUniqueID, true, writeQuorum, []error{errFileNotFound})
-		if err != nil {
-			return ObjectInfo{}, toObjectErr(err, bucket, object)
-		}
-	}
-
 	// Fill all the necessary metadata.
 	// Update `xl.json` content on each disks.
 	for index := range partsMetadata {
@@ -769,19 +774,54 @@ func (xl xlObjects) putObject(ctx context.Context, bucket string, object string,
 		partsMetadata[index].Stat.ModTime = modTime
 	}
 
-	// Write unique `xl.json` for each disk.
-	if onlineDisks, err = writeUniqueXLMetadata(ctx, onlineDisks, minioMetaTmpBucket, tempObj, partsMetadata, writeQuorum); err != nil {
-		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	// Deny replacing a version protected by object lock.
+	if !opts.Versioned {
+		if err = xl.checkObjectLock(ctx, bucket, object, nullVersionID, opts.BypassGovernance); err != nil {
+			return ObjectInfo{}, err
+		}
 	}
 
-	// Rename the successfully written temporary object to final location.
-	if _, err = rename(ctx, onlineDisks, minioMetaTmpBucket, tempObj, bucket, object, true, writeQuorum, nil); err != nil {
-		return ObjectInfo{}, toObjectErr(err, bucket, object)
-	}
+	if opts.Versioned || opts.VersionSuspended {
+		// Commit as a new version of the object, existing versions are kept.
+		if xlMeta, err = xl.putObjectVersion(ctx, onlineDisks, minioMetaTmpBucket, tempObj, bucket, object, partsMetadata, writeQuorum, opts); err != nil {
+			return ObjectInfo{}, toObjectErr(err, bucket, object)
+		}
+	} else {
+		if xl.isObject(bucket, object) {
+			// Deny if WORM is enabled
+			if globalWORMEnabled {
+				return ObjectInfo{}, ObjectAlreadyExists{Bucket: bucket, Object: object}
+			}
+
+			// Rename if an object already exists to temporary location.
+			newUniqueID := mustGetUUID()
+
+			// Delete successfully renamed object.
+			defer xl.deleteObject(ctx, minioMetaTmpBucket, newUniqueID, writeQuorum, false)
+
+			// NOTE: Do not use online disks slice here: the reason is that existing object should be purged
+			// regardless of `xl.json` status and rolled back in case of errors. Also allow renaming the
+			// existing object if it is not present in quorum disks so users can overwrite stale objects.
+			_, err = rename(ctx, xl.getDisks(), bucket, object, minioMetaTmpBucket, newUniqueID, true, writeQuorum, []error{errFileNotFound})
+			if err != nil {
+				return ObjectInfo{}, toObjectErr(err, bucket, object)
+			}
+		}
+
+		// Write unique `xl.json` for each disk.
+		if onlineDisks, err = writeUniqueXLMetadata(ctx, onlineDisks, minioMetaTmpBucket, tempObj, partsMetadata, writeQuorum); err != nil {
+			return ObjectInfo{}, toObjectErr(err, bucket, object)
+		}
+
+		// Rename the successfully written temporary object to final location.
+		if _, err = rename(ctx, onlineDisks, minioMetaTmpBucket, tempObj, bucket, object, true, writeQuorum, nil); err != nil {
+			return ObjectInfo{}, toObjectErr(err, bucket, object)
+		}
 
-	// Object info is the same in all disks, so we can pick the first meta
-	// of the first disk
-	xlMeta = partsMetadata[0]
+		// Object info is the same in all disks, so we can pick the first meta
+		// of the first disk
+		xlMeta = partsMetadata[0]
+	}
 
 	objInfo = ObjectInfo{
 		IsDir:           false,
@@ -792,6 +832,8 @@ func (xl xlObjects) putObject(ctx context.Context, bucket string, object string,
 		ETag:            xlMeta.Meta["etag"],
 		ContentType:     xlMeta.Meta["content-type"],
 		ContentEncoding: xlMeta.Meta["content-encoding"],
+		VersionID:       xlMeta.VersionID,
+		IsLatest:        true,
 		UserDefined:     xlMeta.Meta,
 	}
 
@@ -865,6 +907,12 @@ func (xl xlObjects) deleteObject(ctx context.Context, bucket, object string, wri
 // any error as it is not necessary for the handler to reply back a
 // response to the client request.
 func (xl xlObjects) DeleteObject(ctx context.Context, bucket, object string) (err error) {
+	return xl.removeObject(ctx, bucket, object, false)
+}
+
+// removeObject - deletes the object along with all its versions, unless
+// one of them is protected by object lock.
+func (xl xlObjects) removeObject(ctx context.Context, bucket, object string, bypassGovernance bool) (err error) {
 	// Acquire a write lock before deleting the object.
 	objectLock := xl.nsMutex.NewNSLock(bucket, object)
 	if perr := objectLock.GetLock(globalOperationTimeout); perr != nil {
@@ -876,6 +924,10 @@ func (xl xlObjects) DeleteObject(ctx context.Context, bucket, object string) (er
 		return err
 	}
 
+	if err = xl.checkObjectLock(ctx, bucket, object, "", bypassGovernance); err != nil {
+		return err
+	}
+
 	var writeQuorum int
 	var isObjectDir = hasSuffix(object, slashSeparator)
 
@@ -904,6 +956,112 @@ func (xl xlObjects) DeleteObject(ctx context.Context, bucket, object string) (er
 	return nil
 }
 
+// checkObjectLock - returns ObjectLocked if the requested version of the
+// object, any of its versions for an empty versionID, is protected by
+// object lock. Missing objects and versions are not protected.
+func (xl xlObjects) checkObjectLock(ctx context.Context, bucket, object, versionID string, bypassGovernance bool) error {
+	if !globalBucketObjectLockSys.Enabled(bucket) {
+		return nil
+	}
+
+	var objInfos []ObjectInfo
+	var err error
+	if versionID == "" {
+		objInfos, err = xl.getObjectVersions(ctx, bucket, object)
+	} else {
+		var objInfo ObjectInfo
+		objInfo, err = xl.getObjectInfo(ctx, bucket, object, ObjectOptions{VersionID: versionID})
+		objInfos = []ObjectInfo{objInfo}
+	}
+	if err != nil {
+		if err == errFileNotFound || err == errFileVersionNotFound {
+			return nil
+		}
+		return toObjectErr(err, bucket, object)
+	}
+
+	for _, objInfo := range objInfos {
+		if err = enforceObjectLock(bucket, object, objInfo.UserDefined, bypassGovernance); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PutObjectTags - replaces the tags of the requested version of the object
+// in `xl.json` of all disks, empty tags remove all tags of the version.
+func (xl xlObjects) PutObjectTags(ctx context.Context, bucket, object, tags string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	return xl.PutObjectMetadata(ctx, bucket, object, map[string]string{amzObjectTagging: tags}, opts)
+}
+
+// PutObjectMetadata - merges the given metadata into the metadata of the
+// requested version of the object in `xl.json` of all disks.
+func (xl xlObjects) PutObjectMetadata(ctx context.Context, bucket, object string, meta map[string]string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	// Acquire a write lock before updating the object metadata.
+	objectLock := xl.nsMutex.NewNSLock(bucket, object)
+	if err = objectLock.GetLock(globalOperationTimeout); err != nil {
+		return objInfo, err
+	}
+	defer objectLock.Unlock()
+
+	if err = checkGetObjArgs(ctx, bucket, object); err != nil {
+		return objInfo, err
+	}
+
+	disks := xl.getDisks()
+
+	metaArr, errs := readAllXLMetadata(ctx, disks, bucket, object)
+
+	readQuorum, writeQuorum, err := objectQuorumFromMeta(ctx, xl, metaArr, errs)
+	if err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	if reducedErr := reduceReadQuorumErrs(ctx, errs, objectOpIgnoredErrs, readQuorum); reducedErr != nil {
+		return objInfo, toObjectErr(reducedErr, bucket, object)
+	}
+
+	_, modTime := listOnlineDisks(disks, metaArr, errs)
+
+	xlMeta, err := pickValidXLMeta(ctx, metaArr, modTime, readQuorum)
+	if err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	version, err := xlMeta.getVersion(opts.VersionID)
+	if err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	distribution := xlMeta.Erasure.Distribution
+	metas := readAllXLMetadataShuffled(ctx, disks, bucket, object, distribution)
+	disks = shuffleDisks(disks, distribution)
+
+	for index := range metas {
+		if !metas[index].IsValid() {
+			// Outdated disks are taken care of by healing.
+			disks[index] = nil
+			continue
+		}
+		if metas[index].VersionID == version.VersionID {
+			metas[index].Meta = updateObjectMetadata(metas[index].Meta, meta)
+			continue
+		}
+		for i := range metas[index].Versions {
+			if metas[index].Versions[i].VersionID == version.VersionID {
+				metas[index].Versions[i].Meta = updateObjectMetadata(metas[index].Versions[i].Meta, meta)
+			}
+		}
+	}
+
+	if _, err = xl.writeXLMetadataVersions(ctx, disks, bucket, object, metas, writeQuorum); err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	version.Meta = updateObjectMetadata(version.Meta, meta)
+	return version.ToObjectInfo(bucket, object), nil
+}
+
 // ListObjectsV2 lists all blobs in bucket filtered by prefix
 func (xl xlObjects) ListObjectsV2(ctx context.Context, bucket, prefix, continuationToken, delimiter string, maxKeys int, fetchOwner bool, startAfter string) (result ListObjectsV2Info, err error) {
 	marker := continuationToken
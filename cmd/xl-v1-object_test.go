@@ -28,6 +28,7 @@ import (
 	"time"
 
 	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/pkg/madmin"
 )
 
 func TestRepeatPutObjectPart(t *testing.T) {
@@ -164,6 +165,10 @@ func TestXLDeleteObjectDiskNotFound(t *testing.T) {
 }
 
 func TestGetObjectNoQuorum(t *testing.T) {
+	// Store the object in part files, inline objects are read with `xl.json`.
+	defer func(threshold int64) { globalXLInlineThreshold = threshold }(globalXLInlineThreshold)
+	globalXLInlineThreshold = 0
+
 	// Create an instance of xl backend.
 	obj, fsDirs, err := prepareXL16()
 	if err != nil {
@@ -369,3 +374,175 @@ func TestHealing(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestPutObjectInline(t *testing.T) {
+	obj, fsDirs, err := prepareXL16()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+	xl := obj.(*xlObjects)
+
+	defer func(threshold int64) { globalXLInlineThreshold = threshold }(globalXLInlineThreshold)
+	globalXLInlineThreshold = 4 * humanize.KiByte
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(context.Background(), bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		object string
+		size   int
+		inline bool
+	}{
+		{"empty", 0, false},
+		{"small", 1, true},
+		{"threshold", 4 * humanize.KiByte, true},
+		{"large", 4*humanize.KiByte + 1, false},
+	}
+	for i, testCase := range testCases {
+		data := make([]byte, testCase.size)
+		if _, err = rand.Read(data); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = obj.PutObject(context.Background(), bucket, testCase.object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatalf("test %v: failed to put object: %v", i+1, err)
+		}
+
+		for _, disk := range xl.storageDisks {
+			xlMeta, err := readXLMeta(context.Background(), disk, bucket, testCase.object)
+			if err != nil {
+				t.Fatalf("test %v: failed to read xl.json: %v", i+1, err)
+			}
+			if xlMeta.isInline() != testCase.inline {
+				t.Fatalf("test %v: inline: expected: %v, got: %v", i+1, testCase.inline, xlMeta.isInline())
+			}
+			_, err = disk.StatFile(bucket, pathJoin(testCase.object, "part.1"))
+			if testCase.inline && err != errFileNotFound {
+				t.Fatalf("test %v: expected no part file, got: %v", i+1, err)
+			}
+		}
+
+		var buf bytes.Buffer
+		if err = obj.GetObject(context.Background(), bucket, testCase.object, 0, int64(len(data)), &buf, "", ObjectOptions{}); err != nil {
+			t.Fatalf("test %v: failed to get object: %v", i+1, err)
+		}
+		if !bytes.Equal(buf.Bytes(), data) {
+			t.Fatalf("test %v: object data mismatch", i+1)
+		}
+	}
+
+	// Ranges of inline objects are read with the parity shards.
+	object := "threshold"
+	var data bytes.Buffer
+	if err = obj.GetObject(context.Background(), bucket, object, 0, -1, &data, "", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	storageDisks := append([]StorageAPI{}, xl.storageDisks...)
+	for i := range storageDisks[:8] {
+		xl.storageDisks[i] = nil
+	}
+	var buf bytes.Buffer
+	if err = xl.GetObject(context.Background(), bucket, object, 100, 1000, &buf, "", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), data.Bytes()[100:1100]) {
+		t.Fatal("Range of the inline object mismatch")
+	}
+	copy(xl.storageDisks, storageDisks)
+
+	// Removing an inline version keeps the parts of the "null" version
+	// written to part files next to `xl.json`.
+	object = "large"
+	objInfo, err := obj.PutObject(context.Background(), bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte("abcd")), 4, "", ""), ObjectOptions{Versioned: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = xl.DeleteObjectVersion(context.Background(), bucket, object, ObjectOptions{VersionID: objInfo.VersionID}); err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	if err = obj.GetObject(context.Background(), bucket, object, 0, 4*humanize.KiByte+1, &buf, "", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 4*humanize.KiByte+1 {
+		t.Fatalf("Expected the null version to be readable, got %d bytes", buf.Len())
+	}
+}
+
+func TestHealObjectInline(t *testing.T) {
+	obj, fsDirs, err := prepareXL16()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+	xl := obj.(*xlObjects)
+
+	bucket := "bucket"
+	object := "object"
+	if err = obj.MakeBucketWithLocation(context.Background(), bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+	data := bytes.Repeat([]byte("a"), 1024)
+	if _, err = obj.PutObject(context.Background(), bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	disk := xl.storageDisks[0]
+	xlMetaPreHeal, err := readXLMeta(context.Background(), disk, bucket, object)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !xlMetaPreHeal.isInline() {
+		t.Fatal("Expected the object to be stored inline")
+	}
+
+	// Corrupt the inline shard of the first disk.
+	xlMetaCorrupted := xlMetaPreHeal
+	xlMetaCorrupted.Data = bytes.Repeat([]byte("b"), len(xlMetaPreHeal.Data))
+	if err = disk.DeleteFile(bucket, pathJoin(object, xlMetaJSONFile)); err != nil {
+		t.Fatal(err)
+	}
+	if err = writeXLMetadata(context.Background(), disk, bucket, object, xlMetaCorrupted); err != nil {
+		t.Fatal(err)
+	}
+	res, err := xl.HealObject(context.Background(), bucket, object, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Before.Drives[0].State != madmin.DriveStateCorrupt {
+		t.Fatalf("Expected the first drive to be corrupted, got: %s", res.Before.Drives[0].State)
+	}
+
+	// Remove the object from the second disk.
+	if err = os.RemoveAll(path.Join(fsDirs[1], bucket, object)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = xl.HealObject(context.Background(), bucket, object, false, false); err != nil {
+		t.Fatal(err)
+	}
+
+	xlMetaPostHeal, err := readXLMeta(context.Background(), disk, bucket, object)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(xlMetaPreHeal, xlMetaPostHeal) {
+		t.Fatal("HealObject failed")
+	}
+
+	// Read the object with the healed shards, without a single spare disk.
+	storageDisks := append([]StorageAPI{}, xl.storageDisks...)
+	for i := range storageDisks[2:10] {
+		xl.storageDisks[i+2] = nil
+	}
+	var buf bytes.Buffer
+	if err = xl.GetObject(context.Background(), bucket, object, 0, int64(len(data)), &buf, "", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Fatal("Healed object data mismatch")
+	}
+	copy(xl.storageDisks, storageDisks)
+}
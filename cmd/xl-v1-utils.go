@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/hex"
 	"errors"
 	"hash/crc32"
@@ -244,6 +245,25 @@ func xlMetaV1UnmarshalJSON(ctx context.Context, xlMetaBuf []byte) (xlMeta xlMeta
 	xlMeta.Minio.Release = parseXLRelease(xlMetaBuf)
 	// parse xlMetaV1.
 	xlMeta.Meta = parseXLMetaMap(xlMetaBuf)
+	// Parse the version fields.
+	xlMeta.VersionID = gjson.GetBytes(xlMetaBuf, "versionId").String()
+	xlMeta.DataDir = gjson.GetBytes(xlMetaBuf, "dataDir").String()
+	xlMeta.DeleteMarker = gjson.GetBytes(xlMetaBuf, "deleteMarker").Bool()
+	// Parse the inline data.
+	if data := gjson.GetBytes(xlMetaBuf, "data").String(); data != "" {
+		if xlMeta.Data, err = base64.StdEncoding.DecodeString(data); err != nil {
+			logger.LogIf(ctx, err)
+			return xlMeta, err
+		}
+	}
+	// Parse the noncurrent versions.
+	for _, v := range gjson.GetBytes(xlMetaBuf, "versions").Array() {
+		version, err := xlMetaV1UnmarshalJSON(ctx, []byte(v.Raw))
+		if err != nil {
+			return xlMeta, err
+		}
+		xlMeta.Versions = append(xlMeta.Versions, version)
+	}
 
 	return xlMeta, nil
 }
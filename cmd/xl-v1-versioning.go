@@ -0,0 +1,497 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"sync"
+
+	"github.com/minio/minio/cmd/logger"
+)
+
+// noncurrent - returns a copy of the version without its noncurrent versions.
+func (m xlMetaV1) noncurrent() xlMetaV1 {
+	m.Versions = nil
+	return m
+}
+
+// allVersions - returns all versions of the object, newest first.
+func (m xlMetaV1) allVersions() []xlMetaV1 {
+	return append([]xlMetaV1{m.noncurrent()}, m.Versions...)
+}
+
+// getVersion - returns the requested version of the object, an empty
+// versionID selects the latest version. Delete markers are reported
+// as errFileNotFound.
+func (m xlMetaV1) getVersion(versionID string) (xlMetaV1, error) {
+	if versionID == "" {
+		if m.DeleteMarker {
+			return xlMetaV1{}, errFileNotFound
+		}
+		return m, nil
+	}
+	if versionID == nullVersionID {
+		versionID = ""
+	}
+	for _, version := range m.allVersions() {
+		if version.VersionID == versionID {
+			if version.DeleteMarker {
+				return xlMetaV1{}, errFileNotFound
+			}
+			return version, nil
+		}
+	}
+	return xlMetaV1{}, errFileVersionNotFound
+}
+
+// stackVersions - returns the versions of the existing object which are kept
+// once a new version with the given versionID is added on top of it, along
+// with the versions being replaced by it.
+func stackVersions(current xlMetaV1, versionID string) (versions, replaced []xlMetaV1) {
+	if !current.IsValid() {
+		return nil, nil
+	}
+	for _, version := range current.allVersions() {
+		if version.VersionID == versionID {
+			replaced = append(replaced, version)
+			continue
+		}
+		versions = append(versions, version)
+	}
+	return versions, replaced
+}
+
+// readAllXLMetadataVersion - reads `xl.json` from all disks and narrows the
+// metadata of each disk down to the requested version of the object.
+func readAllXLMetadataVersion(ctx context.Context, disks []StorageAPI, bucket, object, versionID string) ([]xlMetaV1, []error) {
+	metaArr, errs := readAllXLMetadata(ctx, disks, bucket, object)
+	for index := range metaArr {
+		if errs[index] != nil {
+			continue
+		}
+		metaArr[index], errs[index] = metaArr[index].getVersion(versionID)
+	}
+	return metaArr, errs
+}
+
+// isSameXLVersion - tells if both metadata describe the same version, the
+// "null" version is replaced in place and is told apart by its modTime.
+func isSameXLVersion(a, b xlMetaV1) bool {
+	return a.VersionID == b.VersionID && a.Stat.ModTime.Equal(b.Stat.ModTime)
+}
+
+// hasXLVersion - tells if the version is one of the given versions.
+func hasXLVersion(versions []xlMetaV1, version xlMetaV1) bool {
+	for _, v := range versions {
+		if isSameXLVersion(v, version) {
+			return true
+		}
+	}
+	return false
+}
+
+// xlMetadataVersion - narrows the metadata read from each disk down to the
+// given version, disks without the version report errFileNotFound.
+func xlMetadataVersion(metaArr []xlMetaV1, errs []error, version xlMetaV1) ([]xlMetaV1, []error) {
+	metas := make([]xlMetaV1, len(metaArr))
+	vErrs := make([]error, len(metaArr))
+	for index := range metaArr {
+		if errs[index] != nil {
+			vErrs[index] = errs[index]
+			continue
+		}
+		vErrs[index] = errFileNotFound
+		for _, v := range metaArr[index].allVersions() {
+			if isSameXLVersion(v, version) {
+				metas[index], vErrs[index] = v, nil
+				break
+			}
+		}
+	}
+	return metas, vErrs
+}
+
+// readAllXLMetadataShuffled - reads `xl.json` from all disks ordered by the
+// given erasure distribution, metadata of failed reads is left empty.
+func readAllXLMetadataShuffled(ctx context.Context, disks []StorageAPI, bucket, object string, distribution []int) []xlMetaV1 {
+	metaArr, errs := readAllXLMetadata(ctx, disks, bucket, object)
+	for index := range metaArr {
+		if errs[index] != nil {
+			metaArr[index] = xlMetaV1{}
+		}
+	}
+	return shufflePartsMetadata(metaArr, distribution)
+}
+
+// deleteXLVersionData - removes the parts of an object version from a single disk.
+func deleteXLVersionData(ctx context.Context, disk StorageAPI, bucket, object string, version xlMetaV1) error {
+	// Delete markers and inline versions have no parts.
+	if version.DeleteMarker || version.isInline() {
+		return nil
+	}
+	if version.DataDir != "" {
+		return cleanupDir(ctx, disk, bucket, retainSlash(pathJoin(object, version.DataDir)))
+	}
+	// Versions written before versioning was enabled keep
+	// their parts next to `xl.json`.
+	for _, part := range version.Parts {
+		if err := disk.DeleteFile(bucket, pathJoin(object, part.Name)); err != nil && err != errFileNotFound {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteAllXLVersionData - removes the parts of the given per disk versions
+// from all disks in parallel, errors are only logged.
+func deleteAllXLVersionData(ctx context.Context, disks []StorageAPI, bucket, object string, versions [][]xlMetaV1) {
+	var wg = &sync.WaitGroup{}
+	for index, disk := range disks {
+		if disk == nil || len(versions[index]) == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func(index int, disk StorageAPI) {
+			defer wg.Done()
+			for _, version := range versions[index] {
+				logger.LogIf(ctx, deleteXLVersionData(ctx, disk, bucket, object, version))
+			}
+		}(index, disk)
+	}
+	wg.Wait()
+}
+
+// renameXLVersions - moves the healed data of the given versions and
+// `xl.json` from tmp location to the object on a single disk, keeping
+// the other versions present on the disk.
+func renameXLVersions(disk StorageAPI, tmpID, bucket, object string, versions []xlMetaV1) error {
+	for _, version := range versions {
+		// Delete markers and inline versions have no parts.
+		if version.DeleteMarker || version.isInline() || len(version.Parts) == 0 {
+			continue
+		}
+		if version.DataDir != "" {
+			if err := disk.RenameFile(minioMetaTmpBucket, retainSlash(pathJoin(tmpID, version.DataDir)),
+				bucket, retainSlash(pathJoin(object, version.DataDir))); err != nil {
+				return err
+			}
+			continue
+		}
+		// Versions written before versioning was enabled keep
+		// their parts next to `xl.json`.
+		for _, part := range version.Parts {
+			if err := disk.RenameFile(minioMetaTmpBucket, pathJoin(tmpID, part.Name),
+				bucket, pathJoin(object, part.Name)); err != nil {
+				return err
+			}
+		}
+	}
+	return disk.RenameFile(minioMetaTmpBucket, pathJoin(tmpID, xlMetaJSONFile), bucket, pathJoin(object, xlMetaJSONFile))
+}
+
+// writeXLMetadataVersions - atomically replaces `xl.json` of the object with
+// the given metadata on each disk in order.
+func (xl xlObjects) writeXLMetadataVersions(ctx context.Context, disks []StorageAPI, bucket, object string, metas []xlMetaV1, writeQuorum int) ([]StorageAPI, error) {
+	tempObj := mustGetUUID()
+
+	// Delete temporary `xl.json` in the event of failure.
+	defer xl.deleteObject(ctx, minioMetaTmpBucket, tempObj, writeQuorum, false)
+
+	disks, err := writeUniqueXLMetadata(ctx, disks, minioMetaTmpBucket, tempObj, metas, writeQuorum)
+	if err != nil {
+		return nil, err
+	}
+	return renameXLMetadata(ctx, disks, minioMetaTmpBucket, tempObj, bucket, object, writeQuorum)
+}
+
+// putObjectVersion - commits the parts written at srcBucket/srcPrefix as a
+// new version of the object, previous versions are carried over into the
+// new `xl.json` of each disk. partsMetadata and onlineDisks are expected
+// in erasure distribution order.
+func (xl xlObjects) putObjectVersion(ctx context.Context, onlineDisks []StorageAPI, srcBucket, srcPrefix, bucket, object string, partsMetadata []xlMetaV1, writeQuorum int, opts ObjectOptions) (xlMetaV1, error) {
+	// Objects written while versioning is suspended get the "null" version.
+	var versionID string
+	if opts.Versioned {
+		versionID = mustGetUUID()
+	}
+	dataDir := mustGetUUID()
+
+	currentMetas := readAllXLMetadataShuffled(ctx, xl.getDisks(), bucket, object, partsMetadata[0].Erasure.Distribution)
+
+	// Objects stored inline in `xl.json` have no parts to move.
+	var inline bool
+	for index := range partsMetadata {
+		inline = inline || partsMetadata[index].isInline()
+	}
+
+	replaced := make([][]xlMetaV1, len(partsMetadata))
+	for index := range partsMetadata {
+		partsMetadata[index].VersionID = versionID
+		if !inline {
+			partsMetadata[index].DataDir = dataDir
+		}
+		partsMetadata[index].Versions, replaced[index] = stackVersions(currentMetas[index], versionID)
+	}
+
+	// Move the parts into the data directory of the new version.
+	var err error
+	if !inline {
+		if onlineDisks, err = rename(ctx, onlineDisks, srcBucket, srcPrefix, bucket, pathJoin(object, dataDir), true, writeQuorum, nil); err != nil {
+			return xlMetaV1{}, err
+		}
+	}
+
+	if onlineDisks, err = xl.writeXLMetadataVersions(ctx, onlineDisks, bucket, object, partsMetadata, writeQuorum); err != nil {
+		// Remove the orphaned data directory.
+		dataDirs := make([][]xlMetaV1, len(onlineDisks))
+		for index := range dataDirs {
+			dataDirs[index] = []xlMetaV1{{DataDir: dataDir}}
+		}
+		deleteAllXLVersionData(ctx, onlineDisks, bucket, object, dataDirs)
+		return xlMetaV1{}, err
+	}
+
+	// Purge the data of the replaced "null" version.
+	deleteAllXLVersionData(ctx, onlineDisks, bucket, object, replaced)
+
+	return partsMetadata[0], nil
+}
+
+// addDeleteMarker - adds a delete marker as the latest version of the object.
+func (xl xlObjects) addDeleteMarker(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error) {
+	if _, err := xl.getBucketInfo(ctx, bucket); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket)
+	}
+
+	dataDrives, parityDrives := getRedundancyCount("", len(xl.getDisks()))
+	writeQuorum := dataDrives + 1
+
+	marker := newXLMetaV1(object, dataDrives, parityDrives)
+	marker.Stat.ModTime = UTCNow()
+	marker.DeleteMarker = true
+	// Delete markers added while versioning is suspended get the "null" version.
+	if opts.Versioned {
+		marker.VersionID = mustGetUUID()
+	}
+
+	currentMetas := readAllXLMetadataShuffled(ctx, xl.getDisks(), bucket, object, marker.Erasure.Distribution)
+
+	metas := make([]xlMetaV1, len(currentMetas))
+	replaced := make([][]xlMetaV1, len(currentMetas))
+	for index := range metas {
+		metas[index] = marker
+		metas[index].Versions, replaced[index] = stackVersions(currentMetas[index], marker.VersionID)
+	}
+
+	disks := shuffleDisks(xl.getDisks(), marker.Erasure.Distribution)
+	disks, err := xl.writeXLMetadataVersions(ctx, disks, bucket, object, metas, writeQuorum)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	// Purge the data of the replaced "null" version.
+	deleteAllXLVersionData(ctx, disks, bucket, object, replaced)
+
+	return marker.ToObjectInfo(bucket, object), nil
+}
+
+// deleteObjectVersion - permanently removes a single version of the object,
+// the next newest version becomes the latest version.
+func (xl xlObjects) deleteObjectVersion(ctx context.Context, bucket, object, versionID string) (ObjectInfo, error) {
+	disks := xl.getDisks()
+
+	metaArr, errs := readAllXLMetadata(ctx, disks, bucket, object)
+
+	readQuorum, writeQuorum, err := objectQuorumFromMeta(ctx, xl, metaArr, errs)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	if reducedErr := reduceReadQuorumErrs(ctx, errs, objectOpIgnoredErrs, readQuorum); reducedErr != nil {
+		return ObjectInfo{}, toObjectErr(reducedErr, bucket, object)
+	}
+
+	_, modTime := listOnlineDisks(disks, metaArr, errs)
+
+	xlMeta, err := pickValidXLMeta(ctx, metaArr, modTime, readQuorum)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	vid := versionID
+	if vid == nullVersionID {
+		vid = ""
+	}
+
+	var found bool
+	var deleted xlMetaV1
+	for _, version := range xlMeta.allVersions() {
+		if version.VersionID == vid {
+			deleted, found = version, true
+			break
+		}
+	}
+	if !found {
+		return ObjectInfo{}, VersionNotFound{Bucket: bucket, Object: object, VersionID: versionID}
+	}
+
+	objInfo := deleted.ToObjectInfo(bucket, object)
+
+	// Removing the only version removes the object.
+	if len(xlMeta.Versions) == 0 {
+		if err = xl.deleteObject(ctx, bucket, object, writeQuorum, false); err != nil {
+			return ObjectInfo{}, toObjectErr(err, bucket, object)
+		}
+		return objInfo, nil
+	}
+
+	distribution := xlMeta.Erasure.Distribution
+	currentMetas := readAllXLMetadataShuffled(ctx, disks, bucket, object, distribution)
+	disks = shuffleDisks(disks, distribution)
+
+	metas := make([]xlMetaV1, len(currentMetas))
+	removed := make([][]xlMetaV1, len(currentMetas))
+	for index, current := range currentMetas {
+		if !current.IsValid() {
+			// Outdated disks are taken care of by healing.
+			disks[index] = nil
+			continue
+		}
+		var versions []xlMetaV1
+		for _, version := range current.allVersions() {
+			if version.VersionID == vid {
+				removed[index] = append(removed[index], version)
+				continue
+			}
+			versions = append(versions, version)
+		}
+		if len(versions) == 0 {
+			disks[index] = nil
+			continue
+		}
+		metas[index] = versions[0]
+		metas[index].Versions = versions[1:]
+	}
+
+	if disks, err = xl.writeXLMetadataVersions(ctx, disks, bucket, object, metas, writeQuorum); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	deleteAllXLVersionData(ctx, disks, bucket, object, removed)
+
+	return objInfo, nil
+}
+
+// DeleteObjectVersion - removes the requested version of the object, without
+// a version ID a delete marker is added on versioned buckets.
+func (xl xlObjects) DeleteObjectVersion(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	if opts.VersionID == "" && !opts.Versioned && !opts.VersionSuspended {
+		if err = xl.removeObject(ctx, bucket, object, opts.BypassGovernance); err != nil {
+			return objInfo, err
+		}
+		return ObjectInfo{Bucket: bucket, Name: object}, nil
+	}
+
+	// Acquire a write lock before deleting the object.
+	objectLock := xl.nsMutex.NewNSLock(bucket, object)
+	if err = objectLock.GetLock(globalOperationTimeout); err != nil {
+		return objInfo, err
+	}
+	defer objectLock.Unlock()
+
+	if err = checkDelObjArgs(ctx, bucket, object); err != nil {
+		return objInfo, err
+	}
+
+	// Delete markers never remove data, unlike replacing the "null" version.
+	if opts.VersionID == "" && opts.Versioned {
+		return xl.addDeleteMarker(ctx, bucket, object, opts)
+	}
+
+	// Deny removing a version protected by object lock.
+	versionID := opts.VersionID
+	if versionID == "" {
+		versionID = nullVersionID
+	}
+	if err = xl.checkObjectLock(ctx, bucket, object, versionID, opts.BypassGovernance); err != nil {
+		return objInfo, err
+	}
+
+	if opts.VersionID == "" {
+		return xl.addDeleteMarker(ctx, bucket, object, opts)
+	}
+	return xl.deleteObjectVersion(ctx, bucket, object, opts.VersionID)
+}
+
+// getObjectVersions - returns all versions of the object, newest first.
+func (xl xlObjects) getObjectVersions(ctx context.Context, bucket, object string) ([]ObjectInfo, error) {
+	metaArr, errs := readAllXLMetadata(ctx, xl.getDisks(), bucket, object)
+
+	readQuorum, _, err := objectQuorumFromMeta(ctx, xl, metaArr, errs)
+	if err != nil {
+		return nil, err
+	}
+
+	modTime, _ := commonTime(listObjectModtimes(metaArr, errs))
+
+	xlMeta, err := pickValidXLMeta(ctx, metaArr, modTime, readQuorum)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := xlMeta.allVersions()
+	objInfos := make([]ObjectInfo, len(versions))
+	for index, version := range versions {
+		objInfos[index] = version.ToObjectInfo(bucket, object)
+	}
+	objInfos[0].IsLatest = true
+	return objInfos, nil
+}
+
+// ListObjectVersions - lists all versions of the objects at prefix, delimited by '/'.
+func (xl xlObjects) ListObjectVersions(ctx context.Context, bucket, prefix, keyMarker, versionIDMarker, delimiter string, maxKeys int) (loi ListObjectVersionsInfo, e error) {
+	if err := checkListObjsArgs(ctx, bucket, prefix, keyMarker, delimiter, xl); err != nil {
+		return loi, err
+	}
+
+	// With max keys of zero we have reached eof, return right here.
+	if maxKeys == 0 {
+		return loi, nil
+	}
+
+	// For delimiter and prefix as '/' we do not list anything at all.
+	if delimiter == slashSeparator && prefix == slashSeparator {
+		return loi, nil
+	}
+
+	// Over flowing count - reset to maxObjectList.
+	if maxKeys < 0 || maxKeys > maxObjectList {
+		maxKeys = maxObjectList
+	}
+
+	recursive := delimiter != slashSeparator
+
+	endWalkCh := make(chan struct{})
+	defer close(endWalkCh)
+
+	isLeaf := xl.isObject
+	isLeafDir := xl.isObjectDir
+	listDir := listDirFactory(ctx, isLeaf, xl.getLoadBalancedDisks()...)
+	walkResultCh := startTreeWalk(ctx, bucket, prefix, keyMarker, recursive, listDir, isLeaf, isLeafDir, endWalkCh)
+
+	return listObjectVersions(ctx, bucket, keyMarker, versionIDMarker, delimiter, maxKeys, walkResultCh, xl.getObjectVersions)
+}
@@ -0,0 +1,94 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accesslog
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+)
+
+// Maximum length of a target prefix, as the key of a log object is limited to 1024 bytes.
+const maxTargetPrefixLength = 512
+
+// ErrMissingTargetBucket - logging is enabled without a target bucket.
+var ErrMissingTargetBucket = errors.New("target bucket must be specified when logging is enabled")
+
+// ErrInvalidTargetPrefix - target prefix is too long.
+var ErrInvalidTargetPrefix = errors.New("target prefix must not be longer than 512 characters")
+
+// ErrTargetGrantsNotSupported - target grants are given, which are not supported.
+var ErrTargetGrantsNotSupported = errors.New("target grants are not supported, use bucket policies instead")
+
+// unsupportedElement - holds configuration elements which are parsed only to be rejected.
+type unsupportedElement struct {
+	InnerXML string `xml:",innerxml"`
+}
+
+// LoggingEnabled - target bucket and prefix of the access log objects.
+type LoggingEnabled struct {
+	TargetBucket string              `xml:"TargetBucket"`
+	TargetPrefix string              `xml:"TargetPrefix"`
+	TargetGrants *unsupportedElement `xml:"TargetGrants,omitempty"`
+}
+
+// Config - bucket logging status, logging is disabled if LoggingEnabled is not set.
+type Config struct {
+	XMLNS          string          `xml:"xmlns,attr,omitempty"`
+	XMLName        xml.Name        `xml:"BucketLoggingStatus"`
+	LoggingEnabled *LoggingEnabled `xml:"LoggingEnabled,omitempty"`
+}
+
+// Enabled - returns true if access logging is enabled.
+func (c Config) Enabled() bool {
+	return c.LoggingEnabled != nil
+}
+
+// Validate - validates the bucket logging status.
+func (c Config) Validate() error {
+	if c.LoggingEnabled == nil {
+		return nil
+	}
+
+	if c.LoggingEnabled.TargetBucket == "" {
+		return ErrMissingTargetBucket
+	}
+
+	if len(c.LoggingEnabled.TargetPrefix) > maxTargetPrefixLength {
+		return ErrInvalidTargetPrefix
+	}
+
+	if c.LoggingEnabled.TargetGrants != nil {
+		return ErrTargetGrantsNotSupported
+	}
+
+	return nil
+}
+
+// ParseConfig - parses data in given reader to bucket logging status.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
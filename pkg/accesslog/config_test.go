@@ -0,0 +1,51 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accesslog
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		data            string
+		expectedEnabled bool
+		expectedErr     error
+		shouldFail      bool
+	}{
+		{`<BucketLoggingStatus xmlns="http://doc.s3.amazonaws.com/2006-03-01"><LoggingEnabled><TargetBucket>logs</TargetBucket><TargetPrefix>access/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`, true, nil, false},
+		{`<BucketLoggingStatus xmlns="http://doc.s3.amazonaws.com/2006-03-01"></BucketLoggingStatus>`, false, nil, false},
+		{`<BucketLoggingStatus><LoggingEnabled><TargetPrefix>access/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`, false, ErrMissingTargetBucket, true},
+		{`<BucketLoggingStatus><LoggingEnabled><TargetBucket>logs</TargetBucket><TargetPrefix>` + strings.Repeat("a", 513) + `</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`, false, ErrInvalidTargetPrefix, true},
+		{`<BucketLoggingStatus><LoggingEnabled><TargetBucket>logs</TargetBucket><TargetGrants><Grant></Grant></TargetGrants></LoggingEnabled></BucketLoggingStatus>`, false, ErrTargetGrantsNotSupported, true},
+		{`<BucketLoggingStatus><LoggingEnabled>`, false, nil, true},
+	}
+
+	for i, testCase := range testCases {
+		config, err := ParseConfig(strings.NewReader(testCase.data))
+		if testCase.shouldFail != (err != nil) {
+			t.Fatalf("test %v: expected failure: %v, got: %v", i+1, testCase.shouldFail, err)
+		}
+		if testCase.expectedErr != nil && err != testCase.expectedErr {
+			t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+		if err == nil && config.Enabled() != testCase.expectedEnabled {
+			t.Fatalf("test %v: expected enabled: %v, got: %v", i+1, testCase.expectedEnabled, config.Enabled())
+		}
+	}
+}
@@ -0,0 +1,130 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accesslog
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Time format of a record, e.g. [06/Feb/2019:00:00:38 +0000]
+	recordTimeFormat = "[02/Jan/2006:15:04:05 -0700]"
+
+	// Time format of the key of a log object, e.g. 2019-02-06-00-00-38
+	objectTimeFormat = "2006-01-02-15-04-05"
+)
+
+// Record - an access log record of a request, written in the AWS S3 server
+// access log format.
+type Record struct {
+	BucketOwner      string
+	Bucket           string
+	Time             time.Time
+	RemoteIP         string
+	Requester        string
+	RequestID        string
+	Operation        string
+	Key              string
+	RequestURI       string
+	HTTPStatus       int
+	ErrorCode        string
+	BytesSent        int64
+	ObjectSize       int64
+	TotalTime        time.Duration
+	TurnAroundTime   time.Duration
+	Referrer         string
+	UserAgent        string
+	VersionID        string
+	HostID           string
+	SignatureVersion string
+	CipherSuite      string
+	AuthType         string
+	HostHeader       string
+	TLSVersion       string
+}
+
+// String - returns the record as a single line of space separated fields,
+// missing values are written as '-'.
+func (r Record) String() string {
+	key := ""
+	if r.Key != "" {
+		key = (&url.URL{Path: r.Key}).EscapedPath()
+	}
+
+	fields := []string{
+		field(r.BucketOwner),
+		field(r.Bucket),
+		r.Time.UTC().Format(recordTimeFormat),
+		field(r.RemoteIP),
+		field(r.Requester),
+		field(r.RequestID),
+		field(r.Operation),
+		field(key),
+		quotedField(r.RequestURI),
+		strconv.Itoa(r.HTTPStatus),
+		field(r.ErrorCode),
+		sizeField(r.BytesSent),
+		sizeField(r.ObjectSize),
+		strconv.FormatInt(int64(r.TotalTime/time.Millisecond), 10),
+		strconv.FormatInt(int64(r.TurnAroundTime/time.Millisecond), 10),
+		quotedField(r.Referrer),
+		quotedField(r.UserAgent),
+		field(r.VersionID),
+		field(r.HostID),
+		field(r.SignatureVersion),
+		field(r.CipherSuite),
+		field(r.AuthType),
+		field(r.HostHeader),
+		field(r.TLSVersion),
+	}
+
+	return strings.Join(fields, " ")
+}
+
+// ObjectKey - returns the key of a log object under the given prefix,
+// holding records delivered at the given time.
+func ObjectKey(prefix string, t time.Time, uniqueID string) string {
+	return prefix + t.UTC().Format(objectTimeFormat) + "-" + uniqueID
+}
+
+func field(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	// Values are space separated, spaces are only allowed in quoted fields.
+	return strings.Replace(value, " ", "%20", -1)
+}
+
+func quotedField(value string) string {
+	if value == "" {
+		value = "-"
+	}
+
+	return `"` + strings.Replace(value, `"`, `\"`, -1) + `"`
+}
+
+func sizeField(size int64) string {
+	if size <= 0 {
+		return "-"
+	}
+
+	return strconv.FormatInt(size, 10)
+}
@@ -0,0 +1,82 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accesslog
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecordString(t *testing.T) {
+	requestTime := time.Date(2019, time.February, 6, 0, 0, 38, 0, time.UTC)
+
+	testCases := []struct {
+		record         Record
+		expectedString string
+	}{
+		{
+			Record{
+				BucketOwner:      "minio",
+				Bucket:           "photos",
+				Time:             requestTime,
+				RemoteIP:         "192.0.2.3",
+				Requester:        "minio",
+				RequestID:        "15A87D4C3E6B2A1F",
+				Operation:        "REST.GET.OBJECT",
+				Key:              "2019/my puppy.jpg",
+				RequestURI:       "GET /photos/2019/my%20puppy.jpg HTTP/1.1",
+				HTTPStatus:       200,
+				BytesSent:        2662992,
+				ObjectSize:       2662992,
+				TotalTime:        70 * time.Millisecond,
+				TurnAroundTime:   10 * time.Millisecond,
+				UserAgent:        "Minio (linux; amd64) minio-go/v6.0.14",
+				SignatureVersion: "SigV4",
+				AuthType:         "AuthHeader",
+				HostHeader:       "localhost:9000",
+			},
+			`minio photos [06/Feb/2019:00:00:38 +0000] 192.0.2.3 minio 15A87D4C3E6B2A1F REST.GET.OBJECT 2019/my%20puppy.jpg "GET /photos/2019/my%20puppy.jpg HTTP/1.1" 200 - 2662992 2662992 70 10 "-" "Minio (linux; amd64) minio-go/v6.0.14" - - SigV4 - AuthHeader localhost:9000 -`,
+		},
+		{
+			Record{
+				Bucket:     "photos",
+				Time:       requestTime,
+				RemoteIP:   "192.0.2.3",
+				Operation:  "REST.GET.VERSIONING",
+				RequestURI: `GET /photos?versioning HTTP/1.1`,
+				HTTPStatus: 403,
+				ErrorCode:  "AccessDenied",
+				BytesSent:  243,
+				Referrer:   `say "hello"`,
+			},
+			`- photos [06/Feb/2019:00:00:38 +0000] 192.0.2.3 - - REST.GET.VERSIONING - "GET /photos?versioning HTTP/1.1" 403 AccessDenied 243 - 0 0 "say \"hello\"" "-" - - - - - - -`,
+		},
+	}
+
+	for i, testCase := range testCases {
+		if s := testCase.record.String(); s != testCase.expectedString {
+			t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expectedString, s)
+		}
+	}
+}
+
+func TestObjectKey(t *testing.T) {
+	deliveryTime := time.Date(2019, time.February, 6, 0, 5, 0, 0, time.UTC)
+	if key := ObjectKey("access/", deliveryTime, "7796180109EFD5D6"); key != "access/2019-02-06-00-05-00-7796180109EFD5D6" {
+		t.Fatalf("unexpected object key: %v", key)
+	}
+}
@@ -0,0 +1,85 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cors
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+)
+
+// Maximum number of rules allowed in a CORS configuration, as per AWS S3 specification.
+const maxRules = 100
+
+// ErrTooManyRules - CORS configuration has more than 100 rules.
+var ErrTooManyRules = errors.New("CORS configuration allows a maximum of 100 rules")
+
+// ErrNoRules - CORS configuration has no rules.
+var ErrNoRules = errors.New("CORS configuration should have at least one rule")
+
+// Config - bucket CORS configuration.
+type Config struct {
+	XMLNS     string   `xml:"xmlns,attr,omitempty"`
+	XMLName   xml.Name `xml:"CORSConfiguration"`
+	CORSRules []Rule   `xml:"CORSRule"`
+}
+
+// Validate - validates the CORS configuration.
+func (c Config) Validate() error {
+	if len(c.CORSRules) == 0 {
+		return ErrNoRules
+	}
+
+	if len(c.CORSRules) > maxRules {
+		return ErrTooManyRules
+	}
+
+	for _, rule := range c.CORSRules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Match - returns the first rule allowing a request from the given origin
+// with the given method and request headers. Rules are evaluated in order,
+// as per AWS S3 specification.
+func (c Config) Match(origin, method string, headers []string) (Rule, bool) {
+	for _, rule := range c.CORSRules {
+		if rule.matchOrigin(origin) && rule.matchMethod(method) && rule.matchHeaders(headers) {
+			return rule, true
+		}
+	}
+
+	return Rule{}, false
+}
+
+// ParseConfig - parses data in given reader to CORS configuration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
@@ -0,0 +1,78 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cors
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		data        string
+		expectedErr error
+		shouldFail  bool
+	}{
+		{`<CORSConfiguration><CORSRule><AllowedOrigin>http://www.example.com</AllowedOrigin><AllowedMethod>PUT</AllowedMethod><AllowedHeader>*</AllowedHeader><MaxAgeSeconds>3000</MaxAgeSeconds></CORSRule></CORSConfiguration>`, nil, false},
+		{`<CORSConfiguration></CORSConfiguration>`, ErrNoRules, true},
+		{`<CORSConfiguration><CORSRule><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`, ErrMissingAllowedOrigin, true},
+		{`<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>PATCH</AllowedMethod></CORSRule></CORSConfiguration>`, ErrInvalidMethod, true},
+		{`<CORSConfiguration><CORSRule>`, nil, true},
+	}
+
+	for i, testCase := range testCases {
+		_, err := ParseConfig(strings.NewReader(testCase.data))
+		if testCase.shouldFail != (err != nil) {
+			t.Fatalf("test %v: expected failure: %v, got: %v", i+1, testCase.shouldFail, err)
+		}
+		if testCase.expectedErr != nil && err != testCase.expectedErr {
+			t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+	}
+}
+
+func TestConfigMatch(t *testing.T) {
+	config := Config{
+		CORSRules: []Rule{
+			{ID: "write", AllowedOrigins: []string{"http://*.example.com"}, AllowedMethods: []string{"PUT", "POST", "DELETE"}, AllowedHeaders: []string{"x-amz-*", "Content-Type"}},
+			{ID: "read", AllowedOrigins: []string{"*"}, AllowedMethods: []string{"GET"}},
+		},
+	}
+
+	testCases := []struct {
+		origin     string
+		method     string
+		headers    []string
+		expectedID string
+		expectedOk bool
+	}{
+		{"http://www.example.com", "PUT", []string{"X-Amz-Date", "content-type"}, "write", true},
+		{"http://www.example.com", "PUT", []string{"Authorization"}, "", false},
+		{"http://www.example.org", "PUT", nil, "", false},
+		{"http://example.com", "DELETE", nil, "", false},
+		{"http://www.example.org", "GET", nil, "read", true},
+		{"http://www.example.org", "GET", []string{"x-amz-date"}, "", false},
+		{"http://www.example.com", "HEAD", nil, "", false},
+	}
+
+	for i, testCase := range testCases {
+		rule, ok := config.Match(testCase.origin, testCase.method, testCase.headers)
+		if ok != testCase.expectedOk || rule.ID != testCase.expectedID {
+			t.Fatalf("test %v: expected: %v %v, got: %v %v", i+1, testCase.expectedID, testCase.expectedOk, rule.ID, ok)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cors
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Maximum length of a rule ID, as per AWS S3 specification.
+const maxRuleIDLength = 255
+
+// ErrInvalidRuleID - rule ID is longer than 255 characters.
+var ErrInvalidRuleID = errors.New("ID must be less than 255 characters")
+
+// ErrMissingAllowedOrigin - rule has no allowed origin.
+var ErrMissingAllowedOrigin = errors.New("rule must have at least one allowed origin")
+
+// ErrMissingAllowedMethod - rule has no allowed method.
+var ErrMissingAllowedMethod = errors.New("rule must have at least one allowed method")
+
+// ErrInvalidMethod - rule allows a method other than GET, PUT, HEAD, POST and DELETE.
+var ErrInvalidMethod = errors.New("allowed method must be one of GET, PUT, HEAD, POST or DELETE")
+
+// ErrInvalidWildcard - an allowed origin or header has more than one wildcard.
+var ErrInvalidWildcard = errors.New("allowed origins and headers can have at most one wildcard")
+
+// ErrInvalidExposeHeader - an expose header has a wildcard.
+var ErrInvalidExposeHeader = errors.New("expose headers can not have wildcards")
+
+// ErrInvalidMaxAge - max age is negative.
+var ErrInvalidMaxAge = errors.New("max age must not be negative")
+
+// Rule - a CORS rule allowing requests from a set of origins.
+type Rule struct {
+	ID             string   `xml:"ID,omitempty"`
+	AllowedHeaders []string `xml:"AllowedHeader,omitempty"`
+	AllowedMethods []string `xml:"AllowedMethod"`
+	AllowedOrigins []string `xml:"AllowedOrigin"`
+	ExposeHeaders  []string `xml:"ExposeHeader,omitempty"`
+	MaxAgeSeconds  *int     `xml:"MaxAgeSeconds,omitempty"`
+}
+
+// Validate - validates the CORS rule.
+func (rule Rule) Validate() error {
+	if len(rule.ID) > maxRuleIDLength {
+		return ErrInvalidRuleID
+	}
+
+	if len(rule.AllowedOrigins) == 0 {
+		return ErrMissingAllowedOrigin
+	}
+
+	if len(rule.AllowedMethods) == 0 {
+		return ErrMissingAllowedMethod
+	}
+
+	for _, method := range rule.AllowedMethods {
+		switch method {
+		case http.MethodGet, http.MethodPut, http.MethodHead, http.MethodPost, http.MethodDelete:
+		default:
+			return ErrInvalidMethod
+		}
+	}
+
+	for _, origin := range rule.AllowedOrigins {
+		if strings.Count(origin, "*") > 1 {
+			return ErrInvalidWildcard
+		}
+	}
+
+	for _, header := range rule.AllowedHeaders {
+		if strings.Count(header, "*") > 1 {
+			return ErrInvalidWildcard
+		}
+	}
+
+	for _, header := range rule.ExposeHeaders {
+		if strings.Contains(header, "*") {
+			return ErrInvalidExposeHeader
+		}
+	}
+
+	if rule.MaxAgeSeconds != nil && *rule.MaxAgeSeconds < 0 {
+		return ErrInvalidMaxAge
+	}
+
+	return nil
+}
+
+// AllowsAnyOrigin - returns true if the rule allows requests from all origins.
+func (rule Rule) AllowsAnyOrigin() bool {
+	for _, origin := range rule.AllowedOrigins {
+		if origin == "*" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (rule Rule) matchOrigin(origin string) bool {
+	for _, pattern := range rule.AllowedOrigins {
+		if wildcardMatch(pattern, origin) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (rule Rule) matchMethod(method string) bool {
+	for _, m := range rule.AllowedMethods {
+		if m == method {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchHeaders - returns true if all the given headers are allowed,
+// header names are case insensitive.
+func (rule Rule) matchHeaders(headers []string) bool {
+	for _, header := range headers {
+		allowed := false
+		for _, pattern := range rule.AllowedHeaders {
+			if wildcardMatch(strings.ToLower(pattern), strings.ToLower(header)) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false
+		}
+	}
+
+	return true
+}
+
+// wildcardMatch - matches the given value against a pattern having at
+// most one '*' wildcard, which matches any sequence of characters.
+func wildcardMatch(pattern, value string) bool {
+	i := strings.Index(pattern, "*")
+	if i < 0 {
+		return pattern == value
+	}
+
+	prefix, suffix := pattern[:i], pattern[i+1:]
+	return len(value) >= len(prefix)+len(suffix) &&
+		strings.HasPrefix(value, prefix) &&
+		strings.HasSuffix(value, suffix)
+}
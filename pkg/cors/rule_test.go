@@ -0,0 +1,72 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cors
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRuleValidate(t *testing.T) {
+	origins := []string{"*"}
+	methods := []string{"GET"}
+	negative := -1
+
+	testCases := []struct {
+		rule        Rule
+		expectedErr error
+	}{
+		{Rule{AllowedOrigins: origins, AllowedMethods: methods}, nil},
+		{Rule{AllowedOrigins: []string{"https://*.example.com"}, AllowedMethods: []string{"PUT", "POST"}, AllowedHeaders: []string{"*"}, ExposeHeaders: []string{"ETag"}}, nil},
+		{Rule{ID: strings.Repeat("a", 256), AllowedOrigins: origins, AllowedMethods: methods}, ErrInvalidRuleID},
+		{Rule{AllowedMethods: methods}, ErrMissingAllowedOrigin},
+		{Rule{AllowedOrigins: origins}, ErrMissingAllowedMethod},
+		{Rule{AllowedOrigins: origins, AllowedMethods: []string{"get"}}, ErrInvalidMethod},
+		{Rule{AllowedOrigins: []string{"https://*.example.*"}, AllowedMethods: methods}, ErrInvalidWildcard},
+		{Rule{AllowedOrigins: origins, AllowedMethods: methods, AllowedHeaders: []string{"**"}}, ErrInvalidWildcard},
+		{Rule{AllowedOrigins: origins, AllowedMethods: methods, ExposeHeaders: []string{"x-amz-*"}}, ErrInvalidExposeHeader},
+		{Rule{AllowedOrigins: origins, AllowedMethods: methods, MaxAgeSeconds: &negative}, ErrInvalidMaxAge},
+	}
+
+	for i, testCase := range testCases {
+		if err := testCase.rule.Validate(); err != testCase.expectedErr {
+			t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+	}
+}
+
+func TestWildcardMatch(t *testing.T) {
+	testCases := []struct {
+		pattern  string
+		value    string
+		expected bool
+	}{
+		{"*", "http://www.example.com", true},
+		{"http://www.example.com", "http://www.example.com", true},
+		{"http://www.example.com", "https://www.example.com", false},
+		{"http://*.example.com", "http://www.example.com", true},
+		{"http://*.example.com", "http://example.com", false},
+		{"x-amz-*", "x-amz-date", true},
+		{"ab*ba", "aba", false},
+	}
+
+	for i, testCase := range testCases {
+		if result := wildcardMatch(testCase.pattern, testCase.value); result != testCase.expected {
+			t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expected, result)
+		}
+	}
+}
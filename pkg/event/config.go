@@ -20,6 +20,7 @@ import (
 	"encoding/xml"
 	"errors"
 	"io"
+	"net/url"
 	"reflect"
 	"strings"
 	"unicode/utf8"
@@ -124,11 +125,132 @@ type S3Key struct {
 	RuleList FilterRuleList `xml:"S3Key,omitempty" json:"S3Key,omitempty"`
 }
 
+// KeyValueRule - represents elements inside <FilterRule>...</FilterRule> of
+// <Metadata> and <Tags>, value may contain '*' and '?' wildcards.
+type KeyValueRule struct {
+	Name  string `xml:"Name" json:"Name"`
+	Value string `xml:"Value" json:"Value"`
+}
+
+// KeyValueRuleList - represents multiple <FilterRule>...</FilterRule> of
+// <Metadata> and <Tags>.
+type KeyValueRuleList struct {
+	Rules []KeyValueRule `xml:"FilterRule,omitempty" json:"FilterRule,omitempty"`
+}
+
+// validate - checks rule names with given function, values and duplicate names.
+func (ruleList KeyValueRuleList) validate(validName func(string) bool) error {
+	nameSet := set.NewStringSet()
+	for _, rule := range ruleList.Rules {
+		if !validName(rule.Name) {
+			return &ErrInvalidFilterName{rule.Name}
+		}
+
+		if len(rule.Value) > 1024 || !utf8.ValidString(rule.Value) {
+			return &ErrInvalidFilterValue{rule.Value}
+		}
+
+		name := strings.ToLower(rule.Name)
+		if nameSet.Contains(name) {
+			return &ErrDuplicateFilterName{rule.Name}
+		}
+		nameSet.Add(name)
+	}
+
+	return nil
+}
+
+// encode - returns URL encoded rules, sorted by name.
+func (ruleList *KeyValueRuleList) encode(canonicalName func(string) string) string {
+	if ruleList == nil {
+		return ""
+	}
+
+	values := make(url.Values)
+	for _, rule := range ruleList.Rules {
+		values.Set(canonicalName(rule.Name), rule.Value)
+	}
+
+	return values.Encode()
+}
+
+// SizeRange - represents elements inside <Size>...</Size>, zero maximum
+// size means no upper limit.
+type SizeRange struct {
+	Min int64 `xml:"Min,omitempty" json:"Min,omitempty"`
+	Max int64 `xml:"Max,omitempty" json:"Max,omitempty"`
+}
+
+// Filter - represents elements inside <Filter>...</Filter>
+type Filter struct {
+	S3Key
+	Metadata *KeyValueRuleList `xml:"Metadata,omitempty" json:"Metadata,omitempty"`
+	Tags     *KeyValueRuleList `xml:"Tags,omitempty" json:"Tags,omitempty"`
+	Size     *SizeRange        `xml:"Size,omitempty" json:"Size,omitempty"`
+}
+
+// UnmarshalXML - decodes XML data.
+func (filter *Filter) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	// Make subtype to avoid recursive UnmarshalXML().
+	type subFilter Filter
+	parsedFilter := subFilter{}
+	if err := d.DecodeElement(&parsedFilter, &start); err != nil {
+		return err
+	}
+
+	if parsedFilter.Metadata != nil {
+		if err := parsedFilter.Metadata.validate(isMetadataFilterName); err != nil {
+			return err
+		}
+	}
+
+	if parsedFilter.Tags != nil {
+		if err := parsedFilter.Tags.validate(isTagFilterName); err != nil {
+			return err
+		}
+	}
+
+	if size := parsedFilter.Size; size != nil {
+		if size.Min < 0 || size.Max < 0 || (size.Max != 0 && size.Max < size.Min) {
+			return &ErrInvalidFilterSize{size.Min, size.Max}
+		}
+	}
+
+	*filter = Filter(parsedFilter)
+	return nil
+}
+
+// ObjectFilter - returns object filter of metadata, tags and size rules.
+func (filter Filter) ObjectFilter() ObjectFilter {
+	objectFilter := ObjectFilter{
+		Metadata: filter.Metadata.encode(strings.ToLower),
+		Tags:     filter.Tags.encode(func(name string) string { return name }),
+	}
+
+	if filter.Size != nil {
+		objectFilter.MinSize = filter.Size.Min
+		objectFilter.MaxSize = filter.Size.Max
+	}
+
+	return objectFilter
+}
+
+// isMetadataFilterName - checks whether name is a user metadata key such as
+// "X-Amz-Meta-Project".
+func isMetadataFilterName(name string) bool {
+	return len(name) > len(userMetadataPrefix) && strings.HasPrefix(strings.ToLower(name), userMetadataPrefix)
+}
+
+// isTagFilterName - checks whether name is a valid object tag key.
+func isTagFilterName(name string) bool {
+	return name != "" && len(name) <= 128 && utf8.ValidString(name)
+}
+
 // common - represents common elements inside <QueueConfiguration>, <CloudFunctionConfiguration>
 // and <TopicConfiguration>
 type common struct {
 	ID     string `xml:"Id" json:"Id"`
-	Filter S3Key  `xml:"Filter" json:"Filter"`
+	Filter Filter `xml:"Filter" json:"Filter"`
 	Events []Name `xml:"Event" json:"Event"`
 }
 
@@ -185,8 +307,11 @@ func (q *Queue) SetRegion(region string) {
 
 // ToRulesMap - converts Queue to RulesMap
 func (q Queue) ToRulesMap() RulesMap {
-	pattern := q.Filter.RuleList.Pattern()
-	return NewRulesMap(q.Events, pattern, q.ARN.TargetID)
+	rule := Rule{
+		Pattern: q.Filter.RuleList.Pattern(),
+		Filter:  q.Filter.ObjectFilter(),
+	}
+	return NewRulesMapWithRule(q.Events, rule, q.ARN.TargetID)
 }
 
 // Unused.  Available for completion.
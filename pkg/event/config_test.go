@@ -129,6 +129,46 @@ func TestFilterRuleListPattern(t *testing.T) {
 	}
 }
 
+func TestFilterUnmarshalXML(t *testing.T) {
+	testCases := []struct {
+		data                 []byte
+		expectedObjectFilter ObjectFilter
+		expectErr            bool
+	}{
+		{[]byte(`<Filter></Filter>`), ObjectFilter{}, false},
+		{[]byte(`<Filter><Metadata><FilterRule><Name>X-Amz-Meta-Project</Name><Value>alpha*</Value></FilterRule></Metadata></Filter>`), ObjectFilter{Metadata: "x-amz-meta-project=alpha%2A"}, false},
+		{[]byte(`<Filter><Tags><FilterRule><Name>pipeline</Name><Value>ingest</Value></FilterRule><FilterRule><Name>env</Name><Value>prod</Value></FilterRule></Tags></Filter>`), ObjectFilter{Tags: "env=prod&pipeline=ingest"}, false},
+		{[]byte(`<Filter><Size><Min>104857600</Min></Size></Filter>`), ObjectFilter{MinSize: 104857600}, false},
+		{[]byte(`<Filter><Size><Min>1024</Min><Max>2048</Max></Size></Filter>`), ObjectFilter{MinSize: 1024, MaxSize: 2048}, false},
+		// Metadata name without user metadata prefix.
+		{[]byte(`<Filter><Metadata><FilterRule><Name>Content-Type</Name><Value>image/*</Value></FilterRule></Metadata></Filter>`), ObjectFilter{}, true},
+		// Duplicate metadata names.
+		{[]byte(`<Filter><Metadata><FilterRule><Name>X-Amz-Meta-Project</Name><Value>a</Value></FilterRule><FilterRule><Name>x-amz-meta-project</Name><Value>b</Value></FilterRule></Metadata></Filter>`), ObjectFilter{}, true},
+		// Empty tag name.
+		{[]byte(`<Filter><Tags><FilterRule><Name></Name><Value>ingest</Value></FilterRule></Tags></Filter>`), ObjectFilter{}, true},
+		// Negative size.
+		{[]byte(`<Filter><Size><Min>-1</Min></Size></Filter>`), ObjectFilter{}, true},
+		// Maximum size less than minimum size.
+		{[]byte(`<Filter><Size><Min>2048</Min><Max>1024</Max></Size></Filter>`), ObjectFilter{}, true},
+	}
+
+	for i, testCase := range testCases {
+		var filter Filter
+		err := xml.Unmarshal(testCase.data, &filter)
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("test %v: error: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+
+		if !testCase.expectErr {
+			if result := filter.ObjectFilter(); result != testCase.expectedObjectFilter {
+				t.Fatalf("test %v: result: expected: %+v, got: %+v", i+1, testCase.expectedObjectFilter, result)
+			}
+		}
+	}
+}
+
 func TestQueueUnmarshalXML(t *testing.T) {
 	dataCase1 := []byte(`
 <QueueConfiguration>
@@ -376,8 +416,34 @@ func TestQueueToRulesMap(t *testing.T) {
 		panic(err)
 	}
 
+	data = []byte(`
+<QueueConfiguration>
+   <Id>1</Id>
+    <Filter>
+        <Tags>
+            <FilterRule>
+                <Name>pipeline</Name>
+                <Value>ingest</Value>
+            </FilterRule>
+        </Tags>
+        <Size>
+            <Min>104857600</Min>
+        </Size>
+   </Filter>
+   <Queue>arn:minio:sqs:us-east-1:1:kafka</Queue>
+   <Event>s3:ObjectCreated:Put</Event>
+</QueueConfiguration>`)
+	queueCase3 := &Queue{}
+	if err := xml.Unmarshal(data, queueCase3); err != nil {
+		panic(err)
+	}
+
 	rulesMapCase1 := NewRulesMap([]Name{ObjectAccessedAll, ObjectCreatedAll, ObjectRemovedAll}, "*", TargetID{"1", "webhook"})
 	rulesMapCase2 := NewRulesMap([]Name{ObjectCreatedPut}, "images/*jpg", TargetID{"1", "webhook"})
+	rulesMapCase3 := NewRulesMapWithRule([]Name{ObjectCreatedPut}, Rule{
+		Pattern: "*",
+		Filter:  ObjectFilter{Tags: "pipeline=ingest", MinSize: 104857600},
+	}, TargetID{"1", "kafka"})
 
 	testCases := []struct {
 		queue          *Queue
@@ -385,6 +451,7 @@ func TestQueueToRulesMap(t *testing.T) {
 	}{
 		{queueCase1, rulesMapCase1},
 		{queueCase2, rulesMapCase2},
+		{queueCase3, rulesMapCase3},
 	}
 
 	for i, testCase := range testCases {
@@ -810,7 +877,7 @@ func TestConfigToRulesMap(t *testing.T) {
 	rulesMapCase2 := NewRulesMap([]Name{ObjectCreatedPut}, "images/*jpg", TargetID{"1", "webhook"})
 
 	rulesMapCase3 := NewRulesMap([]Name{ObjectAccessedAll, ObjectCreatedAll, ObjectRemovedAll}, "*", TargetID{"1", "webhook"})
-	rulesMapCase3.add([]Name{ObjectCreatedPut}, "images/*jpg", TargetID{"2", "amqp"})
+	rulesMapCase3.add([]Name{ObjectCreatedPut}, Rule{Pattern: "images/*jpg"}, TargetID{"2", "amqp"})
 
 	testCases := []struct {
 		config         *Config
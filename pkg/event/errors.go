@@ -32,6 +32,10 @@ func IsEventError(err error) bool {
 		return true
 	case ErrInvalidFilterValue, *ErrInvalidFilterValue:
 		return true
+	case ErrDuplicateFilterName, *ErrDuplicateFilterName:
+		return true
+	case ErrInvalidFilterSize, *ErrInvalidFilterSize:
+		return true
 	case ErrDuplicateEventName, *ErrDuplicateEventName:
 		return true
 	case ErrUnsupportedConfiguration, *ErrUnsupportedConfiguration:
@@ -83,6 +87,25 @@ func (err ErrInvalidFilterValue) Error() string {
 	return fmt.Sprintf("invalid filter value '%v'", err.FilterValue)
 }
 
+// ErrDuplicateFilterName - duplicate metadata or tag filter rule name error.
+type ErrDuplicateFilterName struct {
+	FilterName string
+}
+
+func (err ErrDuplicateFilterName) Error() string {
+	return fmt.Sprintf("duplicate filter name '%v' found", err.FilterName)
+}
+
+// ErrInvalidFilterSize - invalid size range filter error.
+type ErrInvalidFilterSize struct {
+	Min int64
+	Max int64
+}
+
+func (err ErrInvalidFilterSize) Error() string {
+	return fmt.Sprintf("invalid filter size range, minimum %v maximum %v", err.Min, err.Max)
+}
+
 // ErrDuplicateEventName - duplicate event name error.
 type ErrDuplicateEventName struct {
 	EventName Name
@@ -37,6 +37,16 @@ const (
 	ObjectCreatedPut
 	ObjectRemovedAll
 	ObjectRemovedDelete
+	BucketCreated
+	BucketRemoved
+	ObjectCreatedDeleteTagging
+	ObjectCreatedPutLegalHold
+	ObjectCreatedPutRetention
+	ObjectCreatedPutTagging
+	ObjectRemovedDeleteMarkerCreated
+	ObjectReplicationAll
+	ObjectReplicationComplete
+	ObjectReplicationFailed
 )
 
 // Expand - returns expanded values of abbreviated event type.
@@ -45,9 +55,14 @@ func (name Name) Expand() []Name {
 	case ObjectAccessedAll:
 		return []Name{ObjectAccessedGet, ObjectAccessedHead}
 	case ObjectCreatedAll:
-		return []Name{ObjectCreatedCompleteMultipartUpload, ObjectCreatedCopy, ObjectCreatedPost, ObjectCreatedPut}
+		return []Name{
+			ObjectCreatedCompleteMultipartUpload, ObjectCreatedCopy, ObjectCreatedDeleteTagging, ObjectCreatedPost,
+			ObjectCreatedPut, ObjectCreatedPutLegalHold, ObjectCreatedPutRetention, ObjectCreatedPutTagging,
+		}
 	case ObjectRemovedAll:
-		return []Name{ObjectRemovedDelete}
+		return []Name{ObjectRemovedDelete, ObjectRemovedDeleteMarkerCreated}
+	case ObjectReplicationAll:
+		return []Name{ObjectReplicationComplete, ObjectReplicationFailed}
 	default:
 		return []Name{name}
 	}
@@ -56,6 +71,10 @@ func (name Name) Expand() []Name {
 // String - returns string representation of event type.
 func (name Name) String() string {
 	switch name {
+	case BucketCreated:
+		return "s3:BucketCreated"
+	case BucketRemoved:
+		return "s3:BucketRemoved"
 	case ObjectAccessedAll:
 		return "s3:ObjectAccessed:*"
 	case ObjectAccessedGet:
@@ -68,14 +87,30 @@ func (name Name) String() string {
 		return "s3:ObjectCreated:CompleteMultipartUpload"
 	case ObjectCreatedCopy:
 		return "s3:ObjectCreated:Copy"
+	case ObjectCreatedDeleteTagging:
+		return "s3:ObjectCreated:DeleteTagging"
 	case ObjectCreatedPost:
 		return "s3:ObjectCreated:Post"
 	case ObjectCreatedPut:
 		return "s3:ObjectCreated:Put"
+	case ObjectCreatedPutLegalHold:
+		return "s3:ObjectCreated:PutLegalHold"
+	case ObjectCreatedPutRetention:
+		return "s3:ObjectCreated:PutRetention"
+	case ObjectCreatedPutTagging:
+		return "s3:ObjectCreated:PutTagging"
 	case ObjectRemovedAll:
 		return "s3:ObjectRemoved:*"
 	case ObjectRemovedDelete:
 		return "s3:ObjectRemoved:Delete"
+	case ObjectRemovedDeleteMarkerCreated:
+		return "s3:ObjectRemoved:DeleteMarkerCreated"
+	case ObjectReplicationAll:
+		return "s3:Replication:*"
+	case ObjectReplicationComplete:
+		return "s3:Replication:OperationCompletedReplication"
+	case ObjectReplicationFailed:
+		return "s3:Replication:OperationFailedReplication"
 	}
 
 	return ""
@@ -126,6 +161,10 @@ func (name *Name) UnmarshalJSON(data []byte) error {
 // ParseName - parses string to Name.
 func ParseName(s string) (Name, error) {
 	switch s {
+	case "s3:BucketCreated":
+		return BucketCreated, nil
+	case "s3:BucketRemoved":
+		return BucketRemoved, nil
 	case "s3:ObjectAccessed:*":
 		return ObjectAccessedAll, nil
 	case "s3:ObjectAccessed:Get":
@@ -138,14 +177,30 @@ func ParseName(s string) (Name, error) {
 		return ObjectCreatedCompleteMultipartUpload, nil
 	case "s3:ObjectCreated:Copy":
 		return ObjectCreatedCopy, nil
+	case "s3:ObjectCreated:DeleteTagging":
+		return ObjectCreatedDeleteTagging, nil
 	case "s3:ObjectCreated:Post":
 		return ObjectCreatedPost, nil
 	case "s3:ObjectCreated:Put":
 		return ObjectCreatedPut, nil
+	case "s3:ObjectCreated:PutLegalHold":
+		return ObjectCreatedPutLegalHold, nil
+	case "s3:ObjectCreated:PutRetention":
+		return ObjectCreatedPutRetention, nil
+	case "s3:ObjectCreated:PutTagging":
+		return ObjectCreatedPutTagging, nil
 	case "s3:ObjectRemoved:*":
 		return ObjectRemovedAll, nil
 	case "s3:ObjectRemoved:Delete":
 		return ObjectRemovedDelete, nil
+	case "s3:ObjectRemoved:DeleteMarkerCreated":
+		return ObjectRemovedDeleteMarkerCreated, nil
+	case "s3:Replication:*":
+		return ObjectReplicationAll, nil
+	case "s3:Replication:OperationCompletedReplication":
+		return ObjectReplicationComplete, nil
+	case "s3:Replication:OperationFailedReplication":
+		return ObjectReplicationFailed, nil
 	default:
 		return 0, &ErrInvalidEventName{s}
 	}
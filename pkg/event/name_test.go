@@ -29,9 +29,14 @@ func TestNameExpand(t *testing.T) {
 		expectedResult []Name
 	}{
 		{ObjectAccessedAll, []Name{ObjectAccessedGet, ObjectAccessedHead}},
-		{ObjectCreatedAll, []Name{ObjectCreatedCompleteMultipartUpload, ObjectCreatedCopy, ObjectCreatedPost, ObjectCreatedPut}},
-		{ObjectRemovedAll, []Name{ObjectRemovedDelete}},
+		{ObjectCreatedAll, []Name{
+			ObjectCreatedCompleteMultipartUpload, ObjectCreatedCopy, ObjectCreatedDeleteTagging, ObjectCreatedPost,
+			ObjectCreatedPut, ObjectCreatedPutLegalHold, ObjectCreatedPutRetention, ObjectCreatedPutTagging,
+		}},
+		{ObjectRemovedAll, []Name{ObjectRemovedDelete, ObjectRemovedDeleteMarkerCreated}},
+		{ObjectReplicationAll, []Name{ObjectReplicationComplete, ObjectReplicationFailed}},
 		{ObjectAccessedHead, []Name{ObjectAccessedHead}},
+		{BucketCreated, []Name{BucketCreated}},
 	}
 
 	for i, testCase := range testCases {
@@ -50,16 +55,26 @@ func TestNameString(t *testing.T) {
 		name           Name
 		expectedResult string
 	}{
+		{BucketCreated, "s3:BucketCreated"},
+		{BucketRemoved, "s3:BucketRemoved"},
 		{ObjectAccessedAll, "s3:ObjectAccessed:*"},
 		{ObjectAccessedGet, "s3:ObjectAccessed:Get"},
 		{ObjectAccessedHead, "s3:ObjectAccessed:Head"},
 		{ObjectCreatedAll, "s3:ObjectCreated:*"},
 		{ObjectCreatedCompleteMultipartUpload, "s3:ObjectCreated:CompleteMultipartUpload"},
 		{ObjectCreatedCopy, "s3:ObjectCreated:Copy"},
+		{ObjectCreatedDeleteTagging, "s3:ObjectCreated:DeleteTagging"},
 		{ObjectCreatedPost, "s3:ObjectCreated:Post"},
 		{ObjectCreatedPut, "s3:ObjectCreated:Put"},
+		{ObjectCreatedPutLegalHold, "s3:ObjectCreated:PutLegalHold"},
+		{ObjectCreatedPutRetention, "s3:ObjectCreated:PutRetention"},
+		{ObjectCreatedPutTagging, "s3:ObjectCreated:PutTagging"},
 		{ObjectRemovedAll, "s3:ObjectRemoved:*"},
 		{ObjectRemovedDelete, "s3:ObjectRemoved:Delete"},
+		{ObjectRemovedDeleteMarkerCreated, "s3:ObjectRemoved:DeleteMarkerCreated"},
+		{ObjectReplicationAll, "s3:Replication:*"},
+		{ObjectReplicationComplete, "s3:Replication:OperationCompletedReplication"},
+		{ObjectReplicationFailed, "s3:Replication:OperationFailedReplication"},
 		{blankName, ""},
 	}
 
@@ -200,6 +215,12 @@ func TestParseName(t *testing.T) {
 	}{
 		{"s3:ObjectAccessed:*", ObjectAccessedAll, false},
 		{"s3:ObjectRemoved:Delete", ObjectRemovedDelete, false},
+		{"s3:BucketCreated", BucketCreated, false},
+		{"s3:BucketRemoved", BucketRemoved, false},
+		{"s3:ObjectCreated:PutTagging", ObjectCreatedPutTagging, false},
+		{"s3:ObjectRemoved:DeleteMarkerCreated", ObjectRemovedDeleteMarkerCreated, false},
+		{"s3:Replication:OperationFailedReplication", ObjectReplicationFailed, false},
+		{"s3:BucketCreated:*", blankName, true},
 		{"", blankName, true},
 	}
 
@@ -17,6 +17,7 @@
 package event
 
 import (
+	"net/url"
 	"strings"
 
 	"github.com/minio/minio/pkg/wildcard"
@@ -45,20 +46,107 @@ func NewPattern(prefix, suffix string) (pattern string) {
 	return pattern
 }
 
+// userMetadataPrefix - prefix of user metadata keys in lower case.
+const userMetadataPrefix = "x-amz-meta-"
+
+// ObjectInfo - object properties rules are matched with.
+type ObjectInfo struct {
+	Name     string
+	Size     int64
+	Metadata map[string]string
+	Tags     map[string]string
+}
+
+// ObjectFilter - conditions on object metadata, tags and size of a rule.
+// Metadata and tag conditions are kept URL encoded to keep rules comparable,
+// zero MaxSize means no upper limit.
+type ObjectFilter struct {
+	Metadata string
+	Tags     string
+	MinSize  int64
+	MaxSize  int64
+}
+
+// matchValues - checks whether every URL encoded key/value condition matches
+// a value returned by lookup.
+func matchValues(conditions string, lookup func(string) (string, bool)) bool {
+	if conditions == "" {
+		return true
+	}
+
+	values, err := url.ParseQuery(conditions)
+	if err != nil {
+		return false
+	}
+
+	for key := range values {
+		value, ok := lookup(key)
+		if !ok || !wildcard.MatchSimple(values.Get(key), value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Match - checks whether object matches this filter.
+func (filter ObjectFilter) Match(object ObjectInfo) bool {
+	if object.Size < filter.MinSize {
+		return false
+	}
+
+	if filter.MaxSize != 0 && object.Size > filter.MaxSize {
+		return false
+	}
+
+	// Metadata keys are case insensitive, filter keys are in lower case.
+	lookupMetadata := func(key string) (string, bool) {
+		for k, v := range object.Metadata {
+			if strings.ToLower(k) == key {
+				return v, true
+			}
+		}
+		return "", false
+	}
+
+	lookupTag := func(key string) (string, bool) {
+		value, ok := object.Tags[key]
+		return value, ok
+	}
+
+	return matchValues(filter.Metadata, lookupMetadata) && matchValues(filter.Tags, lookupTag)
+}
+
+// Rule - object name pattern and object filter of a rule.
+type Rule struct {
+	Pattern string
+	Filter  ObjectFilter
+}
+
+// Match - checks whether object matches this rule.
+func (rule Rule) Match(object ObjectInfo) bool {
+	return wildcard.MatchSimple(rule.Pattern, object.Name) && rule.Filter.Match(object)
+}
+
 // Rules - event rules
-type Rules map[string]TargetIDSet
+type Rules map[Rule]TargetIDSet
 
 // Add - adds pattern and target ID.
 func (rules Rules) Add(pattern string, targetID TargetID) {
-	rules[pattern] = NewTargetIDSet(targetID).Union(rules[pattern])
+	rules.AddRule(Rule{Pattern: pattern}, targetID)
+}
+
+// AddRule - adds rule and target ID.
+func (rules Rules) AddRule(rule Rule, targetID TargetID) {
+	rules[rule] = NewTargetIDSet(targetID).Union(rules[rule])
 }
 
-// Match - returns TargetIDSet matching object name in rules.
-func (rules Rules) Match(objectName string) TargetIDSet {
+// Match - returns TargetIDSet matching object in rules.
+func (rules Rules) Match(object ObjectInfo) TargetIDSet {
 	targetIDs := NewTargetIDSet()
 
-	for pattern, targetIDSet := range rules {
-		if wildcard.MatchSimple(pattern, objectName) {
+	for rule, targetIDSet := range rules {
+		if rule.Match(object) {
 			targetIDs = targetIDs.Union(targetIDSet)
 		}
 	}
@@ -70,8 +158,8 @@ func (rules Rules) Match(objectName string) TargetIDSet {
 func (rules Rules) Clone() Rules {
 	rulesCopy := make(Rules)
 
-	for pattern, targetIDSet := range rules {
-		rulesCopy[pattern] = targetIDSet.Clone()
+	for rule, targetIDSet := range rules {
+		rulesCopy[rule] = targetIDSet.Clone()
 	}
 
 	return rulesCopy
@@ -81,8 +169,8 @@ func (rules Rules) Clone() Rules {
 func (rules Rules) Union(rules2 Rules) Rules {
 	nrules := rules.Clone()
 
-	for pattern, targetIDSet := range rules2 {
-		nrules[pattern] = nrules[pattern].Union(targetIDSet)
+	for rule, targetIDSet := range rules2 {
+		nrules[rule] = nrules[rule].Union(targetIDSet)
 	}
 
 	return nrules
@@ -92,9 +180,9 @@ func (rules Rules) Union(rules2 Rules) Rules {
 func (rules Rules) Difference(rules2 Rules) Rules {
 	nrules := make(Rules)
 
-	for pattern, targetIDSet := range rules {
-		if nv := targetIDSet.Difference(rules2[pattern]); len(nv) > 0 {
-			nrules[pattern] = nv
+	for rule, targetIDSet := range rules {
+		if nv := targetIDSet.Difference(rules2[rule]); len(nv) > 0 {
+			nrules[rule] = nv
 		}
 	}
 
@@ -124,7 +124,7 @@ func TestRulesMatch(t *testing.T) {
 	}
 
 	for i, testCase := range testCases {
-		result := testCase.rules.Match(testCase.objectName)
+		result := testCase.rules.Match(ObjectInfo{Name: testCase.objectName})
 
 		if !reflect.DeepEqual(testCase.expectedResult, result) {
 			t.Fatalf("test %v: result: expected: %v, got: %v", i+1, testCase.expectedResult, result)
@@ -132,6 +132,45 @@ func TestRulesMatch(t *testing.T) {
 	}
 }
 
+func TestObjectFilterMatch(t *testing.T) {
+	object := ObjectInfo{
+		Name:     "ingest/2010/data.csv",
+		Size:     200 << 20,
+		Metadata: map[string]string{"X-Amz-Meta-Project": "alpha-1", "Content-Type": "text/csv"},
+		Tags:     map[string]string{"pipeline": "ingest", "env": "prod"},
+	}
+
+	testCases := []struct {
+		filter         ObjectFilter
+		object         ObjectInfo
+		expectedResult bool
+	}{
+		{ObjectFilter{}, object, true},
+		{ObjectFilter{}, ObjectInfo{Name: "photo.jpg"}, true},
+		{ObjectFilter{MinSize: 100 << 20}, object, true},
+		{ObjectFilter{MinSize: 300 << 20}, object, false},
+		{ObjectFilter{MaxSize: 100 << 20}, object, false},
+		{ObjectFilter{MinSize: 100 << 20, MaxSize: 200 << 20}, object, true},
+		{ObjectFilter{Metadata: "x-amz-meta-project=alpha%2A"}, object, true},
+		{ObjectFilter{Metadata: "x-amz-meta-project=beta%2A"}, object, false},
+		{ObjectFilter{Metadata: "x-amz-meta-owner=%2A"}, object, false},
+		{ObjectFilter{Tags: "pipeline=ingest"}, object, true},
+		{ObjectFilter{Tags: "env=prod&pipeline=ingest"}, object, true},
+		{ObjectFilter{Tags: "env=dev&pipeline=ingest"}, object, false},
+		{ObjectFilter{Tags: "Pipeline=ingest"}, object, false},
+		{ObjectFilter{Tags: "pipeline=ingest", MinSize: 100 << 20}, object, true},
+		{ObjectFilter{Tags: "pipeline=ingest", MinSize: 100 << 20}, ObjectInfo{Name: "data.csv", Size: 200 << 20}, false},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.filter.Match(testCase.object)
+
+		if result != testCase.expectedResult {
+			t.Fatalf("test %v: result: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
 func TestRulesClone(t *testing.T) {
 	rulesCase1 := make(Rules)
 
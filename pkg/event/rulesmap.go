@@ -19,10 +19,10 @@ package event
 // RulesMap - map of rules for every event name.
 type RulesMap map[Name]Rules
 
-// add - adds event names, prefixes, suffixes and target ID to rules map.
-func (rulesMap RulesMap) add(eventNames []Name, pattern string, targetID TargetID) {
+// add - adds event names, rule and target ID to rules map.
+func (rulesMap RulesMap) add(eventNames []Name, rule Rule, targetID TargetID) {
 	rules := make(Rules)
-	rules.Add(pattern, targetID)
+	rules.AddRule(rule, targetID)
 
 	for _, eventName := range eventNames {
 		for _, name := range eventName.Expand() {
@@ -60,19 +60,25 @@ func (rulesMap RulesMap) Remove(rulesMap2 RulesMap) {
 	}
 }
 
-// Match - returns TargetIDSet matching object name and event name in rules map.
-func (rulesMap RulesMap) Match(eventName Name, objectName string) TargetIDSet {
-	return rulesMap[eventName].Match(objectName)
+// Match - returns TargetIDSet matching object and event name in rules map.
+func (rulesMap RulesMap) Match(eventName Name, object ObjectInfo) TargetIDSet {
+	return rulesMap[eventName].Match(object)
 }
 
 // NewRulesMap - creates new rules map with given values.
 func NewRulesMap(eventNames []Name, pattern string, targetID TargetID) RulesMap {
+	return NewRulesMapWithRule(eventNames, Rule{Pattern: pattern}, targetID)
+}
+
+// NewRulesMapWithRule - creates new rules map with given rule, which may also
+// filter objects by metadata, tags and size.
+func NewRulesMapWithRule(eventNames []Name, rule Rule, targetID TargetID) RulesMap {
 	// If pattern is empty, add '*' wildcard to match all.
-	if pattern == "" {
-		pattern = "*"
+	if rule.Pattern == "" {
+		rule.Pattern = "*"
 	}
 
 	rulesMap := make(RulesMap)
-	rulesMap.add(eventNames, pattern, targetID)
+	rulesMap.add(eventNames, rule, targetID)
 	return rulesMap
 }
@@ -42,6 +42,8 @@ type AMQPArgs struct {
 	Internal     bool     `json:"internal"`
 	NoWait       bool     `json:"noWait"`
 	AutoDeleted  bool     `json:"autoDeleted"`
+	QueueDir     string   `json:"queueDir"`
+	QueueLimit   uint16   `json:"queueLimit"`
 }
 
 // Validate AMQP arguments
@@ -52,6 +54,9 @@ func (a *AMQPArgs) Validate() error {
 	if _, err := amqp.ParseURI(a.URL.String()); err != nil {
 		return err
 	}
+	if err := validateQueueDir(a.QueueDir); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -61,6 +66,7 @@ type AMQPTarget struct {
 	args      AMQPArgs
 	conn      *amqp.Connection
 	connMutex sync.Mutex
+	queue     *eventQueue
 }
 
 // ID - returns TargetID.
@@ -107,8 +113,8 @@ func (target *AMQPTarget) channel() (*amqp.Channel, error) {
 	return ch, nil
 }
 
-// Send - sends event to AMQP.
-func (target *AMQPTarget) Send(eventData event.Event) error {
+// send - sends event to AMQP.
+func (target *AMQPTarget) send(eventData event.Event) error {
 	ch, err := target.channel()
 	if err != nil {
 		return err
@@ -142,8 +148,20 @@ func (target *AMQPTarget) Send(eventData event.Event) error {
 		})
 }
 
-// Close - does nothing and available for interface compatibility.
+// Send - sends event to AMQP, persists the event to be sent later on
+// when AMQP is unreachable and a queue store is configured.
+func (target *AMQPTarget) Send(eventData event.Event) error {
+	return target.queue.Send(eventData)
+}
+
+// PendingEvents - returns the number of events pending to be sent.
+func (target *AMQPTarget) PendingEvents() int {
+	return target.queue.Len()
+}
+
+// Close - stops replaying persisted events.
 func (target *AMQPTarget) Close() error {
+	target.queue.Close()
 	return nil
 }
 
@@ -163,9 +181,17 @@ func NewAMQPTarget(id string, args AMQPArgs) (*AMQPTarget, error) {
 		time.Sleep(2 * time.Second)
 	}
 
-	return &AMQPTarget{
+	store, err := newQueueStore(args.QueueDir, args.QueueLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	target := &AMQPTarget{
 		id:   event.TargetID{ID: id, Name: "amqp"},
 		args: args,
 		conn: conn,
-	}, nil
+	}
+	target.queue = newEventQueue(store, target.send)
+
+	return target, nil
 }
@@ -31,10 +31,12 @@ import (
 
 // ElasticsearchArgs - Elasticsearch target arguments.
 type ElasticsearchArgs struct {
-	Enable bool     `json:"enable"`
-	Format string   `json:"format"`
-	URL    xnet.URL `json:"url"`
-	Index  string   `json:"index"`
+	Enable     bool     `json:"enable"`
+	Format     string   `json:"format"`
+	URL        xnet.URL `json:"url"`
+	Index      string   `json:"index"`
+	QueueDir   string   `json:"queueDir"`
+	QueueLimit uint16   `json:"queueLimit"`
 }
 
 // Validate ElasticsearchArgs fields
@@ -54,6 +56,9 @@ func (a ElasticsearchArgs) Validate() error {
 	if a.Index == "" {
 		return errors.New("empty index value")
 	}
+	if err := validateQueueDir(a.QueueDir); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -62,6 +67,7 @@ type ElasticsearchTarget struct {
 	id     event.TargetID
 	args   ElasticsearchArgs
 	client *elastic.Client
+	queue  *eventQueue
 }
 
 // ID - returns target ID.
@@ -69,8 +75,8 @@ func (target *ElasticsearchTarget) ID() event.TargetID {
 	return target.id
 }
 
-// Send - sends event to Elasticsearch.
-func (target *ElasticsearchTarget) Send(eventData event.Event) error {
+// send - sends event to Elasticsearch.
+func (target *ElasticsearchTarget) send(eventData event.Event) error {
 	var key string
 
 	remove := func() error {
@@ -111,8 +117,20 @@ func (target *ElasticsearchTarget) Send(eventData event.Event) error {
 	return nil
 }
 
-// Close - does nothing and available for interface compatibility.
+// Send - sends event to Elasticsearch, persists the event to be sent later on
+// when Elasticsearch is unreachable and a queue store is configured.
+func (target *ElasticsearchTarget) Send(eventData event.Event) error {
+	return target.queue.Send(eventData)
+}
+
+// PendingEvents - returns the number of events pending to be sent.
+func (target *ElasticsearchTarget) PendingEvents() int {
+	return target.queue.Len()
+}
+
+// Close - stops replaying persisted events.
 func (target *ElasticsearchTarget) Close() error {
+	target.queue.Close()
 	return nil
 }
 
@@ -139,9 +157,17 @@ func NewElasticsearchTarget(id string, args ElasticsearchArgs) (*ElasticsearchTa
 		}
 	}
 
-	return &ElasticsearchTarget{
+	store, err := newQueueStore(args.QueueDir, args.QueueLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	target := &ElasticsearchTarget{
 		id:     event.TargetID{ID: id, Name: "elasticsearch"},
 		args:   args,
 		client: client,
-	}, nil
+	}
+	target.queue = newEventQueue(store, target.send)
+
+	return target, nil
 }
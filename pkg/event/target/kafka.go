@@ -43,6 +43,8 @@ type KafkaArgs struct {
 		User     string `json:"username"`
 		Password string `json:"password"`
 	} `json:"sasl"`
+	QueueDir   string `json:"queueDir"`
+	QueueLimit uint16 `json:"queueLimit"`
 }
 
 // Validate KafkaArgs fields
@@ -58,6 +60,9 @@ func (k KafkaArgs) Validate() error {
 			return err
 		}
 	}
+	if err := validateQueueDir(k.QueueDir); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -66,6 +71,7 @@ type KafkaTarget struct {
 	id       event.TargetID
 	args     KafkaArgs
 	producer sarama.SyncProducer
+	queue    *eventQueue
 }
 
 // ID - returns target ID.
@@ -73,8 +79,8 @@ func (target *KafkaTarget) ID() event.TargetID {
 	return target.id
 }
 
-// Send - sends event to Kafka.
-func (target *KafkaTarget) Send(eventData event.Event) error {
+// send - sends event to Kafka.
+func (target *KafkaTarget) send(eventData event.Event) error {
 	objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
 	if err != nil {
 		return err
@@ -96,8 +102,21 @@ func (target *KafkaTarget) Send(eventData event.Event) error {
 	return err
 }
 
+// Send - sends event to Kafka, persists the event to be sent later on
+// when Kafka is unreachable and a queue store is configured.
+func (target *KafkaTarget) Send(eventData event.Event) error {
+	return target.queue.Send(eventData)
+}
+
+// PendingEvents - returns the number of events pending to be sent.
+func (target *KafkaTarget) PendingEvents() int {
+	return target.queue.Len()
+}
+
 // Close - closes underneath kafka connection.
 func (target *KafkaTarget) Close() error {
+	target.queue.Close()
+
 	return target.producer.Close()
 }
 
@@ -128,9 +147,17 @@ func NewKafkaTarget(id string, args KafkaArgs) (*KafkaTarget, error) {
 		return nil, err
 	}
 
-	return &KafkaTarget{
+	store, err := newQueueStore(args.QueueDir, args.QueueLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	target := &KafkaTarget{
 		id:       event.TargetID{ID: id, Name: "kafka"},
 		args:     args,
 		producer: producer,
-	}, nil
+	}
+	target.queue = newEventQueue(store, target.send)
+
+	return target, nil
 }
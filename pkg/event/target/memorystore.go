@@ -17,6 +17,7 @@
 package target
 
 import (
+	"sort"
 	"sync"
 
 	"github.com/minio/minio/pkg/event"
@@ -59,7 +60,7 @@ func (store *MemoryStore) Put(e event.Event) error {
 	if store.eC == store.limit {
 		return ErrLimitExceeded
 	}
-	key, kErr := getNewUUID()
+	key, kErr := newEventKey()
 	if kErr != nil {
 		return kErr
 	}
@@ -85,12 +86,24 @@ func (store *MemoryStore) Del(key string) {
 	store.Lock()
 	defer store.Unlock()
 
+	if _, exist := store.events[key]; !exist {
+		return
+	}
+
 	delete(store.events, key)
 
 	store.eC--
 }
 
-// ListAll - lists all the keys in the store.
+// Len - returns the number of events in the store.
+func (store *MemoryStore) Len() int {
+	store.RLock()
+	defer store.RUnlock()
+
+	return int(store.eC)
+}
+
+// ListAll - lists all the keys in the store, oldest first.
 func (store *MemoryStore) ListAll() []string {
 	store.RLock()
 	defer store.RUnlock()
@@ -99,6 +112,7 @@ func (store *MemoryStore) ListAll() []string {
 	for k := range store.events {
 		keys = append(keys, k)
 	}
+	sort.Strings(keys)
 
 	return keys
 }
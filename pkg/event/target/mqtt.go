@@ -22,7 +22,6 @@ import (
 	"encoding/json"
 	"errors"
 	"net/url"
-	"path/filepath"
 	"time"
 
 	"github.com/eclipse/paho.mqtt.golang"
@@ -64,13 +63,11 @@ func (m MQTTArgs) Validate() error {
 	default:
 		return errors.New("unknown protocol in broker address")
 	}
-	if m.QueueDir != "" {
-		if !filepath.IsAbs(m.QueueDir) {
-			return errors.New("queueDir path should be absolute")
-		}
-		if m.QoS == 0 {
-			return errors.New("qos should be set to 1 or 2 if queueDir is set")
-		}
+	if err := validateQueueDir(m.QueueDir); err != nil {
+		return err
+	}
+	if m.QueueDir != "" && m.QoS == 0 {
+		return errors.New("qos should be set to 1 or 2 if queueDir is set")
 	}
 
 	return nil
@@ -81,8 +78,7 @@ type MQTTTarget struct {
 	id     event.TargetID
 	args   MQTTArgs
 	client mqtt.Client
-	store  Store
-	reconn bool
+	queue  *eventQueue
 }
 
 // ID - returns target ID.
@@ -90,33 +86,10 @@ func (target *MQTTTarget) ID() event.TargetID {
 	return target.id
 }
 
-// Reads persisted events from the store and re-plays.
-func (target *MQTTTarget) retry() {
-	target.reconn = true
-	events := target.store.ListAll()
-	for len(events) != 0 {
-		for _, key := range events {
-			event, eErr := target.store.Get(key)
-			if eErr != nil {
-				continue
-			}
-			for !target.client.IsConnectionOpen() {
-				time.Sleep(retryInterval * time.Second)
-			}
-			// The connection is open.
-			if err := target.send(event); err != nil {
-				continue
-			}
-			// Delete after a successful publish.
-			target.store.Del(key)
-		}
-		events = target.store.ListAll()
-	}
-	// Release the reconn state.
-	target.reconn = false
-}
-
 func (target *MQTTTarget) send(eventData event.Event) error {
+	if !target.client.IsConnectionOpen() {
+		return errNotConnected
+	}
 
 	objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
 	if err != nil {
@@ -135,26 +108,20 @@ func (target *MQTTTarget) send(eventData event.Event) error {
 
 }
 
-// Send - sends event to MQTT when the connection is active.
+// Send - sends event to MQTT when the connection is active, persists the
+// event to be sent once reconnected otherwise.
 func (target *MQTTTarget) Send(eventData event.Event) error {
-	// Persist the events if the connection is not active.
-	if !target.client.IsConnectionOpen() {
-		if err := target.store.Put(eventData); err != nil {
-			return err
-		}
-		// Ignore if retry is triggered already.
-		if !target.reconn {
-			go target.retry()
-		}
-		return nil
-	}
+	return target.queue.Send(eventData)
+}
 
-	// Publishes to the broker as the connection is active.
-	return target.send(eventData)
+// PendingEvents - returns the number of events pending to be sent.
+func (target *MQTTTarget) PendingEvents() int {
+	return target.queue.Len()
 }
 
-// Close - does nothing and available for interface compatibility.
+// Close - stops replaying persisted events.
 func (target *MQTTTarget) Close() error {
+	target.queue.Close()
 	return nil
 }
 
@@ -170,14 +137,11 @@ func NewMQTTTarget(id string, args MQTTArgs) (*MQTTTarget, error) {
 		SetTLSConfig(&tls.Config{RootCAs: args.RootCAs}).
 		AddBroker(args.Broker.String())
 
-	var store Store
-
-	if args.QueueDir != "" {
-		store = NewQueueStore(args.QueueDir, args.QueueLimit)
-		if oErr := store.Open(); oErr != nil {
-			return nil, oErr
-		}
-	} else {
+	store, err := newQueueStore(args.QueueDir, args.QueueLimit)
+	if err != nil {
+		return nil, err
+	}
+	if store == nil {
 		store = NewMemoryStore(args.QueueLimit)
 	}
 
@@ -204,15 +168,8 @@ func NewMQTTTarget(id string, args MQTTArgs) (*MQTTTarget, error) {
 		id:     event.TargetID{ID: id, Name: "mqtt"},
 		args:   args,
 		client: client,
-		store:  store,
-		reconn: false,
-	}
-
-	// Replay any previously persisted events in the store.
-	if len(target.store.ListAll()) != 0 {
-		go target.retry()
-
 	}
+	target.queue = newEventQueue(store, target.send)
 
 	return target, nil
 }
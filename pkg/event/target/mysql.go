@@ -79,15 +79,17 @@ const (
 
 // MySQLArgs - MySQL target arguments.
 type MySQLArgs struct {
-	Enable   bool     `json:"enable"`
-	Format   string   `json:"format"`
-	DSN      string   `json:"dsnString"`
-	Table    string   `json:"table"`
-	Host     xnet.URL `json:"host"`
-	Port     string   `json:"port"`
-	User     string   `json:"user"`
-	Password string   `json:"password"`
-	Database string   `json:"database"`
+	Enable     bool     `json:"enable"`
+	Format     string   `json:"format"`
+	DSN        string   `json:"dsnString"`
+	Table      string   `json:"table"`
+	Host       xnet.URL `json:"host"`
+	Port       string   `json:"port"`
+	User       string   `json:"user"`
+	Password   string   `json:"password"`
+	Database   string   `json:"database"`
+	QueueDir   string   `json:"queueDir"`
+	QueueLimit uint16   `json:"queueLimit"`
 }
 
 // Validate MySQLArgs fields
@@ -123,6 +125,9 @@ func (m MySQLArgs) Validate() error {
 			return fmt.Errorf("database unspecified")
 		}
 	}
+	if err := validateQueueDir(m.QueueDir); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -134,6 +139,7 @@ type MySQLTarget struct {
 	deleteStmt *sql.Stmt
 	insertStmt *sql.Stmt
 	db         *sql.DB
+	queue      *eventQueue
 }
 
 // ID - returns target ID.
@@ -141,8 +147,8 @@ func (target *MySQLTarget) ID() event.TargetID {
 	return target.id
 }
 
-// Send - sends event to MySQL.
-func (target *MySQLTarget) Send(eventData event.Event) error {
+// send - sends event to MySQL.
+func (target *MySQLTarget) send(eventData event.Event) error {
 	if target.args.Format == event.NamespaceFormat {
 		objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
 		if err != nil {
@@ -181,8 +187,21 @@ func (target *MySQLTarget) Send(eventData event.Event) error {
 	return nil
 }
 
+// Send - sends event to MySQL, persists the event to be sent later on
+// when MySQL is unreachable and a queue store is configured.
+func (target *MySQLTarget) Send(eventData event.Event) error {
+	return target.queue.Send(eventData)
+}
+
+// PendingEvents - returns the number of events pending to be sent.
+func (target *MySQLTarget) PendingEvents() int {
+	return target.queue.Len()
+}
+
 // Close - closes underneath connections to MySQL database.
 func (target *MySQLTarget) Close() error {
+	target.queue.Close()
+
 	if target.updateStmt != nil {
 		// FIXME: log returned error. ignore time being.
 		_ = target.updateStmt.Close()
@@ -253,12 +272,20 @@ func NewMySQLTarget(id string, args MySQLArgs) (*MySQLTarget, error) {
 		}
 	}
 
-	return &MySQLTarget{
+	store, err := newQueueStore(args.QueueDir, args.QueueLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	target := &MySQLTarget{
 		id:         event.TargetID{ID: id, Name: "mysql"},
 		args:       args,
 		updateStmt: updateStmt,
 		deleteStmt: deleteStmt,
 		insertStmt: insertStmt,
 		db:         db,
-	}, nil
+	}
+	target.queue = newEventQueue(store, target.send)
+
+	return target, nil
 }
@@ -43,6 +43,8 @@ type NATSArgs struct {
 		Async              bool   `json:"async"`
 		MaxPubAcksInflight int    `json:"maxPubAcksInflight"`
 	} `json:"streaming"`
+	QueueDir   string `json:"queueDir"`
+	QueueLimit uint16 `json:"queueLimit"`
 }
 
 // Validate NATSArgs fields
@@ -65,6 +67,10 @@ func (n NATSArgs) Validate() error {
 		}
 	}
 
+	if err := validateQueueDir(n.QueueDir); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -74,6 +80,7 @@ type NATSTarget struct {
 	args     NATSArgs
 	natsConn *nats.Conn
 	stanConn stan.Conn
+	queue    *eventQueue
 }
 
 // ID - returns target ID.
@@ -81,8 +88,8 @@ func (target *NATSTarget) ID() event.TargetID {
 	return target.id
 }
 
-// Send - sends event to NATS.
-func (target *NATSTarget) Send(eventData event.Event) (err error) {
+// send - sends event to NATS.
+func (target *NATSTarget) send(eventData event.Event) (err error) {
 	objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
 	if err != nil {
 		return err
@@ -107,8 +114,21 @@ func (target *NATSTarget) Send(eventData event.Event) (err error) {
 	return err
 }
 
+// Send - sends event to NATS, persists the event to be sent later on
+// when NATS is unreachable and a queue store is configured.
+func (target *NATSTarget) Send(eventData event.Event) error {
+	return target.queue.Send(eventData)
+}
+
+// PendingEvents - returns the number of events pending to be sent.
+func (target *NATSTarget) PendingEvents() int {
+	return target.queue.Len()
+}
+
 // Close - closes underneath connections to NATS server.
 func (target *NATSTarget) Close() (err error) {
+	target.queue.Close()
+
 	if target.stanConn != nil {
 		err = target.stanConn.Close()
 	}
@@ -158,10 +178,18 @@ func NewNATSTarget(id string, args NATSArgs) (*NATSTarget, error) {
 		return nil, err
 	}
 
-	return &NATSTarget{
+	store, err := newQueueStore(args.QueueDir, args.QueueLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	target := &NATSTarget{
 		id:       event.TargetID{ID: id, Name: "nats"},
 		args:     args,
 		stanConn: stanConn,
 		natsConn: natsConn,
-	}, nil
+	}
+	target.queue = newEventQueue(store, target.send)
+
+	return target, nil
 }
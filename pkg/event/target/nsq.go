@@ -37,6 +37,8 @@ type NSQArgs struct {
 		Enable     bool `json:"enable"`
 		SkipVerify bool `json:"skipVerify"`
 	} `json:"tls"`
+	QueueDir   string `json:"queueDir"`
+	QueueLimit uint16 `json:"queueLimit"`
 }
 
 // Validate NSQArgs fields
@@ -53,6 +55,10 @@ func (n NSQArgs) Validate() error {
 		return errors.New("empty topic")
 	}
 
+	if err := validateQueueDir(n.QueueDir); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -61,6 +67,7 @@ type NSQTarget struct {
 	id       event.TargetID
 	args     NSQArgs
 	producer *nsq.Producer
+	queue    *eventQueue
 }
 
 // ID - returns target ID.
@@ -68,8 +75,8 @@ func (target *NSQTarget) ID() event.TargetID {
 	return target.id
 }
 
-// Send - sends event to NSQD.
-func (target *NSQTarget) Send(eventData event.Event) (err error) {
+// send - sends event to NSQD.
+func (target *NSQTarget) send(eventData event.Event) (err error) {
 	objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
 	if err != nil {
 		return err
@@ -86,8 +93,21 @@ func (target *NSQTarget) Send(eventData event.Event) (err error) {
 	return err
 }
 
+// Send - sends event to NSQD, persists the event to be sent later on
+// when NSQD is unreachable and a queue store is configured.
+func (target *NSQTarget) Send(eventData event.Event) error {
+	return target.queue.Send(eventData)
+}
+
+// PendingEvents - returns the number of events pending to be sent.
+func (target *NSQTarget) PendingEvents() int {
+	return target.queue.Len()
+}
+
 // Close - closes underneath connections to NSQD server.
 func (target *NSQTarget) Close() (err error) {
+	target.queue.Close()
+
 	// this blocks until complete:
 	target.producer.Stop()
 	return nil
@@ -108,9 +128,17 @@ func NewNSQTarget(id string, args NSQArgs) (*NSQTarget, error) {
 		return nil, err
 	}
 
-	return &NSQTarget{
+	store, err := newQueueStore(args.QueueDir, args.QueueLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	target := &NSQTarget{
 		id:       event.TargetID{ID: id, Name: "nsq"},
 		args:     args,
 		producer: producer,
-	}, nil
+	}
+	target.queue = newEventQueue(store, target.send)
+
+	return target, nil
 }
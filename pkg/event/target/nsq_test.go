@@ -71,6 +71,7 @@ func TestNewNSQTarget(t *testing.T) {
 			got, err := NewNSQTarget(tt.args.id, tt.args.args)
 			// dirty hack, otherwhise cannot compare the pointers:
 			tt.want.producer = got.producer
+			tt.want.queue = got.queue
 			if (err != nil) != tt.wantErr {
 				t.Errorf("NewNSQTarget() error = %v, wantErr %v", err, tt.wantErr)
 				return
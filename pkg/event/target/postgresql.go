@@ -89,6 +89,8 @@ type PostgreSQLArgs struct {
 	User             string   `json:"user"`     // default: user running minio
 	Password         string   `json:"password"` // default: no password
 	Database         string   `json:"database"` // default: same as user
+	QueueDir         string   `json:"queueDir"`
+	QueueLimit       uint16   `json:"queueLimit"`
 }
 
 // Validate PostgreSQLArgs fields
@@ -122,6 +124,10 @@ func (p PostgreSQLArgs) Validate() error {
 		}
 	}
 
+	if err := validateQueueDir(p.QueueDir); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -133,6 +139,7 @@ type PostgreSQLTarget struct {
 	deleteStmt *sql.Stmt
 	insertStmt *sql.Stmt
 	db         *sql.DB
+	queue      *eventQueue
 }
 
 // ID - returns target ID.
@@ -140,8 +147,8 @@ func (target *PostgreSQLTarget) ID() event.TargetID {
 	return target.id
 }
 
-// Send - sends event to PostgreSQL.
-func (target *PostgreSQLTarget) Send(eventData event.Event) error {
+// send - sends event to PostgreSQL.
+func (target *PostgreSQLTarget) send(eventData event.Event) error {
 	if target.args.Format == event.NamespaceFormat {
 		objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
 		if err != nil {
@@ -180,8 +187,21 @@ func (target *PostgreSQLTarget) Send(eventData event.Event) error {
 	return nil
 }
 
+// Send - sends event to PostgreSQL, persists the event to be sent later on
+// when PostgreSQL is unreachable and a queue store is configured.
+func (target *PostgreSQLTarget) Send(eventData event.Event) error {
+	return target.queue.Send(eventData)
+}
+
+// PendingEvents - returns the number of events pending to be sent.
+func (target *PostgreSQLTarget) PendingEvents() int {
+	return target.queue.Len()
+}
+
 // Close - closes underneath connections to PostgreSQL database.
 func (target *PostgreSQLTarget) Close() error {
+	target.queue.Close()
+
 	if target.updateStmt != nil {
 		// FIXME: log returned error. ignore time being.
 		_ = target.updateStmt.Close()
@@ -258,12 +278,20 @@ func NewPostgreSQLTarget(id string, args PostgreSQLArgs) (*PostgreSQLTarget, err
 		}
 	}
 
-	return &PostgreSQLTarget{
+	store, err := newQueueStore(args.QueueDir, args.QueueLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	target := &PostgreSQLTarget{
 		id:         event.TargetID{ID: id, Name: "postgresql"},
 		args:       args,
 		updateStmt: updateStmt,
 		deleteStmt: deleteStmt,
 		insertStmt: insertStmt,
 		db:         db,
-	}, nil
+	}
+	target.queue = newEventQueue(store, target.send)
+
+	return target, nil
 }
@@ -21,6 +21,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 
@@ -98,7 +99,7 @@ func (store *QueueStore) Put(e event.Event) error {
 	if store.eC >= store.limit {
 		return ErrLimitExceeded
 	}
-	key, kErr := getNewUUID()
+	key, kErr := newEventKey()
 	if kErr != nil {
 		return kErr
 	}
@@ -153,7 +154,14 @@ func (store *QueueStore) del(key string) {
 	store.eC--
 }
 
-// ListAll - lists all the keys in the directory.
+// Len - returns the number of events in the store.
+func (store *QueueStore) Len() int {
+	store.RLock()
+	defer store.RUnlock()
+	return int(store.eC)
+}
+
+// ListAll - lists all the keys in the directory, oldest first.
 func (store *QueueStore) ListAll() []string {
 	store.RLock()
 	defer store.RUnlock()
@@ -171,7 +179,18 @@ func (store *QueueStore) listAll() []string {
 		return nil
 	}
 
+	// Keys of events written in the same time slot sort by name.
+	sort.Slice(files, func(i, j int) bool {
+		if !files[i].ModTime().Equal(files[j].ModTime()) {
+			return files[i].ModTime().Before(files[j].ModTime())
+		}
+		return files[i].Name() < files[j].Name()
+	})
+
 	for _, f := range files {
+		if !strings.HasSuffix(f.Name(), eventExt) {
+			continue
+		}
 		keys = append(keys, strings.TrimSuffix(f.Name(), eventExt))
 	}
 	return keys
@@ -30,11 +30,13 @@ import (
 
 // RedisArgs - Redis target arguments.
 type RedisArgs struct {
-	Enable   bool      `json:"enable"`
-	Format   string    `json:"format"`
-	Addr     xnet.Host `json:"address"`
-	Password string    `json:"password"`
-	Key      string    `json:"key"`
+	Enable     bool      `json:"enable"`
+	Format     string    `json:"format"`
+	Addr       xnet.Host `json:"address"`
+	Password   string    `json:"password"`
+	Key        string    `json:"key"`
+	QueueDir   string    `json:"queueDir"`
+	QueueLimit uint16    `json:"queueLimit"`
 }
 
 // Validate RedisArgs fields
@@ -54,14 +56,19 @@ func (r RedisArgs) Validate() error {
 		return fmt.Errorf("empty key")
 	}
 
+	if err := validateQueueDir(r.QueueDir); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // RedisTarget - Redis target.
 type RedisTarget struct {
-	id   event.TargetID
-	args RedisArgs
-	pool *redis.Pool
+	id    event.TargetID
+	args  RedisArgs
+	pool  *redis.Pool
+	queue *eventQueue
 }
 
 // ID - returns target ID.
@@ -69,8 +76,8 @@ func (target *RedisTarget) ID() event.TargetID {
 	return target.id
 }
 
-// Send - sends event to Redis.
-func (target *RedisTarget) Send(eventData event.Event) error {
+// send - sends event to Redis.
+func (target *RedisTarget) send(eventData event.Event) error {
 	conn := target.pool.Get()
 	defer func() {
 		// FIXME: log returned error. ignore time being.
@@ -109,8 +116,20 @@ func (target *RedisTarget) Send(eventData event.Event) error {
 	return nil
 }
 
-// Close - does nothing and available for interface compatibility.
+// Send - sends event to Redis, persists the event to be sent later on
+// when Redis is unreachable and a queue store is configured.
+func (target *RedisTarget) Send(eventData event.Event) error {
+	return target.queue.Send(eventData)
+}
+
+// PendingEvents - returns the number of events pending to be sent.
+func (target *RedisTarget) PendingEvents() int {
+	return target.queue.Len()
+}
+
+// Close - stops replaying persisted events.
 func (target *RedisTarget) Close() error {
+	target.queue.Close()
 	return nil
 }
 
@@ -169,9 +188,17 @@ func NewRedisTarget(id string, args RedisArgs) (*RedisTarget, error) {
 		}
 	}
 
-	return &RedisTarget{
+	store, err := newQueueStore(args.QueueDir, args.QueueLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	target := &RedisTarget{
 		id:   event.TargetID{ID: id, Name: "redis"},
 		args: args,
 		pool: pool,
-	}, nil
+	}
+	target.queue = newEventQueue(store, target.send)
+
+	return target, nil
 }
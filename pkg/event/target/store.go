@@ -18,6 +18,10 @@ package target
 
 import (
 	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
 	"github.com/minio/minio/pkg/event"
 )
 
@@ -27,11 +31,150 @@ var ErrLimitExceeded = errors.New("[Store] The maximum limit reached")
 // ErrNoSuchKey error is sent in Get when the key is not found.
 var ErrNoSuchKey = errors.New("[Store] No such key found")
 
+// errNotConnected error is returned when sending to a disconnected target.
+var errNotConnected = errors.New("not connected to target server")
+
 // Store - To persist the events.
 type Store interface {
 	Put(event event.Event) error
 	Get(key string) (event.Event, error)
 	ListAll() []string
 	Del(key string)
+	Len() int
 	Open() error
 }
+
+// newEventKey - returns a new unique key for an event, keys sort in the
+// order they are created.
+func newEventKey() (string, error) {
+	uuid, err := getNewUUID()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%020d-%s", time.Now().UnixNano(), uuid), nil
+}
+
+// eventQueue - sends events to a target by given send function. Events
+// failing to be sent are persisted in the store, if any, and replayed in
+// order by a background goroutine once the target is reachable again.
+type eventQueue struct {
+	store  Store
+	send   func(event.Event) error
+	doneCh chan struct{}
+}
+
+// Send - sends event, or persists it in the store when sending fails or
+// previous events are pending to be sent.
+func (q *eventQueue) Send(eventData event.Event) error {
+	if q.store == nil {
+		return q.send(eventData)
+	}
+
+	// Newer events wait for pending ones to keep the order.
+	if q.store.Len() == 0 {
+		if err := q.send(eventData); err == nil {
+			return nil
+		}
+	}
+
+	return q.store.Put(eventData)
+}
+
+// Len - returns the number of events pending to be sent.
+func (q *eventQueue) Len() int {
+	if q.store == nil {
+		return 0
+	}
+
+	return q.store.Len()
+}
+
+// Close - stops replaying persisted events.
+func (q *eventQueue) Close() {
+	if q.store != nil {
+		close(q.doneCh)
+	}
+}
+
+// replay - sends persisted events in order at regular intervals until
+// the queue is closed.
+func (q *eventQueue) replay() {
+	ticker := time.NewTicker(retryInterval * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			q.sendPending()
+		case <-q.doneCh:
+			return
+		}
+	}
+}
+
+// sendPending - sends persisted events in order, stops at the first
+// event failing to be sent to retry it later on.
+func (q *eventQueue) sendPending() {
+	for _, key := range q.store.ListAll() {
+		eventData, err := q.store.Get(key)
+		if err != nil {
+			continue
+		}
+
+		if err = q.send(eventData); err != nil {
+			return
+		}
+
+		// Delete after a successful send.
+		q.store.Del(key)
+
+		select {
+		case <-q.doneCh:
+			return
+		default:
+		}
+	}
+}
+
+// newEventQueue - creates an event queue sending events by given send
+// function. Events are persisted in given store, if not nil, when sending
+// fails, events persisted earlier are replayed.
+func newEventQueue(store Store, send func(event.Event) error) *eventQueue {
+	q := &eventQueue{
+		store:  store,
+		send:   send,
+		doneCh: make(chan struct{}),
+	}
+
+	if store != nil {
+		go q.replay()
+	}
+
+	return q
+}
+
+// newQueueStore - creates and opens a queue store in given directory, or
+// returns nil if directory is empty.
+func newQueueStore(directory string, limit uint16) (Store, error) {
+	if directory == "" {
+		return nil, nil
+	}
+
+	store := NewQueueStore(directory, limit)
+	if err := store.Open(); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// validateQueueDir - checks whether given queue directory, if any, is an
+// absolute path.
+func validateQueueDir(directory string) error {
+	if directory != "" && !filepath.IsAbs(directory) {
+		return errors.New("queueDir path should be absolute")
+	}
+
+	return nil
+}
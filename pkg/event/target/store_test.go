@@ -0,0 +1,89 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package target
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio/pkg/event"
+)
+
+// TestEventQueue - tests events failing to be sent are persisted and
+// replayed in order.
+func TestEventQueue(t *testing.T) {
+	defer func() {
+		if err := tearDownStore(); err != nil {
+			t.Fatal("Failed to tear down store ", err)
+		}
+	}()
+
+	queueStore, err := setUpStore(queueDir, 10)
+	if err != nil {
+		t.Fatal("Failed to create a queue store ", err)
+	}
+
+	for i, store := range []Store{queueStore, NewMemoryStore(10)} {
+		var sent []string
+		unreachable := true
+		send := func(eventData event.Event) error {
+			if unreachable {
+				return errors.New("unreachable")
+			}
+			sent = append(sent, eventData.EventTime)
+			return nil
+		}
+
+		// Replay is triggered by hand instead of a background goroutine.
+		q := &eventQueue{store: store, send: send, doneCh: make(chan struct{})}
+
+		for _, eventTime := range []string{"1", "2", "3"} {
+			if err := q.Send(event.Event{EventName: event.ObjectCreatedPut, EventTime: eventTime}); err != nil {
+				t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+			}
+		}
+
+		// Events keep pending while the target is unreachable.
+		q.sendPending()
+		if q.Len() != 3 || len(sent) != 0 {
+			t.Fatalf("case %v: expected: 3 pending events, got: %v pending, %v sent\n", i+1, q.Len(), sent)
+		}
+
+		// Newer events wait for pending ones.
+		unreachable = false
+		if err := q.Send(event.Event{EventName: event.ObjectCreatedPut, EventTime: "4"}); err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+		if q.Len() != 4 || len(sent) != 0 {
+			t.Fatalf("case %v: expected: 4 pending events, got: %v pending, %v sent\n", i+1, q.Len(), sent)
+		}
+
+		q.sendPending()
+		if expected := []string{"1", "2", "3", "4"}; q.Len() != 0 || !reflect.DeepEqual(sent, expected) {
+			t.Fatalf("case %v: expected: %v sent, got: %v pending, %v sent\n", i+1, expected, q.Len(), sent)
+		}
+
+		// Events are sent right away once nothing is pending.
+		if err := q.Send(event.Event{EventName: event.ObjectCreatedPut, EventTime: "5"}); err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+		if q.Len() != 0 || len(sent) != 5 {
+			t.Fatalf("case %v: expected: 5 sent events, got: %v pending, %v sent\n", i+1, q.Len(), sent)
+		}
+	}
+}
@@ -36,9 +36,11 @@ import (
 
 // WebhookArgs - Webhook target arguments.
 type WebhookArgs struct {
-	Enable   bool           `json:"enable"`
-	Endpoint xnet.URL       `json:"endpoint"`
-	RootCAs  *x509.CertPool `json:"-"`
+	Enable     bool           `json:"enable"`
+	Endpoint   xnet.URL       `json:"endpoint"`
+	RootCAs    *x509.CertPool `json:"-"`
+	QueueDir   string         `json:"queueDir"`
+	QueueLimit uint16         `json:"queueLimit"`
 }
 
 // Validate WebhookArgs fields
@@ -49,6 +51,9 @@ func (w WebhookArgs) Validate() error {
 	if w.Endpoint.IsEmpty() {
 		return errors.New("endpoint empty")
 	}
+	if err := validateQueueDir(w.QueueDir); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -57,6 +62,7 @@ type WebhookTarget struct {
 	id         event.TargetID
 	args       WebhookArgs
 	httpClient *http.Client
+	queue      *eventQueue
 }
 
 // ID - returns target ID.
@@ -64,8 +70,8 @@ func (target WebhookTarget) ID() event.TargetID {
 	return target.id
 }
 
-// Send - sends event to Webhook.
-func (target *WebhookTarget) Send(eventData event.Event) error {
+// send - sends event to Webhook.
+func (target *WebhookTarget) send(eventData event.Event) error {
 	objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
 	if err != nil {
 		return err
@@ -100,14 +106,31 @@ func (target *WebhookTarget) Send(eventData event.Event) error {
 	return nil
 }
 
-// Close - does nothing and available for interface compatibility.
+// Send - sends event to Webhook, persists the event to be sent later on
+// when Webhook is unreachable and a queue store is configured.
+func (target *WebhookTarget) Send(eventData event.Event) error {
+	return target.queue.Send(eventData)
+}
+
+// PendingEvents - returns the number of events pending to be sent.
+func (target *WebhookTarget) PendingEvents() int {
+	return target.queue.Len()
+}
+
+// Close - stops replaying persisted events.
 func (target *WebhookTarget) Close() error {
+	target.queue.Close()
 	return nil
 }
 
 // NewWebhookTarget - creates new Webhook target.
-func NewWebhookTarget(id string, args WebhookArgs) *WebhookTarget {
-	return &WebhookTarget{
+func NewWebhookTarget(id string, args WebhookArgs) (*WebhookTarget, error) {
+	store, err := newQueueStore(args.QueueDir, args.QueueLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	target := &WebhookTarget{
 		id:   event.TargetID{ID: id, Name: "webhook"},
 		args: args,
 		httpClient: &http.Client{
@@ -123,4 +146,7 @@ func NewWebhookTarget(id string, args WebhookArgs) *WebhookTarget {
 			},
 		},
 	}
+	target.queue = newEventQueue(store, target.send)
+
+	return target, nil
 }
@@ -114,6 +114,22 @@ func (list *TargetList) List() []TargetID {
 	return keys
 }
 
+// PendingEvents - returns the number of events pending to be sent by each
+// target persisting events it fails to send.
+func (list *TargetList) PendingEvents() map[TargetID]int {
+	list.RLock()
+	defer list.RUnlock()
+
+	pendingEvents := make(map[TargetID]int)
+	for id, target := range list.targets {
+		if t, ok := target.(interface{ PendingEvents() int }); ok {
+			pendingEvents[id] = t.PendingEvents()
+		}
+	}
+
+	return pendingEvents
+}
+
 // Send - sends events to targets identified by target IDs.
 func (list *TargetList) Send(event Event, targetIDs ...TargetID) <-chan TargetIDErr {
 	errCh := make(chan TargetIDErr)
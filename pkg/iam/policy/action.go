@@ -33,6 +33,10 @@ const (
 	// AbortMultipartUploadAction - AbortMultipartUpload Rest API action.
 	AbortMultipartUploadAction Action = "s3:AbortMultipartUpload"
 
+	// BypassGovernanceRetentionAction - overwriting or deleting object versions
+	// in GOVERNANCE mode, and shortening or removing their retention.
+	BypassGovernanceRetentionAction = "s3:BypassGovernanceRetention"
+
 	// CreateBucketAction - CreateBucket Rest API action.
 	CreateBucketAction = "s3:CreateBucket"
 
@@ -42,9 +46,36 @@ const (
 	// DeleteBucketPolicyAction - DeleteBucketPolicy Rest API action.
 	DeleteBucketPolicyAction = "s3:DeleteBucketPolicy"
 
+	// DeleteBucketWebsiteAction - DeleteBucketWebsite Rest API action.
+	DeleteBucketWebsiteAction = "s3:DeleteBucketWebsite"
+
 	// DeleteObjectAction - DeleteObject Rest API action.
 	DeleteObjectAction = "s3:DeleteObject"
 
+	// DeleteObjectTaggingAction - DeleteObjectTagging Rest API action.
+	DeleteObjectTaggingAction = "s3:DeleteObjectTagging"
+
+	// DeleteObjectVersionAction - DeleteObject Rest API action with a version ID.
+	DeleteObjectVersionAction = "s3:DeleteObjectVersion"
+
+	// DeleteObjectVersionTaggingAction - DeleteObjectTagging Rest API action with a version ID.
+	DeleteObjectVersionTaggingAction = "s3:DeleteObjectVersionTagging"
+
+	// GetBucketCorsAction - GetBucketCors Rest API action.
+	GetBucketCorsAction = "s3:GetBucketCORS"
+
+	// GetBucketLifecycleAction - GetBucketLifecycle Rest API action.
+	GetBucketLifecycleAction = "s3:GetLifecycleConfiguration"
+
+	// GetBucketLoggingAction - GetBucketLogging Rest API action.
+	GetBucketLoggingAction = "s3:GetBucketLogging"
+
+	// GetReplicationConfigurationAction - GetBucketReplication Rest API action.
+	GetReplicationConfigurationAction = "s3:GetReplicationConfiguration"
+
+	// GetBucketObjectLockConfigurationAction - GetObjectLockConfiguration Rest API action.
+	GetBucketObjectLockConfigurationAction = "s3:GetBucketObjectLockConfiguration"
+
 	// GetBucketLocationAction - GetBucketLocation Rest API action.
 	GetBucketLocationAction = "s3:GetBucketLocation"
 
@@ -54,9 +85,33 @@ const (
 	// GetBucketPolicyAction - GetBucketPolicy Rest API action.
 	GetBucketPolicyAction = "s3:GetBucketPolicy"
 
+	// GetBucketTaggingAction - GetBucketTagging Rest API action.
+	GetBucketTaggingAction = "s3:GetBucketTagging"
+
+	// GetBucketVersioningAction - GetBucketVersioning Rest API action.
+	GetBucketVersioningAction = "s3:GetBucketVersioning"
+
+	// GetBucketWebsiteAction - GetBucketWebsite Rest API action.
+	GetBucketWebsiteAction = "s3:GetBucketWebsite"
+
 	// GetObjectAction - GetObject Rest API action.
 	GetObjectAction = "s3:GetObject"
 
+	// GetObjectLegalHoldAction - GetObjectLegalHold Rest API action.
+	GetObjectLegalHoldAction = "s3:GetObjectLegalHold"
+
+	// GetObjectRetentionAction - GetObjectRetention Rest API action.
+	GetObjectRetentionAction = "s3:GetObjectRetention"
+
+	// GetObjectTaggingAction - GetObjectTagging Rest API action.
+	GetObjectTaggingAction = "s3:GetObjectTagging"
+
+	// GetObjectVersionAction - GetObject Rest API action with a version ID.
+	GetObjectVersionAction = "s3:GetObjectVersion"
+
+	// GetObjectVersionTaggingAction - GetObjectTagging Rest API action with a version ID.
+	GetObjectVersionTaggingAction = "s3:GetObjectVersionTagging"
+
 	// HeadBucketAction - HeadBucket Rest API action. This action is unused in minio.
 	HeadBucketAction = "s3:HeadBucket"
 
@@ -66,6 +121,9 @@ const (
 	// ListBucketAction - ListBucket Rest API action.
 	ListBucketAction = "s3:ListBucket"
 
+	// ListBucketVersionsAction - ListObjectVersions Rest API action.
+	ListBucketVersionsAction = "s3:ListBucketVersions"
+
 	// ListBucketMultipartUploadsAction - ListMultipartUploads Rest API action.
 	ListBucketMultipartUploadsAction = "s3:ListBucketMultipartUploads"
 
@@ -76,40 +134,117 @@ const (
 	// ListMultipartUploadPartsAction - ListParts Rest API action.
 	ListMultipartUploadPartsAction = "s3:ListMultipartUploadParts"
 
+	// PutBucketCorsAction - PutBucketCors and DeleteBucketCors Rest API action.
+	PutBucketCorsAction = "s3:PutBucketCORS"
+
+	// PutBucketLifecycleAction - PutBucketLifecycle and DeleteBucketLifecycle Rest API action.
+	PutBucketLifecycleAction = "s3:PutLifecycleConfiguration"
+
+	// PutBucketLoggingAction - PutBucketLogging Rest API action.
+	PutBucketLoggingAction = "s3:PutBucketLogging"
+
+	// PutReplicationConfigurationAction - PutBucketReplication and DeleteBucketReplication Rest API action.
+	PutReplicationConfigurationAction = "s3:PutReplicationConfiguration"
+
+	// ReplicateObjectAction - writing objects as replicas of the objects of
+	// another bucket, with the replica replication status.
+	ReplicateObjectAction = "s3:ReplicateObject"
+
+	// ReplicateDeleteAction - deleting objects as the replication of the
+	// deletions of another bucket.
+	ReplicateDeleteAction = "s3:ReplicateDelete"
+
+	// PutBucketObjectLockConfigurationAction - PutObjectLockConfiguration Rest API action.
+	PutBucketObjectLockConfigurationAction = "s3:PutBucketObjectLockConfiguration"
+
 	// PutBucketNotificationAction - PutObjectNotification Rest API action.
 	PutBucketNotificationAction = "s3:PutBucketNotification"
 
 	// PutBucketPolicyAction - PutBucketPolicy Rest API action.
 	PutBucketPolicyAction = "s3:PutBucketPolicy"
 
+	// PutBucketTaggingAction - PutBucketTagging and DeleteBucketTagging Rest API action.
+	PutBucketTaggingAction = "s3:PutBucketTagging"
+
+	// PutBucketVersioningAction - PutBucketVersioning Rest API action.
+	PutBucketVersioningAction = "s3:PutBucketVersioning"
+
+	// PutBucketWebsiteAction - PutBucketWebsite Rest API action.
+	PutBucketWebsiteAction = "s3:PutBucketWebsite"
+
 	// PutObjectAction - PutObject Rest API action.
 	PutObjectAction = "s3:PutObject"
 
+	// PutObjectLegalHoldAction - PutObjectLegalHold Rest API action.
+	PutObjectLegalHoldAction = "s3:PutObjectLegalHold"
+
+	// PutObjectRetentionAction - PutObjectRetention Rest API action.
+	PutObjectRetentionAction = "s3:PutObjectRetention"
+
+	// PutObjectTaggingAction - PutObjectTagging Rest API action.
+	PutObjectTaggingAction = "s3:PutObjectTagging"
+
+	// PutObjectVersionTaggingAction - PutObjectTagging Rest API action with a version ID.
+	PutObjectVersionTaggingAction = "s3:PutObjectVersionTagging"
+
 	// AllActions - all API actions
 	AllActions = "s3:*"
 )
 
 // List of all supported actions.
 var supportedActions = map[Action]struct{}{
-	AllActions:                       {},
-	AbortMultipartUploadAction:       {},
-	CreateBucketAction:               {},
-	DeleteBucketAction:               {},
-	DeleteBucketPolicyAction:         {},
-	DeleteObjectAction:               {},
-	GetBucketLocationAction:          {},
-	GetBucketNotificationAction:      {},
-	GetBucketPolicyAction:            {},
-	GetObjectAction:                  {},
-	HeadBucketAction:                 {},
-	ListAllMyBucketsAction:           {},
-	ListBucketAction:                 {},
-	ListBucketMultipartUploadsAction: {},
-	ListenBucketNotificationAction:   {},
-	ListMultipartUploadPartsAction:   {},
-	PutBucketNotificationAction:      {},
-	PutBucketPolicyAction:            {},
-	PutObjectAction:                  {},
+	AllActions:                             {},
+	AbortMultipartUploadAction:             {},
+	CreateBucketAction:                     {},
+	DeleteBucketAction:                     {},
+	DeleteBucketPolicyAction:               {},
+	DeleteObjectAction:                     {},
+	DeleteObjectTaggingAction:              {},
+	DeleteObjectVersionAction:              {},
+	DeleteObjectVersionTaggingAction:       {},
+	GetBucketCorsAction:                    {},
+	GetBucketWebsiteAction:                 {},
+	DeleteBucketWebsiteAction:              {},
+	GetBucketLifecycleAction:               {},
+	GetBucketLocationAction:                {},
+	GetBucketLoggingAction:                 {},
+	GetReplicationConfigurationAction:      {},
+	GetBucketObjectLockConfigurationAction: {},
+	GetObjectRetentionAction:               {},
+	GetObjectLegalHoldAction:               {},
+	GetBucketNotificationAction:            {},
+	GetBucketPolicyAction:                  {},
+	GetBucketTaggingAction:                 {},
+	GetBucketVersioningAction:              {},
+	GetObjectAction:                        {},
+	GetObjectTaggingAction:                 {},
+	GetObjectVersionAction:                 {},
+	GetObjectVersionTaggingAction:          {},
+	HeadBucketAction:                       {},
+	ListAllMyBucketsAction:                 {},
+	ListBucketAction:                       {},
+	ListBucketMultipartUploadsAction:       {},
+	ListBucketVersionsAction:               {},
+	ListenBucketNotificationAction:         {},
+	ListMultipartUploadPartsAction:         {},
+	PutBucketCorsAction:                    {},
+	PutBucketWebsiteAction:                 {},
+	PutBucketLifecycleAction:               {},
+	PutBucketLoggingAction:                 {},
+	PutReplicationConfigurationAction:      {},
+	ReplicateObjectAction:                  {},
+	ReplicateDeleteAction:                  {},
+	PutBucketObjectLockConfigurationAction: {},
+	PutObjectRetentionAction:               {},
+	PutObjectLegalHoldAction:               {},
+	BypassGovernanceRetentionAction:        {},
+	PutBucketNotificationAction:            {},
+	PutBucketPolicyAction:                  {},
+	PutBucketTaggingAction:                 {},
+	PutBucketVersioningAction:              {},
+	PutObjectAction:                        {},
+	PutObjectTaggingAction:                 {},
+	PutObjectVersionTaggingAction:          {},
 }
 
 // isObjectAction - returns whether action is object type or not.
@@ -117,6 +252,18 @@ func (action Action) isObjectAction() bool {
 	switch action {
 	case AbortMultipartUploadAction, DeleteObjectAction, GetObjectAction:
 		fallthrough
+	case DeleteObjectVersionAction, GetObjectVersionAction:
+		fallthrough
+	case DeleteObjectTaggingAction, GetObjectTaggingAction, PutObjectTaggingAction:
+		fallthrough
+	case DeleteObjectVersionTaggingAction, GetObjectVersionTaggingAction, PutObjectVersionTaggingAction:
+		fallthrough
+	case GetObjectRetentionAction, PutObjectRetentionAction, BypassGovernanceRetentionAction:
+		fallthrough
+	case GetObjectLegalHoldAction, PutObjectLegalHoldAction:
+		fallthrough
+	case ReplicateObjectAction, ReplicateDeleteAction:
+		fallthrough
 	case ListMultipartUploadPartsAction, PutObjectAction, AllActions:
 		return true
 	}
@@ -184,6 +331,12 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 
 	DeleteObjectAction: condition.NewKeySet(condition.CommonKeys...),
 
+	DeleteObjectVersionAction: condition.NewKeySet(condition.CommonKeys...),
+
+	DeleteObjectTaggingAction: condition.NewKeySet(append([]condition.Key{condition.S3ExistingObjectTag}, condition.CommonKeys...)...),
+
+	DeleteObjectVersionTaggingAction: condition.NewKeySet(append([]condition.Key{condition.S3ExistingObjectTag}, condition.CommonKeys...)...),
+
 	GetBucketLocationAction: condition.NewKeySet(condition.CommonKeys...),
 
 	GetBucketNotificationAction: condition.NewKeySet(condition.CommonKeys...),
@@ -195,6 +348,41 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 			condition.S3XAmzServerSideEncryption,
 			condition.S3XAmzServerSideEncryptionCustomerAlgorithm,
 			condition.S3XAmzStorageClass,
+			condition.S3ExistingObjectTag,
+		}, condition.CommonKeys...)...),
+
+	GetBucketCorsAction: condition.NewKeySet(condition.CommonKeys...),
+
+	GetBucketWebsiteAction: condition.NewKeySet(condition.CommonKeys...),
+
+	DeleteBucketWebsiteAction: condition.NewKeySet(condition.CommonKeys...),
+
+	GetBucketLifecycleAction: condition.NewKeySet(condition.CommonKeys...),
+
+	GetBucketLoggingAction: condition.NewKeySet(condition.CommonKeys...),
+
+	GetReplicationConfigurationAction: condition.NewKeySet(condition.CommonKeys...),
+
+	GetBucketObjectLockConfigurationAction: condition.NewKeySet(condition.CommonKeys...),
+
+	GetObjectRetentionAction: condition.NewKeySet(condition.CommonKeys...),
+
+	GetObjectLegalHoldAction: condition.NewKeySet(condition.CommonKeys...),
+
+	GetBucketTaggingAction: condition.NewKeySet(condition.CommonKeys...),
+
+	GetBucketVersioningAction: condition.NewKeySet(condition.CommonKeys...),
+
+	GetObjectTaggingAction: condition.NewKeySet(append([]condition.Key{condition.S3ExistingObjectTag}, condition.CommonKeys...)...),
+
+	GetObjectVersionTaggingAction: condition.NewKeySet(append([]condition.Key{condition.S3ExistingObjectTag}, condition.CommonKeys...)...),
+
+	GetObjectVersionAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3XAmzServerSideEncryption,
+			condition.S3XAmzServerSideEncryptionCustomerAlgorithm,
+			condition.S3XAmzStorageClass,
+			condition.S3ExistingObjectTag,
 		}, condition.CommonKeys...)...),
 
 	HeadBucketAction: condition.NewKeySet(condition.CommonKeys...),
@@ -210,6 +398,13 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 
 	ListBucketMultipartUploadsAction: condition.NewKeySet(condition.CommonKeys...),
 
+	ListBucketVersionsAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3Prefix,
+			condition.S3Delimiter,
+			condition.S3MaxKeys,
+		}, condition.CommonKeys...)...),
+
 	ListenBucketNotificationAction: condition.NewKeySet(condition.CommonKeys...),
 
 	ListMultipartUploadPartsAction: condition.NewKeySet(condition.CommonKeys...),
@@ -218,6 +413,36 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 
 	PutBucketPolicyAction: condition.NewKeySet(condition.CommonKeys...),
 
+	PutBucketCorsAction: condition.NewKeySet(condition.CommonKeys...),
+
+	PutBucketWebsiteAction: condition.NewKeySet(condition.CommonKeys...),
+
+	PutBucketLifecycleAction: condition.NewKeySet(condition.CommonKeys...),
+
+	PutBucketLoggingAction: condition.NewKeySet(condition.CommonKeys...),
+
+	PutReplicationConfigurationAction: condition.NewKeySet(condition.CommonKeys...),
+
+	ReplicateObjectAction: condition.NewKeySet(condition.CommonKeys...),
+
+	ReplicateDeleteAction: condition.NewKeySet(condition.CommonKeys...),
+
+	PutBucketObjectLockConfigurationAction: condition.NewKeySet(condition.CommonKeys...),
+
+	PutObjectRetentionAction: condition.NewKeySet(condition.CommonKeys...),
+
+	PutObjectLegalHoldAction: condition.NewKeySet(condition.CommonKeys...),
+
+	BypassGovernanceRetentionAction: condition.NewKeySet(condition.CommonKeys...),
+
+	PutBucketTaggingAction: condition.NewKeySet(condition.CommonKeys...),
+
+	PutBucketVersioningAction: condition.NewKeySet(condition.CommonKeys...),
+
+	PutObjectTaggingAction: condition.NewKeySet(append([]condition.Key{condition.S3ExistingObjectTag}, condition.CommonKeys...)...),
+
+	PutObjectVersionTaggingAction: condition.NewKeySet(append([]condition.Key{condition.S3ExistingObjectTag}, condition.CommonKeys...)...),
+
 	PutObjectAction: condition.NewKeySet(
 		append([]condition.Key{
 			condition.S3XAmzCopySource,
@@ -225,5 +450,6 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 			condition.S3XAmzServerSideEncryptionCustomerAlgorithm,
 			condition.S3XAmzMetadataDirective,
 			condition.S3XAmzStorageClass,
+			condition.S3ContentLength,
 		}, condition.CommonKeys...)...),
 }
@@ -20,13 +20,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/policy/condition"
 )
 
 // DefaultVersion - default policy version as per AWS S3 specification.
 const DefaultVersion = "2012-10-17"
 
+// SessionPolicyName - claim of temporary credentials carrying the base64
+// encoded inline session policy.
+const SessionPolicyName = "sessionPolicy"
+
 // Args - arguments to policy to check whether it is allowed
 type Args struct {
 	AccountName     string                 `json:"account"`
@@ -38,6 +44,44 @@ type Args struct {
 	Claims          map[string]interface{} `json:"claims"`
 }
 
+// policyValues - returns condition values of args along with the values of
+// policy variables, "${aws:username}" and "${aws:userid}" being the account
+// name and "${jwt:<claim>}" being the claims of temporary credentials.
+func (args Args) policyValues() map[string][]string {
+	values := make(map[string][]string, len(args.ConditionValues)+len(args.Claims)+2)
+	for k, v := range args.ConditionValues {
+		// Only the claims of args are trusted as "jwt:" values.
+		if strings.HasPrefix(strings.ToLower(k), condition.JWTPrefix) {
+			continue
+		}
+		values[k] = v
+	}
+
+	if args.AccountName != "" {
+		values[condition.AWSUsername.Name()] = []string{args.AccountName}
+		values[condition.AWSUserID.Name()] = []string{args.AccountName}
+	}
+
+	for k, v := range args.Claims {
+		switch claim := v.(type) {
+		case string:
+			values[condition.JWTPrefix+k] = []string{claim}
+		case []interface{}:
+			var claimValues []string
+			for _, c := range claim {
+				if s, ok := c.(string); ok {
+					claimValues = append(claimValues, s)
+				}
+			}
+			if len(claimValues) > 0 {
+				values[condition.JWTPrefix+k] = claimValues
+			}
+		}
+	}
+
+	return values
+}
+
 // Policy - iam bucket iamp.
 type Policy struct {
 	ID         policy.ID `json:"ID,omitempty"`
@@ -73,6 +117,32 @@ func (iamp Policy) IsAllowed(args Args) bool {
 	return false
 }
 
+// Merge - returns a policy carrying the statements of both policies, the
+// merged policy allows what any of them allows unless denied by one of them.
+// Statements equal to one already merged are dropped.
+func (iamp Policy) Merge(input Policy) Policy {
+	mergedPolicy := Policy{Version: iamp.Version}
+	if mergedPolicy.Version == "" {
+		mergedPolicy.Version = input.Version
+	}
+	for _, statement := range append(append([]Statement{}, iamp.Statements...), input.Statements...) {
+		if !mergedPolicy.hasStatement(statement) {
+			mergedPolicy.Statements = append(mergedPolicy.Statements, statement)
+		}
+	}
+	return mergedPolicy
+}
+
+// hasStatement - checks whether policy has a statement equal to given one.
+func (iamp Policy) hasStatement(statement Statement) bool {
+	for _, st := range iamp.Statements {
+		if st.Equals(statement) {
+			return true
+		}
+	}
+	return false
+}
+
 // IsEmpty - returns whether policy is empty or not.
 func (iamp Policy) IsEmpty() bool {
 	return len(iamp.Statements) == 0
@@ -92,6 +162,15 @@ func (iamp Policy) isValid() error {
 
 	for i := range iamp.Statements {
 		for _, statement := range iamp.Statements[i+1:] {
+			// Statements having NotAction or NotResource are duplicates
+			// only when they are equal.
+			if iamp.Statements[i].hasNotElements() || statement.hasNotElements() {
+				if iamp.Statements[i].Equals(statement) {
+					return fmt.Errorf("duplicate statements %v, %v found", iamp.Statements[i], statement)
+				}
+				continue
+			}
+
 			actions := iamp.Statements[i].Actions.Intersection(statement.Actions)
 			if len(actions) == 0 {
 				continue
@@ -17,6 +17,7 @@
 package iampolicy
 
 import (
+	"bytes"
 	"encoding/json"
 	"net"
 	"reflect"
@@ -178,6 +179,70 @@ func TestPolicyIsAllowed(t *testing.T) {
 	}
 }
 
+func TestPolicyMerge(t *testing.T) {
+	policy1 := Policy{
+		Version: DefaultVersion,
+		Statements: []Statement{
+			NewStatement(
+				policy.Allow,
+				NewActionSet(GetObjectAction),
+				NewResourceSet(NewResource("mybucket", "/myobject*")),
+				condition.NewFunctions(),
+			),
+		},
+	}
+	policy2 := Policy{
+		Version: DefaultVersion,
+		Statements: []Statement{
+			NewStatement(
+				policy.Deny,
+				NewActionSet(GetObjectAction),
+				NewResourceSet(NewResource("mybucket", "/myobject-secret")),
+				condition.NewFunctions(),
+			),
+			NewStatement(
+				policy.Allow,
+				NewActionSet(PutObjectAction),
+				NewResourceSet(NewResource("mybucket", "/myobject*")),
+				condition.NewFunctions(),
+			),
+		},
+	}
+
+	mergedPolicy := Policy{}.Merge(policy1).Merge(policy2)
+	if mergedPolicy.Version != DefaultVersion || len(mergedPolicy.Statements) != 3 {
+		t.Fatalf("unexpected merged policy %+v", mergedPolicy)
+	}
+
+	// Statements equal to merged ones are dropped.
+	if remerged := mergedPolicy.Merge(policy1); len(remerged.Statements) != 3 {
+		t.Fatalf("unexpected merged policy %+v", remerged)
+	}
+
+	testCases := []struct {
+		action         Action
+		objectName     string
+		expectedResult bool
+	}{
+		{GetObjectAction, "myobject", true},
+		{PutObjectAction, "myobject", true},
+		{GetObjectAction, "myobject-secret", false},
+		{DeleteObjectAction, "myobject", false},
+	}
+
+	for i, testCase := range testCases {
+		result := mergedPolicy.IsAllowed(Args{
+			AccountName: "Q3AM3UQ867SPQQA43P2F",
+			Action:      testCase.action,
+			BucketName:  "mybucket",
+			ObjectName:  testCase.objectName,
+		})
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
 func TestPolicyIsEmpty(t *testing.T) {
 	case1Policy := Policy{
 		Version: DefaultVersion,
@@ -1030,3 +1095,145 @@ func TestPolicyValidate(t *testing.T) {
 		}
 	}
 }
+
+func TestPolicyNumericAndDateConditions(t *testing.T) {
+	data := []byte(`{
+    "Version": "2012-10-17",
+    "Statement": [
+        {
+            "Effect": "Allow",
+            "Action": ["s3:ListBucket"],
+            "Resource": ["arn:aws:s3:::mybucket"],
+            "Condition": {
+                "NumericLessThanEquals": {"s3:max-keys": "100"},
+                "DateLessThan": {"aws:CurrentTime": "2019-10-01T00:00:00Z"},
+                "ForAllValues:StringLike": {"s3:prefix": ["home/*"]}
+            }
+        }
+    ]
+}`)
+
+	p, err := ParseConfig(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	// Policy is preserved across JSON round-trips.
+	encoded, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	decoded, err := ParseConfig(bytes.NewReader(encoded))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	reencoded, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	if string(reencoded) != string(encoded) {
+		t.Fatalf("expected: %s, got: %s\n", encoded, reencoded)
+	}
+
+	testCases := []struct {
+		conditionValues map[string][]string
+		expectedResult  bool
+	}{
+		{map[string][]string{"max-keys": {"100"}, "CurrentTime": {"2019-09-30T00:00:00.000Z"}, "prefix": {"home/a"}}, true},
+		{map[string][]string{"max-keys": {"1000"}, "CurrentTime": {"2019-09-30T00:00:00.000Z"}, "prefix": {"home/a"}}, false},
+		{map[string][]string{"max-keys": {"100"}, "CurrentTime": {"2019-10-01T00:00:00.000Z"}, "prefix": {"home/a"}}, false},
+		{map[string][]string{"max-keys": {"100"}, "CurrentTime": {"2019-09-30T00:00:00.000Z"}, "prefix": {"home/a", "tmp/"}}, false},
+	}
+
+	for i, testCase := range testCases {
+		result := p.IsAllowed(Args{
+			AccountName:     "Q3AM3UQ867SPQQA43P2F",
+			Action:          ListBucketAction,
+			BucketName:      "mybucket",
+			ConditionValues: testCase.conditionValues,
+		})
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestPolicyVariables(t *testing.T) {
+	data := []byte(`{
+    "Version": "2012-10-17",
+    "Statement": [
+        {
+            "Effect": "Allow",
+            "Action": ["s3:GetObject"],
+            "Resource": ["arn:aws:s3:::mybucket/home/${aws:username}/*"]
+        },
+        {
+            "Effect": "Allow",
+            "Action": ["s3:ListBucket"],
+            "Resource": ["arn:aws:s3:::mybucket"],
+            "Condition": {"StringLike": {"s3:prefix": ["users/${jwt:sub}/*"]}}
+        }
+    ]
+}`)
+
+	p, err := ParseConfig(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	testCases := []struct {
+		args           Args
+		expectedResult bool
+	}{
+		{Args{
+			AccountName: "alice",
+			Action:      GetObjectAction,
+			BucketName:  "mybucket",
+			ObjectName:  "home/alice/myobject",
+		}, true},
+		{Args{
+			AccountName: "bob",
+			Action:      GetObjectAction,
+			BucketName:  "mybucket",
+			ObjectName:  "home/alice/myobject",
+		}, false},
+		// Account name takes precedence over given username value.
+		{Args{
+			AccountName:     "bob",
+			Action:          GetObjectAction,
+			BucketName:      "mybucket",
+			ObjectName:      "home/alice/myobject",
+			ConditionValues: map[string][]string{"username": {"alice"}},
+		}, false},
+		{Args{
+			AccountName:     "Q3AM3UQ867SPQQA43P2F",
+			Action:          ListBucketAction,
+			BucketName:      "mybucket",
+			ConditionValues: map[string][]string{"prefix": {"users/alice/photos"}},
+			Claims:          map[string]interface{}{"sub": "alice"},
+		}, true},
+		{Args{
+			AccountName:     "Q3AM3UQ867SPQQA43P2F",
+			Action:          ListBucketAction,
+			BucketName:      "mybucket",
+			ConditionValues: map[string][]string{"prefix": {"users/bob/photos"}},
+			Claims:          map[string]interface{}{"sub": "alice"},
+		}, false},
+		// Claims are taken from args only, not from condition values.
+		{Args{
+			AccountName:     "Q3AM3UQ867SPQQA43P2F",
+			Action:          ListBucketAction,
+			BucketName:      "mybucket",
+			ConditionValues: map[string][]string{"prefix": {"users/alice/photos"}, "jwt:sub": {"alice"}},
+		}, false},
+	}
+
+	for i, testCase := range testCases {
+		result := p.IsAllowed(testCase.args)
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+}
@@ -27,17 +27,44 @@ import (
 
 // Statement - iam policy statement.
 type Statement struct {
-	SID        policy.ID           `json:"Sid,omitempty"`
-	Effect     policy.Effect       `json:"Effect"`
-	Actions    ActionSet           `json:"Action"`
-	Resources  ResourceSet         `json:"Resource"`
-	Conditions condition.Functions `json:"Condition,omitempty"`
+	SID          policy.ID           `json:"Sid,omitempty"`
+	Effect       policy.Effect       `json:"Effect"`
+	Actions      ActionSet           `json:"Action,omitempty"`
+	NotActions   ActionSet           `json:"NotAction,omitempty"`
+	Resources    ResourceSet         `json:"Resource,omitempty"`
+	NotResources ResourceSet         `json:"NotResource,omitempty"`
+	Conditions   condition.Functions `json:"Condition,omitempty"`
+}
+
+// matchAction - checks whether given action matches Action, or does not
+// match NotAction.
+func (statement Statement) matchAction(action Action) bool {
+	if len(statement.NotActions) != 0 {
+		return !statement.NotActions.Match(action)
+	}
+
+	return statement.Actions.Match(action)
+}
+
+// matchResource - checks whether given resource matches Resource, or does
+// not match NotResource.
+func (statement Statement) matchResource(resource string, conditionValues map[string][]string) bool {
+	if len(statement.NotResources) != 0 {
+		return !statement.NotResources.Match(resource, conditionValues)
+	}
+
+	return statement.Resources.Match(resource, conditionValues)
+}
+
+// hasNotElements - checks whether statement has NotAction or NotResource.
+func (statement Statement) hasNotElements() bool {
+	return len(statement.NotActions) != 0 || len(statement.NotResources) != 0
 }
 
 // IsAllowed - checks given policy args is allowed to continue the Rest API.
 func (statement Statement) IsAllowed(args Args) bool {
 	check := func() bool {
-		if !statement.Actions.Match(args.Action) {
+		if !statement.matchAction(args.Action) {
 			return false
 		}
 
@@ -52,27 +79,47 @@ func (statement Statement) IsAllowed(args Args) bool {
 			resource += "/"
 		}
 
-		if !statement.Resources.Match(resource, args.ConditionValues) {
+		values := args.policyValues()
+		if !statement.matchResource(resource, values) {
 			return false
 		}
 
-		return statement.Conditions.Evaluate(args.ConditionValues)
+		return statement.Conditions.Evaluate(values)
 	}
 
 	return statement.Effect.IsAllowed(check())
 }
 
+// Equals - checks whether given statement is equal to current statement,
+// regardless of their Sid.
+func (statement Statement) Equals(st Statement) bool {
+	return statement.Effect == st.Effect &&
+		statement.Actions.Equals(st.Actions) &&
+		statement.NotActions.Equals(st.NotActions) &&
+		statement.Resources.Equals(st.Resources) &&
+		statement.NotResources.Equals(st.NotResources) &&
+		statement.Conditions.String() == st.Conditions.String()
+}
+
 // isValid - checks whether statement is valid or not.
 func (statement Statement) isValid() error {
 	if !statement.Effect.IsValid() {
 		return fmt.Errorf("invalid Effect %v", statement.Effect)
 	}
 
-	if len(statement.Actions) == 0 {
+	if len(statement.Actions) != 0 && len(statement.NotActions) != 0 {
+		return fmt.Errorf("only one of Action and NotAction is allowed")
+	}
+
+	if len(statement.Actions) == 0 && len(statement.NotActions) == 0 {
 		return fmt.Errorf("Action must not be empty")
 	}
 
-	if len(statement.Resources) == 0 {
+	if len(statement.Resources) != 0 && len(statement.NotResources) != 0 {
+		return fmt.Errorf("only one of Resource and NotResource is allowed")
+	}
+
+	if len(statement.Resources) == 0 && len(statement.NotResources) == 0 {
 		return fmt.Errorf("Resource must not be empty")
 	}
 
@@ -80,8 +127,14 @@ func (statement Statement) isValid() error {
 		return err
 	}
 
+	if err := statement.NotResources.Validate(); err != nil {
+		return err
+	}
+
+	// Actions and resources excluded by NotAction and NotResource are not
+	// known ahead, hence only listed actions and resources are checked.
 	for action := range statement.Actions {
-		if !statement.Resources.objectResourceExists() && !statement.Resources.bucketResourceExists() {
+		if len(statement.Resources) != 0 && !statement.Resources.objectResourceExists() && !statement.Resources.bucketResourceExists() {
 			return fmt.Errorf("unsupported Resource found %v for action %v", statement.Resources, action)
 		}
 
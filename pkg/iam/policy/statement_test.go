@@ -513,3 +513,59 @@ func TestStatementValidate(t *testing.T) {
 		}
 	}
 }
+
+func TestStatementNotElements(t *testing.T) {
+	testCases := []struct {
+		data           string
+		args           Args
+		expectedResult bool
+		expectErr      bool
+	}{
+		// Deny everything except GetObject.
+		{`{"Effect": "Deny", "NotAction": "s3:GetObject", "Resource": "arn:aws:s3:::*"}`,
+			Args{AccountName: "Q3AM3UQ867SPQQA43P2F", Action: PutObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, false, false},
+		{`{"Effect": "Deny", "NotAction": "s3:GetObject", "Resource": "arn:aws:s3:::*"}`,
+			Args{AccountName: "Q3AM3UQ867SPQQA43P2F", Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, true, false},
+		// Allow everything on all buckets except given bucket.
+		{`{"Effect": "Allow", "Action": "s3:*", "NotResource": ["arn:aws:s3:::mybucket", "arn:aws:s3:::mybucket/*"]}`,
+			Args{AccountName: "Q3AM3UQ867SPQQA43P2F", Action: GetObjectAction, BucketName: "yourbucket", ObjectName: "myobject"}, true, false},
+		{`{"Effect": "Allow", "Action": "s3:*", "NotResource": ["arn:aws:s3:::mybucket", "arn:aws:s3:::mybucket/*"]}`,
+			Args{AccountName: "Q3AM3UQ867SPQQA43P2F", Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, false, false},
+		// Both an element and its exclusion.
+		{`{"Effect": "Allow", "Action": "s3:GetObject", "NotAction": "s3:PutObject", "Resource": "arn:aws:s3:::*"}`,
+			Args{}, false, true},
+		{`{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::*", "NotResource": "arn:aws:s3:::mybucket/*"}`,
+			Args{}, false, true},
+	}
+
+	for i, testCase := range testCases {
+		var statement Statement
+		err := json.Unmarshal([]byte(testCase.data), &statement)
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v\n", i+1, testCase.expectErr, expectErr)
+		}
+
+		if testCase.expectErr {
+			continue
+		}
+
+		if result := statement.IsAllowed(testCase.args); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+
+		// Statement is preserved across JSON round-trips.
+		data, err := json.Marshal(statement)
+		if err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+		var decoded Statement
+		if err = json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("case %v: unexpected error. %v\n", i+1, err)
+		}
+		if !decoded.Equals(statement) {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, statement, decoded)
+		}
+	}
+}
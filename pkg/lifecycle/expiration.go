@@ -0,0 +1,95 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lifecycle
+
+import (
+	"encoding/xml"
+	"errors"
+	"time"
+)
+
+// ErrInvalidDays - number of days is not a positive integer.
+var ErrInvalidDays = errors.New("number of days must be a positive integer")
+
+// ErrInvalidDate - expiration date is not at midnight UTC.
+var ErrInvalidDate = errors.New("date must be at midnight UTC")
+
+// ErrDaysAndDate - expiration has both or neither of days and date.
+var ErrDaysAndDate = errors.New("expiration must have either days or date")
+
+// Expiration - expires objects a number of days after their creation or on a given date.
+type Expiration struct {
+	XMLName xml.Name   `xml:"Expiration"`
+	Days    int        `xml:"Days,omitempty"`
+	Date    *time.Time `xml:"Date,omitempty"`
+}
+
+// Validate - validates the expiration.
+func (e Expiration) Validate() error {
+	if (e.Days == 0) == (e.Date == nil) {
+		return ErrDaysAndDate
+	}
+
+	if e.Days < 0 {
+		return ErrInvalidDays
+	}
+
+	if e.Date != nil {
+		date := e.Date.UTC()
+		if !date.Equal(date.Truncate(24 * time.Hour)) {
+			return ErrInvalidDate
+		}
+	}
+
+	return nil
+}
+
+// IsExpired - returns true if an object last modified at modTime has expired by now.
+func (e Expiration) IsExpired(modTime, now time.Time) bool {
+	if e.Date != nil {
+		return !now.Before(*e.Date)
+	}
+
+	return !now.Before(expiryTime(modTime, e.Days))
+}
+
+// AbortIncompleteMultipartUpload - aborts incomplete multipart uploads a
+// number of days after their initiation.
+type AbortIncompleteMultipartUpload struct {
+	XMLName             xml.Name `xml:"AbortIncompleteMultipartUpload"`
+	DaysAfterInitiation int      `xml:"DaysAfterInitiation"`
+}
+
+// Validate - validates the abort incomplete multipart upload action.
+func (a AbortIncompleteMultipartUpload) Validate() error {
+	if a.DaysAfterInitiation <= 0 {
+		return ErrInvalidDays
+	}
+
+	return nil
+}
+
+// IsStale - returns true if an upload initiated at the given time is stale by now.
+func (a AbortIncompleteMultipartUpload) IsStale(initiated, now time.Time) bool {
+	return !now.Before(expiryTime(initiated, a.DaysAfterInitiation))
+}
+
+// expiryTime - returns the midnight UTC following the given number of days
+// after t, as AWS S3 rounds expiry times up to the next midnight.
+func expiryTime(t time.Time, days int) time.Time {
+	return t.UTC().Add(time.Duration(days) * 24 * time.Hour).Truncate(24 * time.Hour).Add(24 * time.Hour)
+}
@@ -0,0 +1,85 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lifecycle
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpirationValidate(t *testing.T) {
+	midnight := time.Date(2019, time.March, 1, 0, 0, 0, 0, time.UTC)
+	noon := midnight.Add(12 * time.Hour)
+
+	testCases := []struct {
+		expiration  Expiration
+		expectedErr error
+	}{
+		{Expiration{Days: 1}, nil},
+		{Expiration{Date: &midnight}, nil},
+		{Expiration{}, ErrDaysAndDate},
+		{Expiration{Days: 1, Date: &midnight}, ErrDaysAndDate},
+		{Expiration{Days: -1}, ErrInvalidDays},
+		{Expiration{Date: &noon}, ErrInvalidDate},
+	}
+
+	for i, testCase := range testCases {
+		if err := testCase.expiration.Validate(); err != testCase.expectedErr {
+			t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+	}
+}
+
+func TestExpirationIsExpired(t *testing.T) {
+	date := time.Date(2019, time.March, 1, 0, 0, 0, 0, time.UTC)
+	modTime := time.Date(2019, time.March, 1, 15, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		expiration     Expiration
+		now            time.Time
+		expectedResult bool
+	}{
+		// Expiry is rounded up to the midnight following modTime + days.
+		{Expiration{Days: 1}, time.Date(2019, time.March, 2, 23, 59, 0, 0, time.UTC), false},
+		{Expiration{Days: 1}, time.Date(2019, time.March, 3, 0, 0, 0, 0, time.UTC), true},
+		{Expiration{Date: &date}, date.Add(-time.Second), false},
+		{Expiration{Date: &date}, date, true},
+	}
+
+	for i, testCase := range testCases {
+		if result := testCase.expiration.IsExpired(modTime, testCase.now); result != testCase.expectedResult {
+			t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestAbortIncompleteMultipartUploadIsStale(t *testing.T) {
+	initiated := time.Date(2019, time.March, 1, 15, 30, 0, 0, time.UTC)
+	a := AbortIncompleteMultipartUpload{DaysAfterInitiation: 7}
+
+	if a.IsStale(initiated, time.Date(2019, time.March, 8, 23, 59, 0, 0, time.UTC)) {
+		t.Fatalf("expected upload not to be stale")
+	}
+
+	if !a.IsStale(initiated, time.Date(2019, time.March, 9, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected upload to be stale")
+	}
+
+	if err := (AbortIncompleteMultipartUpload{}).Validate(); err != ErrInvalidDays {
+		t.Fatalf("expected: %v, got: %v", ErrInvalidDays, err)
+	}
+}
@@ -0,0 +1,42 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lifecycle
+
+import (
+	"encoding/xml"
+	"errors"
+)
+
+// ErrTagFilterNotSupported - filter selects objects by their tags.
+var ErrTagFilterNotSupported = errors.New("only prefix based filters are supported")
+
+// Filter - selects the objects a rule applies to.
+type Filter struct {
+	XMLName xml.Name            `xml:"Filter"`
+	Prefix  string              `xml:"Prefix"`
+	And     *unsupportedElement `xml:"And,omitempty"`
+	Tag     *unsupportedElement `xml:"Tag,omitempty"`
+}
+
+// Validate - validates the filter.
+func (f Filter) Validate() error {
+	if f.And != nil || f.Tag != nil {
+		return ErrTagFilterNotSupported
+	}
+
+	return nil
+}
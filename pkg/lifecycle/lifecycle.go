@@ -0,0 +1,160 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lifecycle
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Maximum number of rules allowed in a lifecycle configuration, as per AWS S3 specification.
+const maxRules = 1000
+
+// ErrTooManyRules - lifecycle configuration has more than 1000 rules.
+var ErrTooManyRules = errors.New("lifecycle configuration allows a maximum of 1000 rules")
+
+// ErrNoRules - lifecycle configuration has no rules.
+var ErrNoRules = errors.New("lifecycle configuration should have at least one rule")
+
+// ErrDuplicateRuleID - two rules of the lifecycle configuration have the same ID.
+var ErrDuplicateRuleID = errors.New("lifecycle configuration has rules with duplicate IDs")
+
+// Action - action to be taken on an object or an incomplete multipart upload.
+type Action int
+
+const (
+	// NoneAction - nothing to be done.
+	NoneAction Action = iota
+
+	// DeleteAction - the object has expired and must be deleted.
+	DeleteAction
+
+	// AbortMultipartUploadAction - the incomplete multipart upload is stale and must be aborted.
+	AbortMultipartUploadAction
+)
+
+// Lifecycle - bucket lifecycle configuration.
+type Lifecycle struct {
+	XMLNS   string   `xml:"xmlns,attr,omitempty"`
+	XMLName xml.Name `xml:"LifecycleConfiguration"`
+	Rules   []Rule   `xml:"Rule"`
+}
+
+// Validate - validates the lifecycle configuration.
+func (lc Lifecycle) Validate() error {
+	if len(lc.Rules) == 0 {
+		return ErrNoRules
+	}
+
+	if len(lc.Rules) > maxRules {
+		return ErrTooManyRules
+	}
+
+	ids := make(map[string]struct{})
+	for _, rule := range lc.Rules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+
+		if rule.ID == "" {
+			continue
+		}
+		if _, ok := ids[rule.ID]; ok {
+			return ErrDuplicateRuleID
+		}
+		ids[rule.ID] = struct{}{}
+	}
+
+	return nil
+}
+
+// Prefixes - returns the smallest set of prefixes covering all objects
+// the enabled rules apply to, an empty prefix covers the whole bucket.
+func (lc Lifecycle) Prefixes() []string {
+	var prefixes []string
+	for _, rule := range lc.Rules {
+		if rule.Enabled() {
+			prefixes = append(prefixes, rule.prefix())
+		}
+	}
+
+	sort.Strings(prefixes)
+
+	var result []string
+	for _, prefix := range prefixes {
+		// A prefix sorts after any of its own prefixes, so comparing
+		// with the last kept prefix is enough to drop overlaps.
+		if len(result) > 0 && strings.HasPrefix(prefix, result[len(result)-1]) {
+			continue
+		}
+		result = append(result, prefix)
+	}
+
+	return result
+}
+
+// ComputeAction - returns the action to be taken on the given object
+// last modified at the given time.
+func (lc Lifecycle) ComputeAction(objName string, modTime time.Time) Action {
+	now := time.Now().UTC()
+	for _, rule := range lc.Rules {
+		if !rule.Enabled() || !strings.HasPrefix(objName, rule.prefix()) {
+			continue
+		}
+
+		if rule.Expiration != nil && rule.Expiration.IsExpired(modTime, now) {
+			return DeleteAction
+		}
+	}
+
+	return NoneAction
+}
+
+// ComputeMultipartAction - returns the action to be taken on an
+// incomplete multipart upload of the given object initiated at the given time.
+func (lc Lifecycle) ComputeMultipartAction(objName string, initiated time.Time) Action {
+	now := time.Now().UTC()
+	for _, rule := range lc.Rules {
+		if !rule.Enabled() || !strings.HasPrefix(objName, rule.prefix()) {
+			continue
+		}
+
+		if rule.AbortIncompleteMultipartUpload != nil && rule.AbortIncompleteMultipartUpload.IsStale(initiated, now) {
+			return AbortMultipartUploadAction
+		}
+	}
+
+	return NoneAction
+}
+
+// ParseLifecycleConfig - parses data in given reader to lifecycle configuration.
+func ParseLifecycleConfig(reader io.Reader) (*Lifecycle, error) {
+	var lc Lifecycle
+	if err := xml.NewDecoder(reader).Decode(&lc); err != nil {
+		return nil, err
+	}
+
+	if err := lc.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &lc, nil
+}
@@ -0,0 +1,147 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lifecycle
+
+import (
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseLifecycleConfig(t *testing.T) {
+	testCases := []struct {
+		data        string
+		expectedErr error
+	}{
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status><Filter><Prefix>logs/</Prefix></Filter><Expiration><Days>30</Days></Expiration></Rule></LifecycleConfiguration>`, nil},
+		{`<LifecycleConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><ID>tmp</ID><Prefix>tmp/</Prefix><Status>Disabled</Status><Expiration><Date>2019-01-01T00:00:00.000Z</Date></Expiration></Rule></LifecycleConfiguration>`, nil},
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status><Filter></Filter><AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`, nil},
+		{`<LifecycleConfiguration></LifecycleConfiguration>`, ErrNoRules},
+		{`<LifecycleConfiguration><Rule><ID>a</ID><Status>Enabled</Status><Expiration><Days>1</Days></Expiration></Rule><Rule><ID>a</ID><Status>Enabled</Status><Expiration><Days>2</Days></Expiration></Rule></LifecycleConfiguration>`, ErrDuplicateRuleID},
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status><Filter><Tag><Key>k</Key><Value>v</Value></Tag></Filter><Expiration><Days>1</Days></Expiration></Rule></LifecycleConfiguration>`, ErrTagFilterNotSupported},
+		{`<LifecycleConfiguration><Rule><Status>Enabled</Status><Transition><Days>1</Days><StorageClass>GLACIER</StorageClass></Transition></Rule></LifecycleConfiguration>`, ErrUnsupportedRuleAction},
+	}
+
+	for i, testCase := range testCases {
+		_, err := ParseLifecycleConfig(strings.NewReader(testCase.data))
+		if err != testCase.expectedErr {
+			t.Fatalf("test %v: error: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+	}
+
+	// Malformed XML.
+	if _, err := ParseLifecycleConfig(strings.NewReader(`<LifecycleConfiguration><Rule>`)); err == nil {
+		t.Fatalf("expected an error for malformed XML")
+	}
+}
+
+func TestLifecycleMarshalXML(t *testing.T) {
+	data := `<LifecycleConfiguration><Rule><ID>logs</ID><Status>Enabled</Status><Filter><Prefix>logs/</Prefix></Filter><Expiration><Days>30</Days></Expiration></Rule></LifecycleConfiguration>`
+	lc, err := ParseLifecycleConfig(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := xml.Marshal(lc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(result) != data {
+		t.Fatalf("expected: %s, got: %s", data, string(result))
+	}
+}
+
+func TestLifecyclePrefixes(t *testing.T) {
+	newPrefix := func(s string) *string { return &s }
+	expiration := &Expiration{Days: 1}
+
+	testCases := []struct {
+		rules          []Rule
+		expectedResult []string
+	}{
+		{[]Rule{{Status: Enabled, Filter: &Filter{Prefix: "logs/"}, Expiration: expiration}}, []string{"logs/"}},
+		{[]Rule{
+			{Status: Enabled, Filter: &Filter{Prefix: "logs/2019/"}, Expiration: expiration},
+			{Status: Enabled, Prefix: newPrefix("logs/"), Expiration: expiration},
+			{Status: Enabled, Filter: &Filter{Prefix: "tmp/"}, Expiration: expiration},
+			{Status: Disabled, Filter: &Filter{Prefix: "data/"}, Expiration: expiration},
+		}, []string{"logs/", "tmp/"}},
+		{[]Rule{
+			{Status: Enabled, Filter: &Filter{Prefix: "logs/"}, Expiration: expiration},
+			{Status: Enabled, Expiration: expiration},
+		}, []string{""}},
+		{[]Rule{{Status: Disabled, Expiration: expiration}}, nil},
+	}
+
+	for i, testCase := range testCases {
+		result := Lifecycle{Rules: testCase.rules}.Prefixes()
+		if !reflect.DeepEqual(result, testCase.expectedResult) {
+			t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestLifecycleComputeAction(t *testing.T) {
+	past := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	lc := Lifecycle{
+		Rules: []Rule{
+			{Status: Enabled, Filter: &Filter{Prefix: "logs/"}, Expiration: &Expiration{Days: 30}},
+			{Status: Enabled, Filter: &Filter{Prefix: "tmp/"}, Expiration: &Expiration{Date: &past}},
+			{Status: Disabled, Filter: &Filter{Prefix: "data/"}, Expiration: &Expiration{Days: 1}},
+			{Status: Enabled, Filter: &Filter{Prefix: "uploads/"}, AbortIncompleteMultipartUpload: &AbortIncompleteMultipartUpload{DaysAfterInitiation: 7}},
+		},
+	}
+
+	now := time.Now().UTC()
+	testCases := []struct {
+		objName        string
+		modTime        time.Time
+		expectedAction Action
+	}{
+		{"logs/old", now.Add(-31 * 24 * time.Hour), DeleteAction},
+		{"logs/new", now, NoneAction},
+		{"tmp/new", now, DeleteAction},
+		{"data/old", past, NoneAction},
+		{"other/old", past, NoneAction},
+		{"uploads/old", past, NoneAction},
+	}
+
+	for i, testCase := range testCases {
+		if action := lc.ComputeAction(testCase.objName, testCase.modTime); action != testCase.expectedAction {
+			t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expectedAction, action)
+		}
+	}
+
+	multipartTestCases := []struct {
+		objName        string
+		initiated      time.Time
+		expectedAction Action
+	}{
+		{"uploads/old", now.Add(-8 * 24 * time.Hour), AbortMultipartUploadAction},
+		{"uploads/new", now, NoneAction},
+		{"logs/old", past, NoneAction},
+	}
+
+	for i, testCase := range multipartTestCases {
+		if action := lc.ComputeMultipartAction(testCase.objName, testCase.initiated); action != testCase.expectedAction {
+			t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expectedAction, action)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lifecycle
+
+import (
+	"encoding/xml"
+	"errors"
+)
+
+// Status - status of a lifecycle rule.
+type Status string
+
+const (
+	// Enabled - the rule is applied.
+	Enabled Status = "Enabled"
+
+	// Disabled - the rule is ignored.
+	Disabled Status = "Disabled"
+)
+
+// Maximum length of a rule ID, as per AWS S3 specification.
+const maxRuleIDLength = 255
+
+// ErrInvalidRuleID - rule ID is longer than 255 characters.
+var ErrInvalidRuleID = errors.New("ID must be less than 255 characters")
+
+// ErrInvalidRuleStatus - rule status is neither Enabled nor Disabled.
+var ErrInvalidRuleStatus = errors.New("rule status must be Enabled or Disabled")
+
+// ErrMissingRuleAction - rule has neither an expiration nor an abort incomplete multipart upload action.
+var ErrMissingRuleAction = errors.New("rule must have at least one action")
+
+// ErrPrefixAndFilter - rule has both the legacy prefix and a filter.
+var ErrPrefixAndFilter = errors.New("rule must have either a prefix or a filter, not both")
+
+// ErrUnsupportedRuleAction - rule has a transition or noncurrent version action.
+var ErrUnsupportedRuleAction = errors.New("only expiration and abort incomplete multipart upload actions are supported")
+
+// unsupportedElement - holds configuration elements which are parsed only to be rejected.
+type unsupportedElement struct {
+	InnerXML string `xml:",innerxml"`
+}
+
+// Rule - a lifecycle rule applying actions to objects under a prefix.
+type Rule struct {
+	XMLName                        xml.Name                        `xml:"Rule"`
+	ID                             string                          `xml:"ID,omitempty"`
+	Status                         Status                          `xml:"Status"`
+	Prefix                         *string                         `xml:"Prefix,omitempty"`
+	Filter                         *Filter                         `xml:"Filter,omitempty"`
+	Expiration                     *Expiration                     `xml:"Expiration,omitempty"`
+	AbortIncompleteMultipartUpload *AbortIncompleteMultipartUpload `xml:"AbortIncompleteMultipartUpload,omitempty"`
+	Transition                     *unsupportedElement             `xml:"Transition,omitempty"`
+	NoncurrentVersionTransition    *unsupportedElement             `xml:"NoncurrentVersionTransition,omitempty"`
+	NoncurrentVersionExpiration    *unsupportedElement             `xml:"NoncurrentVersionExpiration,omitempty"`
+}
+
+// Validate - validates the rule.
+func (rule Rule) Validate() error {
+	if len(rule.ID) > maxRuleIDLength {
+		return ErrInvalidRuleID
+	}
+
+	switch rule.Status {
+	case Enabled, Disabled:
+	default:
+		return ErrInvalidRuleStatus
+	}
+
+	if rule.Prefix != nil && rule.Filter != nil {
+		return ErrPrefixAndFilter
+	}
+
+	if rule.Filter != nil {
+		if err := rule.Filter.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if rule.Transition != nil || rule.NoncurrentVersionTransition != nil || rule.NoncurrentVersionExpiration != nil {
+		return ErrUnsupportedRuleAction
+	}
+
+	if rule.Expiration == nil && rule.AbortIncompleteMultipartUpload == nil {
+		return ErrMissingRuleAction
+	}
+
+	if rule.Expiration != nil {
+		if err := rule.Expiration.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if rule.AbortIncompleteMultipartUpload != nil {
+		if err := rule.AbortIncompleteMultipartUpload.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Enabled - returns true if the rule is applied.
+func (rule Rule) Enabled() bool {
+	return rule.Status == Enabled
+}
+
+// prefix - returns the object name prefix the rule applies to.
+func (rule Rule) prefix() string {
+	if rule.Filter != nil {
+		return rule.Filter.Prefix
+	}
+
+	if rule.Prefix != nil {
+		return *rule.Prefix
+	}
+
+	return ""
+}
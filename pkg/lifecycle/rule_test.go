@@ -0,0 +1,49 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lifecycle
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRuleValidate(t *testing.T) {
+	prefix := "logs/"
+	expiration := &Expiration{Days: 1}
+
+	testCases := []struct {
+		rule        Rule
+		expectedErr error
+	}{
+		{Rule{Status: Enabled, Expiration: expiration}, nil},
+		{Rule{Status: Disabled, Prefix: &prefix, AbortIncompleteMultipartUpload: &AbortIncompleteMultipartUpload{DaysAfterInitiation: 1}}, nil},
+		{Rule{ID: strings.Repeat("a", 256), Status: Enabled, Expiration: expiration}, ErrInvalidRuleID},
+		{Rule{Status: "enabled", Expiration: expiration}, ErrInvalidRuleStatus},
+		{Rule{Status: Enabled}, ErrMissingRuleAction},
+		{Rule{Status: Enabled, Prefix: &prefix, Filter: &Filter{Prefix: prefix}, Expiration: expiration}, ErrPrefixAndFilter},
+		{Rule{Status: Enabled, Filter: &Filter{Tag: &unsupportedElement{}}, Expiration: expiration}, ErrTagFilterNotSupported},
+		{Rule{Status: Enabled, Expiration: expiration, NoncurrentVersionExpiration: &unsupportedElement{}}, ErrUnsupportedRuleAction},
+		{Rule{Status: Enabled, Expiration: &Expiration{}}, ErrDaysAndDate},
+		{Rule{Status: Enabled, AbortIncompleteMultipartUpload: &AbortIncompleteMultipartUpload{}}, ErrInvalidDays},
+	}
+
+	for i, testCase := range testCases {
+		if err := testCase.rule.Validate(); err != testCase.expectedErr {
+			t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+	}
+}
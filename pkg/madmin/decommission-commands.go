@@ -0,0 +1,141 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Decommission states of a server pool or of an erasure set, an active
+// pool or set stores new objects while a draining one moves its objects
+// to the active pools or sets until it is decommissioned and its hardware
+// can be removed.
+const (
+	PoolActive         = "active"
+	PoolDraining       = "draining"
+	PoolDecommissioned = "decommissioned"
+)
+
+// PoolDecommissionInfo - decommission state and progress of a server pool.
+type PoolDecommissionInfo struct {
+	Pool          int       `json:"pool"`
+	State         string    `json:"state"`
+	StartTime     time.Time `json:"startTime,omitempty"`
+	LastUpdate    time.Time `json:"lastUpdate,omitempty"`
+	ObjectsMoved  uint64    `json:"objectsMoved"`
+	UploadsMoved  uint64    `json:"uploadsMoved"`
+	ObjectsFailed uint64    `json:"objectsFailed"`
+}
+
+// SetDecommissionInfo - decommission state and progress of an erasure set
+// of a server pool.
+type SetDecommissionInfo struct {
+	PoolDecommissionInfo
+	Set int `json:"set"`
+}
+
+// DecommissionStatus - decommission state of all server pools and of the
+// erasure sets draining or decommissioned.
+type DecommissionStatus struct {
+	Pools []PoolDecommissionInfo `json:"pools"`
+	Sets  []SetDecommissionInfo  `json:"sets,omitempty"`
+}
+
+// DecommissionPool - starts draining a server pool, the pool is given by its
+// position on the server command line starting at zero.
+func (adm *AdminClient) DecommissionPool(pool int) error {
+	queryValues := url.Values{}
+	queryValues.Set("pool", strconv.Itoa(pool))
+
+	reqData := requestData{
+		relPath:     "/v1/decommission",
+		queryValues: queryValues,
+	}
+
+	// Execute POST on /minio/admin/v1/decommission to start draining a pool.
+	resp, err := adm.executeMethod("POST", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// DecommissionSet - starts draining an erasure set of a server pool, the
+// objects of the set are moved to the other sets of the pool. The set is
+// given by its position in the pool starting at zero.
+func (adm *AdminClient) DecommissionSet(pool, set int) error {
+	queryValues := url.Values{}
+	queryValues.Set("pool", strconv.Itoa(pool))
+	queryValues.Set("set", strconv.Itoa(set))
+
+	reqData := requestData{
+		relPath:     "/v1/decommission",
+		queryValues: queryValues,
+	}
+
+	// Execute POST on /minio/admin/v1/decommission to start draining a set.
+	resp, err := adm.executeMethod("POST", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// DecommissionStatus - returns the decommission state and progress of all server pools.
+func (adm *AdminClient) DecommissionStatus() (status DecommissionStatus, err error) {
+	// Execute GET on /minio/admin/v1/decommission-status
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/decommission-status"})
+
+	defer closeResponse(resp)
+	if err != nil {
+		return status, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return status, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return status, err
+	}
+
+	if err = json.Unmarshal(respBytes, &status); err != nil {
+		return status, err
+	}
+
+	return status, nil
+}
@@ -258,3 +258,85 @@ func (adm *AdminClient) Heal(bucket, prefix string, healOpts HealOpts,
 	}
 	return healStart, healTaskStatus, nil
 }
+
+// BackgroundHealStatus - state and progress of the background heal of
+// replaced drives, no heal is running when Drives is empty.
+type BackgroundHealStatus struct {
+	Drives        []string  `json:"drives"`
+	StartTime     time.Time `json:"startTime"`
+	LastUpdate    time.Time `json:"lastUpdate"`
+	Bucket        string    `json:"bucket"`
+	Object        string    `json:"object"`
+	BucketsHealed uint64    `json:"bucketsHealed"`
+	ObjectsHealed uint64    `json:"objectsHealed"`
+	ObjectsFailed uint64    `json:"objectsFailed"`
+	LastFinished  time.Time `json:"lastFinished"`
+}
+
+// BackgroundHealStatus - returns the state and progress of the background heal.
+func (adm *AdminClient) BackgroundHealStatus() (BackgroundHealStatus, error) {
+	// Execute GET on /minio/admin/v1/background-heal/status
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/background-heal/status"})
+	defer closeResponse(resp)
+	if err != nil {
+		return BackgroundHealStatus{}, err
+	}
+
+	// Check response http status code
+	if resp.StatusCode != http.StatusOK {
+		return BackgroundHealStatus{}, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return BackgroundHealStatus{}, err
+	}
+
+	var healStatus BackgroundHealStatus
+	if err = json.Unmarshal(respBytes, &healStatus); err != nil {
+		return BackgroundHealStatus{}, err
+	}
+
+	return healStatus, nil
+}
+
+// BitrotScrubStatus - progress of the background bitrot scrubber, counters
+// add up all scrubs since the deployment was created.
+type BitrotScrubStatus struct {
+	StartTime        time.Time `json:"startTime"`
+	LastUpdate       time.Time `json:"lastUpdate"`
+	Bucket           string    `json:"bucket"`
+	Object           string    `json:"object"`
+	ObjectsScanned   uint64    `json:"objectsScanned"`
+	BytesScanned     uint64    `json:"bytesScanned"`
+	CorruptionsFound uint64    `json:"corruptionsFound"`
+	CorruptionsFixed uint64    `json:"corruptionsFixed"`
+	LastFinished     time.Time `json:"lastFinished"`
+}
+
+// BitrotScrubStatus - returns the progress of the background bitrot scrubber.
+func (adm *AdminClient) BitrotScrubStatus() (BitrotScrubStatus, error) {
+	// Execute GET on /minio/admin/v1/bitrot-scrub/status
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/bitrot-scrub/status"})
+	defer closeResponse(resp)
+	if err != nil {
+		return BitrotScrubStatus{}, err
+	}
+
+	// Check response http status code
+	if resp.StatusCode != http.StatusOK {
+		return BitrotScrubStatus{}, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return BitrotScrubStatus{}, err
+	}
+
+	var scrubStatus BitrotScrubStatus
+	if err = json.Unmarshal(respBytes, &scrubStatus); err != nil {
+		return BitrotScrubStatus{}, err
+	}
+
+	return scrubStatus, nil
+}
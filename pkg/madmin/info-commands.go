@@ -153,6 +153,56 @@ func (adm *AdminClient) ServerInfo() ([]ServerInfo, error) {
 	return serversInfo, nil
 }
 
+// BucketUsageInfo - bucket usage info provides
+// - total size of the bucket
+// - total objects in a bucket
+// - object size histogram per bucket
+type BucketUsageInfo struct {
+	Size                  uint64            `json:"size"`
+	ObjectsCount          uint64            `json:"objectsCount"`
+	ObjectsSizesHistogram map[string]uint64 `json:"objectsSizesHistogram"`
+}
+
+// DataUsageInfo represents data usage of the object layer as computed by
+// the last run of the data usage crawler.
+type DataUsageInfo struct {
+	LastUpdate            time.Time                  `json:"lastUpdate"`
+	ObjectsCount          uint64                     `json:"objectsCount"`
+	ObjectsTotalSize      uint64                     `json:"objectsTotalSize"`
+	ObjectsSizesHistogram map[string]uint64          `json:"objectsSizesHistogram"`
+	BucketsCount          uint64                     `json:"bucketsCount"`
+	BucketsUsage          map[string]BucketUsageInfo `json:"bucketsUsageInfo"`
+}
+
+// DataUsageInfo - returns data usage of the current object API
+func (adm *AdminClient) DataUsageInfo() (DataUsageInfo, error) {
+	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/datausageinfo"})
+	defer closeResponse(resp)
+	if err != nil {
+		return DataUsageInfo{}, err
+	}
+
+	// Check response http status code
+	if resp.StatusCode != http.StatusOK {
+		return DataUsageInfo{}, httpRespToErrorResponse(resp)
+	}
+
+	// Unmarshal the server's json response
+	var dataUsageInfo DataUsageInfo
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return DataUsageInfo{}, err
+	}
+
+	err = json.Unmarshal(respBytes, &dataUsageInfo)
+	if err != nil {
+		return DataUsageInfo{}, err
+	}
+
+	return dataUsageInfo, nil
+}
+
 // ServerDrivesPerfInfo holds informantion about address and write speed of
 // all drives in a single server node
 type ServerDrivesPerfInfo struct {
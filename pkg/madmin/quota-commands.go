@@ -0,0 +1,141 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// QuotaType - bucket quota type.
+type QuotaType string
+
+// Quota types, a hard quota rejects new data once reached while
+// a FIFO quota evicts the oldest objects to make room.
+const (
+	HardQuota QuotaType = "hard"
+	FIFOQuota QuotaType = "fifo"
+)
+
+// IsValid - returns true if the quota type is known.
+func (t QuotaType) IsValid() bool {
+	return t == HardQuota || t == FIFOQuota
+}
+
+// BucketQuota - quota of a bucket, in bytes.
+type BucketQuota struct {
+	Quota uint64    `json:"quota"`
+	Type  QuotaType `json:"quotatype"`
+}
+
+// IsValid - returns true if the quota limits the bucket size.
+func (q BucketQuota) IsValid() bool {
+	return q.Quota > 0 && q.Type.IsValid()
+}
+
+// SetBucketQuota - sets the quota of a bucket.
+func (adm *AdminClient) SetBucketQuota(bucket string, quota BucketQuota) error {
+	data, err := json.Marshal(quota)
+	if err != nil {
+		return err
+	}
+
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     "/v1/set-bucket-quota",
+		queryValues: queryValues,
+		content:     data,
+	}
+
+	// Execute PUT on /minio/admin/v1/set-bucket-quota to set quota for a bucket.
+	resp, err := adm.executeMethod("PUT", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// GetBucketQuota - returns the quota of a bucket.
+func (adm *AdminClient) GetBucketQuota(bucket string) (quota BucketQuota, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     "/v1/get-bucket-quota",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v1/get-bucket-quota
+	resp, err := adm.executeMethod("GET", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return quota, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return quota, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return quota, err
+	}
+
+	if err = json.Unmarshal(respBytes, &quota); err != nil {
+		return quota, err
+	}
+
+	return quota, nil
+}
+
+// RemoveBucketQuota - removes the quota of a bucket.
+func (adm *AdminClient) RemoveBucketQuota(bucket string) error {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     "/v1/remove-bucket-quota",
+		queryValues: queryValues,
+	}
+
+	// Execute DELETE on /minio/admin/v1/remove-bucket-quota to remove quota of a bucket.
+	resp, err := adm.executeMethod("DELETE", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/minio/minio/pkg/auth"
+)
+
+// AddServiceAccountReq is the request to add a service account to a
+// parent user, the optional policy narrows the policies inherited
+// from the parent user.
+type AddServiceAccountReq struct {
+	Parent string `json:"parent"`
+	Policy string `json:"policy,omitempty"`
+}
+
+// AddServiceAccount - adds a service account to the given parent user
+// and returns its credentials.
+func (adm *AdminClient) AddServiceAccount(parentUser string, policy string) (auth.Credentials, error) {
+	data, err := json.Marshal(AddServiceAccountReq{
+		Parent: parentUser,
+		Policy: policy,
+	})
+	if err != nil {
+		return auth.Credentials{}, err
+	}
+
+	reqData := requestData{
+		relPath: "/v1/add-service-account",
+		content: data,
+	}
+
+	// Execute PUT on /minio/admin/v1/add-service-account
+	resp, err := adm.executeMethod("PUT", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return auth.Credentials{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return auth.Credentials{}, httpRespToErrorResponse(resp)
+	}
+
+	data, err = DecryptData(adm.secretAccessKey, resp.Body)
+	if err != nil {
+		return auth.Credentials{}, err
+	}
+
+	var cred auth.Credentials
+	if err = json.Unmarshal(data, &cred); err != nil {
+		return auth.Credentials{}, err
+	}
+
+	return cred, nil
+}
+
+// ListServiceAccounts - lists the access keys of all service accounts
+// of a parent user.
+func (adm *AdminClient) ListServiceAccounts(parentUser string) ([]string, error) {
+	queryValues := url.Values{}
+	queryValues.Set("user", parentUser)
+
+	reqData := requestData{
+		relPath:     "/v1/list-service-accounts",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v1/list-service-accounts
+	resp, err := adm.executeMethod("GET", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceAccounts := []string{}
+	if err = json.Unmarshal(data, &serviceAccounts); err != nil {
+		return nil, err
+	}
+
+	return serviceAccounts, nil
+}
+
+// DeleteServiceAccount - deletes a service account.
+func (adm *AdminClient) DeleteServiceAccount(serviceAccount string) error {
+	queryValues := url.Values{}
+	queryValues.Set("accessKey", serviceAccount)
+
+	reqData := requestData{
+		relPath:     "/v1/delete-service-account",
+		queryValues: queryValues,
+	}
+
+	// Execute DELETE on /minio/admin/v1/delete-service-account
+	resp, err := adm.executeMethod("DELETE", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectlock
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"time"
+)
+
+// Mode - retention mode of an object version.
+type Mode string
+
+const (
+	// Governance - the retention may be shortened or removed by users
+	// allowed to bypass governance retention.
+	Governance Mode = "GOVERNANCE"
+
+	// Compliance - the retention may only be extended.
+	Compliance Mode = "COMPLIANCE"
+)
+
+// Enabled - object lock state of a bucket, it cannot be disabled.
+const Enabled = "Enabled"
+
+// ErrObjectLockNotEnabled - object lock configuration does not enable object lock.
+var ErrObjectLockNotEnabled = errors.New("object lock configuration must be Enabled")
+
+// ErrInvalidMode - retention mode is neither GOVERNANCE nor COMPLIANCE.
+var ErrInvalidMode = errors.New("retention mode must be GOVERNANCE or COMPLIANCE")
+
+// ErrDaysAndYears - default retention has both or neither of days and years.
+var ErrDaysAndYears = errors.New("default retention must have either days or years")
+
+// ErrInvalidPeriod - default retention period is not a positive integer.
+var ErrInvalidPeriod = errors.New("default retention period must be a positive integer")
+
+// DefaultRetention - retention applied to new objects without retention.
+type DefaultRetention struct {
+	XMLName xml.Name `xml:"DefaultRetention"`
+	Mode    Mode     `xml:"Mode"`
+	Days    int      `xml:"Days,omitempty"`
+	Years   int      `xml:"Years,omitempty"`
+}
+
+// Validate - validates the default retention.
+func (d DefaultRetention) Validate() error {
+	if !d.Mode.IsValid() {
+		return ErrInvalidMode
+	}
+
+	if (d.Days == 0) == (d.Years == 0) {
+		return ErrDaysAndYears
+	}
+
+	if d.Days < 0 || d.Years < 0 {
+		return ErrInvalidPeriod
+	}
+
+	return nil
+}
+
+// Rule - object lock rule of a bucket.
+type Rule struct {
+	XMLName          xml.Name         `xml:"Rule"`
+	DefaultRetention DefaultRetention `xml:"DefaultRetention"`
+}
+
+// Config - bucket object lock configuration.
+type Config struct {
+	XMLNS             string   `xml:"xmlns,attr,omitempty"`
+	XMLName           xml.Name `xml:"ObjectLockConfiguration"`
+	ObjectLockEnabled string   `xml:"ObjectLockEnabled"`
+	Rule              *Rule    `xml:"Rule,omitempty"`
+}
+
+// Validate - validates the object lock configuration.
+func (c Config) Validate() error {
+	if c.ObjectLockEnabled != Enabled {
+		return ErrObjectLockNotEnabled
+	}
+
+	if c.Rule != nil {
+		return c.Rule.DefaultRetention.Validate()
+	}
+
+	return nil
+}
+
+// DefaultRetention - returns the retention of an object created at the
+// given time without retention, if the configuration has a default retention.
+func (c Config) DefaultRetention(now time.Time) (Retention, bool) {
+	if c.Rule == nil {
+		return Retention{}, false
+	}
+
+	d := c.Rule.DefaultRetention
+	return Retention{
+		Mode:            d.Mode,
+		RetainUntilDate: now.UTC().AddDate(d.Years, 0, d.Days),
+	}, true
+}
+
+// IsValid - returns true if the mode is GOVERNANCE or COMPLIANCE.
+func (m Mode) IsValid() bool {
+	switch m {
+	case Governance, Compliance:
+		return true
+	}
+	return false
+}
+
+// ParseConfig - parses data in given reader to object lock configuration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
@@ -0,0 +1,192 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectlock
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		data        string
+		expectedErr error
+	}{
+		{`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled></ObjectLockConfiguration>`, nil},
+		{`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>GOVERNANCE</Mode><Days>30</Days></DefaultRetention></Rule></ObjectLockConfiguration>`, nil},
+		{`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>COMPLIANCE</Mode><Years>1</Years></DefaultRetention></Rule></ObjectLockConfiguration>`, nil},
+		{`<ObjectLockConfiguration><ObjectLockEnabled>Disabled</ObjectLockEnabled></ObjectLockConfiguration>`, ErrObjectLockNotEnabled},
+		{`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>WORM</Mode><Days>30</Days></DefaultRetention></Rule></ObjectLockConfiguration>`, ErrInvalidMode},
+		{`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>GOVERNANCE</Mode><Days>30</Days><Years>1</Years></DefaultRetention></Rule></ObjectLockConfiguration>`, ErrDaysAndYears},
+		{`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>GOVERNANCE</Mode></DefaultRetention></Rule></ObjectLockConfiguration>`, ErrDaysAndYears},
+		{`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>GOVERNANCE</Mode><Days>-1</Days></DefaultRetention></Rule></ObjectLockConfiguration>`, ErrInvalidPeriod},
+	}
+
+	for i, testCase := range testCases {
+		_, err := ParseConfig(strings.NewReader(testCase.data))
+		if err != testCase.expectedErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+	}
+}
+
+func TestConfigDefaultRetention(t *testing.T) {
+	now := time.Date(2019, time.January, 31, 12, 0, 0, 0, time.UTC)
+
+	config, err := ParseConfig(strings.NewReader(`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled></ObjectLockConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := config.DefaultRetention(now); ok {
+		t.Fatal("expected no default retention")
+	}
+
+	config, err = ParseConfig(strings.NewReader(`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>COMPLIANCE</Mode><Days>2</Days></DefaultRetention></Rule></ObjectLockConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	retention, ok := config.DefaultRetention(now)
+	expected := Retention{Mode: Compliance, RetainUntilDate: time.Date(2019, time.February, 2, 12, 0, 0, 0, time.UTC)}
+	if !ok || retention.Mode != expected.Mode || !retention.RetainUntilDate.Equal(expected.RetainUntilDate) {
+		t.Fatalf("expected: %v, got: %v", expected, retention)
+	}
+}
+
+func TestParseRetention(t *testing.T) {
+	now := time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		data        string
+		expectedErr error
+	}{
+		{`<Retention><Mode>GOVERNANCE</Mode><RetainUntilDate>2019-02-01T00:00:00Z</RetainUntilDate></Retention>`, nil},
+		{`<Retention><Mode>COMPLIANCE</Mode><RetainUntilDate>2019-02-01T00:00:00.000Z</RetainUntilDate></Retention>`, nil},
+		// An empty retention removes the retention.
+		{`<Retention></Retention>`, nil},
+		{`<Retention><Mode>GOVERNANCE</Mode></Retention>`, ErrInvalidRetention},
+		{`<Retention><Mode>WORM</Mode><RetainUntilDate>2019-02-01T00:00:00Z</RetainUntilDate></Retention>`, ErrInvalidMode},
+		{`<Retention><Mode>GOVERNANCE</Mode><RetainUntilDate>2018-12-01T00:00:00Z</RetainUntilDate></Retention>`, ErrPastRetainUntilDate},
+	}
+
+	for i, testCase := range testCases {
+		_, err := ParseRetention(strings.NewReader(testCase.data), now)
+		if err != testCase.expectedErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+	}
+}
+
+func TestRetentionMetadata(t *testing.T) {
+	now := time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC)
+	retention := Retention{Mode: Governance, RetainUntilDate: now.Add(time.Hour)}
+
+	result := GetRetention(retention.Metadata())
+	if result.Mode != retention.Mode || !result.RetainUntilDate.Equal(retention.RetainUntilDate) {
+		t.Fatalf("expected: %v, got: %v", retention, result)
+	}
+	if !result.IsActive(now) || result.IsActive(now.Add(2*time.Hour)) {
+		t.Fatal("expected retention to be active for an hour")
+	}
+
+	for k, v := range (Retention{}).Metadata() {
+		if v != "" {
+			t.Fatalf("expected empty retention to remove %s, got: %v", k, v)
+		}
+	}
+	if !GetRetention(nil).IsEmpty() {
+		t.Fatal("expected no retention without metadata")
+	}
+}
+
+func TestParseLegalHold(t *testing.T) {
+	testCases := []struct {
+		data           string
+		expectedStatus LegalHoldStatus
+		expectedErr    error
+	}{
+		{`<LegalHold><Status>ON</Status></LegalHold>`, LegalHoldOn, nil},
+		{`<LegalHold><Status>OFF</Status></LegalHold>`, LegalHoldOff, nil},
+		{`<LegalHold><Status>on</Status></LegalHold>`, "", ErrInvalidLegalHoldStatus},
+		{`<LegalHold></LegalHold>`, "", ErrInvalidLegalHoldStatus},
+	}
+
+	for i, testCase := range testCases {
+		legalHold, err := ParseLegalHold(strings.NewReader(testCase.data))
+		if err != testCase.expectedErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+		if err == nil && legalHold.Status != testCase.expectedStatus {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedStatus, legalHold.Status)
+		}
+	}
+
+	if !GetLegalHold(LegalHold{Status: LegalHoldOn}.Metadata()).IsOn() {
+		t.Fatal("expected legal hold to be on")
+	}
+	if GetLegalHold(LegalHold{Status: LegalHoldOff}.Metadata()).IsOn() {
+		t.Fatal("expected legal hold to be off")
+	}
+}
+
+func TestParseHeaders(t *testing.T) {
+	now := time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		mode, date, legalHold string
+		expectedErr           error
+	}{
+		{"", "", "", nil},
+		{"GOVERNANCE", "2019-02-01T00:00:00Z", "", nil},
+		{"COMPLIANCE", "2019-02-01T00:00:00Z", "ON", nil},
+		{"", "", "OFF", nil},
+		{"GOVERNANCE", "", "", ErrInvalidRetention},
+		{"", "2019-02-01T00:00:00Z", "", ErrInvalidRetention},
+		{"GOVERNANCE", "2019-02-01", "", ErrInvalidRetainUntilDate},
+		{"GOVERNANCE", "2018-02-01T00:00:00Z", "", ErrPastRetainUntilDate},
+		{"WORM", "2019-02-01T00:00:00Z", "", ErrInvalidMode},
+		{"", "", "YES", ErrInvalidLegalHoldStatus},
+	}
+
+	for i, testCase := range testCases {
+		h := http.Header{}
+		if testCase.mode != "" {
+			h.Set(AmzObjectLockMode, testCase.mode)
+		}
+		if testCase.date != "" {
+			h.Set(AmzObjectLockRetainUntilDate, testCase.date)
+		}
+		if testCase.legalHold != "" {
+			h.Set(AmzObjectLockLegalHold, testCase.legalHold)
+		}
+
+		retention, legalHold, err := ParseHeaders(h, now)
+		if err != testCase.expectedErr {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+		if err != nil {
+			continue
+		}
+		if string(retention.Mode) != testCase.mode {
+			t.Fatalf("case %v: expected mode: %v, got: %v", i+1, testCase.mode, retention.Mode)
+		}
+		if string(legalHold.Status) != testCase.legalHold {
+			t.Fatalf("case %v: expected legal hold: %v, got: %v", i+1, testCase.legalHold, legalHold.Status)
+		}
+	}
+}
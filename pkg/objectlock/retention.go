@@ -0,0 +1,218 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectlock
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Request headers and object metadata keys holding the retention and the
+// legal hold of an object version.
+const (
+	AmzObjectLockMode            = "X-Amz-Object-Lock-Mode"
+	AmzObjectLockRetainUntilDate = "X-Amz-Object-Lock-Retain-Until-Date"
+	AmzObjectLockLegalHold       = "X-Amz-Object-Lock-Legal-Hold"
+)
+
+// LegalHoldStatus - legal hold status of an object version.
+type LegalHoldStatus string
+
+const (
+	// LegalHoldOn - the object version cannot be overwritten or deleted.
+	LegalHoldOn LegalHoldStatus = "ON"
+
+	// LegalHoldOff - the object version is protected by its retention only.
+	LegalHoldOff LegalHoldStatus = "OFF"
+)
+
+// ErrInvalidRetention - retention has only one of mode and retain until date.
+var ErrInvalidRetention = errors.New("retention must have both mode and retain until date")
+
+// ErrInvalidRetainUntilDate - retain until date is not an ISO 8601 date.
+var ErrInvalidRetainUntilDate = errors.New("retain until date must be an ISO 8601 date")
+
+// ErrPastRetainUntilDate - retain until date is not in the future.
+var ErrPastRetainUntilDate = errors.New("retain until date must be in the future")
+
+// ErrInvalidLegalHoldStatus - legal hold status is neither ON nor OFF.
+var ErrInvalidLegalHoldStatus = errors.New("legal hold status must be ON or OFF")
+
+// Retention - retention of an object version, which cannot be overwritten
+// or deleted until the retain until date.
+type Retention struct {
+	XMLNS           string    `xml:"xmlns,attr,omitempty"`
+	XMLName         xml.Name  `xml:"Retention"`
+	Mode            Mode      `xml:"Mode,omitempty"`
+	RetainUntilDate time.Time `xml:"RetainUntilDate,omitempty"`
+}
+
+// Validate - validates the retention, an empty retention removes the
+// retention of an object version.
+func (r Retention) Validate(now time.Time) error {
+	if r.IsEmpty() {
+		return nil
+	}
+
+	if r.Mode == "" || r.RetainUntilDate.IsZero() {
+		return ErrInvalidRetention
+	}
+
+	if !r.Mode.IsValid() {
+		return ErrInvalidMode
+	}
+
+	if !r.RetainUntilDate.After(now) {
+		return ErrPastRetainUntilDate
+	}
+
+	return nil
+}
+
+// IsEmpty - returns true if the retention has neither mode nor retain until date.
+func (r Retention) IsEmpty() bool {
+	return r.Mode == "" && r.RetainUntilDate.IsZero()
+}
+
+// IsActive - returns true if the object version is retained at the given time.
+func (r Retention) IsActive(now time.Time) bool {
+	return r.Mode.IsValid() && r.RetainUntilDate.After(now)
+}
+
+// Metadata - returns the object metadata holding the retention, an empty
+// retention has empty values.
+func (r Retention) Metadata() map[string]string {
+	if r.IsEmpty() {
+		return map[string]string{
+			AmzObjectLockMode:            "",
+			AmzObjectLockRetainUntilDate: "",
+		}
+	}
+
+	return map[string]string{
+		AmzObjectLockMode:            string(r.Mode),
+		AmzObjectLockRetainUntilDate: r.RetainUntilDate.UTC().Format(time.RFC3339),
+	}
+}
+
+// GetRetention - returns the retention held in the given object metadata.
+func GetRetention(meta map[string]string) Retention {
+	date, err := time.Parse(time.RFC3339, meta[AmzObjectLockRetainUntilDate])
+	if err != nil {
+		return Retention{}
+	}
+
+	return Retention{
+		Mode:            Mode(meta[AmzObjectLockMode]),
+		RetainUntilDate: date,
+	}
+}
+
+// ParseRetention - parses data in given reader to retention.
+func ParseRetention(reader io.Reader, now time.Time) (*Retention, error) {
+	var r Retention
+	if err := xml.NewDecoder(reader).Decode(&r); err != nil {
+		return nil, err
+	}
+
+	if err := r.Validate(now); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+// LegalHold - legal hold of an object version.
+type LegalHold struct {
+	XMLNS   string          `xml:"xmlns,attr,omitempty"`
+	XMLName xml.Name        `xml:"LegalHold"`
+	Status  LegalHoldStatus `xml:"Status"`
+}
+
+// Validate - validates the legal hold.
+func (l LegalHold) Validate() error {
+	switch l.Status {
+	case LegalHoldOn, LegalHoldOff:
+		return nil
+	}
+	return ErrInvalidLegalHoldStatus
+}
+
+// IsOn - returns true if the legal hold is in effect.
+func (l LegalHold) IsOn() bool {
+	return l.Status == LegalHoldOn
+}
+
+// Metadata - returns the object metadata holding the legal hold, a legal
+// hold which is not in effect has an empty value.
+func (l LegalHold) Metadata() map[string]string {
+	if !l.IsOn() {
+		return map[string]string{AmzObjectLockLegalHold: ""}
+	}
+	return map[string]string{AmzObjectLockLegalHold: string(l.Status)}
+}
+
+// GetLegalHold - returns the legal hold held in the given object metadata.
+func GetLegalHold(meta map[string]string) LegalHold {
+	if meta[AmzObjectLockLegalHold] == string(LegalHoldOn) {
+		return LegalHold{Status: LegalHoldOn}
+	}
+	return LegalHold{Status: LegalHoldOff}
+}
+
+// ParseLegalHold - parses data in given reader to legal hold.
+func ParseLegalHold(reader io.Reader) (*LegalHold, error) {
+	var l LegalHold
+	if err := xml.NewDecoder(reader).Decode(&l); err != nil {
+		return nil, err
+	}
+
+	if err := l.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &l, nil
+}
+
+// ParseHeaders - parses the retention and the legal hold requested in the
+// headers of a request creating an object.
+func ParseHeaders(h http.Header, now time.Time) (r Retention, l LegalHold, err error) {
+	mode, date := h.Get(AmzObjectLockMode), h.Get(AmzObjectLockRetainUntilDate)
+	if mode != "" || date != "" {
+		r.Mode = Mode(mode)
+		if date != "" {
+			if r.RetainUntilDate, err = time.Parse(time.RFC3339, date); err != nil {
+				return r, l, ErrInvalidRetainUntilDate
+			}
+		}
+		if err = r.Validate(now); err != nil {
+			return r, l, err
+		}
+	}
+
+	if status := h.Get(AmzObjectLockLegalHold); status != "" {
+		l.Status = LegalHoldStatus(status)
+		if err = l.Validate(); err != nil {
+			return r, l, err
+		}
+	}
+
+	return r, l, nil
+}
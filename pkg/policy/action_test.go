@@ -32,7 +32,14 @@ func TestActionIsObjectAction(t *testing.T) {
 		{GetObjectAction, true},
 		{ListMultipartUploadPartsAction, true},
 		{PutObjectAction, true},
+		{GetObjectVersionAction, true},
+		{DeleteObjectVersionAction, true},
 		{CreateBucketAction, false},
+		{ListBucketVersionsAction, false},
+		{GetBucketLifecycleAction, false},
+		{PutObjectTaggingAction, true},
+		{GetObjectVersionTaggingAction, true},
+		{GetBucketTaggingAction, false},
 	}
 
 	for i, testCase := range testCases {
@@ -50,6 +57,16 @@ func TestActionIsValid(t *testing.T) {
 		expectedResult bool
 	}{
 		{AbortMultipartUploadAction, true},
+		{PutBucketVersioningAction, true},
+		{PutBucketLifecycleAction, true},
+		{PutBucketCorsAction, true},
+		{DeleteBucketWebsiteAction, true},
+		{PutBucketLoggingAction, true},
+		{PutReplicationConfigurationAction, true},
+		{PutBucketObjectLockConfigurationAction, true},
+		{BypassGovernanceRetentionAction, true},
+		{DeleteObjectTaggingAction, true},
+		{PutBucketTaggingAction, true},
 		{Action("foo"), false},
 	}
 
@@ -38,6 +38,21 @@ func (actionSet ActionSet) Contains(action Action) bool {
 	return found
 }
 
+// Equals - checks whether given action set is equal to current action set.
+func (actionSet ActionSet) Equals(sset ActionSet) bool {
+	if len(actionSet) != len(sset) {
+		return false
+	}
+
+	for k := range actionSet {
+		if _, ok := sset[k]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Intersection - returns actions available in both ActionSet.
 func (actionSet ActionSet) Intersection(sset ActionSet) ActionSet {
 	nset := NewActionSet()
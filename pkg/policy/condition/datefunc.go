@@ -0,0 +1,192 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package condition
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// parseDate - parses a date in ISO 8601 format, such as
+// "2019-10-01T00:00:00Z", or in seconds since epoch.
+func parseDate(s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err == nil {
+		return t, nil
+	}
+
+	secs, perr := strconv.ParseInt(s, 10, 64)
+	if perr != nil {
+		return t, err
+	}
+
+	return time.Unix(secs, 0).UTC(), nil
+}
+
+// dateFunc - Date condition functions. They compare the date value by Key
+// in given values map with the condition value.
+// For example,
+//   - if n = DateLessThan, Key = AWSCurrentTime and value = "2019-10-01T00:00:00Z",
+//     at evaluate() it returns whether the request is made before October 2019.
+// https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_policies_elements_condition_operators.html#Conditions_Date
+type dateFunc struct {
+	n     name
+	k     Key
+	value time.Time
+}
+
+// evaluate() - evaluates to check whether value by Key in given values
+// compares with the condition value. Missing or invalid dates never match.
+func (f dateFunc) evaluate(values map[string][]string) bool {
+	requestValue, ok := values[http.CanonicalHeaderKey(f.k.Name())]
+	if !ok {
+		requestValue = values[f.k.Name()]
+	}
+
+	if len(requestValue) == 0 {
+		return false
+	}
+
+	rvTime, err := parseDate(requestValue[0])
+	if err != nil {
+		return false
+	}
+
+	switch f.n {
+	case dateEquals:
+		return rvTime.Equal(f.value)
+	case dateNotEquals:
+		return !rvTime.Equal(f.value)
+	case dateLessThan:
+		return rvTime.Before(f.value)
+	case dateLessThanEquals:
+		return !rvTime.After(f.value)
+	case dateGreaterThan:
+		return rvTime.After(f.value)
+	case dateGreaterThanEquals:
+		return !rvTime.Before(f.value)
+	}
+
+	return false
+}
+
+// key() - returns condition key which is used by this condition function.
+func (f dateFunc) key() Key {
+	return f.k
+}
+
+// name() - returns condition name of this function, such as "DateLessThan".
+func (f dateFunc) name() name {
+	return f.n
+}
+
+func (f dateFunc) String() string {
+	return fmt.Sprintf("%v:%v:%v", f.n, f.k, f.value.Format(time.RFC3339))
+}
+
+// toMap - returns map representation of this function.
+func (f dateFunc) toMap() map[Key]ValueSet {
+	if !f.k.IsValid() {
+		return nil
+	}
+
+	return map[Key]ValueSet{
+		f.k: NewValueSet(NewStringValue(f.value.Format(time.RFC3339))),
+	}
+}
+
+func newDateFunc(n name, key Key, values ValueSet) (Function, error) {
+	if len(values) != 1 {
+		return nil, fmt.Errorf("only one value is allowed for %v condition", n)
+	}
+
+	var value time.Time
+	for v := range values {
+		switch v.GetType() {
+		case reflect.Int:
+			i, _ := v.GetInt()
+			value = time.Unix(int64(i), 0).UTC()
+		case reflect.String:
+			var err error
+			s, _ := v.GetString()
+			if value, err = parseDate(s); err != nil {
+				return nil, fmt.Errorf("value must be a date for %v condition", n)
+			}
+		default:
+			return nil, fmt.Errorf("value must be a date for %v condition", n)
+		}
+	}
+
+	return &dateFunc{n, key, value}, nil
+}
+
+func newDateEqualsFunc(key Key, values ValueSet) (Function, error) {
+	return newDateFunc(dateEquals, key, values)
+}
+
+func newDateNotEqualsFunc(key Key, values ValueSet) (Function, error) {
+	return newDateFunc(dateNotEquals, key, values)
+}
+
+func newDateLessThanFunc(key Key, values ValueSet) (Function, error) {
+	return newDateFunc(dateLessThan, key, values)
+}
+
+func newDateLessThanEqualsFunc(key Key, values ValueSet) (Function, error) {
+	return newDateFunc(dateLessThanEquals, key, values)
+}
+
+func newDateGreaterThanFunc(key Key, values ValueSet) (Function, error) {
+	return newDateFunc(dateGreaterThan, key, values)
+}
+
+func newDateGreaterThanEqualsFunc(key Key, values ValueSet) (Function, error) {
+	return newDateFunc(dateGreaterThanEquals, key, values)
+}
+
+// NewDateEqualsFunc - returns new DateEquals function.
+func NewDateEqualsFunc(key Key, value time.Time) (Function, error) {
+	return &dateFunc{dateEquals, key, value}, nil
+}
+
+// NewDateNotEqualsFunc - returns new DateNotEquals function.
+func NewDateNotEqualsFunc(key Key, value time.Time) (Function, error) {
+	return &dateFunc{dateNotEquals, key, value}, nil
+}
+
+// NewDateLessThanFunc - returns new DateLessThan function.
+func NewDateLessThanFunc(key Key, value time.Time) (Function, error) {
+	return &dateFunc{dateLessThan, key, value}, nil
+}
+
+// NewDateLessThanEqualsFunc - returns new DateLessThanEquals function.
+func NewDateLessThanEqualsFunc(key Key, value time.Time) (Function, error) {
+	return &dateFunc{dateLessThanEquals, key, value}, nil
+}
+
+// NewDateGreaterThanFunc - returns new DateGreaterThan function.
+func NewDateGreaterThanFunc(key Key, value time.Time) (Function, error) {
+	return &dateFunc{dateGreaterThan, key, value}, nil
+}
+
+// NewDateGreaterThanEqualsFunc - returns new DateGreaterThanEquals function.
+func NewDateGreaterThanEqualsFunc(key Key, value time.Time) (Function, error) {
+	return &dateFunc{dateGreaterThanEquals, key, value}, nil
+}
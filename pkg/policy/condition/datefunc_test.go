@@ -0,0 +1,124 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package condition
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDateFuncEvaluate(t *testing.T) {
+	newFunc := func(n name, value Value) Function {
+		f, err := newDateFunc(n, AWSCurrentTime, NewValueSet(value))
+		if err != nil {
+			t.Fatalf("unexpected error. %v\n", err)
+		}
+		return f
+	}
+
+	date := NewStringValue("2019-10-01T00:00:00Z")
+	testCases := []struct {
+		function       Function
+		values         map[string][]string
+		expectedResult bool
+	}{
+		{newFunc(dateEquals, date), map[string][]string{"CurrentTime": {"2019-10-01T00:00:00.000Z"}}, true},
+		{newFunc(dateNotEquals, date), map[string][]string{"CurrentTime": {"2019-10-01T00:00:00.000Z"}}, false},
+		{newFunc(dateLessThan, date), map[string][]string{"CurrentTime": {"2019-09-30T23:59:59.999Z"}}, true},
+		{newFunc(dateLessThan, date), map[string][]string{"CurrentTime": {"2019-10-01T00:00:00Z"}}, false},
+		{newFunc(dateLessThanEquals, date), map[string][]string{"CurrentTime": {"2019-10-01T00:00:00Z"}}, true},
+		{newFunc(dateGreaterThan, date), map[string][]string{"CurrentTime": {"2019-10-01T02:00:00+01:00"}}, true},
+		{newFunc(dateGreaterThan, date), map[string][]string{"CurrentTime": {"2019-10-01T00:00:00Z"}}, false},
+		{newFunc(dateGreaterThanEquals, date), map[string][]string{"CurrentTime": {"2019-10-01T00:00:00Z"}}, true},
+		// Dates in seconds since epoch.
+		{newFunc(dateLessThan, NewIntValue(1569888000)), map[string][]string{"CurrentTime": {"1569887999"}}, true},
+		{newFunc(dateLessThan, NewStringValue("1569888000")), map[string][]string{"CurrentTime": {"2019-10-01T00:00:00Z"}}, false},
+		// Missing and invalid dates never match.
+		{newFunc(dateNotEquals, date), map[string][]string{}, false},
+		{newFunc(dateNotEquals, date), map[string][]string{"CurrentTime": {"yesterday"}}, false},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.function.evaluate(testCase.values)
+
+		if result != testCase.expectedResult {
+			t.Errorf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestDateFuncToMap(t *testing.T) {
+	case1Function, err := newDateLessThanFunc(AWSCurrentTime, NewValueSet(NewIntValue(1569888000)))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	case1Result := map[Key]ValueSet{
+		AWSCurrentTime: NewValueSet(NewStringValue("2019-10-01T00:00:00Z")),
+	}
+
+	testCases := []struct {
+		f              Function
+		expectedResult map[Key]ValueSet
+	}{
+		{case1Function, case1Result},
+		{&dateFunc{}, nil},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.f.toMap()
+
+		if !reflect.DeepEqual(result, testCase.expectedResult) {
+			t.Fatalf("case %v: result: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestNewDateFunc(t *testing.T) {
+	case1Function, err := NewDateGreaterThanFunc(AWSCurrentTime, time.Date(2019, time.October, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	testCases := []struct {
+		values         ValueSet
+		expectedResult Function
+		expectErr      bool
+	}{
+		{NewValueSet(NewStringValue("2019-10-01T00:00:00Z")), case1Function, false},
+		{NewValueSet(NewIntValue(1569888000)), case1Function, false},
+		{NewValueSet(NewStringValue("2019-10-01T00:00:00Z"), NewStringValue("2019-11-01T00:00:00Z")), nil, true},
+		{NewValueSet(NewStringValue("2019-10-01")), nil, true},
+		{NewValueSet(NewBoolValue(true)), nil, true},
+	}
+
+	for i, testCase := range testCases {
+		result, err := newDateGreaterThanFunc(AWSCurrentTime, testCase.values)
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v\n", i+1, testCase.expectErr, expectErr)
+		}
+
+		if !testCase.expectErr {
+			if result.String() != testCase.expectedResult.String() {
+				t.Fatalf("case %v: result: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+			}
+		}
+	}
+}
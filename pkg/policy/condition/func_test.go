@@ -268,9 +268,8 @@ func TestFunctionsUnmarshalJSON(t *testing.T) {
 
 	case3Data := []byte(`{}`)
 
-	// Remove this test after supporting date conditions.
 	case4Data := []byte(`{
-"DateEquals": { "aws:CurrentTime": "2013-06-30T00:00:00Z" }
+"StringEqualsIfExists": { "s3:prefix": "home/" }
 }`)
 
 	case5Data := []byte(`{
@@ -47,6 +47,10 @@ const (
 	// only.
 	S3XAmzStorageClass Key = "s3:x-amz-storage-class"
 
+	// S3ContentLength - key representing Content-Length HTTP header applicable to PutObject API
+	// only.
+	S3ContentLength Key = "s3:content-length"
+
 	// S3LocationConstraint - key representing LocationConstraint XML tag of CreateBucket API only.
 	S3LocationConstraint Key = "s3:LocationConstraint"
 
@@ -59,6 +63,10 @@ const (
 	// S3MaxKeys - key representing max-keys query parameter of ListBucket API only.
 	S3MaxKeys Key = "s3:max-keys"
 
+	// S3ExistingObjectTag - key representing a tag of an existing object, used as
+	// "s3:ExistingObjectTag/<tag-key>" in object APIs only.
+	S3ExistingObjectTag Key = "s3:ExistingObjectTag"
+
 	// AWSReferer - key representing Referer header of any API.
 	AWSReferer Key = "aws:Referer"
 
@@ -94,10 +102,12 @@ var AllSupportedKeys = []Key{
 	S3XAmzServerSideEncryptionCustomerAlgorithm,
 	S3XAmzMetadataDirective,
 	S3XAmzStorageClass,
+	S3ContentLength,
 	S3LocationConstraint,
 	S3Prefix,
 	S3Delimiter,
 	S3MaxKeys,
+	S3ExistingObjectTag,
 	AWSReferer,
 	AWSSourceIP,
 	AWSUserAgent,
@@ -123,20 +133,60 @@ var CommonKeys = []Key{
 	AWSUsername,
 }
 
+// ExistingObjectTagKey - returns the key representing the given tag of an existing object.
+func ExistingObjectTagKey(tagKey string) Key {
+	return Key(fmt.Sprintf("%s/%s", S3ExistingObjectTag, tagKey))
+}
+
+// generic - returns S3ExistingObjectTag for keys of a specific tag, the key itself otherwise.
+func (key Key) generic() Key {
+	if strings.HasPrefix(string(key), string(S3ExistingObjectTag)+"/") {
+		return S3ExistingObjectTag
+	}
+	return key
+}
+
+// JWTPrefix - prefix of value names holding claims of temporary credentials,
+// usable as policy variables such as "${jwt:sub}".
+const JWTPrefix = "jwt:"
+
+// SubstituteVars - replaces policy variables in given string, such as
+// "${aws:username}" or "${jwt:sub}", by their values in given values map.
+// Variables without value are left as is.
+func SubstituteVars(s string, values map[string][]string) string {
+	if !strings.Contains(s, "${") {
+		return s
+	}
+
+	for _, key := range CommonKeys {
+		// Empty values are not supported for policy variables.
+		if rvalues, ok := values[key.Name()]; ok && len(rvalues) > 0 && rvalues[0] != "" {
+			s = strings.Replace(s, key.VarName(), rvalues[0], -1)
+		}
+	}
+
+	for name, rvalues := range values {
+		if strings.HasPrefix(name, JWTPrefix) && len(rvalues) > 0 && rvalues[0] != "" {
+			s = strings.Replace(s, "${"+name+"}", rvalues[0], -1)
+		}
+	}
+
+	return s
+}
+
 func substFuncFromValues(values map[string][]string) func(string) string {
 	return func(v string) string {
-		for _, key := range CommonKeys {
-			// Empty values are not supported for policy variables.
-			if rvalues, ok := values[key.Name()]; ok && rvalues[0] != "" {
-				v = strings.Replace(v, key.VarName(), rvalues[0], -1)
-			}
-		}
-		return v
+		return SubstituteVars(v, values)
 	}
 }
 
 // IsValid - checks if key is valid or not.
 func (key Key) IsValid() bool {
+	// Tag keys are arbitrary, "s3:ExistingObjectTag" alone is not a valid key.
+	if key.generic() == S3ExistingObjectTag {
+		return len(key) > len(S3ExistingObjectTag)+1
+	}
+
 	for _, supKey := range AllSupportedKeys {
 		if supKey == key {
 			return true
@@ -205,7 +255,9 @@ func (set KeySet) Add(key Key) {
 	set[key] = struct{}{}
 }
 
-// Difference - returns a key set contains difference of two keys.
+// Difference - returns a key set contains difference of two keys. Keys of a
+// specific tag, such as "s3:ExistingObjectTag/env", are contained in a key set
+// having "s3:ExistingObjectTag".
 // Example:
 //     keySet1 := ["one", "two", "three"]
 //     keySet2 := ["two", "four", "three"]
@@ -214,9 +266,13 @@ func (set KeySet) Difference(sset KeySet) KeySet {
 	nset := make(KeySet)
 
 	for k := range set {
-		if _, ok := sset[k]; !ok {
-			nset.Add(k)
+		if _, ok := sset[k]; ok {
+			continue
+		}
+		if _, ok := sset[k.generic()]; ok {
+			continue
 		}
+		nset.Add(k)
 	}
 
 	return nset
@@ -38,6 +38,9 @@ func TestKeyIsValid(t *testing.T) {
 		{S3MaxKeys, true},
 		{AWSReferer, true},
 		{AWSSourceIP, true},
+		{ExistingObjectTagKey("env"), true},
+		{S3ExistingObjectTag, false},
+		{ExistingObjectTagKey(""), false},
 		{Key("foo"), false},
 	}
 
@@ -94,6 +97,34 @@ func TestKeyName(t *testing.T) {
 	}
 }
 
+func TestSubstituteVars(t *testing.T) {
+	values := map[string][]string{
+		"username": {"alice"},
+		"Referer":  {""},
+		"jwt:sub":  {"user-id"},
+		"jwt:aud":  {},
+	}
+
+	testCases := []struct {
+		s              string
+		expectedResult string
+	}{
+		{"mybucket/home/${aws:username}/*", "mybucket/home/alice/*"},
+		{"mybucket/${jwt:sub}/${aws:username}", "mybucket/user-id/alice"},
+		// Variables without value are left as is.
+		{"mybucket/${aws:Referer}/${jwt:aud}/${jwt:iss}", "mybucket/${aws:Referer}/${jwt:aud}/${jwt:iss}"},
+		{"mybucket/myobject", "mybucket/myobject"},
+	}
+
+	for i, testCase := range testCases {
+		result := SubstituteVars(testCase.s, values)
+
+		if testCase.expectedResult != result {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
 func TestKeyUnmarshalJSON(t *testing.T) {
 	testCases := []struct {
 		data        []byte
@@ -148,6 +179,8 @@ func TestKeySetDifference(t *testing.T) {
 	}{
 		{NewKeySet(), NewKeySet(S3XAmzCopySource), NewKeySet()},
 		{NewKeySet(S3Prefix, S3Delimiter, S3MaxKeys), NewKeySet(S3Delimiter, S3MaxKeys), NewKeySet(S3Prefix)},
+		{NewKeySet(ExistingObjectTagKey("env"), S3Prefix), NewKeySet(S3ExistingObjectTag), NewKeySet(S3Prefix)},
+		{NewKeySet(ExistingObjectTagKey("env")), NewKeySet(S3Prefix), NewKeySet(ExistingObjectTagKey("env"))},
 	}
 
 	for i, testCase := range testCases {
@@ -19,6 +19,7 @@ package condition
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 type name string
@@ -35,6 +36,25 @@ const (
 	notIPAddress                   = "NotIpAddress"
 	null                           = "Null"
 	boolean                        = "Bool"
+	numericEquals                  = "NumericEquals"
+	numericNotEquals               = "NumericNotEquals"
+	numericLessThan                = "NumericLessThan"
+	numericLessThanEquals          = "NumericLessThanEquals"
+	numericGreaterThan             = "NumericGreaterThan"
+	numericGreaterThanEquals       = "NumericGreaterThanEquals"
+	dateEquals                     = "DateEquals"
+	dateNotEquals                  = "DateNotEquals"
+	dateLessThan                   = "DateLessThan"
+	dateLessThanEquals             = "DateLessThanEquals"
+	dateGreaterThan                = "DateGreaterThan"
+	dateGreaterThanEquals          = "DateGreaterThanEquals"
+)
+
+// Set qualifiers of condition names, such as "ForAnyValue:StringEquals",
+// for keys having multiple values in a request.
+const (
+	forAllValues = "ForAllValues"
+	forAnyValue  = "ForAnyValue"
 )
 
 var supportedConditions = []name{
@@ -49,13 +69,53 @@ var supportedConditions = []name{
 	notIPAddress,
 	null,
 	boolean,
+	numericEquals,
+	numericNotEquals,
+	numericLessThan,
+	numericLessThanEquals,
+	numericGreaterThan,
+	numericGreaterThanEquals,
+	dateEquals,
+	dateNotEquals,
+	dateLessThan,
+	dateLessThanEquals,
+	dateGreaterThan,
+	dateGreaterThanEquals,
 	// Add new conditions here.
 }
 
+// qualifier - returns the set qualifier of name, if any.
+func (n name) qualifier() string {
+	if i := strings.Index(string(n), ":"); i >= 0 {
+		return string(n)[:i]
+	}
+
+	return ""
+}
+
+// base - returns name without its set qualifier.
+func (n name) base() name {
+	if i := strings.Index(string(n), ":"); i >= 0 {
+		return n[i+1:]
+	}
+
+	return n
+}
+
 // IsValid - checks if name is valid or not.
 func (n name) IsValid() bool {
+	switch n.qualifier() {
+	case "":
+		if n.base() != n {
+			return false
+		}
+	case forAllValues, forAnyValue:
+	default:
+		return false
+	}
+
 	for _, supn := range supportedConditions {
-		if n == supn {
+		if n.base() == supn {
 			return true
 		}
 	}
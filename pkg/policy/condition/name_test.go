@@ -34,6 +34,14 @@ func TestNameIsValid(t *testing.T) {
 		{ipAddress, true},
 		{notIPAddress, true},
 		{null, true},
+		{numericLessThan, true},
+		{dateGreaterThan, true},
+		{name("ForAnyValue:StringEquals"), true},
+		{name("ForAllValues:NumericLessThan"), true},
+		{name("ForAnyValue:foo"), false},
+		{name("ForEachValue:StringEquals"), false},
+		{name("ForAnyValue:ForAllValues:StringEquals"), false},
+		{name(":StringEquals"), false},
 		{name("foo"), false},
 	}
 
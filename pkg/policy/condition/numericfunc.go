@@ -0,0 +1,175 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package condition
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+)
+
+// numericFunc - Numeric condition functions. They compare the integer value
+// by Key in given values map with the condition value.
+// For example,
+//   - if n = NumericLessThanEquals, Key = S3MaxKeys and value = 100, at
+//     evaluate() it returns whether max-keys in value map is at most 100.
+// https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_policies_elements_condition_operators.html#Conditions_Numeric
+type numericFunc struct {
+	n     name
+	k     Key
+	value int
+}
+
+// evaluate() - evaluates to check whether value by Key in given values
+// compares with the condition value. Missing or non-integer values never
+// match.
+func (f numericFunc) evaluate(values map[string][]string) bool {
+	requestValue, ok := values[http.CanonicalHeaderKey(f.k.Name())]
+	if !ok {
+		requestValue = values[f.k.Name()]
+	}
+
+	if len(requestValue) == 0 {
+		return false
+	}
+
+	rvInt, err := strconv.Atoi(requestValue[0])
+	if err != nil {
+		return false
+	}
+
+	switch f.n {
+	case numericEquals:
+		return rvInt == f.value
+	case numericNotEquals:
+		return rvInt != f.value
+	case numericLessThan:
+		return rvInt < f.value
+	case numericLessThanEquals:
+		return rvInt <= f.value
+	case numericGreaterThan:
+		return rvInt > f.value
+	case numericGreaterThanEquals:
+		return rvInt >= f.value
+	}
+
+	return false
+}
+
+// key() - returns condition key which is used by this condition function.
+func (f numericFunc) key() Key {
+	return f.k
+}
+
+// name() - returns condition name of this function, such as "NumericLessThan".
+func (f numericFunc) name() name {
+	return f.n
+}
+
+func (f numericFunc) String() string {
+	return fmt.Sprintf("%v:%v:%v", f.n, f.k, f.value)
+}
+
+// toMap - returns map representation of this function.
+func (f numericFunc) toMap() map[Key]ValueSet {
+	if !f.k.IsValid() {
+		return nil
+	}
+
+	return map[Key]ValueSet{
+		f.k: NewValueSet(NewIntValue(f.value)),
+	}
+}
+
+func newNumericFunc(n name, key Key, values ValueSet) (Function, error) {
+	if len(values) != 1 {
+		return nil, fmt.Errorf("only one value is allowed for %v condition", n)
+	}
+
+	var value int
+	for v := range values {
+		switch v.GetType() {
+		case reflect.Int:
+			value, _ = v.GetInt()
+		case reflect.String:
+			var err error
+			s, _ := v.GetString()
+			if value, err = strconv.Atoi(s); err != nil {
+				return nil, fmt.Errorf("value must be an integer string for %v condition", n)
+			}
+		default:
+			return nil, fmt.Errorf("value must be an integer for %v condition", n)
+		}
+	}
+
+	return &numericFunc{n, key, value}, nil
+}
+
+func newNumericEqualsFunc(key Key, values ValueSet) (Function, error) {
+	return newNumericFunc(numericEquals, key, values)
+}
+
+func newNumericNotEqualsFunc(key Key, values ValueSet) (Function, error) {
+	return newNumericFunc(numericNotEquals, key, values)
+}
+
+func newNumericLessThanFunc(key Key, values ValueSet) (Function, error) {
+	return newNumericFunc(numericLessThan, key, values)
+}
+
+func newNumericLessThanEqualsFunc(key Key, values ValueSet) (Function, error) {
+	return newNumericFunc(numericLessThanEquals, key, values)
+}
+
+func newNumericGreaterThanFunc(key Key, values ValueSet) (Function, error) {
+	return newNumericFunc(numericGreaterThan, key, values)
+}
+
+func newNumericGreaterThanEqualsFunc(key Key, values ValueSet) (Function, error) {
+	return newNumericFunc(numericGreaterThanEquals, key, values)
+}
+
+// NewNumericEqualsFunc - returns new NumericEquals function.
+func NewNumericEqualsFunc(key Key, value int) (Function, error) {
+	return &numericFunc{numericEquals, key, value}, nil
+}
+
+// NewNumericNotEqualsFunc - returns new NumericNotEquals function.
+func NewNumericNotEqualsFunc(key Key, value int) (Function, error) {
+	return &numericFunc{numericNotEquals, key, value}, nil
+}
+
+// NewNumericLessThanFunc - returns new NumericLessThan function.
+func NewNumericLessThanFunc(key Key, value int) (Function, error) {
+	return &numericFunc{numericLessThan, key, value}, nil
+}
+
+// NewNumericLessThanEqualsFunc - returns new NumericLessThanEquals function.
+func NewNumericLessThanEqualsFunc(key Key, value int) (Function, error) {
+	return &numericFunc{numericLessThanEquals, key, value}, nil
+}
+
+// NewNumericGreaterThanFunc - returns new NumericGreaterThan function.
+func NewNumericGreaterThanFunc(key Key, value int) (Function, error) {
+	return &numericFunc{numericGreaterThan, key, value}, nil
+}
+
+// NewNumericGreaterThanEqualsFunc - returns new NumericGreaterThanEquals function.
+func NewNumericGreaterThanEqualsFunc(key Key, value int) (Function, error) {
+	return &numericFunc{numericGreaterThanEquals, key, value}, nil
+}
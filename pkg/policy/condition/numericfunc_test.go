@@ -0,0 +1,122 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package condition
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNumericFuncEvaluate(t *testing.T) {
+	newFunc := func(n name, value Value) Function {
+		f, err := newNumericFunc(n, S3MaxKeys, NewValueSet(value))
+		if err != nil {
+			t.Fatalf("unexpected error. %v\n", err)
+		}
+		return f
+	}
+
+	testCases := []struct {
+		function       Function
+		values         map[string][]string
+		expectedResult bool
+	}{
+		{newFunc(numericEquals, NewIntValue(100)), map[string][]string{"max-keys": {"100"}}, true},
+		{newFunc(numericEquals, NewIntValue(100)), map[string][]string{"max-keys": {"99"}}, false},
+		{newFunc(numericNotEquals, NewIntValue(100)), map[string][]string{"max-keys": {"99"}}, true},
+		{newFunc(numericLessThan, NewIntValue(100)), map[string][]string{"max-keys": {"99"}}, true},
+		{newFunc(numericLessThan, NewIntValue(100)), map[string][]string{"max-keys": {"100"}}, false},
+		{newFunc(numericLessThanEquals, NewStringValue("100")), map[string][]string{"max-keys": {"100"}}, true},
+		{newFunc(numericLessThanEquals, NewStringValue("100")), map[string][]string{"max-keys": {"1000"}}, false},
+		{newFunc(numericGreaterThan, NewIntValue(100)), map[string][]string{"max-keys": {"101"}}, true},
+		{newFunc(numericGreaterThan, NewIntValue(100)), map[string][]string{"max-keys": {"100"}}, false},
+		{newFunc(numericGreaterThanEquals, NewIntValue(100)), map[string][]string{"max-keys": {"100"}}, true},
+		{newFunc(numericGreaterThanEquals, NewIntValue(100)), map[string][]string{"max-keys": {"-1"}}, false},
+		// Missing and non-integer values never match.
+		{newFunc(numericNotEquals, NewIntValue(100)), map[string][]string{}, false},
+		{newFunc(numericNotEquals, NewIntValue(100)), map[string][]string{"max-keys": {"ten"}}, false},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.function.evaluate(testCase.values)
+
+		if result != testCase.expectedResult {
+			t.Errorf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestNumericFuncToMap(t *testing.T) {
+	case1Function, err := newNumericLessThanFunc(S3MaxKeys, NewValueSet(NewStringValue("100")))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	case1Result := map[Key]ValueSet{
+		S3MaxKeys: NewValueSet(NewIntValue(100)),
+	}
+
+	testCases := []struct {
+		f              Function
+		expectedResult map[Key]ValueSet
+	}{
+		{case1Function, case1Result},
+		{&numericFunc{}, nil},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.f.toMap()
+
+		if !reflect.DeepEqual(result, testCase.expectedResult) {
+			t.Fatalf("case %v: result: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestNewNumericFunc(t *testing.T) {
+	case1Function, err := NewNumericGreaterThanEqualsFunc(S3MaxKeys, 10)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	testCases := []struct {
+		values         ValueSet
+		expectedResult Function
+		expectErr      bool
+	}{
+		{NewValueSet(NewIntValue(10)), case1Function, false},
+		{NewValueSet(NewStringValue("10")), case1Function, false},
+		{NewValueSet(NewIntValue(10), NewIntValue(20)), nil, true},
+		{NewValueSet(NewStringValue("ten")), nil, true},
+		{NewValueSet(NewBoolValue(true)), nil, true},
+	}
+
+	for i, testCase := range testCases {
+		result, err := newNumericGreaterThanEqualsFunc(S3MaxKeys, testCase.values)
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v\n", i+1, testCase.expectErr, expectErr)
+		}
+
+		if !testCase.expectErr {
+			if !reflect.DeepEqual(result, testCase.expectedResult) {
+				t.Fatalf("case %v: result: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+			}
+		}
+	}
+}
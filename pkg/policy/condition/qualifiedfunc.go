@@ -0,0 +1,86 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package condition
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// qualifiedFunc - condition function with a set qualifier. It evaluates the
+// wrapped function against each value by Key in given values map.
+// For example,
+//   1. if qualifier = ForAnyValue, at evaluate() it returns whether at least one
+//      value by Key satisfies the wrapped function.
+//   2. if qualifier = ForAllValues, at evaluate() it returns whether every value
+//      by Key satisfies the wrapped function, which is true if Key has no values.
+// https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_policies_multi-value-conditions.html
+type qualifiedFunc struct {
+	Function
+	qualifier string
+}
+
+// evaluate() - evaluates the wrapped function against each value by Key in
+// given values.
+func (f qualifiedFunc) evaluate(values map[string][]string) bool {
+	canonicalName := http.CanonicalHeaderKey(f.key().Name())
+	requestValue, ok := values[canonicalName]
+	if !ok {
+		requestValue = values[f.key().Name()]
+	}
+
+	// Values map with the single value to evaluate the wrapped function against.
+	singleValues := make(map[string][]string, len(values))
+	for k, v := range values {
+		singleValues[k] = v
+	}
+	delete(singleValues, canonicalName)
+
+	for _, v := range requestValue {
+		singleValues[f.key().Name()] = []string{v}
+		matched := f.Function.evaluate(singleValues)
+		if f.qualifier == forAnyValue && matched {
+			return true
+		}
+		if f.qualifier == forAllValues && !matched {
+			return false
+		}
+	}
+
+	return f.qualifier == forAllValues
+}
+
+// name() - returns qualified condition name, such as "ForAnyValue:StringEquals".
+func (f qualifiedFunc) name() name {
+	return name(f.qualifier + ":" + string(f.Function.name()))
+}
+
+func (f qualifiedFunc) String() string {
+	return fmt.Sprintf("%v:%v", f.qualifier, f.Function)
+}
+
+// NewForAllValuesFunc - returns new function evaluating given function
+// against every value of its key.
+func NewForAllValuesFunc(function Function) Function {
+	return &qualifiedFunc{function, forAllValues}
+}
+
+// NewForAnyValueFunc - returns new function evaluating given function
+// against any value of its key.
+func NewForAnyValueFunc(function Function) Function {
+	return &qualifiedFunc{function, forAnyValue}
+}
@@ -0,0 +1,83 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package condition
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestQualifiedFuncEvaluate(t *testing.T) {
+	stringEqualsFunction, err := newStringEqualsFunc(S3Prefix, NewValueSet(NewStringValue("home/"), NewStringValue("public/")))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	stringNotEqualsFunction, err := newStringNotEqualsFunc(S3Prefix, NewValueSet(NewStringValue("private/")))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	testCases := []struct {
+		function       Function
+		values         map[string][]string
+		expectedResult bool
+	}{
+		{NewForAnyValueFunc(stringEqualsFunction), map[string][]string{"prefix": {"home/", "private/"}}, true},
+		{NewForAnyValueFunc(stringEqualsFunction), map[string][]string{"prefix": {"private/"}}, false},
+		{NewForAnyValueFunc(stringEqualsFunction), map[string][]string{}, false},
+		{NewForAllValuesFunc(stringEqualsFunction), map[string][]string{"prefix": {"home/", "public/"}}, true},
+		{NewForAllValuesFunc(stringEqualsFunction), map[string][]string{"prefix": {"home/", "private/"}}, false},
+		{NewForAllValuesFunc(stringEqualsFunction), map[string][]string{}, true},
+		// Without qualifier, StringNotEquals only matches if no value is in the condition values.
+		{stringNotEqualsFunction, map[string][]string{"prefix": {"home/", "private/"}}, false},
+		{NewForAnyValueFunc(stringNotEqualsFunction), map[string][]string{"prefix": {"home/", "private/"}}, true},
+		{NewForAllValuesFunc(stringNotEqualsFunction), map[string][]string{"prefix": {"home/", "private/"}}, false},
+		// Values are looked up by canonical header name first.
+		{NewForAllValuesFunc(stringEqualsFunction), map[string][]string{"Prefix": {"home/"}, "prefix": {"private/"}}, true},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.function.evaluate(testCase.values)
+
+		if result != testCase.expectedResult {
+			t.Errorf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestQualifiedFuncJSON(t *testing.T) {
+	data := []byte(`{"DateLessThan":{"aws:CurrentTime":["2019-10-01T00:00:00Z"]},"ForAllValues:StringLike":{"s3:prefix":["home/*"]},"ForAnyValue:NumericLessThan":{"s3:max-keys":[100]}}`)
+
+	var functions Functions
+	if err := json.Unmarshal(data, &functions); err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	result, err := json.Marshal(functions)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	if string(result) != string(data) {
+		t.Fatalf("expected: %s, got: %s\n", data, result)
+	}
+
+	if !functions.Evaluate(map[string][]string{"CurrentTime": {"2019-09-01T00:00:00Z"}, "prefix": {"home/a", "home/b"}, "max-keys": {"10"}}) {
+		t.Fatalf("expected functions to match\n")
+	}
+}
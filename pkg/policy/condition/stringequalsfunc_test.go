@@ -42,6 +42,11 @@ func TestStringEqualsFuncEvaluate(t *testing.T) {
 		t.Fatalf("unexpected error. %v\n", err)
 	}
 
+	case5Function, err := newStringEqualsFunc(ExistingObjectTagKey("env"), NewValueSet(NewStringValue("prod")))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
 	testCases := []struct {
 		function       Function
 		values         map[string][]string
@@ -65,6 +70,11 @@ func TestStringEqualsFuncEvaluate(t *testing.T) {
 		{case4Function, map[string][]string{"LocationConstraint": {"us-east-1"}}, false},
 		{case4Function, map[string][]string{}, false},
 		{case4Function, map[string][]string{"delimiter": {"/"}}, false},
+
+		{case5Function, map[string][]string{"ExistingObjectTag/env": {"prod"}}, true},
+		{case5Function, map[string][]string{"ExistingObjectTag/env": {"dev"}}, false},
+		{case5Function, map[string][]string{"ExistingObjectTag/team": {"prod"}}, false},
+		{case5Function, map[string][]string{}, false},
 	}
 
 	for i, testCase := range testCases {
@@ -89,6 +89,15 @@ func (policy Policy) isValid() error {
 
 	for i := range policy.Statements {
 		for _, statement := range policy.Statements[i+1:] {
+			// Statements having NotPrincipal, NotAction or NotResource
+			// are duplicates only when they are equal.
+			if policy.Statements[i].hasNotElements() || statement.hasNotElements() {
+				if policy.Statements[i].Equals(statement) {
+					return fmt.Errorf("duplicate statements %v, %v found", policy.Statements[i], statement)
+				}
+				continue
+			}
+
 			principals := policy.Statements[i].Principal.Intersection(statement.Principal)
 			if principals.IsEmpty() {
 				continue
@@ -17,6 +17,7 @@
 package policy
 
 import (
+	"bytes"
 	"encoding/json"
 	"net"
 	"reflect"
@@ -1087,3 +1088,69 @@ func TestPolicyValidate(t *testing.T) {
 		}
 	}
 }
+
+func TestPolicyNumericAndDateConditions(t *testing.T) {
+	data := []byte(`{
+    "Version": "2012-10-17",
+    "Statement": [
+        {
+            "Effect": "Deny",
+            "Principal": "*",
+            "Action": ["s3:PutObject"],
+            "Resource": ["arn:aws:s3:::mybucket/*"],
+            "Condition": {"NumericGreaterThan": {"s3:content-length": 1048576}}
+        },
+        {
+            "Effect": "Allow",
+            "Principal": "*",
+            "Action": ["s3:PutObject"],
+            "Resource": ["arn:aws:s3:::mybucket/*"],
+            "Condition": {"DateGreaterThanEquals": {"aws:CurrentTime": "2019-10-01T00:00:00Z"}}
+        }
+    ]
+}`)
+
+	p, err := ParseConfig(bytes.NewReader(data), "mybucket")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	// Policy is preserved across JSON round-trips.
+	encoded, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	decoded, err := ParseConfig(bytes.NewReader(encoded), "mybucket")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	reencoded, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	if string(reencoded) != string(encoded) {
+		t.Fatalf("expected: %s, got: %s\n", encoded, reencoded)
+	}
+
+	testCases := []struct {
+		conditionValues map[string][]string
+		expectedResult  bool
+	}{
+		{map[string][]string{"Content-Length": {"1048576"}, "CurrentTime": {"2019-10-01T00:00:00.000Z"}}, true},
+		{map[string][]string{"Content-Length": {"1048577"}, "CurrentTime": {"2019-10-01T00:00:00.000Z"}}, false},
+		{map[string][]string{"Content-Length": {"1024"}, "CurrentTime": {"2019-09-30T23:59:59.000Z"}}, false},
+	}
+
+	for i, testCase := range testCases {
+		result := p.IsAllowed(Args{
+			Action:          PutObjectAction,
+			BucketName:      "mybucket",
+			ConditionValues: testCase.conditionValues,
+			ObjectName:      "myobject",
+		})
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+}
@@ -34,6 +34,11 @@ func (p Principal) IsValid() bool {
 	return len(p.AWS) != 0
 }
 
+// Equals - checks whether given principal is equal to current principal.
+func (p Principal) Equals(pp Principal) bool {
+	return p.AWS.Equals(pp.AWS)
+}
+
 // Intersection - returns principals available in both Principal.
 func (p Principal) Intersection(principal Principal) set.StringSet {
 	return p.AWS.Intersection(principal.AWS)
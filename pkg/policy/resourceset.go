@@ -54,6 +54,21 @@ func (resourceSet ResourceSet) Add(resource Resource) {
 	resourceSet[resource] = struct{}{}
 }
 
+// Equals - checks whether given resource set is equal to current resource set.
+func (resourceSet ResourceSet) Equals(sset ResourceSet) bool {
+	if len(resourceSet) != len(sset) {
+		return false
+	}
+
+	for k := range resourceSet {
+		if _, ok := sset[k]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Intersection - returns resouces available in both ResourcsSet.
 func (resourceSet ResourceSet) Intersection(sset ResourceSet) ResourceSet {
 	nset := NewResourceSet()
@@ -26,22 +26,61 @@ import (
 
 // Statement - policy statement.
 type Statement struct {
-	SID        ID                  `json:"Sid,omitempty"`
-	Effect     Effect              `json:"Effect"`
-	Principal  Principal           `json:"Principal"`
-	Actions    ActionSet           `json:"Action"`
-	Resources  ResourceSet         `json:"Resource"`
-	Conditions condition.Functions `json:"Condition,omitempty"`
+	SID          ID                  `json:"Sid,omitempty"`
+	Effect       Effect              `json:"Effect"`
+	Principal    Principal           `json:"Principal"`
+	NotPrincipal Principal           `json:"NotPrincipal"`
+	Actions      ActionSet           `json:"Action,omitempty"`
+	NotActions   ActionSet           `json:"NotAction,omitempty"`
+	Resources    ResourceSet         `json:"Resource,omitempty"`
+	NotResources ResourceSet         `json:"NotResource,omitempty"`
+	Conditions   condition.Functions `json:"Condition,omitempty"`
+}
+
+// matchPrincipal - checks whether given principal is covered by Principal,
+// or is not covered by NotPrincipal.
+func (statement Statement) matchPrincipal(principal string) bool {
+	if statement.NotPrincipal.IsValid() {
+		return !statement.NotPrincipal.Match(principal)
+	}
+
+	return statement.Principal.Match(principal)
+}
+
+// matchAction - checks whether given action is in Action, or is not in
+// NotAction.
+func (statement Statement) matchAction(action Action) bool {
+	if len(statement.NotActions) != 0 {
+		return !statement.NotActions.Contains(action)
+	}
+
+	return statement.Actions.Contains(action)
+}
+
+// matchResource - checks whether given resource matches Resource, or does
+// not match NotResource.
+func (statement Statement) matchResource(resource string, conditionValues map[string][]string) bool {
+	if len(statement.NotResources) != 0 {
+		return !statement.NotResources.Match(resource, conditionValues)
+	}
+
+	return statement.Resources.Match(resource, conditionValues)
+}
+
+// hasNotElements - checks whether statement has NotPrincipal, NotAction or
+// NotResource.
+func (statement Statement) hasNotElements() bool {
+	return statement.NotPrincipal.IsValid() || len(statement.NotActions) != 0 || len(statement.NotResources) != 0
 }
 
 // IsAllowed - checks given policy args is allowed to continue the Rest API.
 func (statement Statement) IsAllowed(args Args) bool {
 	check := func() bool {
-		if !statement.Principal.Match(args.AccountName) {
+		if !statement.matchPrincipal(args.AccountName) {
 			return false
 		}
 
-		if !statement.Actions.Contains(args.Action) {
+		if !statement.matchAction(args.Action) {
 			return false
 		}
 
@@ -54,7 +93,7 @@ func (statement Statement) IsAllowed(args Args) bool {
 			resource += args.ObjectName
 		}
 
-		if !statement.Resources.Match(resource, args.ConditionValues) {
+		if !statement.matchResource(resource, args.ConditionValues) {
 			return false
 		}
 
@@ -64,32 +103,60 @@ func (statement Statement) IsAllowed(args Args) bool {
 	return statement.Effect.IsAllowed(check())
 }
 
+// Equals - checks whether given statement is equal to current statement,
+// regardless of their Sid.
+func (statement Statement) Equals(st Statement) bool {
+	return statement.Effect == st.Effect &&
+		statement.Principal.Equals(st.Principal) &&
+		statement.NotPrincipal.Equals(st.NotPrincipal) &&
+		statement.Actions.Equals(st.Actions) &&
+		statement.NotActions.Equals(st.NotActions) &&
+		statement.Resources.Equals(st.Resources) &&
+		statement.NotResources.Equals(st.NotResources) &&
+		statement.Conditions.String() == st.Conditions.String()
+}
+
 // isValid - checks whether statement is valid or not.
 func (statement Statement) isValid() error {
 	if !statement.Effect.IsValid() {
 		return fmt.Errorf("invalid Effect %v", statement.Effect)
 	}
 
-	if !statement.Principal.IsValid() {
+	if statement.Principal.IsValid() == statement.NotPrincipal.IsValid() {
+		if statement.Principal.IsValid() {
+			return fmt.Errorf("only one of Principal and NotPrincipal is allowed")
+		}
 		return fmt.Errorf("invalid Principal %v", statement.Principal)
 	}
 
-	if len(statement.Actions) == 0 {
+	if len(statement.Actions) != 0 && len(statement.NotActions) != 0 {
+		return fmt.Errorf("only one of Action and NotAction is allowed")
+	}
+
+	if len(statement.Actions) == 0 && len(statement.NotActions) == 0 {
 		return fmt.Errorf("Action must not be empty")
 	}
 
-	if len(statement.Resources) == 0 {
+	if len(statement.Resources) != 0 && len(statement.NotResources) != 0 {
+		return fmt.Errorf("only one of Resource and NotResource is allowed")
+	}
+
+	if len(statement.Resources) == 0 && len(statement.NotResources) == 0 {
 		return fmt.Errorf("Resource must not be empty")
 	}
 
+	// Actions and resources excluded by NotAction and NotResource are not
+	// known ahead, hence only listed actions and resources are checked.
 	for action := range statement.Actions {
-		if action.isObjectAction() {
-			if !statement.Resources.objectResourceExists() {
-				return fmt.Errorf("unsupported Resource found %v for action %v", statement.Resources, action)
-			}
-		} else {
-			if !statement.Resources.bucketResourceExists() {
-				return fmt.Errorf("unsupported Resource found %v for action %v", statement.Resources, action)
+		if len(statement.Resources) != 0 {
+			if action.isObjectAction() {
+				if !statement.Resources.objectResourceExists() {
+					return fmt.Errorf("unsupported Resource found %v for action %v", statement.Resources, action)
+				}
+			} else {
+				if !statement.Resources.bucketResourceExists() {
+					return fmt.Errorf("unsupported Resource found %v for action %v", statement.Resources, action)
+				}
 			}
 		}
 
@@ -109,9 +176,32 @@ func (statement Statement) MarshalJSON() ([]byte, error) {
 		return nil, err
 	}
 
-	// subtype to avoid recursive call to MarshalJSON()
-	type subStatement Statement
-	ss := subStatement(statement)
+	// Only one of Principal and NotPrincipal is encoded.
+	type subStatement struct {
+		SID          ID                  `json:"Sid,omitempty"`
+		Effect       Effect              `json:"Effect"`
+		Principal    *Principal          `json:"Principal,omitempty"`
+		NotPrincipal *Principal          `json:"NotPrincipal,omitempty"`
+		Actions      ActionSet           `json:"Action,omitempty"`
+		NotActions   ActionSet           `json:"NotAction,omitempty"`
+		Resources    ResourceSet         `json:"Resource,omitempty"`
+		NotResources ResourceSet         `json:"NotResource,omitempty"`
+		Conditions   condition.Functions `json:"Condition,omitempty"`
+	}
+	ss := subStatement{
+		SID:          statement.SID,
+		Effect:       statement.Effect,
+		Actions:      statement.Actions,
+		NotActions:   statement.NotActions,
+		Resources:    statement.Resources,
+		NotResources: statement.NotResources,
+		Conditions:   statement.Conditions,
+	}
+	if statement.NotPrincipal.IsValid() {
+		ss.NotPrincipal = &statement.NotPrincipal
+	} else {
+		ss.Principal = &statement.Principal
+	}
 	return json.Marshal(ss)
 }
 
@@ -141,7 +231,11 @@ func (statement Statement) Validate(bucketName string) error {
 		return err
 	}
 
-	return statement.Resources.Validate(bucketName)
+	if err := statement.Resources.Validate(bucketName); err != nil {
+		return err
+	}
+
+	return statement.NotResources.Validate(bucketName)
 }
 
 // NewStatement - creates new statement.
@@ -0,0 +1,83 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replication
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// Prefix of destination buckets given as an ARN.
+const bucketARNPrefix = "arn:aws:s3:::"
+
+// ErrMissingDestinationBucket - destination has no bucket.
+var ErrMissingDestinationBucket = errors.New("destination bucket must be specified")
+
+// ErrInvalidEndpoint - destination endpoint is not an http or https URL.
+var ErrInvalidEndpoint = errors.New("destination endpoint must be an http or https URL without path")
+
+// ErrMissingCredentials - destination has no access key or secret key.
+var ErrMissingCredentials = errors.New("destination access key and secret key must be specified")
+
+// Destination - remote bucket the objects are replicated to, along with the
+// endpoint and the credentials of the remote S3 service.
+type Destination struct {
+	Bucket       string `xml:"Bucket"`
+	StorageClass string `xml:"StorageClass,omitempty"`
+	Endpoint     string `xml:"Endpoint"`
+	AccessKey    string `xml:"AccessKey"`
+	SecretKey    string `xml:"SecretKey,omitempty"`
+}
+
+// Validate - validates the destination.
+func (d Destination) Validate() error {
+	if d.BucketName() == "" {
+		return ErrMissingDestinationBucket
+	}
+
+	u, err := url.Parse(d.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || strings.Trim(u.Path, "/") != "" {
+		return ErrInvalidEndpoint
+	}
+
+	if d.AccessKey == "" || d.SecretKey == "" {
+		return ErrMissingCredentials
+	}
+
+	return nil
+}
+
+// BucketName - returns the name of the destination bucket, which may be
+// given as an ARN.
+func (d Destination) BucketName() string {
+	return strings.TrimPrefix(d.Bucket, bucketARNPrefix)
+}
+
+// Host - returns the host and port of the endpoint.
+func (d Destination) Host() string {
+	u, err := url.Parse(d.Endpoint)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+// Secure - returns true if the endpoint uses https.
+func (d Destination) Secure() bool {
+	return strings.HasPrefix(d.Endpoint, "https://")
+}
@@ -0,0 +1,109 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replication
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+// Maximum number of rules allowed in a replication configuration, as per AWS S3 specification.
+const maxRules = 1000
+
+// ErrTooManyRules - replication configuration has more than 1000 rules.
+var ErrTooManyRules = errors.New("replication configuration allows a maximum of 1000 rules")
+
+// ErrNoRules - replication configuration has no rules.
+var ErrNoRules = errors.New("replication configuration should have at least one rule")
+
+// ErrDuplicateRuleID - two rules of the replication configuration have the same ID.
+var ErrDuplicateRuleID = errors.New("replication configuration has rules with duplicate IDs")
+
+// Config - bucket replication configuration.
+type Config struct {
+	XMLNS   string   `xml:"xmlns,attr,omitempty"`
+	XMLName xml.Name `xml:"ReplicationConfiguration"`
+	Role    string   `xml:"Role,omitempty"`
+	Rules   []Rule   `xml:"Rule"`
+}
+
+// Validate - validates the replication configuration.
+func (c Config) Validate() error {
+	if len(c.Rules) == 0 {
+		return ErrNoRules
+	}
+
+	if len(c.Rules) > maxRules {
+		return ErrTooManyRules
+	}
+
+	ids := make(map[string]struct{})
+	for _, rule := range c.Rules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+
+		if rule.ID == "" {
+			continue
+		}
+		if _, ok := ids[rule.ID]; ok {
+			return ErrDuplicateRuleID
+		}
+		ids[rule.ID] = struct{}{}
+	}
+
+	return nil
+}
+
+// Match - returns the first enabled rule applying to the given object.
+func (c Config) Match(objName string) (Rule, bool) {
+	for _, rule := range c.Rules {
+		if rule.Enabled() && strings.HasPrefix(objName, rule.prefix()) {
+			return rule, true
+		}
+	}
+
+	return Rule{}, false
+}
+
+// WithoutSecrets - returns a copy of the configuration without the secret
+// keys of the destinations, to be returned to clients.
+func (c Config) WithoutSecrets() Config {
+	rules := make([]Rule, len(c.Rules))
+	copy(rules, c.Rules)
+	for i := range rules {
+		rules[i].Destination.SecretKey = ""
+	}
+	c.Rules = rules
+	return c
+}
+
+// ParseConfig - parses data in given reader to replication configuration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
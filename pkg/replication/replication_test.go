@@ -0,0 +1,103 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replication
+
+import (
+	"strings"
+	"testing"
+)
+
+const testDestination = `<Destination><Bucket>arn:aws:s3:::replica</Bucket><Endpoint>https://replica.example.com:9000</Endpoint><AccessKey>minio</AccessKey><SecretKey>minio123</SecretKey></Destination>`
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		data        string
+		expectedErr error
+		shouldFail  bool
+	}{
+		{`<ReplicationConfiguration><Rule><ID>docs</ID><Status>Enabled</Status><Prefix>docs/</Prefix>` + testDestination + `</Rule></ReplicationConfiguration>`, nil, false},
+		{`<ReplicationConfiguration><Rule><Status>Disabled</Status><Filter><Prefix>logs/</Prefix></Filter>` + testDestination + `</Rule></ReplicationConfiguration>`, nil, false},
+		{`<ReplicationConfiguration></ReplicationConfiguration>`, ErrNoRules, true},
+		{`<ReplicationConfiguration><Rule><ID>a</ID><Status>Enabled</Status>` + testDestination + `</Rule><Rule><ID>a</ID><Status>Enabled</Status>` + testDestination + `</Rule></ReplicationConfiguration>`, ErrDuplicateRuleID, true},
+		{`<ReplicationConfiguration><Rule><Status>enabled</Status>` + testDestination + `</Rule></ReplicationConfiguration>`, ErrInvalidRuleStatus, true},
+		{`<ReplicationConfiguration><Rule><Status>Enabled</Status><Prefix>docs/</Prefix><Filter><Prefix>docs/</Prefix></Filter>` + testDestination + `</Rule></ReplicationConfiguration>`, ErrPrefixAndFilter, true},
+		{`<ReplicationConfiguration><Rule><Status>Enabled</Status><Filter><Tag><Key>k</Key><Value>v</Value></Tag></Filter>` + testDestination + `</Rule></ReplicationConfiguration>`, ErrTagFilterNotSupported, true},
+		{`<ReplicationConfiguration><Rule><Status>Enabled</Status><Destination><Endpoint>https://replica.example.com</Endpoint><AccessKey>minio</AccessKey><SecretKey>minio123</SecretKey></Destination></Rule></ReplicationConfiguration>`, ErrMissingDestinationBucket, true},
+		{`<ReplicationConfiguration><Rule><Status>Enabled</Status><Destination><Bucket>replica</Bucket><Endpoint>ftp://replica.example.com</Endpoint><AccessKey>minio</AccessKey><SecretKey>minio123</SecretKey></Destination></Rule></ReplicationConfiguration>`, ErrInvalidEndpoint, true},
+		{`<ReplicationConfiguration><Rule><Status>Enabled</Status><Destination><Bucket>replica</Bucket><Endpoint>https://replica.example.com/replica</Endpoint><AccessKey>minio</AccessKey><SecretKey>minio123</SecretKey></Destination></Rule></ReplicationConfiguration>`, ErrInvalidEndpoint, true},
+		{`<ReplicationConfiguration><Rule><Status>Enabled</Status><Destination><Bucket>replica</Bucket><Endpoint>https://replica.example.com</Endpoint><AccessKey>minio</AccessKey></Destination></Rule></ReplicationConfiguration>`, ErrMissingCredentials, true},
+		{`<ReplicationConfiguration><Rule>`, nil, true},
+	}
+
+	for i, testCase := range testCases {
+		_, err := ParseConfig(strings.NewReader(testCase.data))
+		if testCase.shouldFail != (err != nil) {
+			t.Fatalf("test %v: expected failure: %v, got: %v", i+1, testCase.shouldFail, err)
+		}
+		if testCase.expectedErr != nil && err != testCase.expectedErr {
+			t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+	}
+}
+
+func TestConfigMatch(t *testing.T) {
+	docs, all := "docs/", ""
+	destination := Destination{Bucket: "replica", Endpoint: "https://replica.example.com", AccessKey: "minio", SecretKey: "minio123"}
+	config := Config{
+		Rules: []Rule{
+			{ID: "disabled", Status: Disabled, Prefix: &docs, Destination: destination},
+			{ID: "logs", Status: Enabled, Filter: &Filter{Prefix: "logs/"}, Destination: destination},
+			{ID: "all", Status: Enabled, Prefix: &all, Destination: destination},
+		},
+	}
+
+	testCases := []struct {
+		objName    string
+		expectedID string
+	}{
+		{"logs/today.log", "logs"},
+		{"docs/intro.html", "all"},
+		{"index.html", "all"},
+	}
+
+	for i, testCase := range testCases {
+		rule, ok := config.Match(testCase.objName)
+		if !ok || rule.ID != testCase.expectedID {
+			t.Fatalf("test %v: expected rule: %v, got: %v", i+1, testCase.expectedID, rule.ID)
+		}
+	}
+
+	config.Rules = config.Rules[:2]
+	if _, ok := config.Match("index.html"); ok {
+		t.Fatalf("expected no rule to match")
+	}
+}
+
+func TestConfigWithoutSecrets(t *testing.T) {
+	config := Config{
+		Rules: []Rule{
+			{Status: Enabled, Destination: Destination{Bucket: "replica", Endpoint: "https://replica.example.com", AccessKey: "minio", SecretKey: "minio123"}},
+		},
+	}
+
+	if c := config.WithoutSecrets(); c.Rules[0].Destination.SecretKey != "" {
+		t.Fatalf("expected secret key to be removed")
+	}
+	if config.Rules[0].Destination.SecretKey != "minio123" {
+		t.Fatalf("expected original configuration to keep its secret key")
+	}
+}
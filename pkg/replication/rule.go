@@ -0,0 +1,112 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replication
+
+import (
+	"encoding/xml"
+	"errors"
+)
+
+// Status - status of a replication rule.
+type Status string
+
+const (
+	// Enabled - the rule is applied.
+	Enabled Status = "Enabled"
+
+	// Disabled - the rule is ignored.
+	Disabled Status = "Disabled"
+)
+
+// Maximum length of a rule ID, as per AWS S3 specification.
+const maxRuleIDLength = 255
+
+// ErrInvalidRuleID - rule ID is longer than 255 characters.
+var ErrInvalidRuleID = errors.New("ID must be less than 255 characters")
+
+// ErrInvalidRuleStatus - rule status is neither Enabled nor Disabled.
+var ErrInvalidRuleStatus = errors.New("rule status must be Enabled or Disabled")
+
+// ErrPrefixAndFilter - rule has both the legacy prefix and a filter.
+var ErrPrefixAndFilter = errors.New("rule must have either a prefix or a filter, not both")
+
+// ErrTagFilterNotSupported - filter selects objects by their tags.
+var ErrTagFilterNotSupported = errors.New("only prefix based filters are supported")
+
+// unsupportedElement - holds configuration elements which are parsed only to be rejected.
+type unsupportedElement struct {
+	InnerXML string `xml:",innerxml"`
+}
+
+// Filter - selects the objects a rule applies to.
+type Filter struct {
+	XMLName xml.Name            `xml:"Filter"`
+	Prefix  string              `xml:"Prefix"`
+	And     *unsupportedElement `xml:"And,omitempty"`
+	Tag     *unsupportedElement `xml:"Tag,omitempty"`
+}
+
+// Rule - a replication rule copying objects under a prefix to a destination.
+type Rule struct {
+	XMLName     xml.Name    `xml:"Rule"`
+	ID          string      `xml:"ID,omitempty"`
+	Status      Status      `xml:"Status"`
+	Prefix      *string     `xml:"Prefix,omitempty"`
+	Filter      *Filter     `xml:"Filter,omitempty"`
+	Destination Destination `xml:"Destination"`
+}
+
+// Validate - validates the rule.
+func (rule Rule) Validate() error {
+	if len(rule.ID) > maxRuleIDLength {
+		return ErrInvalidRuleID
+	}
+
+	switch rule.Status {
+	case Enabled, Disabled:
+	default:
+		return ErrInvalidRuleStatus
+	}
+
+	if rule.Prefix != nil && rule.Filter != nil {
+		return ErrPrefixAndFilter
+	}
+
+	if rule.Filter != nil && (rule.Filter.And != nil || rule.Filter.Tag != nil) {
+		return ErrTagFilterNotSupported
+	}
+
+	return rule.Destination.Validate()
+}
+
+// Enabled - returns true if the rule is applied.
+func (rule Rule) Enabled() bool {
+	return rule.Status == Enabled
+}
+
+// prefix - returns the object name prefix the rule applies to.
+func (rule Rule) prefix() string {
+	if rule.Filter != nil {
+		return rule.Filter.Prefix
+	}
+
+	if rule.Prefix != nil {
+		return *rule.Prefix
+	}
+
+	return ""
+}
@@ -0,0 +1,172 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tags
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/url"
+	"sort"
+	"unicode/utf8"
+)
+
+// Limits on tags, as per AWS S3 specification.
+const (
+	maxKeyLength      = 128
+	maxValueLength    = 256
+	maxObjectTagCount = 10
+	maxBucketTagCount = 50
+)
+
+// ErrTooManyTags - more tags than allowed for an object or a bucket.
+var ErrTooManyTags = errors.New("tag set exceeds the maximum number of tags")
+
+// ErrInvalidTagKey - tag key is empty or longer than 128 characters.
+var ErrInvalidTagKey = errors.New("tag key must be between 1 and 128 characters")
+
+// ErrInvalidTagValue - tag value is longer than 256 characters.
+var ErrInvalidTagValue = errors.New("tag value must be at most 256 characters")
+
+// ErrDuplicateTagKey - two tags of the tag set have the same key.
+var ErrDuplicateTagKey = errors.New("tag set has duplicate tag keys")
+
+// Tag - a key/value pair attached to an object or a bucket.
+type Tag struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+// Validate - validates the tag key and value.
+func (tag Tag) Validate() error {
+	if tag.Key == "" || utf8.RuneCountInString(tag.Key) > maxKeyLength {
+		return ErrInvalidTagKey
+	}
+
+	if utf8.RuneCountInString(tag.Value) > maxValueLength {
+		return ErrInvalidTagValue
+	}
+
+	return nil
+}
+
+// TagSet - set of tags.
+type TagSet struct {
+	Tags []Tag `xml:"Tag"`
+}
+
+// Tags - tagging configuration of an object or a bucket.
+type Tags struct {
+	XMLNS   string   `xml:"xmlns,attr,omitempty"`
+	XMLName xml.Name `xml:"Tagging"`
+	TagSet  TagSet   `xml:"TagSet"`
+}
+
+// validate - validates all tags and checks that there are at most maxTags.
+func (tags Tags) validate(maxTags int) error {
+	if len(tags.TagSet.Tags) > maxTags {
+		return ErrTooManyTags
+	}
+
+	keys := make(map[string]struct{})
+	for _, tag := range tags.TagSet.Tags {
+		if err := tag.Validate(); err != nil {
+			return err
+		}
+
+		if _, ok := keys[tag.Key]; ok {
+			return ErrDuplicateTagKey
+		}
+		keys[tag.Key] = struct{}{}
+	}
+
+	return nil
+}
+
+// IsEmpty - returns true if there are no tags.
+func (tags Tags) IsEmpty() bool {
+	return len(tags.TagSet.Tags) == 0
+}
+
+// ToMap - returns tags as a map of key to value.
+func (tags Tags) ToMap() map[string]string {
+	m := make(map[string]string, len(tags.TagSet.Tags))
+	for _, tag := range tags.TagSet.Tags {
+		m[tag.Key] = tag.Value
+	}
+	return m
+}
+
+// String - returns tags URL encoded, as in x-amz-tagging header, sorted by key.
+func (tags Tags) String() string {
+	values := url.Values{}
+	for _, tag := range tags.TagSet.Tags {
+		values.Set(tag.Key, tag.Value)
+	}
+	return values.Encode()
+}
+
+func parseXML(reader io.Reader, maxTags int) (*Tags, error) {
+	var tags Tags
+	if err := xml.NewDecoder(reader).Decode(&tags); err != nil {
+		return nil, err
+	}
+
+	if err := tags.validate(maxTags); err != nil {
+		return nil, err
+	}
+
+	return &tags, nil
+}
+
+// ParseObjectXML - parses data in given reader to object tags.
+func ParseObjectXML(reader io.Reader) (*Tags, error) {
+	return parseXML(reader, maxObjectTagCount)
+}
+
+// ParseBucketXML - parses data in given reader to bucket tags.
+func ParseBucketXML(reader io.Reader) (*Tags, error) {
+	return parseXML(reader, maxBucketTagCount)
+}
+
+// ParseObjectTags - parses URL encoded tags, as in x-amz-tagging header, to object tags.
+func ParseObjectTags(s string) (*Tags, error) {
+	values, err := url.ParseQuery(s)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(values))
+	for key, value := range values {
+		if len(value) > 1 {
+			return nil, ErrDuplicateTagKey
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var tags Tags
+	for _, key := range keys {
+		tags.TagSet.Tags = append(tags.TagSet.Tags, Tag{Key: key, Value: values.Get(key)})
+	}
+
+	if err = tags.validate(maxObjectTagCount); err != nil {
+		return nil, err
+	}
+
+	return &tags, nil
+}
@@ -0,0 +1,93 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tags
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseObjectXML(t *testing.T) {
+	tagsXML := func(n int) string {
+		var s string
+		for i := 0; i < n; i++ {
+			s += fmt.Sprintf("<Tag><Key>key%d</Key><Value>value</Value></Tag>", i)
+		}
+		return "<Tagging><TagSet>" + s + "</TagSet></Tagging>"
+	}
+
+	testCases := []struct {
+		data           string
+		expectedResult map[string]string
+		expectedErr    error
+	}{
+		{`<Tagging><TagSet></TagSet></Tagging>`, map[string]string{}, nil},
+		{`<Tagging><TagSet><Tag><Key>env</Key><Value>prod</Value></Tag></TagSet></Tagging>`, map[string]string{"env": "prod"}, nil},
+		{`<Tagging><TagSet><Tag><Key>env</Key><Value></Value></Tag></TagSet></Tagging>`, map[string]string{"env": ""}, nil},
+		{`<Tagging><TagSet><Tag><Key></Key><Value>prod</Value></Tag></TagSet></Tagging>`, nil, ErrInvalidTagKey},
+		{`<Tagging><TagSet><Tag><Key>` + strings.Repeat("a", 129) + `</Key><Value>prod</Value></Tag></TagSet></Tagging>`, nil, ErrInvalidTagKey},
+		{`<Tagging><TagSet><Tag><Key>env</Key><Value>` + strings.Repeat("a", 257) + `</Value></Tag></TagSet></Tagging>`, nil, ErrInvalidTagValue},
+		{`<Tagging><TagSet><Tag><Key>env</Key><Value>prod</Value></Tag><Tag><Key>env</Key><Value>dev</Value></Tag></TagSet></Tagging>`, nil, ErrDuplicateTagKey},
+		{tagsXML(11), nil, ErrTooManyTags},
+	}
+
+	for i, testCase := range testCases {
+		tags, err := ParseObjectXML(strings.NewReader(testCase.data))
+		if err != testCase.expectedErr {
+			t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+		if err == nil && !reflect.DeepEqual(tags.ToMap(), testCase.expectedResult) {
+			t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expectedResult, tags.ToMap())
+		}
+	}
+
+	// Buckets allow more tags than objects.
+	if _, err := ParseBucketXML(strings.NewReader(tagsXML(50))); err != nil {
+		t.Fatalf("expected: <nil>, got: %v", err)
+	}
+	if _, err := ParseBucketXML(strings.NewReader(tagsXML(51))); err != ErrTooManyTags {
+		t.Fatalf("expected: %v, got: %v", ErrTooManyTags, err)
+	}
+}
+
+func TestParseObjectTags(t *testing.T) {
+	testCases := []struct {
+		s              string
+		expectedResult string
+		expectedErr    error
+	}{
+		{"", "", nil},
+		{"env=prod", "env=prod", nil},
+		{"team=a%20b&env=prod", "env=prod&team=a+b", nil},
+		{"env=", "env=", nil},
+		{"=prod", "", ErrInvalidTagKey},
+		{"env=prod&env=dev", "", ErrDuplicateTagKey},
+		{"a=1&b=2&c=3&d=4&e=5&f=6&g=7&h=8&i=9&j=10&k=11", "", ErrTooManyTags},
+	}
+
+	for i, testCase := range testCases {
+		tags, err := ParseObjectTags(testCase.s)
+		if err != testCase.expectedErr {
+			t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+		if err == nil && tags.String() != testCase.expectedResult {
+			t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expectedResult, tags.String())
+		}
+	}
+}
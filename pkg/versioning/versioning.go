@@ -0,0 +1,98 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package versioning
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+)
+
+// State - versioning state of a bucket.
+type State string
+
+const (
+	// Enabled - all new objects get a unique version ID.
+	Enabled State = "Enabled"
+
+	// Suspended - new objects get the "null" version ID, existing
+	// versions are kept.
+	Suspended State = "Suspended"
+)
+
+// MFADelete - MFA delete state, only "Disabled" is supported.
+type MFADelete string
+
+const (
+	// MFADeleteDisabled - MFA delete is disabled.
+	MFADeleteDisabled MFADelete = "Disabled"
+)
+
+// ErrInvalidStatus - versioning status is neither Enabled nor Suspended.
+var ErrInvalidStatus = errors.New("versioning status must be Enabled or Suspended")
+
+// ErrMFADeleteNotSupported - MFA delete was requested.
+var ErrMFADeleteNotSupported = errors.New("MFA delete is not supported")
+
+// Versioning - bucket versioning configuration.
+type Versioning struct {
+	XMLNS     string    `xml:"xmlns,attr,omitempty"`
+	XMLName   xml.Name  `xml:"VersioningConfiguration"`
+	Status    State     `xml:"Status,omitempty"`
+	MFADelete MFADelete `xml:"MFADelete,omitempty"`
+}
+
+// Validate - validates the versioning configuration.
+func (v Versioning) Validate() error {
+	switch v.Status {
+	case Enabled, Suspended:
+	default:
+		return ErrInvalidStatus
+	}
+
+	switch v.MFADelete {
+	case "", MFADeleteDisabled:
+	default:
+		return ErrMFADeleteNotSupported
+	}
+
+	return nil
+}
+
+// Enabled - returns true if versioning is enabled.
+func (v Versioning) Enabled() bool {
+	return v.Status == Enabled
+}
+
+// Suspended - returns true if versioning is suspended.
+func (v Versioning) Suspended() bool {
+	return v.Status == Suspended
+}
+
+// ParseConfig - parses data in given reader to versioning configuration.
+func ParseConfig(reader io.Reader) (*Versioning, error) {
+	var v Versioning
+	if err := xml.NewDecoder(reader).Decode(&v); err != nil {
+		return nil, err
+	}
+
+	if err := v.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
@@ -0,0 +1,57 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package versioning
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		data            string
+		expectEnabled   bool
+		expectSuspended bool
+		expectErr       bool
+	}{
+		{`<VersioningConfiguration><Status>Enabled</Status></VersioningConfiguration>`, true, false, false},
+		{`<VersioningConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Status>Suspended</Status></VersioningConfiguration>`, false, true, false},
+		{`<VersioningConfiguration><Status>Enabled</Status><MFADelete>Disabled</MFADelete></VersioningConfiguration>`, true, false, false},
+		{`<VersioningConfiguration><Status>Enabled</Status><MFADelete>Enabled</MFADelete></VersioningConfiguration>`, false, false, true},
+		{`<VersioningConfiguration><Status>enabled</Status></VersioningConfiguration>`, false, false, true},
+		{`<VersioningConfiguration></VersioningConfiguration>`, false, false, true},
+		{`<VersioningConfiguration><Status>Enabled</Status>`, false, false, true},
+	}
+
+	for i, testCase := range testCases {
+		v, err := ParseConfig(strings.NewReader(testCase.data))
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("test %v: error: expected: %v, got: %v", i+1, testCase.expectErr, expectErr)
+		}
+
+		if !testCase.expectErr {
+			if v.Enabled() != testCase.expectEnabled {
+				t.Fatalf("test %v: enabled: expected: %v, got: %v", i+1, testCase.expectEnabled, v.Enabled())
+			}
+			if v.Suspended() != testCase.expectSuspended {
+				t.Fatalf("test %v: suspended: expected: %v, got: %v", i+1, testCase.expectSuspended, v.Suspended())
+			}
+		}
+	}
+}
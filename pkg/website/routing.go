@@ -0,0 +1,122 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package website
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidCondition - routing rule condition is empty or has an invalid error code.
+var ErrInvalidCondition = errors.New("condition must have a key prefix or a 4XX/5XX error code")
+
+// ErrInvalidRedirect - routing rule redirect is empty, replaces the key twice or has an invalid redirect code.
+var ErrInvalidRedirect = errors.New("redirect must have at least one element, at most one key replacement and a 3XX redirect code")
+
+// Condition - requests a routing rule applies to.
+type Condition struct {
+	HTTPErrorCodeReturnedEquals string `xml:"HttpErrorCodeReturnedEquals,omitempty"`
+	KeyPrefixEquals             string `xml:"KeyPrefixEquals,omitempty"`
+}
+
+// Redirect - redirect applied to the requests matching a routing rule.
+type Redirect struct {
+	HostName             string `xml:"HostName,omitempty"`
+	HTTPRedirectCode     string `xml:"HttpRedirectCode,omitempty"`
+	Protocol             string `xml:"Protocol,omitempty"`
+	ReplaceKeyPrefixWith string `xml:"ReplaceKeyPrefixWith,omitempty"`
+	ReplaceKeyWith       string `xml:"ReplaceKeyWith,omitempty"`
+}
+
+// RoutingRule - redirects requests matching a condition.
+type RoutingRule struct {
+	Condition *Condition `xml:"Condition,omitempty"`
+	Redirect  Redirect   `xml:"Redirect"`
+}
+
+// Validate - validates the routing rule.
+func (rule RoutingRule) Validate() error {
+	if c := rule.Condition; c != nil {
+		if c.HTTPErrorCodeReturnedEquals == "" && c.KeyPrefixEquals == "" {
+			return ErrInvalidCondition
+		}
+		if c.HTTPErrorCodeReturnedEquals != "" {
+			code, err := strconv.Atoi(c.HTTPErrorCodeReturnedEquals)
+			if err != nil || code < 400 || code > 599 {
+				return ErrInvalidCondition
+			}
+		}
+	}
+
+	r := rule.Redirect
+	if r == (Redirect{}) || (r.ReplaceKeyPrefixWith != "" && r.ReplaceKeyWith != "") {
+		return ErrInvalidRedirect
+	}
+	if r.HTTPRedirectCode != "" {
+		code, err := strconv.Atoi(r.HTTPRedirectCode)
+		if err != nil || code < 300 || code > 399 {
+			return ErrInvalidRedirect
+		}
+	}
+
+	return validateProtocol(r.Protocol)
+}
+
+// RedirectCode - returns the HTTP status code of the redirect, 301 by default.
+func (rule RoutingRule) RedirectCode() int {
+	if code, err := strconv.Atoi(rule.Redirect.HTTPRedirectCode); err == nil {
+		return code
+	}
+
+	return 301
+}
+
+// RedirectKey - returns the key the given key is redirected to.
+func (rule RoutingRule) RedirectKey(key string) string {
+	switch {
+	case rule.Redirect.ReplaceKeyWith != "":
+		return rule.Redirect.ReplaceKeyWith
+	case rule.Redirect.ReplaceKeyPrefixWith != "":
+		prefix := ""
+		if rule.Condition != nil {
+			prefix = rule.Condition.KeyPrefixEquals
+		}
+		return rule.Redirect.ReplaceKeyPrefixWith + strings.TrimPrefix(key, prefix)
+	}
+
+	return key
+}
+
+// match - returns true if the rule applies to a request on the given key,
+// failing with the given HTTP error code or zero if the request did not
+// fail yet. Rules with an error code condition only apply to failed requests.
+func (rule RoutingRule) match(key string, errorCode int) bool {
+	if rule.Condition == nil {
+		return true
+	}
+
+	if !strings.HasPrefix(key, rule.Condition.KeyPrefixEquals) {
+		return false
+	}
+
+	if rule.Condition.HTTPErrorCodeReturnedEquals == "" {
+		return true
+	}
+
+	return rule.Condition.HTTPErrorCodeReturnedEquals == strconv.Itoa(errorCode)
+}
@@ -0,0 +1,41 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package website
+
+import "testing"
+
+func TestRoutingRuleValidate(t *testing.T) {
+	testCases := []struct {
+		rule        RoutingRule
+		expectedErr error
+	}{
+		{RoutingRule{Redirect: Redirect{HostName: "example.com"}}, nil},
+		{RoutingRule{Condition: &Condition{HTTPErrorCodeReturnedEquals: "404"}, Redirect: Redirect{ReplaceKeyWith: "error.html", HTTPRedirectCode: "302"}}, nil},
+		{RoutingRule{Condition: &Condition{}, Redirect: Redirect{HostName: "example.com"}}, ErrInvalidCondition},
+		{RoutingRule{Condition: &Condition{HTTPErrorCodeReturnedEquals: "200"}, Redirect: Redirect{HostName: "example.com"}}, ErrInvalidCondition},
+		{RoutingRule{}, ErrInvalidRedirect},
+		{RoutingRule{Redirect: Redirect{ReplaceKeyWith: "a", ReplaceKeyPrefixWith: "b"}}, ErrInvalidRedirect},
+		{RoutingRule{Redirect: Redirect{HTTPRedirectCode: "200"}}, ErrInvalidRedirect},
+		{RoutingRule{Redirect: Redirect{Protocol: "ftp"}}, ErrInvalidProtocol},
+	}
+
+	for i, testCase := range testCases {
+		if err := testCase.rule.Validate(); err != testCase.expectedErr {
+			t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package website
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+// Maximum number of routing rules allowed in a website configuration, as per AWS S3 specification.
+const maxRoutingRules = 50
+
+// ErrTooManyRoutingRules - website configuration has more than 50 routing rules.
+var ErrTooManyRoutingRules = errors.New("website configuration allows a maximum of 50 routing rules")
+
+// ErrMissingIndexDocument - website configuration has neither an index document nor redirects all requests.
+var ErrMissingIndexDocument = errors.New("website configuration must have an index document or redirect all requests")
+
+// ErrInvalidIndexDocument - index document suffix is empty or has a slash.
+var ErrInvalidIndexDocument = errors.New("index document suffix must not be empty and must not contain a slash")
+
+// ErrInvalidErrorDocument - error document key is empty.
+var ErrInvalidErrorDocument = errors.New("error document key must not be empty")
+
+// ErrInvalidRedirectAllRequestsTo - redirect of all requests is used with other elements or has no host name.
+var ErrInvalidRedirectAllRequestsTo = errors.New("redirect of all requests must have a host name and can not be used with other elements")
+
+// ErrInvalidProtocol - redirect protocol is neither http nor https.
+var ErrInvalidProtocol = errors.New("protocol must be http or https")
+
+// IndexDocument - object served for requests on a prefix.
+type IndexDocument struct {
+	Suffix string `xml:"Suffix"`
+}
+
+// ErrorDocument - object served when a request fails with a 4XX error.
+type ErrorDocument struct {
+	Key string `xml:"Key"`
+}
+
+// RedirectAllRequestsTo - redirects all requests to another host.
+type RedirectAllRequestsTo struct {
+	HostName string `xml:"HostName"`
+	Protocol string `xml:"Protocol,omitempty"`
+}
+
+// Config - bucket website configuration.
+type Config struct {
+	XMLNS                 string                 `xml:"xmlns,attr,omitempty"`
+	XMLName               xml.Name               `xml:"WebsiteConfiguration"`
+	RedirectAllRequestsTo *RedirectAllRequestsTo `xml:"RedirectAllRequestsTo,omitempty"`
+	IndexDocument         *IndexDocument         `xml:"IndexDocument,omitempty"`
+	ErrorDocument         *ErrorDocument         `xml:"ErrorDocument,omitempty"`
+	RoutingRules          []RoutingRule          `xml:"RoutingRules>RoutingRule,omitempty"`
+}
+
+// Validate - validates the website configuration.
+func (c Config) Validate() error {
+	if c.RedirectAllRequestsTo != nil {
+		if c.RedirectAllRequestsTo.HostName == "" || c.IndexDocument != nil || c.ErrorDocument != nil || len(c.RoutingRules) > 0 {
+			return ErrInvalidRedirectAllRequestsTo
+		}
+		return validateProtocol(c.RedirectAllRequestsTo.Protocol)
+	}
+
+	if c.IndexDocument == nil {
+		return ErrMissingIndexDocument
+	}
+
+	if c.IndexDocument.Suffix == "" || strings.Contains(c.IndexDocument.Suffix, "/") {
+		return ErrInvalidIndexDocument
+	}
+
+	if c.ErrorDocument != nil && c.ErrorDocument.Key == "" {
+		return ErrInvalidErrorDocument
+	}
+
+	if len(c.RoutingRules) > maxRoutingRules {
+		return ErrTooManyRoutingRules
+	}
+
+	for _, rule := range c.RoutingRules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IndexKey - returns the key of the object to serve for the given key,
+// requests on the bucket or on a prefix serve its index document.
+func (c Config) IndexKey(key string) string {
+	if c.IndexDocument == nil {
+		return key
+	}
+
+	if key == "" || strings.HasSuffix(key, "/") {
+		return key + c.IndexDocument.Suffix
+	}
+
+	return key
+}
+
+// Route - returns the first routing rule applying to a request on the given
+// key, failing with the given HTTP error code or zero if the request did
+// not fail yet.
+func (c Config) Route(key string, errorCode int) (RoutingRule, bool) {
+	for _, rule := range c.RoutingRules {
+		if rule.match(key, errorCode) {
+			return rule, true
+		}
+	}
+
+	return RoutingRule{}, false
+}
+
+// ParseConfig - parses data in given reader to website configuration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+func validateProtocol(protocol string) error {
+	switch protocol {
+	case "", "http", "https":
+		return nil
+	}
+
+	return ErrInvalidProtocol
+}
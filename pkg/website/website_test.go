@@ -0,0 +1,109 @@
+/*
+ * Minio Cloud Storage, (C) 2019 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package website
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		data        string
+		expectedErr error
+		shouldFail  bool
+	}{
+		{`<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><ErrorDocument><Key>404.html</Key></ErrorDocument></WebsiteConfiguration>`, nil, false},
+		{`<WebsiteConfiguration><RedirectAllRequestsTo><HostName>example.com</HostName><Protocol>https</Protocol></RedirectAllRequestsTo></WebsiteConfiguration>`, nil, false},
+		{`<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RoutingRules><RoutingRule><Condition><KeyPrefixEquals>docs/</KeyPrefixEquals></Condition><Redirect><ReplaceKeyPrefixWith>documents/</ReplaceKeyPrefixWith></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`, nil, false},
+		{`<WebsiteConfiguration></WebsiteConfiguration>`, ErrMissingIndexDocument, true},
+		{`<WebsiteConfiguration><IndexDocument><Suffix>docs/index.html</Suffix></IndexDocument></WebsiteConfiguration>`, ErrInvalidIndexDocument, true},
+		{`<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><ErrorDocument></ErrorDocument></WebsiteConfiguration>`, ErrInvalidErrorDocument, true},
+		{`<WebsiteConfiguration><RedirectAllRequestsTo><HostName>example.com</HostName></RedirectAllRequestsTo><IndexDocument><Suffix>index.html</Suffix></IndexDocument></WebsiteConfiguration>`, ErrInvalidRedirectAllRequestsTo, true},
+		{`<WebsiteConfiguration><RedirectAllRequestsTo><HostName>example.com</HostName><Protocol>ftp</Protocol></RedirectAllRequestsTo></WebsiteConfiguration>`, ErrInvalidProtocol, true},
+		{`<WebsiteConfiguration><IndexDocument>`, nil, true},
+	}
+
+	for i, testCase := range testCases {
+		_, err := ParseConfig(strings.NewReader(testCase.data))
+		if testCase.shouldFail != (err != nil) {
+			t.Fatalf("test %v: expected failure: %v, got: %v", i+1, testCase.shouldFail, err)
+		}
+		if testCase.expectedErr != nil && err != testCase.expectedErr {
+			t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expectedErr, err)
+		}
+	}
+}
+
+func TestConfigIndexKey(t *testing.T) {
+	config := Config{IndexDocument: &IndexDocument{Suffix: "index.html"}}
+
+	testCases := []struct {
+		key         string
+		expectedKey string
+	}{
+		{"", "index.html"},
+		{"docs/", "docs/index.html"},
+		{"docs/intro.html", "docs/intro.html"},
+		{"docs", "docs"},
+	}
+
+	for i, testCase := range testCases {
+		if key := config.IndexKey(testCase.key); key != testCase.expectedKey {
+			t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expectedKey, key)
+		}
+	}
+}
+
+func TestConfigRoute(t *testing.T) {
+	config := Config{
+		IndexDocument: &IndexDocument{Suffix: "index.html"},
+		RoutingRules: []RoutingRule{
+			{Condition: &Condition{KeyPrefixEquals: "docs/"}, Redirect: Redirect{ReplaceKeyPrefixWith: "documents/"}},
+			{Condition: &Condition{HTTPErrorCodeReturnedEquals: "404"}, Redirect: Redirect{HTTPRedirectCode: "302", ReplaceKeyWith: "index.html"}},
+		},
+	}
+
+	testCases := []struct {
+		key          string
+		errorCode    int
+		expectedOk   bool
+		expectedKey  string
+		expectedCode int
+	}{
+		{"docs/intro.html", 0, true, "documents/intro.html", 301},
+		{"app/main.js", 0, false, "", 0},
+		{"app/main.js", 404, true, "index.html", 302},
+		{"app/main.js", 403, false, "", 0},
+	}
+
+	for i, testCase := range testCases {
+		rule, ok := config.Route(testCase.key, testCase.errorCode)
+		if ok != testCase.expectedOk {
+			t.Fatalf("test %v: expected: %v, got: %v", i+1, testCase.expectedOk, ok)
+		}
+		if !ok {
+			continue
+		}
+		if key := rule.RedirectKey(testCase.key); key != testCase.expectedKey {
+			t.Fatalf("test %v: expected key: %v, got: %v", i+1, testCase.expectedKey, key)
+		}
+		if code := rule.RedirectCode(); code != testCase.expectedCode {
+			t.Fatalf("test %v: expected code: %v, got: %v", i+1, testCase.expectedCode, code)
+		}
+	}
+}